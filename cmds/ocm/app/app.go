@@ -22,15 +22,18 @@ import (
 	"github.com/open-component-model/ocm/cmds/ocm/commands/common/options/keyoption"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/misccmds/action"
 	creds "github.com/open-component-model/ocm/cmds/ocm/commands/misccmds/credentials"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/misccmds/keystore"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocicmds"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds"
 	common2 "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common"
+	inputplugin "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/plugin"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/componentarchive"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/components"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/plugins"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/references"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/resources"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/routingslips"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/sbom"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/sources"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/toicmds"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs/add"
@@ -40,11 +43,14 @@ import (
 	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs/controller"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs/create"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs/describe"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs/diff"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs/download"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs/execute"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs/get"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs/hash"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs/install"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs/remove"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs/rotate"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs/show"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs/sign"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs/transfer"
@@ -242,12 +248,15 @@ func newCliCommand(opts *CLIOptions, mod ...func(clictx.Context, *cobra.Command)
 	cmd.AddCommand(show.NewCommand(opts.Context))
 	cmd.AddCommand(transfer.NewCommand(opts.Context))
 	cmd.AddCommand(describe.NewCommand(opts.Context))
+	cmd.AddCommand(diff.NewCommand(opts.Context))
 	cmd.AddCommand(download.NewCommand(opts.Context))
 	cmd.AddCommand(bootstrap.NewCommand(opts.Context))
 	cmd.AddCommand(clean.NewCommand(opts.Context))
 	cmd.AddCommand(install.NewCommand(opts.Context))
 	cmd.AddCommand(execute.NewCommand(opts.Context))
 	cmd.AddCommand(controller.NewCommand(opts.Context))
+	cmd.AddCommand(remove.NewCommand(opts.Context))
+	cmd.AddCommand(rotate.NewCommand(opts.Context))
 
 	cmd.AddCommand(cmdutils.HideCommand(componentarchive.NewCommand(opts.Context)))
 	cmd.AddCommand(cmdutils.HideCommand(resources.NewCommand(opts.Context)))
@@ -257,12 +266,14 @@ func newCliCommand(opts *CLIOptions, mod ...func(clictx.Context, *cobra.Command)
 	cmd.AddCommand(cmdutils.HideCommand(plugins.NewCommand(opts.Context)))
 	cmd.AddCommand(cmdutils.HideCommand(action.NewCommand(opts.Context)))
 	cmd.AddCommand(cmdutils.HideCommand(routingslips.NewCommand(opts.Context)))
+	cmd.AddCommand(cmdutils.HideCommand(sbom.NewCommand(opts.Context)))
 
 	cmd.AddCommand(cmdutils.OverviewCommand(cachecmds.NewCommand(opts.Context)))
 	cmd.AddCommand(cmdutils.OverviewCommand(ocicmds.NewCommand(opts.Context)))
 	cmd.AddCommand(cmdutils.OverviewCommand(ocmcmds.NewCommand(opts.Context)))
 	cmd.AddCommand(cmdutils.OverviewCommand(toicmds.NewCommand(opts.Context)))
 	cmd.AddCommand(cmdutils.OverviewCommand(creds.NewCommand(opts.Context)))
+	cmd.AddCommand(cmdutils.OverviewCommand(keystore.NewCommand(opts.Context)))
 
 	opts.AddFlags(cmd.Flags())
 	cmd.InitDefaultHelpCmd()
@@ -421,7 +432,12 @@ func (o *CLIOptions) Complete() error {
 		}
 		_ = ctx.ApplyConfig(spec, "cli")
 	}
-	return registration.RegisterExtensions(o.Context.OCMContext())
+	err = registration.RegisterExtensions(o.Context.OCMContext())
+	if err != nil {
+		return err
+	}
+	inputplugin.RegisterInputTypes(o.Context)
+	return nil
 }
 
 func prepare(s string) string {
@@ -5,6 +5,7 @@
 package get
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs"
 	"github.com/open-component-model/ocm/cmds/ocm/pkg/output"
 	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
+	"github.com/open-component-model/ocm/pkg/common"
 	"github.com/open-component-model/ocm/pkg/contexts/clictx"
 	"github.com/open-component-model/ocm/pkg/contexts/credentials"
 	"github.com/open-component-model/ocm/pkg/errors"
@@ -33,8 +35,9 @@ type Command struct {
 	Consumer credentials.ConsumerIdentity
 	Matcher  credentials.IdentityMatcher
 
-	Type   string
-	Sloppy bool
+	Type    string
+	Sloppy  bool
+	Explain bool
 }
 
 var _ utils.OCMCommand = (*Command)(nil)
@@ -71,6 +74,15 @@ The following standard identity matchers are supported:
 The used matcher is derived from the consumer attribute <code>type</code>.
 For all other consumer types a matcher matching all attributes will be used.
 The usage of a dedicated matcher can be enforced by the option <code>--matcher</code>.
+
+With option <code>--explain</code> the resolution of the credentials is described.
+It shows the used identity matchers, all consulted consumer providers
+(explicit consumer settings and providers registered by credential
+repositories), every evaluated consumer identity together with its match score
+(number of matching attributes), whether it is a partial match, and whether it
+has been selected by the matcher. Finally the resolution of the winning
+credentials source (including alias resolution) is shown.
+In this mode credential values (except the user name) are masked.
 `,
 	}
 }
@@ -78,6 +90,7 @@ The usage of a dedicated matcher can be enforced by the option <code>--matcher</
 func (o *Command) AddFlags(set *pflag.FlagSet) {
 	set.StringVarP(&o.Type, "matcher", "m", "", "matcher type override")
 	set.BoolVarP(&o.Sloppy, "sloppy", "s", false, "sloppy matching of consumer type")
+	set.BoolVarP(&o.Explain, "explain", "e", false, "explain credential resolution (masks credential values)")
 }
 
 func (o *Command) Complete(args []string) error {
@@ -122,16 +135,76 @@ func (o *Command) Run() error {
 		}
 	}
 
+	if o.Explain {
+		return o.explain()
+	}
+
 	creds, err := credentials.RequiredCredentialsForConsumer(o.CredentialsContext(), o.Consumer, o.Matcher)
 	if err != nil {
 		return err
 	}
+	o.printCredentials(creds.Properties(), false)
+	return nil
+}
+
+func (o *Command) explain() error {
+	cctx := o.CredentialsContext()
+	e, err := cctx.ExplainCredentialsForConsumer(o.Consumer, o.Matcher)
+	if e == nil {
+		return err
+	}
+
+	out.Outf(o, "Consumer: %s\n", e.Consumer.String())
+	out.Outf(o, "Identity matchers: %s\n", strings.Join(e.Matchers, ", "))
+	out.Outf(o, "Consulted consumer providers:\n")
+	for _, p := range e.Providers {
+		out.Outf(o, "- %s\n", p)
+	}
+	out.Outf(o, "\nEvaluated consumer identities:\n")
+	if len(e.Evaluations) == 0 {
+		out.Outf(o, "  none\n")
+	} else {
+		list := [][]string{{"PROVIDER", "IDENTITY", "SCORE", "PARTIAL", "SELECTED"}}
+		for _, m := range e.Evaluations {
+			list = append(list, []string{m.Provider, m.Identity.String(), fmt.Sprintf("%d", m.Score), fmt.Sprintf("%t", m.Partial), fmt.Sprintf("%t", m.Selected)})
+		}
+		output.FormatTable(o, "", list)
+	}
+
+	out.Outf(o, "\n")
+	switch {
+	case e.Winner != nil:
+		out.Outf(o, "Winner: %s from %s\n", e.Winner.Identity.String(), e.Winner.Provider)
+	case e.Default:
+		out.Outf(o, "Winner: default credentials (empty consumer identity)\n")
+	default:
+		out.Outf(o, "No matching credentials found\n")
+		return err
+	}
+	if len(e.Resolution) > 0 {
+		out.Outf(o, "Resolution:\n")
+		for _, r := range e.Resolution {
+			out.Outf(o, "  %s\n", r)
+		}
+	}
+
+	creds, err := e.Source.Credentials(cctx)
+	if err != nil {
+		return errors.Wrapf(err, "lookup credentials failed for %s", o.Consumer)
+	}
+	out.Outf(o, "\n")
+	o.printCredentials(creds.Properties(), true)
+	return nil
+}
 
+func (o *Command) printCredentials(props common.Properties, mask bool) {
 	var list [][]string
-	for k, v := range creds.Properties() {
+	for k, v := range props {
+		if mask && k != credentials.ATTR_USERNAME {
+			v = "***"
+		}
 		list = append(list, []string{k, v})
 	}
 	sort.Slice(list, func(i, j int) bool { return strings.Compare(list[i][0], list[j][0]) < 0 })
 	output.FormatTable(o, "", append([][]string{{"ATTRIBUTE", "VALUE"}}, list...))
-	return nil
 }
@@ -74,3 +74,60 @@ username  testuser
 `))
 	})
 })
+
+var _ = Describe("Explain", func() {
+	var env *TestEnv
+
+	BeforeEach(func() {
+		env = NewTestEnv()
+		cctx := env.CLI.CredentialsContext()
+
+		ids := credentials.NewConsumerIdentity(identity.CONSUMER_TYPE,
+			identity.ID_HOSTNAME, "ghcr.io",
+		)
+		cctx.SetCredentialsForConsumer(ids, credentials.DirectCredentials{
+			"username": "other",
+			"password": "otherpass",
+		})
+
+		ids = credentials.NewConsumerIdentity(identity.CONSUMER_TYPE,
+			identity.ID_HOSTNAME, "ghcr.io",
+			identity.ID_PATHPREFIX, "a",
+		)
+		cctx.SetCredentialsForConsumer(ids, credentials.DirectCredentials{
+			"username": "testuser",
+			"password": "testpass",
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("explains oci credentials", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("get", "credentials", "--explain", cpi.ID_TYPE+"="+identity.CONSUMER_TYPE, identity.ID_HOSTNAME+"=ghcr.io", identity.ID_PATHPREFIX+"=a/b")).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring(`Consumer: {"hostname":"ghcr.io","pathprefix":"a/b","type":"OCIRegistry"}
+Identity matchers: OCIRegistry
+Consulted consumer providers:
+- explicit
+`))
+		Expect(buf.String()).To(MatchRegexp(`(?m)^explicit +{"hostname":"ghcr.io","pathprefix":"a","type":"OCIRegistry"} +3 +true +(true|false) *$`))
+		Expect(buf.String()).To(MatchRegexp(`(?m)^explicit +{"hostname":"ghcr.io","type":"OCIRegistry"} +2 +true +(true|false) *$`))
+		Expect(buf.String()).To(ContainSubstring(`
+Winner: {"hostname":"ghcr.io","pathprefix":"a","type":"OCIRegistry"} from explicit
+Resolution:
+  direct credentials (attributes: password, username)
+`))
+		Expect(buf.String()).To(MatchRegexp(`(?m)^password +\*\*\* *$`))
+		Expect(buf.String()).To(MatchRegexp(`(?m)^username +testuser *$`))
+		Expect(buf.String()).NotTo(ContainSubstring("testpass"))
+	})
+
+	It("explains missing credentials", func() {
+		buf := bytes.NewBuffer(nil)
+		err := env.CatchOutput(buf).Execute("get", "credentials", "-e", cpi.ID_TYPE+"="+identity.CONSUMER_TYPE, identity.ID_HOSTNAME+"=gcr.io")
+		Expect(err).To(MatchError(ContainSubstring("is unknown")))
+		Expect(buf.String()).To(ContainSubstring("No matching credentials found"))
+	})
+})
@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package add
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/open-component-model/ocm/cmds/ocm/commands/misccmds/keystore/common"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/misccmds/names"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs"
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
+	"github.com/open-component-model/ocm/pkg/contexts/clictx"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/out"
+)
+
+var (
+	Names = names.KeystoreEntries
+	Verb  = verbs.Add
+)
+
+type Command struct {
+	utils.BaseCommand
+
+	Name     string
+	Consumer credentials.ConsumerIdentity
+}
+
+var _ utils.OCMCommand = (*Command)(nil)
+
+// NewCommand creates a new keystore entry add command.
+func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
+	return utils.SetupCommand(&Command{BaseCommand: utils.NewBaseCommand(ctx, common.New(), common.NewCredentialOption())}, utils.Names(Names, names...)...)
+}
+
+func (o *Command) ForName(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "[<options>] {<consumer property>=<value>}",
+		Short: "add an entry to the local keystore",
+		Long: `
+Add a credential entry to the encrypted local keystore. If a consumer
+identity is given, the credentials are provided for matching consumers
+when the keystore is used as credential repository. The entry name
+defaults to the JSON representation of the consumer identity.
+`,
+		Example: `
+$ ocm add keystoreentry --credential username=alice --credential password=secret type=OCIRegistry hostname=ghcr.io
+$ ocm add keystoreentry --name signing --credentials-file creds.yaml
+`,
+	}
+}
+
+func (o *Command) AddFlags(set *pflag.FlagSet) {
+	o.BaseCommand.AddFlags(set)
+	set.StringVarP(&o.Name, "name", "n", "", "entry name")
+}
+
+func (o *Command) Complete(args []string) error {
+	var err error
+	o.Consumer, err = common.ParseConsumerId(args)
+	return err
+}
+
+func (o *Command) Run() error {
+	opt := common.From(o)
+	k, err := opt.Load()
+	if err != nil {
+		return err
+	}
+	var copt *common.CredentialOption
+	o.AsOptionSet().Get(&copt)
+	e, err := k.Add(o.Name, o.Consumer, copt.Properties)
+	if err != nil {
+		return err
+	}
+	if err := opt.Save(k); err != nil {
+		return err
+	}
+	out.Outf(o, "added keystore entry %q\n", e.Name)
+	return nil
+}
@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keystore
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/open-component-model/ocm/cmds/ocm/commands/misccmds/keystore/add"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/misccmds/keystore/get"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/misccmds/keystore/remove"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/misccmds/keystore/rotate"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/misccmds/names"
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
+	"github.com/open-component-model/ocm/pkg/contexts/clictx"
+)
+
+var Names = names.KeystoreEntries
+
+// NewCommand creates a new command.
+func NewCommand(ctx clictx.Context) *cobra.Command {
+	cmd := utils.MassageCommand(&cobra.Command{
+		Short: "Commands acting on the entries of the local keystore",
+	}, Names...)
+	cmd.AddCommand(add.NewCommand(ctx, add.Verb))
+	cmd.AddCommand(get.NewCommand(ctx, get.Verb))
+	cmd.AddCommand(rotate.NewCommand(ctx, rotate.Verb))
+	cmd.AddCommand(remove.NewCommand(ctx, remove.Verb))
+	return cmd
+}
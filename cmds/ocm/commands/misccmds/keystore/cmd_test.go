@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keystore_test
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/cmds/ocm/testhelper"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/repositories/keystore"
+)
+
+const (
+	KEYSTORE   = "/keystore"
+	PASSPHRASE = "/passphrase"
+)
+
+var _ = Describe("keystore commands", func() {
+	var env *TestEnv
+
+	opts := []string{"--keystore", KEYSTORE, "--passphrase-file", PASSPHRASE}
+
+	execute := func(args ...string) string {
+		buf := bytes.NewBuffer(nil)
+		ExpectWithOffset(1, env.CatchOutput(buf).Execute(append(args, opts...)...)).To(Succeed())
+		return buf.String()
+	}
+
+	load := func() *keystore.Keystore {
+		return Must(keystore.Load(KEYSTORE, &keystore.Secret{Passphrase: "passphrase"}, env.FileSystem()))
+	}
+
+	BeforeEach(func() {
+		env = NewTestEnv()
+		MustBeSuccessful(vfs.WriteFile(env.FileSystem(), PASSPHRASE, []byte("passphrase\n"), 0o600))
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("adds entries", func() {
+		Expect(execute("add", "keystoreentry", "-C", "username=alice", "-C", "password=secret", "type=OCIRegistry", "hostname=ghcr.io")).
+			To(Equal("added keystore entry \"{\\\"hostname\\\":\\\"ghcr.io\\\",\\\"type\\\":\\\"OCIRegistry\\\"}\"\n"))
+		Expect(execute("add", "keystoreentry", "-n", "token", "-C", "token=t")).To(Equal("added keystore entry \"token\"\n"))
+
+		k := load()
+		Expect(k.Names()).To(ConsistOf("token", `{"hostname":"ghcr.io","type":"OCIRegistry"}`))
+		Expect(k.Get("token").Credentials).To(Equal(common.Properties{"token": "t"}))
+
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute(append([]string{"add", "keystoreentry", "-n", "token", "-C", "token=t"}, opts...)...)).
+			To(MatchError(ContainSubstring("already exists")))
+	})
+
+	It("lists entries", func() {
+		execute("add", "keystoreentry", "-C", "username=alice", "-C", "password=secret", "type=OCIRegistry", "hostname=ghcr.io")
+		execute("add", "keystoreentry", "-n", "token", "-C", "token=t")
+
+		out := execute("get", "keystoreentries")
+		out = regexp.MustCompile(`\d{4}-\d\d-\d\dT\d\d:\d\d:\d\dZ`).ReplaceAllString(out, "<time>")
+		out = regexp.MustCompile(`(?m) +$`).ReplaceAllString(out, "")
+		Expect(out).To(StringEqualTrimmedWithContext(`
+NAME                                        CONSUMER                                    ATTRIBUTES        CREATED              ROTATED
+token                                                                                   token             <time>
+{"hostname":"ghcr.io","type":"OCIRegistry"} {"hostname":"ghcr.io","type":"OCIRegistry"} password,username <time>
+`))
+		Expect(execute("get", "keystoreentries", "hostname=ghcr.io")).NotTo(ContainSubstring("token "))
+	})
+
+	It("rotates entries", func() {
+		execute("add", "keystoreentry", "-C", "username=alice", "-C", "password=secret", "type=OCIRegistry", "hostname=ghcr.io")
+		execute("rotate", "keystoreentry", "-C", "username=alice", "-C", "password=new", "type=OCIRegistry", "hostname=ghcr.io")
+
+		e := load().Get(`{"hostname":"ghcr.io","type":"OCIRegistry"}`)
+		Expect(e.Credentials).To(Equal(common.Properties{"username": "alice", "password": "new"}))
+		Expect(e.Rotated).NotTo(BeNil())
+	})
+
+	It("removes entries", func() {
+		execute("add", "keystoreentry", "-n", "token", "-C", "token=t")
+		Expect(execute("remove", "keystoreentry", "-n", "token")).To(Equal("removed keystore entry \"token\"\n"))
+		Expect(load().Names()).To(BeEmpty())
+
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute(append([]string{"remove", "keystoreentry", "-n", "token"}, opts...)...)).
+			To(MatchError(ContainSubstring("not found")))
+	})
+})
@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"strings"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/options"
+	ocmcommon "github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/clictx"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/repositories/keystore"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+func From(o options.OptionSetProvider) *Option {
+	var opt *Option
+	o.AsOptionSet().Get(&opt)
+	return opt
+}
+
+func New() *Option {
+	return &Option{}
+}
+
+// Option describes the keystore file and the key material used to
+// encrypt it.
+type Option struct {
+	Path           string
+	KeyFile        string
+	PassphraseFile string
+
+	FileSystem vfs.FileSystem
+	Secret     *keystore.Secret
+}
+
+var _ options.Options = (*Option)(nil)
+
+func (o *Option) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.Path, "keystore", "", "", "keystore file (default ~/.ocm/keystore)")
+	fs.StringVarP(&o.KeyFile, "keyfile", "", "", "key file used to encrypt the keystore")
+	fs.StringVarP(&o.PassphraseFile, "passphrase-file", "", "", "file containing the passphrase used to encrypt the keystore")
+}
+
+func (o *Option) Configure(ctx clictx.Context) error {
+	var err error
+
+	o.FileSystem = ctx.FileSystem()
+	if o.Path == "" {
+		o.Path, err = keystore.DefaultKeystore()
+		if err != nil {
+			return err
+		}
+	}
+	if o.KeyFile != "" && o.PassphraseFile != "" {
+		return errors.Newf("only one of --keyfile or --passphrase-file possible")
+	}
+	switch {
+	case o.KeyFile != "":
+		o.Secret, err = keystore.SecretFromKeyFile(o.KeyFile, o.FileSystem)
+		if err != nil {
+			return err
+		}
+	case o.PassphraseFile != "":
+		data, err := vfs.ReadFile(o.FileSystem, o.PassphraseFile)
+		if err != nil {
+			return errors.Wrapf(err, "cannot read passphrase file %q", o.PassphraseFile)
+		}
+		p := strings.TrimSpace(string(data))
+		if p == "" {
+			return errors.Newf("empty passphrase in %q", o.PassphraseFile)
+		}
+		o.Secret = &keystore.Secret{Passphrase: p}
+	default:
+		o.Secret = keystore.SecretFromEnv()
+		if o.Secret == nil {
+			return errors.Newf("keystore requires --keyfile, --passphrase-file or environment variable %s", keystore.ENV_PASSPHRASE)
+		}
+	}
+	return nil
+}
+
+func (o *Option) Load() (*keystore.Keystore, error) {
+	return keystore.Load(o.Path, o.Secret, o.FileSystem)
+}
+
+func (o *Option) Save(k *keystore.Keystore) error {
+	return k.Save(o.Path, o.Secret, o.FileSystem)
+}
+
+func (o *Option) Usage() string {
+	return `
+The keystore is an encrypted file (default <code>~/.ocm/keystore</code>).
+It can be used as credential repository of type <code>` + keystore.Type + `</code>.
+The key material is taken from a key file (option <code>--keyfile</code>),
+a passphrase file (option <code>--passphrase-file</code>), or the passphrase
+is taken from the environment variable <code>` + keystore.ENV_PASSPHRASE + `</code>.
+`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// ParseConsumerId parses a consumer identity given by arguments of the
+// form <name>=<value>.
+func ParseConsumerId(args []string) (credentials.ConsumerIdentity, error) {
+	id := credentials.ConsumerIdentity{}
+	for _, s := range args {
+		i := strings.Index(s, "=")
+		if i <= 0 {
+			return nil, errors.ErrInvalid("consumer setting", s)
+		}
+		id[s[:i]] = s[i+1:]
+	}
+	return id, nil
+}
+
+// CredentialOption describes credential attributes given
+// by command line arguments or by a file.
+type CredentialOption struct {
+	Settings []string
+	File     string
+
+	Properties ocmcommon.Properties
+}
+
+var _ options.Options = (*CredentialOption)(nil)
+
+func NewCredentialOption() *CredentialOption {
+	return &CredentialOption{}
+}
+
+func (o *CredentialOption) AddFlags(fs *pflag.FlagSet) {
+	fs.StringArrayVarP(&o.Settings, "credential", "C", nil, "credential attribute (<name>=<value>)")
+	fs.StringVarP(&o.File, "credentials-file", "", "", "YAML or JSON file with credential attributes")
+}
+
+func (o *CredentialOption) Configure(ctx clictx.Context) error {
+	o.Properties = ocmcommon.Properties{}
+	if o.File != "" {
+		data, err := vfs.ReadFile(ctx.FileSystem(), o.File)
+		if err != nil {
+			return errors.Wrapf(err, "cannot read credentials file %q", o.File)
+		}
+		if err := yaml.Unmarshal(data, &o.Properties); err != nil {
+			return errors.Wrapf(err, "invalid credentials file %q", o.File)
+		}
+	}
+	for _, s := range o.Settings {
+		i := strings.Index(s, "=")
+		if i <= 0 {
+			return errors.ErrInvalid("credential setting", s)
+		}
+		o.Properties[s[:i]] = s[i+1:]
+	}
+	if len(o.Properties) == 0 {
+		return errors.Newf("credential attributes required (use --credential or --credentials-file)")
+	}
+	return nil
+}
+
+func (o *CredentialOption) Usage() string {
+	return `
+The credential attributes are given by the option <code>--credential</code>
+(<code>&lt;name>=&lt;value></code>) or read from a YAML or JSON file given by
+option <code>--credentials-file</code>.
+`
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Select determines the entry name for a name or consumer identity.
+func Select(k *keystore.Keystore, name string, id credentials.ConsumerIdentity) (string, error) {
+	if name != "" {
+		if len(id) > 0 {
+			return "", errors.Newf("only entry name or consumer identity possible")
+		}
+		if k.Get(name) == nil {
+			return "", errors.ErrNotFound("keystore entry", name)
+		}
+		return name, nil
+	}
+	if len(id) == 0 {
+		return "", errors.ErrRequired("entry name or consumer identity")
+	}
+	n := k.Lookup(id)
+	if n == "" {
+		return "", errors.ErrNotFound("keystore entry for consumer", id.String())
+	}
+	return n, nil
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-component-model/ocm/cmds/ocm/commands/misccmds/keystore/common"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/misccmds/names"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs"
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/output"
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
+	"github.com/open-component-model/ocm/pkg/contexts/clictx"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	utils2 "github.com/open-component-model/ocm/pkg/utils"
+)
+
+var (
+	Names = names.KeystoreEntries
+	Verb  = verbs.Get
+)
+
+type Command struct {
+	utils.BaseCommand
+
+	Consumer credentials.ConsumerIdentity
+}
+
+var _ utils.OCMCommand = (*Command)(nil)
+
+// NewCommand creates a new keystore entry listing command.
+func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
+	return utils.SetupCommand(&Command{BaseCommand: utils.NewBaseCommand(ctx, common.New())}, utils.Names(Names, names...)...)
+}
+
+func (o *Command) ForName(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "[<options>] {<consumer property>=<value>}",
+		Short: "list the entries of the local keystore",
+		Long: `
+List the entries of the encrypted local keystore. Only the names of the
+credential attributes are shown, not their values. If consumer properties
+are given, only entries with a consumer identity containing those properties
+are listed.
+`,
+	}
+}
+
+func (o *Command) Complete(args []string) error {
+	var err error
+	o.Consumer, err = common.ParseConsumerId(args)
+	return err
+}
+
+func (o *Command) Run() error {
+	k, err := common.From(o).Load()
+	if err != nil {
+		return err
+	}
+	list := [][]string{{"NAME", "CONSUMER", "ATTRIBUTES", "CREATED", "ROTATED"}}
+	for _, e := range k.Entries() {
+		if len(o.Consumer) > 0 && (len(e.ConsumerId) == 0 || !o.Consumer.Match(e.ConsumerId)) {
+			continue
+		}
+		consumer := ""
+		if len(e.ConsumerId) > 0 {
+			consumer = e.ConsumerId.String()
+		}
+		rotated := ""
+		if e.Rotated != nil {
+			rotated = e.Rotated.Format(time.RFC3339)
+		}
+		list = append(list, []string{
+			e.Name, consumer,
+			strings.Join(utils2.StringMapKeys(e.Credentials), ","),
+			e.Created.Format(time.RFC3339), rotated,
+		})
+	}
+	output.FormatTable(o, "", list)
+	return nil
+}
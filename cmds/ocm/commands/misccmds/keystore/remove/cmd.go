@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remove
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/open-component-model/ocm/cmds/ocm/commands/misccmds/keystore/common"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/misccmds/names"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs"
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
+	"github.com/open-component-model/ocm/pkg/contexts/clictx"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/out"
+)
+
+var (
+	Names = names.KeystoreEntries
+	Verb  = verbs.Remove
+)
+
+type Command struct {
+	utils.BaseCommand
+
+	Name     string
+	Consumer credentials.ConsumerIdentity
+}
+
+var _ utils.OCMCommand = (*Command)(nil)
+
+// NewCommand creates a new keystore entry remove command.
+func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
+	return utils.SetupCommand(&Command{BaseCommand: utils.NewBaseCommand(ctx, common.New())}, utils.Names(Names, names...)...)
+}
+
+func (o *Command) ForName(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "[<options>] {<consumer property>=<value>}",
+		Short: "remove an entry from the local keystore",
+		Long: `
+Remove an entry from the encrypted local keystore. The entry is selected
+by its name (option <code>--name</code>) or by its consumer identity.
+`,
+		Example: `
+$ ocm remove keystoreentry type=OCIRegistry hostname=ghcr.io
+`,
+	}
+}
+
+func (o *Command) AddFlags(set *pflag.FlagSet) {
+	o.BaseCommand.AddFlags(set)
+	set.StringVarP(&o.Name, "name", "n", "", "entry name")
+}
+
+func (o *Command) Complete(args []string) error {
+	var err error
+	o.Consumer, err = common.ParseConsumerId(args)
+	return err
+}
+
+func (o *Command) Run() error {
+	opt := common.From(o)
+	k, err := opt.Load()
+	if err != nil {
+		return err
+	}
+	name, err := common.Select(k, o.Name, o.Consumer)
+	if err != nil {
+		return err
+	}
+	k.Remove(name)
+	if err := opt.Save(k); err != nil {
+		return err
+	}
+	out.Outf(o, "removed keystore entry %q\n", name)
+	return nil
+}
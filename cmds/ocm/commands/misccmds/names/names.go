@@ -5,7 +5,8 @@
 package names
 
 var (
-	Hash        = []string{"hash"}
-	RSAKeyPair  = []string{"rsakeypair", "rsa"}
-	Credentials = []string{"credentials", "creds", "cred"}
+	Hash            = []string{"hash"}
+	RSAKeyPair      = []string{"rsakeypair", "rsa", "keypair"}
+	Credentials     = []string{"credentials", "creds", "cred"}
+	KeystoreEntries = []string{"keystoreentries", "keystoreentry", "kse"}
 )
@@ -5,14 +5,15 @@
 package rsakeypair
 
 import (
+	"crypto/elliptic"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
-	parse "github.com/mandelsoft/spiff/dynaml/x509"
 	"github.com/mandelsoft/vfs/pkg/vfs"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -27,8 +28,11 @@ import (
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/signingattr"
 	"github.com/open-component-model/ocm/pkg/encrypt"
 	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/listformat"
 	"github.com/open-component-model/ocm/pkg/out"
 	"github.com/open-component-model/ocm/pkg/signing"
+	"github.com/open-component-model/ocm/pkg/signing/handlers/ecdsa"
+	"github.com/open-component-model/ocm/pkg/signing/handlers/ed25519"
 	"github.com/open-component-model/ocm/pkg/signing/handlers/rsa"
 	"github.com/open-component-model/ocm/pkg/signing/signutils"
 	utils2 "github.com/open-component-model/ocm/pkg/utils"
@@ -39,6 +43,52 @@ var (
 	Verb  = verbs.Create
 )
 
+// KeyType describes a supported type of key pairs.
+type KeyType struct {
+	// Name is used as prefix for the default key file names.
+	Name   string
+	Create func() (signutils.GenericPrivateKey, signutils.GenericPublicKey, error)
+	Write  func(key interface{}, w io.Writer) error
+	Data   func(key interface{}) ([]byte, error)
+}
+
+func ecdsaKeyType(curve elliptic.Curve) *KeyType {
+	return &KeyType{
+		Name: "ecdsa",
+		Create: func() (signutils.GenericPrivateKey, signutils.GenericPublicKey, error) {
+			return ecdsa.CreateKeyPairFor(curve)
+		},
+		Write: ecdsa.WriteKeyData,
+		Data:  ecdsa.KeyData,
+	}
+}
+
+const (
+	ALGO_RSA        = "RSA"
+	ALGO_ECDSA_P256 = "ECDSA-P256"
+	ALGO_ECDSA_P384 = "ECDSA-P384"
+	ALGO_ECDSA_P521 = "ECDSA-P521"
+	ALGO_ED25519    = "Ed25519"
+)
+
+// KeyTypes lists the key pair types supported by the command.
+var KeyTypes = map[string]*KeyType{
+	ALGO_RSA:        {Name: "rsa", Create: rsa.CreateKeyPair, Write: rsa.WriteKeyData, Data: rsa.KeyData},
+	ALGO_ECDSA_P256: ecdsaKeyType(elliptic.P256()),
+	ALGO_ECDSA_P384: ecdsaKeyType(elliptic.P384()),
+	ALGO_ECDSA_P521: ecdsaKeyType(elliptic.P521()),
+	ALGO_ED25519:    {Name: "ed25519", Create: ed25519.CreateKeyPair, Write: ed25519.WriteKeyData, Data: ed25519.KeyData},
+}
+
+func keyTypeFor(algo string) *KeyType {
+	for k, t := range KeyTypes {
+		if strings.EqualFold(k, algo) {
+			return t
+		}
+	}
+	return nil
+}
+
 type Command struct {
 	utils.BaseCommand
 
@@ -62,6 +112,9 @@ type Command struct {
 
 	Encrypt             string
 	CreateEncryptionKey bool
+
+	Algorithm string
+	keyType   *KeyType
 }
 
 var _ utils.OCMCommand = (*Command)(nil)
@@ -74,11 +127,16 @@ func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
 func (o *Command) ForName(name string) *cobra.Command {
 	return &cobra.Command{
 		Use:   "[<private key file> [<public key file>]] {<subject-attribute>=<value>}",
-		Short: "create RSA public key pair",
+		Short: "create public key pair (RSA, ECDSA or Ed25519)",
 		Long: `
-Create an RSA public key pair and save to files.
+Create a public key pair and save to files.
 
-The default for the filename to store the private key is <code>rsa.priv</code>.
+The key algorithm can be selected with option <code>--algorithm</code>.
+The following algorithms are supported:
+` + listformat.FormatList(ALGO_RSA, utils2.StringMapKeys(KeyTypes)...) + `
+
+The default for the filename to store the private key is <code>&lt;type>.priv</code>,
+where type is <code>rsa</code>, <code>ecdsa</code> or <code>ed25519</code>.
 If no public key file is specified, its name will be derived from the filename for
 the private key (suffix <code>.pub</code> for public key or <code>.cert</code>
 for certificate). If a certificate authority is given (<code>--ca-cert</code>)
@@ -105,6 +163,7 @@ For signing the public key the following subject attributes are supported:
 	`,
 		Example: `
 $ ocm create rsakeypair mandelsoft.priv mandelsoft.cert issuer=mandelsoft
+$ ocm create keypair --algorithm ECDSA-P384 mandelsoft.priv mandelsoft.cert issuer=mandelsoft
 `,
 	}
 }
@@ -117,6 +176,7 @@ func (o *Command) AddFlags(set *pflag.FlagSet) {
 	set.DurationVarP(&o.Validity, "validity", "", 10*24*365*time.Hour, "certificate validity")
 	set.StringVarP(&o.Encrypt, "encryptionKey", "e", "", "encrypt private key with given key")
 	set.BoolVarP(&o.CreateEncryptionKey, "encrypt", "E", false, "encrypt private key with new key")
+	set.StringVarP(&o.Algorithm, "algorithm", "a", ALGO_RSA, "key algorithm")
 
 	flag.StringVarPF(set, &o.cacert, "cacert", "", "", "certificate authority to sign public key").Hidden = true
 	flag.StringVarPF(set, &o.cakey, "cakey", "", "", "private key for certificate authority").Hidden = true
@@ -136,6 +196,10 @@ func (o *Command) Complete(args []string) error {
 	if o.CreateEncryptionKey && o.Encrypt != "" {
 		return errors.Newf("only one of --encrypt or --encryptionKey is possible")
 	}
+	o.keyType = keyTypeFor(o.Algorithm)
+	if o.keyType == nil {
+		return errors.ErrUnknown("key algorithm", o.Algorithm)
+	}
 
 	if o.rootcerts != "" {
 		pool, err := signutils.GetCertPool(o.rootcerts, false)
@@ -220,14 +284,14 @@ func (o *Command) Complete(args []string) error {
 		}
 	}
 	if o.cakey != "" {
-		key, err := parse.ParsePrivateKey(o.cakey)
+		key, err := signutils.ParsePrivateKey([]byte(o.cakey))
 		if err != nil {
 			path, _ := utils2.ResolvePath(o.cakey)
 			data, err := vfs.ReadFile(o.Context.FileSystem(), path)
 			if err != nil {
 				return errors.Wrapf(err, "cannot read private key file %q", o.cakey)
 			}
-			key, err = parse.ParsePrivateKey(string(data))
+			key, err = signutils.ParsePrivateKey(data)
 			if err != nil {
 				return errors.Wrapf(err, "unknown private key in file %q", o.cakey)
 			}
@@ -249,7 +313,7 @@ func (o *Command) Complete(args []string) error {
 	if len(args) > 0 {
 		o.priv = args[0]
 	} else {
-		o.priv = "rsa.priv"
+		o.priv = o.keyType.Name + ".priv"
 	}
 	if len(args) > 1 {
 		o.pub = args[1]
@@ -275,7 +339,7 @@ func (o *Command) Complete(args []string) error {
 func (o *Command) Run() error {
 	raw := false
 
-	priv, pub, err := rsa.Handler{}.CreateKeyPair()
+	priv, pub, err := o.keyType.Create()
 	if err != nil {
 		return err
 	}
@@ -327,7 +391,7 @@ func (o *Command) Run() error {
 		}
 	}
 	if key != nil {
-		data, err := rsa.KeyData(priv)
+		data, err := o.keyType.Data(priv)
 		if err != nil {
 			return err
 		}
@@ -360,7 +424,7 @@ func (o *Command) Run() error {
 			add = "[" + o.ekey + "]"
 		}
 	}
-	out.Outf(o.Context, "created%s rsa key pair %s[%s]%s\n", msg, o.priv, o.pub, add)
+	out.Outf(o.Context, "created%s %s key pair %s[%s]%s\n", msg, o.keyType.Name, o.priv, o.pub, add)
 	return nil
 }
 
@@ -378,7 +442,7 @@ func (o *Command) WriteKey(key interface{}, path string, raw bool) error {
 			err = pem.Encode(fd, block)
 		}
 	} else {
-		err = rsa.WriteKeyData(key, fd)
+		err = o.keyType.Write(key, fd)
 	}
 	if err != nil {
 		fd.Close()
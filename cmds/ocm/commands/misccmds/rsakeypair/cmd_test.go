@@ -20,6 +20,8 @@ import (
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/signingattr"
 	"github.com/open-component-model/ocm/pkg/encrypt"
 	"github.com/open-component-model/ocm/pkg/signing"
+	"github.com/open-component-model/ocm/pkg/signing/handlers/ecdsa"
+	"github.com/open-component-model/ocm/pkg/signing/handlers/ed25519"
 	"github.com/open-component-model/ocm/pkg/signing/handlers/rsa"
 	"github.com/open-component-model/ocm/pkg/signing/signutils"
 )
@@ -99,6 +101,59 @@ created rsa key pair key.priv[key.cert]
 		Expect(err).To(Succeed())
 	})
 
+	Context("algorithms", func() {
+		DescribeTable("create key pair", func(algo string, name string, handler signing.SignatureHandler) {
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("create", "keypair", "--algorithm", algo)).To(Succeed())
+			Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+created ` + name + ` key pair ` + name + `.priv[` + name + `.pub]
+`))
+			priv := Must(env.ReadFile(name + ".priv"))
+			pub := Must(env.ReadFile(name + ".pub"))
+
+			d := digest.FromBytes([]byte("digest"))
+			sig := Must(handler.Sign(defaultContext, d.Hex(), &signing.DefaultSigningContext{PrivateKey: priv}))
+			Expect(sig.Algorithm).To(Equal(handler.Algorithm()))
+			MustBeSuccessful(handler.Verify(d.Hex(), sig, &signing.DefaultSigningContext{PublicKey: pub}))
+		},
+			Entry("ecdsa P-256", "ECDSA-P256", "ecdsa", ecdsa.NewHandler()),
+			Entry("ecdsa P-384", "ecdsa-p384", "ecdsa", ecdsa.NewHandler()),
+			Entry("ecdsa P-521", "ECDSA-P521", "ecdsa", ecdsa.NewHandler()),
+			Entry("ed25519", "Ed25519", "ed25519", ed25519.NewHandler()),
+		)
+
+		It("rejects unknown algorithm", func() {
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("create", "keypair", "--algorithm", "DSA")).To(MatchError(`key algorithm "DSA" is unknown`))
+		})
+
+		It("creates ed25519 certificate chain", func() {
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("create", "keypair", "-a", "Ed25519", "--ca", "CN=acme.org", "root.priv")).To(Succeed())
+			buf.Reset()
+			Expect(env.CatchOutput(buf).Execute("create", "keypair", "-a", "Ed25519", "CN=mandelsoft", "--ca-key", "root.priv", "--ca-cert", "root.cert", "key.priv")).To(Succeed())
+			Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+created ed25519 key pair key.priv[key.cert]
+`))
+			root := Must(env.ReadFile("root.cert"))
+			priv := Must(env.ReadFile("key.priv"))
+			certs := Must(env.ReadFile("key.cert"))
+
+			sctx := &signing.DefaultSigningContext{
+				PrivateKey: priv,
+				PublicKey:  certs,
+				RootCerts:  root,
+				Issuer:     ISSUER,
+			}
+			d := digest.FromBytes([]byte("digest"))
+			sig := Must(ed25519.NewHandler().Sign(defaultContext, d.Hex(), sctx))
+			Expect(sig.MediaType).To(Equal(signutils.MediaTypePEM))
+			Expect(sig.Issuer).To(Equal("CN=mandelsoft"))
+			chain := Must(signutils.GetCertificateChain(certs, false))
+			MustBeSuccessful(ed25519.NewHandler().Verify(d.Hex(), sig, &signing.DefaultSigningContext{PublicKey: chain[0]}))
+		})
+	})
+
 	Context("encryption", func() {
 		It("creates encrypted key with new encryption key", func() {
 			buf := bytes.NewBuffer(nil)
@@ -22,6 +22,7 @@ import (
 	"github.com/open-component-model/ocm/pkg/contexts/oci"
 	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf"
 	"github.com/open-component-model/ocm/pkg/contexts/oci/transfer"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/transfer/filters"
 	"github.com/open-component-model/ocm/pkg/errors"
 	"github.com/open-component-model/ocm/pkg/out"
 )
@@ -35,6 +36,8 @@ type Command struct {
 	utils.BaseCommand
 
 	TransferRepo bool
+	Referrers    bool
+	Platforms    []string
 
 	Refs   []string
 	Target string
@@ -66,12 +69,26 @@ Sources may be specified as
 - dedicated artifacts with repository and version or tag
 - repository (without version), which is resolved to all available tags
 - registry, if the specified registry implementation supports a namespace/repository lister,
-  which is not the case for registries conforming to the OCI distribution specification.`,
+  which is not the case for registries conforming to the OCI distribution specification.
+
+With option <code>--referrers</code> all artifacts referring to the transferred
+artifacts (for example signatures, SBOMs or attestations) are transferred, also.
+If the target does not support the OCI referrers API, the referrers are
+registered using the tag schema fallback.
+
+With option <code>--platform</code> the transfer of multi-arch images (OCI
+image indices) can be restricted to dedicated platforms, given as
+<code>&lt;os>/&lt;architecture></code>. The transferred index is rewritten
+to list only the retained manifests. If only a single manifest remains,
+this manifest is transferred instead of an index. Artifacts
+which are not an index are transferred as they are.`,
 		Example: `
 $ ocm oci artifact transfer ghcr.io/mandelsoft/kubelink:v1.0.0 gcr.io
 $ ocm oci artifact transfer ghcr.io/mandelsoft/kubelink gcr.io
 $ ocm oci artifact transfer ghcr.io/mandelsoft/kubelink gcr.io/my-project
 $ ocm oci artifact transfer /tmp/ctf gcr.io/my-project
+$ ocm oci artifact transfer --referrers ghcr.io/mandelsoft/kubelink:v1.0.0 gcr.io
+$ ocm oci artifact transfer --platform linux/amd64,linux/arm64 ghcr.io/mandelsoft/kubelink:v1.0.0 gcr.io
 `,
 	}
 }
@@ -79,6 +96,8 @@ $ ocm oci artifact transfer /tmp/ctf gcr.io/my-project
 func (o *Command) AddFlags(flags *pflag.FlagSet) {
 	o.BaseCommand.AddFlags(flags)
 	flags.BoolVarP(&o.TransferRepo, "repo-name", "R", false, "transfer repository name")
+	flags.BoolVarP(&o.Referrers, "referrers", "", false, "transfer referring artifacts (signatures, SBOMs, attestations)")
+	flags.StringSliceVarP(&o.Platforms, "platform", "", nil, "restrict transfer of image indices to given platforms (<os>/<arch>)")
 }
 
 func (o *Command) Complete(args []string) error {
@@ -87,7 +106,8 @@ func (o *Command) Complete(args []string) error {
 	}
 	o.Target = args[len(args)-1]
 	o.Refs = args[:len(args)-1]
-	return nil
+	_, err := filters.Platforms(o.Platforms...)
+	return err
 }
 
 func (o *Command) Run() error {
@@ -101,6 +121,8 @@ func (o *Command) Run() error {
 	if err != nil {
 		return err
 	}
+	a.Referrers = o.Referrers
+	a.Filter, _ = filters.Platforms(o.Platforms...)
 
 	handler := artifacthdlr.NewTypeHandler(o.Context.OCI(), session, repooption.From(o).Repository)
 
@@ -114,6 +136,8 @@ type action struct {
 	Registry     oci.Repository
 	Ref          oci.RefSpec
 	TransferRepo bool
+	Referrers    bool
+	Filter       filters.Filter
 
 	srcs         []*artifacthdlr.Object
 	repositories map[string]map[string]digest.Digest
@@ -219,11 +243,30 @@ func (a *action) handleArtifact(src *artifacthdlr.Object) error {
 		tgt.Tag = &tag
 	}
 	out.Outf(a.Context, "copying %s to %s...\n", &src.Spec, &tgt)
-	err = transfer.TransferArtifact(src.Artifact, ns, tag)
-	if err == nil {
-		a.copied++
+	if a.Filter != nil && src.Artifact.IsIndex() {
+		var tags []string
+		var dig *digest.Digest
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+		dig, err = transfer.TransferArtifactWithFilter(src.Artifact, ns, a.Filter, tags...)
+		if err == nil && *dig != src.Artifact.Digest() {
+			out.Outf(a.Context, "  filtered index %s -> %s\n", src.Artifact.Digest(), dig)
+		}
+	} else {
+		err = transfer.TransferArtifact(src.Artifact, ns, tag)
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	a.copied++
+	if a.Referrers {
+		err = transfer.TransferReferrers(src.Namespace, ns, src.Artifact.Digest())
+		if err != nil {
+			return errors.Wrapf(err, "transferring referrers of %s", &src.Spec)
+		}
+	}
+	return nil
 }
 
 func (a *action) Target(obj *artifacthdlr.Object) (string, string) {
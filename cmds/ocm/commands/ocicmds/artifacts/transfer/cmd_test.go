@@ -10,9 +10,14 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/open-component-model/ocm/cmds/ocm/testhelper"
+	. "github.com/open-component-model/ocm/pkg/contexts/oci/testhelper"
 	. "github.com/open-component-model/ocm/pkg/testutils"
 
+	"github.com/open-component-model/ocm/pkg/blobaccess"
 	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/referrers"
 	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf"
 	"github.com/open-component-model/ocm/pkg/mime"
 )
@@ -127,4 +132,73 @@ copied 1 from 1 artifact(s) and 1 repositories
 `))
 		Expect(env.ReadFile(OUT + "/" + ctf.ArtifactIndexFileName)).To(Equal([]byte("{\"schemaVersion\":1,\"artifacts\":[{\"repository\":\"mandelsoft/test\",\"tag\":\"v1\",\"digest\":\"sha256:2c3e2c59e0ac9c99864bf0a9f9727c09f21a66080f9f9b03b36a2dad3cce6ff9\"}]}")))
 	})
+
+	It("transfers an artifact with referrers", func() {
+		env.OCICommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.Namespace(NS, func() {
+				env.Manifest(VERSION, func() {
+					env.Config(func() {
+						env.BlobStringData(mime.MIME_JSON, "{}")
+					})
+					env.Layer(func() {
+						env.BlobStringData(mime.MIME_TEXT, "testdata")
+					})
+				})
+			})
+		})
+
+		src := Must(ctf.Open(env.OCIContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+		ns := Must(src.LookupNamespace(NS))
+		art := Must(ns.GetArtifact(VERSION))
+		subject := Must(referrers.SubjectDescriptor(art))
+		sbom := Must(referrers.AttachBlob(ns, subject, referrers.ARTIFACT_TYPE_SPDX, blobaccess.ForString(referrers.ARTIFACT_TYPE_SPDX, "{}"), nil))
+		MustBeSuccessful(art.Close())
+		MustBeSuccessful(ns.Close())
+		MustBeSuccessful(src.Close())
+
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("transfer", "artifact", "--referrers", ARCH+"//"+NS+":"+VERSION, "directory::"+OUT)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(
+			`
+copying /tmp/ctf//mandelsoft/test:v1 to directory::` + OUT + `//mandelsoft/test:v1...
+copied 1 from 1 artifact(s) and 1 repositories
+`))
+
+		tgt := Must(ctf.Open(env.OCIContext(), accessobj.ACC_READONLY, OUT, 0, env))
+		defer Close(tgt)
+		tns := Must(tgt.LookupNamespace(NS))
+		defer Close(tns)
+		Expect(Must(referrers.List(tns, subject.Digest, ""))).To(Equal([]artdesc.Descriptor{*sbom}))
+	})
+
+	It("transfers an index restricted to platforms", func() {
+		var idesc *artdesc.Descriptor
+		env.OCICommonTransport(ARCH, accessio.FormatDirectory, func() {
+			idesc = OCIIndex1(env.Builder)
+		})
+
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("transfer", "artifact", "--platform", "linux/amd64,linux/arm64", ARCH+"//"+OCINAMESPACE3+":"+OCIINDEXVERSION, "directory::"+OUT)).To(Succeed())
+
+		tgt := Must(ctf.Open(env.OCIContext(), accessobj.ACC_READONLY, OUT, 0, env))
+		defer Close(tgt)
+		art := Must(tgt.LookupArtifact(OCINAMESPACE3, OCIINDEXVERSION))
+		defer Close(art)
+		Expect(art.IsIndex()).To(BeTrue())
+		manifests := art.IndexAccess().GetDescriptor().Manifests
+		Expect(manifests).To(HaveLen(2))
+		Expect(manifests[0].Platform).To(Equal(&artdesc.Platform{OS: "linux", Architecture: "amd64"}))
+		Expect(manifests[1].Platform).To(Equal(&artdesc.Platform{OS: "linux", Architecture: "arm64"}))
+
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(
+			`
+copying /tmp/ctf//ocm/index:v2.0-index to directory::` + OUT + `//ocm/index:v2.0-index...
+  filtered index ` + idesc.Digest.String() + ` -> ` + art.Digest().String() + `
+copied 1 from 1 artifact(s) and 1 repositories
+`))
+	})
+
+	It("rejects invalid platforms", func() {
+		ExpectError(env.Execute("transfer", "artifact", "--platform", "linux", ARCH, "directory::"+OUT)).To(MatchError("platform \"linux\" is invalid"))
+	})
 })
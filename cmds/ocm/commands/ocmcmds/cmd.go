@@ -15,6 +15,7 @@ import (
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/resourceconfig"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/resources"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/routingslips"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/sbom"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/sourceconfig"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/sources"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/versions"
@@ -42,6 +43,7 @@ func NewCommand(ctx clictx.Context) *cobra.Command {
 	cmd.AddCommand(versions.NewCommand(ctx))
 	cmd.AddCommand(plugins.NewCommand(ctx))
 	cmd.AddCommand(routingslips.NewCommand(ctx))
+	cmd.AddCommand(sbom.NewCommand(ctx))
 
 	cmd.AddCommand(topicocmrefs.New(ctx))
 	cmd.AddCommand(topicocmaccessmethods.New(ctx))
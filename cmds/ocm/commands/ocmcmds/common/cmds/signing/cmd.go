@@ -51,7 +51,7 @@ func newOperation(op string, sign bool, terms []string, example string) *spec {
 // NewCommand creates a new ctf command.
 func NewCommand(ctx clictx.Context, op string, sign bool, terms []string, example string, names ...string) *cobra.Command {
 	spec := newOperation(op, sign, terms, example)
-	return utils.SetupCommand(&SignatureCommand{spec: spec, BaseCommand: utils.NewBaseCommand(ctx, versionconstraintsoption.New(), repooption.New(), signoption.New(sign), lookupoption.New())}, names...)
+	return utils.SetupCommand(&SignatureCommand{spec: spec, BaseCommand: utils.NewBaseCommand(ctx, versionconstraintsoption.New(), repooption.New(), signoption.New(ctx.OCMContext(), sign), lookupoption.New())}, names...)
 }
 
 func (o *SignatureCommand) ForName(name string) *cobra.Command {
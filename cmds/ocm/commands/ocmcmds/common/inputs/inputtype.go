@@ -178,10 +178,26 @@ type inputTypeScheme struct {
 	optionTypes flagsets.ConfigTypeOptionSetConfigProvider
 }
 
-func NewInputTypeScheme(defaultRepoDecoder runtime.TypedObjectDecoder[InputSpec]) InputTypeScheme {
-	scheme := runtime.MustNewDefaultScheme[InputSpec, InputType](&UnknownInputSpec{}, false, defaultRepoDecoder)
+// NewInputTypeScheme provides a new input type scheme. If a base scheme
+// is given, its types are inherited. Option types of types registered
+// for the base scheme after the creation of the new scheme are not
+// observed.
+func NewInputTypeScheme(defaultRepoDecoder runtime.TypedObjectDecoder[InputSpec], base ...InputTypeScheme) InputTypeScheme {
+	var rbase []runtime.Scheme[InputSpec, InputType]
+	b := utils.Optional(base...)
+	if b != nil {
+		rbase = append(rbase, b)
+	}
+	scheme := runtime.MustNewDefaultScheme[InputSpec, InputType](&UnknownInputSpec{}, false, defaultRepoDecoder, rbase...)
 	prov := flagsets.NewTypedConfigProvider("input", "blob input specification", "inputType")
 	prov.AddGroups("Input Specification Options")
+	if b != nil {
+		for _, n := range b.KnownTypeNames() {
+			if h := b.GetInputType(n).ConfigOptionTypeSetHandler(); h != nil {
+				prov.AddTypeSet(h)
+			}
+		}
+	}
 	return &inputTypeScheme{scheme, prov}
 }
 
@@ -13,6 +13,11 @@ var (
 	HTTPVerbOption     = options.HTTPVerbOption
 	HTTPBodyOption     = options.HTTPBodyOption
 	HTTPRedirectOption = options.HTTPRedirectOption
+	CommitOption       = options.CommitOption
+	GroupOption        = options.GroupOption
+	ArtifactOption     = options.ArtifactOption
+	ClassifierOption   = options.ClassifierOption
+	ExtensionOption    = options.ExtensionOption
 )
 
 // string options
@@ -20,6 +25,8 @@ var (
 	VersionOption        = flagsets.NewStringOptionType("inputVersion", "version info for inputs")
 	TextOption           = flagsets.NewStringOptionType("inputText", "utf8 text")
 	HelmRepositoryOption = flagsets.NewStringOptionType("inputHelmRepository", "helm repository base URL")
+	RepositoryOption     = flagsets.NewStringOptionType("inputRepository", "repository URL for inputs")
+	RefOption            = flagsets.NewStringOptionType("inputRef", "git ref for inputs")
 )
 var (
 	VariantsOption  = flagsets.NewStringArrayOptionType("inputVariants", "(platform) variants for inputs")
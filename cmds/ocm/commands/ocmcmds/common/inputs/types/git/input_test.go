@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package git_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs/testutils"
+
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs/cpi"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs/options"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/git"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+var _ = Describe("Input Type", func() {
+	var env *InputTest
+
+	BeforeEach(func() {
+		env = NewInputTest(git.TYPE)
+	})
+
+	It("simple decode", func() {
+		env.Set(options.RepositoryOption, "git@gitlab.acme.org:org/repo.git")
+		env.Set(options.RefOption, "refs/tags/v1.0.0")
+		env.Set(options.CommitOption, "0123456789012345678901234567890123456789")
+		env.Set(options.PathOption, "docs")
+		env.Set(options.MediaTypeOption, mime.MIME_TGZ)
+		env.Set(options.CompressOption, "true")
+		env.Check(&git.Spec{
+			InputSpecBase: inputs.InputSpecBase{},
+			ProcessSpec:   cpi.NewProcessSpec(mime.MIME_TGZ, true),
+			Repository:    "git@gitlab.acme.org:org/repo.git",
+			Ref:           "refs/tags/v1.0.0",
+			Commit:        "0123456789012345678901234567890123456789",
+			Path:          "docs",
+		})
+	})
+})
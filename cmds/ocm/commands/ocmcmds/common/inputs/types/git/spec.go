@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs/cpi"
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/blobaccess/git"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+type Spec struct {
+	inputs.InputSpecBase `json:",inline"`
+	cpi.ProcessSpec      `json:",inline"`
+
+	// Repository is the URL of the git repository.
+	Repository string `json:"repository"`
+	// Ref is the ref used to determine the commit, if no commit is given.
+	Ref string `json:"ref,omitempty"`
+	// Commit is the commit id to capture.
+	Commit string `json:"commit,omitempty"`
+	// Path is an optional path filter for the content of the commit.
+	Path string `json:"path,omitempty"`
+}
+
+var _ inputs.InputSpec = (*Spec)(nil)
+
+func New(repository, ref, commit string) *Spec {
+	return &Spec{
+		InputSpecBase: inputs.InputSpecBase{
+			ObjectVersionedType: runtime.ObjectVersionedType{
+				Type: TYPE,
+			},
+		},
+		Repository: repository,
+		Ref:        ref,
+		Commit:     commit,
+	}
+}
+
+func (s *Spec) Validate(fldPath *field.Path, ctx inputs.Context, inputFilePath string) field.ErrorList {
+	var allErrs field.ErrorList
+	if s.Repository == "" {
+		pathField := fldPath.Child("repository")
+		allErrs = append(allErrs, field.Invalid(pathField, s.Repository, "no repository"))
+	}
+	return allErrs
+}
+
+func (s *Spec) GetBlob(ctx inputs.Context, info inputs.InputResourceInfo) (blobaccess.BlobAccess, string, error) {
+	access, err := git.BlobAccessForGit(s.Repository,
+		git.WithCredentialContext(ctx),
+		git.WithLoggingContext(ctx),
+		git.WithRef(s.Ref),
+		git.WithCommit(s.Commit),
+		git.WithPath(s.Path),
+		git.WithMimeType(s.MediaType),
+		git.WithCompressWithGzip(s.Compress()),
+	)
+	return access, "", err
+}
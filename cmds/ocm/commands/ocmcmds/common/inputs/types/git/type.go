@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const TYPE = "git"
+
+func init() {
+	inputs.DefaultInputTypeScheme.Register(inputs.NewInputType(TYPE, &Spec{}, usage, ConfigHandler()))
+}
+
+const usage = `
+The file tree of a commit of a git repository is archived as tar archive
+and provided as blob. The commit is determined by the fields <code>commit</code>
+and <code>ref</code>. If both are omitted, the HEAD of the repository is used.
+
+This blob type specification supports the following fields:
+- **<code>repository</code>** *string*
+
+  This REQUIRED property describes the URL of the git repository.
+  Besides regular <code>https</code>, <code>ssh</code> and <code>file</code>
+  URLs, scp-like ssh references (<code>user@host:path</code>) are supported.
+  Credentials are requested for the consumer type <code>Git</code>
+  (see <CMD>ocm get credentials</CMD>).
+
+- **<code>ref</code>** *string*
+
+  This OPTIONAL property describes the ref (branch, tag or revision
+  expression) used to determine the commit, if no commit is given.
+
+- **<code>commit</code>** *string*
+
+  This OPTIONAL property describes the id of the commit to capture.
+
+- **<code>path</code>** *string*
+
+  This OPTIONAL property describes a path filter. If given, only the file
+  or directory tree with this path is included in the archive.
+
+- **<code>mediaType</code>** *string*
+
+  This OPTIONAL property describes the media type to store with the local blob.
+  The default media type is ` + mime.MIME_TAR + ` and
+  ` + mime.MIME_TGZ + ` if compression is enabled.
+
+- **<code>compress</code>** *bool*
+
+  This OPTIONAL property describes whether the archive should be stored
+  compressed or not.
+`
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package maven_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs/testutils"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs/options"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/maven"
+	"github.com/open-component-model/ocm/pkg/contexts/clictx"
+	me "github.com/open-component-model/ocm/pkg/maven"
+)
+
+var _ = Describe("Input Type", func() {
+	var env *InputTest
+
+	BeforeEach(func() {
+		env = NewInputTest(maven.TYPE)
+	})
+
+	It("simple decode", func() {
+		env.Set(options.RepositoryOption, "repo")
+		env.Set(options.GroupOption, "org.acme")
+		env.Set(options.ArtifactOption, "demo")
+		env.Set(options.VersionOption, "1.0.0")
+		env.Set(options.ClassifierOption, "sources")
+		env.Set(options.ExtensionOption, "zip")
+		env.Check(&maven.Spec{
+			InputSpecBase: inputs.InputSpecBase{},
+			Repository:    "repo",
+			GroupId:       "org.acme",
+			ArtifactId:    "demo",
+			Version:       "1.0.0",
+			Classifier:    "sources",
+			Extension:     "zip",
+		})
+	})
+
+	Context("blob", func() {
+		ctx := inputs.NewContext(clictx.DefaultContext(), nil, nil)
+		var repo string
+
+		BeforeEach(func() {
+			repo = GinkgoT().TempDir()
+			path := filepath.Join(repo, me.NewCoordinates("org.acme", "demo", "1.0.0").FilePath())
+			MustBeSuccessful(os.MkdirAll(filepath.Dir(path), 0o755))
+			MustBeSuccessful(os.WriteFile(path, []byte("jar content"), 0o644))
+		})
+
+		It("rejects file without checksum", func() {
+			spec := maven.New(repo, "org.acme", "demo", "1.0.0")
+			_, _, err := spec.GetBlob(ctx, inputs.InputResourceInfo{})
+			Expect(err).To(MatchError(ContainSubstring("no checksum sidecar file found for org.acme:demo:1.0.0")))
+		})
+
+		It("accepts file without checksum on request", func() {
+			spec := maven.New(repo, "org.acme", "demo", "1.0.0")
+			spec.AllowUnverified = true
+			blob, hint := Must2(spec.GetBlob(ctx, inputs.InputResourceInfo{}))
+			defer Close(blob, "blob")
+			Expect(hint).To(Equal("org.acme:demo:1.0.0"))
+			Expect(string(Must(blob.Get()))).To(Equal("jar content"))
+		})
+	})
+})
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package maven
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/maven/identity"
+	"github.com/open-component-model/ocm/pkg/maven"
+	"github.com/open-component-model/ocm/pkg/runtime"
+	"github.com/open-component-model/ocm/pkg/utils"
+)
+
+// DEFAULT_REPOSITORY is the local Maven repository used if no
+// repository is given.
+const DEFAULT_REPOSITORY = "~/.m2/repository"
+
+type Spec struct {
+	inputs.InputSpecBase `json:",inline"`
+
+	// Repository is the Maven repository (directory or URL).
+	Repository string `json:"repository,omitempty"`
+	// GroupId of the Maven artifact.
+	GroupId string `json:"groupId"`
+	// ArtifactId of the Maven artifact.
+	ArtifactId string `json:"artifactId"`
+	// Version of the Maven artifact.
+	Version string `json:"version"`
+	// Classifier of the Maven artifact file.
+	Classifier string `json:"classifier,omitempty"`
+	// Extension of the Maven artifact file (default jar).
+	Extension string `json:"extension,omitempty"`
+	// AllowUnverified allows to use files without checksum sidecar file.
+	AllowUnverified bool `json:"allowUnverified,omitempty"`
+}
+
+var _ inputs.InputSpec = (*Spec)(nil)
+
+func New(repository, groupId, artifactId, version string, classifier ...string) *Spec {
+	coords := maven.NewCoordinates(groupId, artifactId, version, classifier...)
+	return &Spec{
+		InputSpecBase: inputs.InputSpecBase{
+			ObjectVersionedType: runtime.ObjectVersionedType{
+				Type: TYPE,
+			},
+		},
+		Repository: repository,
+		GroupId:    coords.GroupId,
+		ArtifactId: coords.ArtifactId,
+		Version:    coords.Version,
+		Classifier: coords.Classifier,
+		Extension:  coords.Extension,
+	}
+}
+
+// GetCoordinates provides the Maven coordinates of the described file.
+func (s *Spec) GetCoordinates() *maven.Coordinates {
+	return maven.NewCoordinates(s.GroupId, s.ArtifactId, s.Version, s.Classifier, s.Extension)
+}
+
+func (s *Spec) Validate(fldPath *field.Path, ctx inputs.Context, inputFilePath string) field.ErrorList {
+	var allErrs field.ErrorList
+	if s.GroupId == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("groupId"), "groupId is required"))
+	}
+	if s.ArtifactId == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("artifactId"), "artifactId is required"))
+	}
+	if s.Version == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("version"), "version is required"))
+	}
+	return allErrs
+}
+
+func (s *Spec) GetBlob(ctx inputs.Context, info inputs.InputResourceInfo) (blobaccess.BlobAccess, string, error) {
+	repo, err := s.repository(ctx, info.InputFilePath)
+	if err != nil {
+		return nil, "", err
+	}
+	coords := s.GetCoordinates()
+	var creds map[string]string
+	if !repo.IsFileSystem() {
+		creds = identity.GetCredentials(ctx, s.Repository, s.GroupId)
+	}
+	blob, err := repo.Download(coords, creds)
+	if err != nil {
+		return nil, "", err
+	}
+	return blob, coords.String(), nil
+}
+
+func (s *Spec) repository(ctx inputs.Context, inputFilePath string) (*maven.Repository, error) {
+	path := s.Repository
+	if path == "" {
+		path = DEFAULT_REPOSITORY
+	}
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		repo, err := maven.NewUrlRepository(path)
+		if err != nil {
+			return nil, err
+		}
+		return repo.AllowUnverified(s.AllowUnverified), nil
+	}
+	path = strings.TrimPrefix(path, "file://")
+	path, err := utils.ResolvePath(path)
+	if err != nil {
+		return nil, err
+	}
+	path, err = inputs.GetPath(ctx, path, inputFilePath)
+	if err != nil {
+		return nil, err
+	}
+	return maven.NewFileRepository(path, ctx.FileSystem()).AllowUnverified(s.AllowUnverified), nil
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package maven
+
+import (
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+	"github.com/open-component-model/ocm/pkg/maven"
+)
+
+const TYPE = "maven"
+
+func init() {
+	inputs.DefaultInputTypeScheme.Register(inputs.NewInputType(TYPE, &Spec{}, usage, ConfigHandler()))
+}
+
+const usage = `
+The file of a Maven artifact is taken from a Maven repository and provided
+as blob. By default, the local Maven repository <code>` + DEFAULT_REPOSITORY + `</code>
+is used. The content is verified with the checksum sidecar file (<code>.sha256</code>
+or <code>.sha1</code>) provided by the repository. Files without sidecar file
+are rejected, unless <code>allowUnverified</code> is set.
+The media type is derived from the file extension.
+
+This blob type specification supports the following fields:
+- **<code>repository</code>** *string*
+
+  This OPTIONAL property describes the Maven repository. It may be a directory
+  (relative to the resources file), a file URL or an http(s) URL.
+  For http(s) repositories credentials are requested for the consumer
+  type <code>MavenRepository</code> (see <CMD>ocm get credentials</CMD>).
+
+- **<code>groupId</code>** *string*
+
+  This REQUIRED property describes the groupId of the Maven artifact.
+
+- **<code>artifactId</code>** *string*
+
+  This REQUIRED property describes the artifactId of the Maven artifact.
+
+- **<code>version</code>** *string*
+
+  This REQUIRED property describes the version of the Maven artifact.
+
+- **<code>classifier</code>** *string*
+
+  This OPTIONAL property describes the classifier of the artifact file.
+
+- **<code>extension</code>** *string*
+
+  This OPTIONAL property describes the file extension of the artifact
+  file (default ` + maven.DEFAULT_EXTENSION + `).
+
+- **<code>allowUnverified</code>** *bool*
+
+  This OPTIONAL property allows to use files without checksum sidecar file,
+  for example, from a local Maven repository not keeping checksums.
+`
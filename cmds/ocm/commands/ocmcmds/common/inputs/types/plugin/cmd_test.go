@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/cmds/ocm/testhelper"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/localblob"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/plugindirattr"
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/comparch"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const CA = "/tmp/ca"
+const VERSION = "v1"
+
+var _ = Describe("Add with plugin input type", func() {
+	var env *TestEnv
+
+	BeforeEach(func() {
+		env = NewTestEnv(TestData())
+		plugindirattr.Set(env.OCMContext(), "testdata")
+
+		Expect(env.Execute("create", "ca", "-ft", "directory", "test.de/x", VERSION, "--provider", "mandelsoft", "--file", CA)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	check := func(name string, mimeType string, content string) {
+		ca := Must(comparch.Open(env.OCMContext(), accessobj.ACC_READONLY, CA, 0, env))
+		defer Close(ca)
+
+		r := Must(ca.GetResource(metav1.NewIdentity(name)))
+		Expect(r.Meta().Relation).To(Equal(metav1.LocalRelation))
+		spec := Must(r.Access())
+		Expect(spec.GetKind()).To(Equal(localblob.Type))
+		Expect(spec.(*localblob.AccessSpec).ReferenceName).To(Equal("testhint"))
+
+		m := Must(r.AccessMethod())
+		defer Close(m)
+		Expect(m.MimeType()).To(Equal(mimeType))
+		Expect(string(Must(m.Get()))).To(Equal(content))
+	}
+
+	It("registers input type", func() {
+		var buf bytes.Buffer
+		Expect(env.CatchOutput(&buf).Execute("add", "resources", "--help")).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring("--testText string"))
+		Expect(buf.String()).To(ContainSubstring("- Input type «test»"))
+
+		t := inputs.For(env.CLI).GetInputType("test")
+		Expect(t).NotTo(BeNil())
+		Expect(t.ConfigOptionTypeSetHandler().OptionTypeNames()).To(ConsistOf("testText", "mediaType"))
+		Expect(t.Usage()).To(ContainSubstring("test input"))
+	})
+
+	It("adds resource by options", func() {
+		Expect(env.Execute("add", "resources", CA,
+			"--type", "plainText",
+			"--name", "text",
+			"--version", "v0.1.0",
+			"--inputType", "test",
+			"--testText", "some text",
+			"--mediaType", mime.MIME_JSON)).To(Succeed())
+
+		check("text", mime.MIME_JSON, `some text
+--inputFilePath
+resource (by options)
+--componentVersion
+test.de/x:v1
+--elementName
+text
+`)
+	})
+
+	It("adds resource by resource file", func() {
+		env.WriteFile("/tmp/resources.yaml", []byte(`
+name: text
+type: plainText
+input:
+  type: test
+  text: other text
+`), 0o600)
+		Expect(env.Execute("add", "resources", CA, "/tmp/resources.yaml")).To(Succeed())
+
+		check("text", mime.MIME_TEXT, `other text
+--inputFilePath
+/tmp/resources.yaml
+--componentVersion
+test.de/x:v1
+--elementName
+text
+`)
+	})
+
+	It("rejects content not matching the declared digest", func() {
+		env.WriteFile("/tmp/resources.yaml", []byte(`
+name: text
+type: plainText
+input:
+  type: test
+  text: other text
+  digest: sha256:0000000000000000000000000000000000000000000000000000000000000000
+`), 0o600)
+		ExpectError(env.Execute("add", "resources", CA, "/tmp/resources.yaml")).To(MatchError(ContainSubstring("digest mismatch for plugin input: declared sha256:0000000000000000000000000000000000000000000000000000000000000000")))
+	})
+})
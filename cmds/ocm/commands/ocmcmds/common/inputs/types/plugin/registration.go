@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+	"github.com/open-component-model/ocm/pkg/contexts/clictx"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/plugincacheattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/registration"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+// RegisterInputTypes registers the input types provided by the plugins
+// found for the given context. They are registered for a context specific
+// input type scheme based on the actual one. Input types already registered
+// for the context are skipped.
+func RegisterInputTypes(ctx clictx.Context) {
+	pi := plugincacheattr.Get(ctx.OCMContext())
+
+	logger := registration.Logger(ctx)
+	var scheme inputs.InputTypeScheme
+	for _, n := range pi.PluginNames() {
+		p := pi.Get(n)
+		if !p.IsValid() {
+			continue
+		}
+		for _, t := range p.GetDescriptor().InputTypes {
+			name := t.Name
+			if t.Version != "" {
+				name = name + runtime.VersionSeparator + t.Version
+			}
+			if old, ok := inputs.For(ctx).GetInputType(name).(*inputType); ok && old.plug == p {
+				// already registered for this context
+				continue
+			}
+			if scheme == nil {
+				scheme = inputs.NewInputTypeScheme(nil, inputs.For(ctx))
+			}
+			logger.Info("registering input type",
+				"plugin", p.Name(),
+				"type", name)
+			scheme.Register(NewType(name, p, &t))
+		}
+	}
+	if scheme != nil {
+		inputs.SetFor(ctx, scheme)
+	}
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+type Spec struct {
+	runtime.UnstructuredVersionedTypedObject `json:",inline"`
+	plug                                     plugin.Plugin
+	info                                     *plugin.InputSpecInfo
+}
+
+var _ inputs.InputSpec = (*Spec)(nil)
+
+func (s *Spec) Validate(fldPath *field.Path, ctx inputs.Context, inputFilePath string) field.ErrorList {
+	_, err := s.validate(inputFilePath)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, s.GetType(), err.Error())}
+	}
+	return nil
+}
+
+func (s *Spec) validate(inputFilePath string) (*plugin.InputSpecInfo, error) {
+	if s.info != nil {
+		return s.info, nil
+	}
+	data, err := s.GetRaw()
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot marshal input specification")
+	}
+	info, err := s.plug.ValidateInputSpec(inputFilePath, data)
+	if err != nil {
+		return nil, err
+	}
+	s.info = info
+	return info, nil
+}
+
+func (s *Spec) GetBlob(ctx inputs.Context, info inputs.InputResourceInfo) (blobaccess.BlobAccess, string, error) {
+	sinfo, err := s.validate(info.InputFilePath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var creddata json.RawMessage
+	if len(sinfo.ConsumerId) > 0 {
+		creds, err := credentials.CredentialsForConsumer(ctx, sinfo.ConsumerId, hostpath.IdentityMatcher(sinfo.ConsumerId.Type()))
+		if err != nil {
+			return nil, "", err
+		}
+		if creds != nil {
+			creddata, err = json.Marshal(creds)
+			if err != nil {
+				return nil, "", err
+			}
+		}
+	}
+
+	data, err := s.GetRaw()
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "cannot marshal input specification")
+	}
+	rinfo := plugin.InputResourceInfo{
+		ComponentVersion: info.ComponentVersion,
+		ElementName:      info.ElementName,
+		InputFilePath:    info.InputFilePath,
+	}
+	blob := accessobj.CachedBlobAccessForWriter(ctx, sinfo.MediaType, plugin.NewInputDataWriter(s.plug, rinfo, creddata, data, sinfo.Digest))
+	if sinfo.Digest != "" {
+		blob = blobaccess.ForDataAccess(sinfo.Digest, blobaccess.BLOB_UNKNOWN_SIZE, sinfo.MediaType, blob)
+	}
+	return blob, sinfo.Hint, nil
+}
+
+func (s *Spec) GetInputVersion(ctx inputs.Context) string {
+	return ""
+}
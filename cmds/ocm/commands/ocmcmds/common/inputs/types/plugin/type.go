@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+	"github.com/open-component-model/ocm/pkg/cobrautils/flagsets"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/options"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+type inputType struct {
+	inputs.InputType
+	plug    plugin.Plugin
+	cliopts flagsets.ConfigOptionTypeSet
+}
+
+var _ inputs.InputType = (*inputType)(nil)
+
+// NewType provides an input type forwarding the blob generation
+// to the given plugin.
+func NewType(name string, p plugin.Plugin, desc *plugin.InputTypeDescriptor) inputs.InputType {
+	usage := desc.Description
+	if desc.Format != "" {
+		usage += "\n\n" + desc.Format
+	}
+
+	t := &inputType{
+		plug: p,
+	}
+
+	cfghdlr := flagsets.NewConfigOptionTypeSetHandler(name, t.AddConfig)
+	for _, o := range desc.CLIOptions {
+		var opt flagsets.ConfigOptionType
+		if o.Type == "" {
+			opt = options.DefaultRegistry.GetOptionType(o.Name)
+			if opt == nil {
+				p.Context().Logger(plugin.TAG).Warn("unknown option", "plugin", p.Name(), "inputtype", name, "option", o.Name)
+			}
+		} else {
+			var err error
+			opt, err = options.DefaultRegistry.CreateOptionType(o.Type, o.Name, o.Description)
+			if err != nil {
+				p.Context().Logger(plugin.TAG).Warn("invalid option", "plugin", p.Name(), "inputtype", name, "option", o.Name, "error", err.Error())
+			}
+		}
+		if opt != nil {
+			cfghdlr.AddOptionType(opt)
+		}
+	}
+	if cfghdlr.Size() > 0 {
+		t.cliopts = cfghdlr
+	} else {
+		cfghdlr = nil
+	}
+	t.InputType = inputs.NewInputType(name, &Spec{}, usage, cfghdlr)
+	return t
+}
+
+func (t *inputType) Decode(data []byte, unmarshaler runtime.Unmarshaler) (inputs.InputSpec, error) {
+	spec, err := t.InputType.Decode(data, unmarshaler)
+	if err != nil {
+		return nil, err
+	}
+	spec.(*Spec).plug = t.plug
+	return spec, nil
+}
+
+func (t *inputType) AddConfig(opts flagsets.ConfigOptions, cfg flagsets.Config) error {
+	opts = opts.FilterBy(t.cliopts.HasOptionType)
+	return t.plug.ComposeInputSpec(t.GetType(), opts, cfg)
+}
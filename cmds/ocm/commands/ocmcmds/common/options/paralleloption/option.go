@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package paralleloption
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/options"
+	"github.com/open-component-model/ocm/pkg/cobrautils/flag"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler/standard"
+)
+
+func From(o options.OptionSetProvider) *Option {
+	var opt *Option
+	o.AsOptionSet().Get(&opt)
+	return opt
+}
+
+func New() *Option {
+	return &Option{}
+}
+
+type Option struct {
+	standard.TransferOptionsCreator
+	flag     *pflag.Flag
+	Parallel int
+}
+
+var _ transferhandler.TransferOption = (*Option)(nil)
+
+func (o *Option) AddFlags(fs *pflag.FlagSet) {
+	o.flag = flag.IntVarPF(fs, &o.Parallel, "parallel", "", 1, "number of parallel transfer operations")
+}
+
+func (o *Option) Usage() string {
+	s := `
+With the option <code>--parallel</code> the maximum number of parallel
+transfer operations can be configured. Independent component version references
+and resources of a component version are then transferred concurrently.
+A component version is added to the target repository only after all
+its artifacts and references have been transferred.
+`
+	return s
+}
+
+func (o *Option) ApplyTransferOption(opts transferhandler.TransferOptions) error {
+	if o.flag != nil && o.flag.Changed {
+		return standard.Concurrency(o.Parallel).ApplyTransferOption(opts)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package platformoption
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/options"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/transfer/filters"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler/standard"
+)
+
+func From(o options.OptionSetProvider) *Option {
+	var opt *Option
+	o.AsOptionSet().Get(&opt)
+	return opt
+}
+
+func New() *Option {
+	return &Option{}
+}
+
+type Option struct {
+	standard.TransferOptionsCreator
+	Platforms      []string
+	DropSignatures bool
+}
+
+var _ transferhandler.TransferOption = (*Option)(nil)
+
+func (o *Option) AddFlags(fs *pflag.FlagSet) {
+	fs.StringSliceVarP(&o.Platforms, "platform", "", nil, "restrict by-value transfer of multi-arch OCI artifacts to given platforms (<os>/<arch>)")
+	fs.BoolVarP(&o.DropSignatures, "drop-signatures", "", false, "drop signatures invalidated by platform filtering")
+}
+
+func (o *Option) Complete() error {
+	_, err := filters.Platforms(o.Platforms...)
+	return err
+}
+
+func (o *Option) Usage() string {
+	s := `
+If the option <code>--platform</code> is given, the by-value transfer of
+multi-arch OCI artifacts (image indices) is restricted to the given platforms
+(<code>&lt;os>/&lt;arch></code>). The transferred index is rewritten to list
+only the retained manifests, and the resource digest in the component
+descriptor is recalculated accordingly. This invalidates existing signatures
+of the component version and of all transferred component versions referencing
+it (directly or indirectly), whose reference digests become stale. Therefore,
+such a transfer is rejected for signed component versions, unless the option
+<code>--drop-signatures</code> is given, which removes the signatures from the
+affected component versions and updates the digests of their references.
+`
+	return s
+}
+
+func (o *Option) ApplyTransferOption(opts transferhandler.TransferOptions) error {
+	if len(o.Platforms) > 0 {
+		err := standard.Platforms(o.Platforms...).ApplyTransferOption(opts)
+		if err != nil {
+			return err
+		}
+	}
+	if o.DropSignatures {
+		return standard.DropSignatures().ApplyTransferOption(opts)
+	}
+	return nil
+}
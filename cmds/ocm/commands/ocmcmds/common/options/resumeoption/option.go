@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resumeoption
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/options"
+	"github.com/open-component-model/ocm/pkg/contexts/clictx"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/journal"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler/standard"
+)
+
+func From(o options.OptionSetProvider) *Option {
+	var opt *Option
+	o.AsOptionSet().Get(&opt)
+	return opt
+}
+
+func New() *Option {
+	return &Option{}
+}
+
+type Option struct {
+	standard.TransferOptionsCreator
+	StateFile string
+	Journal   *journal.Journal
+}
+
+var (
+	_ transferhandler.TransferOption        = (*Option)(nil)
+	_ options.OptionWithCLIContextCompleter = (*Option)(nil)
+)
+
+func (o *Option) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.StateFile, "resume", "", "", "transfer state file used to record and resume a transfer")
+}
+
+func (o *Option) Configure(ctx clictx.Context) error {
+	if o.StateFile == "" {
+		return nil
+	}
+	j, err := journal.New(ctx.FileSystem(), o.StateFile)
+	if err != nil {
+		return err
+	}
+	o.Journal = j
+	return nil
+}
+
+func (o *Option) Usage() string {
+	s := `
+If the option <code>--resume</code> is given, the progress of the transfer
+is recorded in the given state file. If the state file already exists,
+the transfer is resumed: component versions and resources already successfully
+transferred according to the state file (and still available in the target
+repository) are skipped, only failed or missing parts are transferred again.
+Component versions changed in the source since they have been recorded
+are transferred again, as well as all component versions, if the transport
+is enforced.
+`
+	return s
+}
+
+func (o *Option) ApplyTransferOption(opts transferhandler.TransferOptions) error {
+	if o.Journal != nil {
+		return standard.Journal(o.Journal).ApplyTransferOption(opts)
+	}
+	return nil
+}
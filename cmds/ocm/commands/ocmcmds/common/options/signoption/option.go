@@ -14,7 +14,9 @@ import (
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/hashoption"
 	"github.com/open-component-model/ocm/cmds/ocm/pkg/options"
 	"github.com/open-component-model/ocm/pkg/contexts/clictx"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/signingattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/trustpolicyattr"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/normalizations/jsonv1"
 	ocmsign "github.com/open-component-model/ocm/pkg/contexts/ocm/signing"
@@ -34,13 +36,14 @@ func From(o options.OptionSetProvider) *Option {
 
 var _ options.Options = (*Option)(nil)
 
-func New(sign bool) *Option {
-	return &Option{SignMode: sign}
+func New(ctx ocm.Context, sign bool) *Option {
+	return &Option{ctx: ctx, SignMode: sign}
 }
 
 type Option struct {
 	keyoption.Option
 
+	ctx   ocm.Context
 	local bool
 
 	SignMode      bool
@@ -55,6 +58,7 @@ type Option struct {
 	SignatureNames []string
 	Update         bool
 	Signer         signing.Signer
+	Verifier       signing.Verifier
 
 	UseTSA bool
 	TSAUrl string
@@ -76,6 +80,7 @@ func (o *Option) AddFlags(fs *pflag.FlagSet) {
 		fs.StringVarP(&o.TSAUrl, "tsa-url", "", "", "TSA server URL")
 	} else {
 		fs.BoolVarP(&o.local, "local", "L", false, "verification based on information found in component versions, only")
+		fs.StringVarP(&o.signAlgorithm, "algorithm", "S", "", "required signature algorithm for verification (default: algorithm of signature)")
 	}
 	fs.BoolVarP(&o.Verify, "verify", "V", o.SignMode, "verify existing digests")
 	fs.BoolVar(&o.Keyless, "keyless", false, "use keyless signing")
@@ -113,6 +118,12 @@ func (o *Option) Configure(ctx clictx.Context) error {
 		}
 	} else {
 		o.Recursively = !o.local
+		if o.signAlgorithm != "" {
+			o.Verifier = signingattr.Get(ctx).GetVerifier(o.signAlgorithm)
+			if o.Verifier == nil {
+				return errors.ErrUnknown(compdesc.KIND_VERIFY_ALGORITHM, o.signAlgorithm)
+			}
+		}
 	}
 
 	err := o.Option.Configure(ctx)
@@ -144,7 +155,7 @@ given signature name will be verified, instead of recreated.
 		s += `
 
 The following signing types are supported with option <code>--algorithm</code>:
-` + listformat.FormatList(rsa.Algorithm, signing.DefaultRegistry().SignerNames()...)
+` + listformat.FormatList(rsa.Algorithm, o.registry().SignerNames()...)
 
 		s += `
 
@@ -154,19 +165,47 @@ The following normalization modes are supported with option <code>--normalizatio
 		s += `
 
 The following hash modes are supported with option <code>--hash</code>:
-` + listformat.FormatList(sha256.Algorithm, signing.DefaultRegistry().HasherNames()...)
+` + listformat.FormatList(sha256.Algorithm, o.registry().HasherNames()...)
+	} else {
+		s += `
+By default, a signature is verified by the handler registered for the
+signature algorithm recorded in the signature. With option
+<code>--algorithm</code> the verification is restricted to signatures
+created with the given algorithm, for example a plugin-provided handler
+verifying signatures with a remote key management service. Verified
+signatures with any other algorithm are rejected. The following handlers
+are supported:
+` + listformat.FormatList("", o.registry().SignerNames()...)
+
+		s += `
 
-		signing.DefaultRegistry().HasherNames()
+If a trust policy is configured with the config type
+<code>` + trustpolicyattr.ConfigType + `</code>, it is evaluated for all
+component versions in the closure of a verified component version. The
+result of the matching rule is reported for every component version and the
+verification fails, if a rule is violated or no rule matches a component
+version (unless the policy is configured to accept such component versions).
+`
 	}
 	return s
 }
 
+func (o *Option) registry() signing.Registry {
+	if o.ctx == nil {
+		return signing.DefaultRegistry()
+	}
+	return signingattr.Get(o.ctx)
+}
+
 var _ ocmsign.Option = (*Option)(nil)
 
 func (o *Option) ApplySigningOption(opts *ocmsign.Options) {
 	if o.Signer != nil {
 		opts.Signer = o.Signer
 	}
+	if o.Verifier != nil {
+		opts.Verifier = o.Verifier
+	}
 	opts.SignatureNames = o.SignatureNames
 	opts.Verify = o.Verify
 	opts.Recursively = o.Recursively
@@ -9,6 +9,7 @@ import (
 
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/components/add"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/components/check"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/components/diff"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/components/download"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/components/get"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/components/hash"
@@ -40,4 +41,5 @@ func AddCommands(ctx clictx.Context, cmd *cobra.Command) {
 	cmd.AddCommand(verify.NewCommand(ctx, verify.Verb))
 	cmd.AddCommand(download.NewCommand(ctx, download.Verb))
 	cmd.AddCommand(check.NewCommand(ctx, check.Verb))
+	cmd.AddCommand(diff.NewCommand(ctx, diff.Verb))
 }
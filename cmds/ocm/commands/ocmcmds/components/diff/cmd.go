@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package diff
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	ocmcommon "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/handlers/comphdlr"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/lookupoption"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/repooption"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/names"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs"
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/output"
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
+	"github.com/open-component-model/ocm/pkg/contexts/clictx"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/utils/diff"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+var (
+	Names = names.Components
+	Verb  = verbs.Diff
+)
+
+type Command struct {
+	utils.BaseCommand
+
+	Old string
+	New string
+}
+
+// NewCommand creates a new component version diff command.
+func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
+	return utils.SetupCommand(
+		&Command{
+			BaseCommand: utils.NewBaseCommand(ctx,
+				repooption.New(),
+				lookupoption.New(),
+				NewOption(),
+				output.OutputOptions(outputs),
+			),
+		},
+		utils.Names(Names, names...)...,
+	)
+}
+
+func (o *Command) ForName(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "[<options>] <old component-reference> <new component-reference>",
+		Args:  cobra.ExactArgs(2),
+		Short: "compare two component versions",
+		Long: `
+Compare two component versions and show the changes required to get from
+the first (old) one to the second (new) one. The provider, labels, sources,
+resources and component references are compared. Sources, resources and
+references are matched by their identity (name and extra identity). For
+matched elements all fields, including digests and access specifications,
+are compared. Labels are matched by their name.
+
+Every change is described by its kind (<code>added</code>,
+<code>removed</code> or <code>modified</code>) and the path of the changed
+field in the component descriptor.
+
+With option <code>--recursive</code> the component versions of the
+reference closures are compared, also. Component versions are matched by
+their component name.
+
+Option <code>--ignore-access</code> ignores changes of access specifications
+of resources and sources. This can be used to compare a component version
+with a transferred one.
+`,
+		Example: `
+$ ocm diff componentversion ghcr.io/mandelsoft/kubelink:1.4.2 ghcr.io/mandelsoft/kubelink:1.5.0
+$ ocm diff componentversion --repo OCIRegistry::ghcr.io -r mandelsoft/kubelink:1.4.2 mandelsoft/kubelink:1.5.0
+`,
+	}
+}
+
+func (o *Command) Complete(args []string) error {
+	o.Old = args[0]
+	o.New = args[1]
+	return nil
+}
+
+func (o *Command) Run() error {
+	session := ocm.NewSession(nil)
+	defer session.Close()
+
+	err := o.ProcessOnOptions(ocmcommon.CompleteOptionsWithSession(o, session))
+	if err != nil {
+		return err
+	}
+
+	opts := output.From(o)
+	oldCV, err := o.lookup(session, opts, o.Old)
+	if err != nil {
+		return err
+	}
+	newCV, err := o.lookup(session, opts, o.New)
+	if err != nil {
+		return err
+	}
+
+	dopts := diff.Diff(From(o), diff.Resolver(lookupoption.From(o)))
+	changes, err := dopts.For(oldCV, newCV)
+	if err != nil {
+		return err
+	}
+	return utils.HandleOutputs(opts, &typeHandler{changes})
+}
+
+func (o *Command) lookup(session ocm.Session, opts *output.Options, ref string) (ocm.ComponentVersionAccess, error) {
+	objs, err := comphdlr.Evaluate(o.Context.OCM(), session, repooption.From(o).Repository, []string{ref}, opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", ref)
+	}
+	if len(objs) > 1 {
+		return nil, fmt.Errorf("%s: multiple component versions selected", ref)
+	}
+	return objs[0].ComponentVersion, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Object struct {
+	*diff.Change
+}
+
+var _ output.Manifest = (*Object)(nil)
+
+func (o *Object) AsManifest() interface{} {
+	return o.Change
+}
+
+type typeHandler struct {
+	changes diff.Changes
+}
+
+var _ utils.TypeHandler = (*typeHandler)(nil)
+
+func (h *typeHandler) All() ([]output.Object, error) {
+	result := []output.Object{}
+	for _, c := range h.changes {
+		result = append(result, &Object{c})
+	}
+	return result, nil
+}
+
+func (h *typeHandler) Get(elemspec utils.ElemSpec) ([]output.Object, error) {
+	return nil, errors.ErrNotSupported("element selection")
+}
+
+func (h *typeHandler) Close() error {
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+var outputs = output.NewOutputs(getRegular, output.Outputs{
+	"wide": getWide,
+}).AddManifestOutputs()
+
+func getRegular(opts *output.Options) output.Output {
+	return (&output.TableOutput{
+		Headers: output.Fields("COMPONENT", "CHANGE", "PATH"),
+		Options: opts,
+		Mapping: mapGetRegularOutput,
+	}).New()
+}
+
+func getWide(opts *output.Options) output.Output {
+	return (&output.TableOutput{
+		Headers: output.Fields("COMPONENT", "CHANGE", "PATH", "OLD", "NEW"),
+		Options: opts,
+		Mapping: mapGetWideOutput,
+	}).New()
+}
+
+func mapGetRegularOutput(e interface{}) interface{} {
+	c := e.(*Object)
+	return []string{c.Component, string(c.Kind), c.Path}
+}
+
+func mapGetWideOutput(e interface{}) interface{} {
+	c := e.(*Object)
+	return append(mapGetRegularOutput(e).([]string), diff.FormatValue(c.Old), diff.FormatValue(c.New))
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package diff_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/cmds/ocm/testhelper"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const (
+	ARCH     = "/tmp/ctf"
+	PROVIDER = "acme.org"
+	COMP     = "acme.org/comp"
+	SUB      = "acme.org/sub"
+	V1       = "1.0.0"
+	V2       = "2.0.0"
+)
+
+var _ = Describe("Test Environment", func() {
+	var env *TestEnv
+
+	BeforeEach(func() {
+		env = NewTestEnv()
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.ComponentVersion(SUB, V1, func() {
+				env.Provider(PROVIDER)
+			})
+			env.ComponentVersion(SUB, V2, func() {
+				env.Provider(PROVIDER)
+				env.Label("stage", "test")
+			})
+			env.ComponentVersion(COMP, V1, func() {
+				env.Provider(PROVIDER)
+				env.Label("stage", "dev")
+				env.Resource("text", V1, resourcetypes.PLAIN_TEXT, metav1.LocalRelation, func() {
+					env.BlobStringData(mime.MIME_TEXT, "old")
+				})
+				env.Reference("sub", SUB, V1)
+			})
+			env.ComponentVersion(COMP, V2, func() {
+				env.Provider(PROVIDER)
+				env.Label("stage", "prod")
+				env.Resource("text", V1, resourcetypes.PLAIN_TEXT, metav1.LocalRelation, func() {
+					env.BlobStringData(mime.MIME_TEXT, "new")
+				})
+				env.Reference("sub", SUB, V2)
+			})
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("shows changes", func() {
+		buf := bytes.NewBuffer(nil)
+		MustBeSuccessful(env.CatchOutput(buf).Execute("diff", "componentversions", "--repo", ARCH, "--ignore-access", COMP+":"+V1, COMP+":"+V2))
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+COMPONENT     CHANGE   PATH
+acme.org/comp modified labels[stage].value
+acme.org/comp modified version
+acme.org/comp modified resources[name=text].digest.value
+acme.org/comp modified componentReferences[name=sub].version
+`))
+	})
+
+	It("shows changes of closure", func() {
+		buf := bytes.NewBuffer(nil)
+		MustBeSuccessful(env.CatchOutput(buf).Execute("diff", "componentversions", "--repo", ARCH, "-r", "--ignore-access", "-o", "wide", COMP+":"+V1, COMP+":"+V2))
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+COMPONENT     CHANGE   PATH                                  OLD                                                              NEW
+acme.org/comp modified labels[stage].value                   dev                                                              prod
+acme.org/comp modified version                               1.0.0                                                            2.0.0
+acme.org/comp modified resources[name=text].digest.value     cba06b5736faf67e54b07b561eae94395e774c517a7d910a54369e1263ccfbd4 11507a0e2f5e69d5dfa40a62a1bd7b6ee57e6bcd85c67c9b8431b36fff21c437
+acme.org/comp modified componentReferences[name=sub].version 1.0.0                                                            2.0.0
+acme.org/sub  added    labels[stage]                                                                                          {"value":"test"}
+acme.org/sub  modified version                               1.0.0                                                            2.0.0
+`))
+	})
+
+	It("shows changes as yaml", func() {
+		buf := bytes.NewBuffer(nil)
+		MustBeSuccessful(env.CatchOutput(buf).Execute("diff", "componentversions", "--repo", ARCH, "-o", "yaml", SUB+":"+V1, SUB+":"+V2))
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+---
+component: acme.org/sub
+kind: added
+new:
+  value: test
+path: labels[stage]
+---
+component: acme.org/sub
+kind: modified
+new: 2.0.0
+old: 1.0.0
+path: version
+`))
+	})
+})
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package diff
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/options"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/utils/diff"
+	"github.com/open-component-model/ocm/pkg/optionutils"
+)
+
+func From(o options.OptionSetProvider) *Option {
+	var opt *Option
+	o.AsOptionSet().Get(&opt)
+	return opt
+}
+
+var _ options.Options = (*Option)(nil)
+
+type Option struct {
+	Recursive    bool
+	IgnoreAccess bool
+}
+
+func NewOption() *Option {
+	return &Option{}
+}
+
+func (o *Option) ApplyTo(opts *diff.Options) {
+	optionutils.ApplyOption(&o.Recursive, &opts.Recursive)
+	optionutils.ApplyOption(&o.IgnoreAccess, &opts.IgnoreAccess)
+}
+
+func (o *Option) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVarP(&o.Recursive, "recursive", "r", false, "compare the component versions of the reference closures, also")
+	fs.BoolVarP(&o.IgnoreAccess, "ignore-access", "", false, "ignore changes of access specifications (for example caused by a transfer)")
+}
@@ -8,6 +8,8 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/mandelsoft/vfs/pkg/layerfs"
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
 	"github.com/mandelsoft/vfs/pkg/vfs"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -16,10 +18,14 @@ import (
 	"github.com/open-component-model/ocm/cmds/ocm/commands/common/options/formatoption"
 	ocmcommon "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/handlers/comphdlr"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/dryrunoption"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/lookupoption"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/omitaccesstypeoption"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/overwriteoption"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/paralleloption"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/platformoption"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/repooption"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/resumeoption"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/rscbyvalueoption"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/scriptoption"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/skipupdateoption"
@@ -34,10 +40,13 @@ import (
 	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
 	"github.com/open-component-model/ocm/pkg/common"
 	"github.com/open-component-model/ocm/pkg/contexts/clictx"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext/attrs/vfsattr"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/plan"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler/spiff"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler/standard"
 	"github.com/open-component-model/ocm/pkg/errors"
 	"github.com/open-component-model/ocm/pkg/generics"
 	"github.com/open-component-model/ocm/pkg/out"
@@ -70,7 +79,12 @@ func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
 		rscbyvalueoption.New(),
 		srcbyvalueoption.New(),
 		omitaccesstypeoption.New(),
+		platformoption.New(),
 		stoponexistingoption.New(),
+		paralleloption.New(),
+		resumeoption.New(),
+		dryrunoption.New("plan the transfer without writing to the target repository", false),
+		output.OutputOptions(planOutputs),
 		uploaderoption.New(ctx.OCMContext()),
 		scriptoption.New(),
 	)}, utils.Names(Names, names...)...)
@@ -85,10 +99,19 @@ func (o *Command) ForName(name string) *cobra.Command {
 Transfer all component versions specified to the given target repository.
 If only a component (instead of a component version) is specified all versions
 are transferred.
+
+With the option <code>--dry-run</code> the transfer is only planned. Nothing
+is written to the target repository. Instead, the decisions taken for the
+involved component versions (new, overwritten, updated or skipped) and their
+resources and sources (copied by-value or kept by reference, expected
+OCI upload targets and estimated blob sizes) are shown using the selected
+output mode.
 `,
 		Example: `
 $ ocm transfer components -t tgz ghcr.io/mandelsoft/kubelink ctf.tgz
 $ ocm transfer components -t tgz --repo OCIRegistry::ghcr.io mandelsoft/kubelink ctf.tgz
+$ ocm transfer components --dry-run -o wide --copy-resources ghcr.io/mandelsoft/kubelink ghcr.io/acme
+$ ocm transfer components --copy-resources --platform linux/arm64 ghcr.io/mandelsoft/kubelink ghcr.io/acme
 `,
 	}
 }
@@ -122,22 +145,40 @@ func (o *Command) Run() error {
 		return err
 	}
 
+	var p *plan.Plan
+	var fs vfs.FileSystem = o.Context.FileSystem()
+	printer := common.NewPrinter(o.Context.StdOut())
+	if dryrunoption.From(o).DryRun {
+		p = plan.New()
+		printer = common.NewPrinter(nil)
+		// file based targets are opened (and potentially created)
+		// on a copy-on-write layer to keep the filesystem untouched.
+		fs = layerfs.New(memoryfs.New(), fs)
+		vfsattr.Set(o.Context.OCMContext().AttributesContext(), fs)
+	} else if mode := output.From(o).OutputMode; mode != "" {
+		return errors.Newf("output mode %q requires option --dry-run", mode)
+	}
+
 	err = uploaderoption.From(o).Register(o)
 	if err != nil {
 		return err
 	}
 
-	target, err := ocm.AssureTargetRepository(session, o.Context.OCMContext(), o.TargetName, ocm.CommonTransportFormat, formatoption.From(o).ChangedFormat(), o.Context.FileSystem())
+	target, err := ocm.AssureTargetRepository(session, o.Context.OCMContext(), o.TargetName, ocm.CommonTransportFormat, formatoption.From(o).ChangedFormat(), fs)
 	if err != nil {
 		return err
 	}
 
 	transferopts := &spiff.Options{}
 	transferhandler.From(o.ConfigContext(), transferopts)
-	transferhandler.ApplyOptions(transferopts, append(options.FindOptions[transferhandler.TransferOption](o),
+	topts := append(options.FindOptions[transferhandler.TransferOption](o),
 		spiff.Script(scriptoption.From(o).ScriptData),
 		spiff.ScriptFilesystem(o.FileSystem()),
-	)...)
+	)
+	if p != nil {
+		topts = append(topts, standard.Plan(p))
+	}
+	transferhandler.ApplyOptions(transferopts, topts...)
 	thdlr, err := spiff.New(transferopts)
 
 	if err != nil {
@@ -146,7 +187,8 @@ func (o *Command) Run() error {
 	hdlr := comphdlr.NewTypeHandler(o.Context.OCM(), session, repooption.From(o).Repository, comphdlr.OptionsFor(o))
 	err = utils.HandleOutput(&action{
 		cmd:     o,
-		printer: common.NewPrinter(o.Context.StdOut()),
+		printer: printer,
+		plan:    p,
 		target:  target,
 		handler: thdlr,
 		closure: transfer.TransportClosure{},
@@ -167,6 +209,7 @@ type action struct {
 	handler transferhandler.TransferHandler
 	closure transfer.TransportClosure
 	errors  *errors.ErrorList
+	plan    *plan.Plan
 }
 
 var _ output.Output = (*action)(nil)
@@ -180,6 +223,9 @@ func (a *action) Add(e interface{}) error {
 	a.errors.Add(err)
 	if err != nil {
 		a.printer.Printf("Error: %s\n", err)
+		if a.plan != nil {
+			out.Errf(a.cmd, "Error: %s\n", err)
+		}
 	}
 	return nil
 }
@@ -189,6 +235,16 @@ func (a *action) Close() error {
 }
 
 func (a *action) Out() error {
+	if a.plan != nil {
+		err := outputPlan(output.From(a.cmd), a.plan)
+		if err != nil {
+			return err
+		}
+		if a.errors.Result() != nil {
+			return fmt.Errorf("transfer plan finished with %d error(s)", a.errors.Len())
+		}
+		return nil
+	}
 	a.printer.Printf("%d versions transferred\n", len(a.closure))
 	if a.errors.Result() != nil {
 		return fmt.Errorf("transfer finished with %d error(s)", a.errors.Len())
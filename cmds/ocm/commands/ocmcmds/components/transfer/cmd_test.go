@@ -160,6 +160,36 @@ transferring version "github.com/mandelsoft/test:v1"...
 		CheckComponentInArchive(env, ldesc, OUT)
 	})
 
+	It("plans transfer of ctf with --dry-run", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("transfer", "components", "--dry-run", "--copy-resources", ARCH, ARCH, OUT)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+COMPONENT                  VERSION ACTION COPIED SIZE MESSAGE
+github.com/mandelsoft/test v1      new    3      35   
+estimated size of copied artifacts: 35 bytes
+`))
+		Expect(env.DirExists(OUT)).To(BeFalse())
+	})
+
+	It("plans transfer of ctf with --dry-run -o wide", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("transfer", "components", "--dry-run", "-o", "wide", "--copy-resources", ARCH, ARCH, OUT)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+COMPONENT                  VERSION ACTION KIND     INDEX NAME     TYPE      MODE  ARTIFACT ACTION TARGET SIZE
+github.com/mandelsoft/test v1      new    resource 0     testdata plainText value copy                   8
+github.com/mandelsoft/test v1      new    resource 1     value    ociImage  value copy                   15
+github.com/mandelsoft/test v1      new    resource 2     ref      ociImage  value copy                   12
+estimated size of copied artifacts: 35 bytes
+`))
+		Expect(env.DirExists(OUT)).To(BeFalse())
+	})
+
+	It("rejects output mode without --dry-run", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("transfer", "components", "-o", "yaml", ARCH, ARCH, OUT)).To(MatchError(`output mode "yaml" requires option --dry-run`))
+		Expect(env.DirExists(OUT)).To(BeFalse())
+	})
+
 	It("transfers ctf with --closure --lookup", func() {
 		buf := bytes.NewBuffer(nil)
 		Expect(env.CatchOutput(buf).Execute("transfer", "components", "--copy-resources", "--recursive", "--lookup", ARCH, ARCH2, ARCH2, OUT)).To(Succeed())
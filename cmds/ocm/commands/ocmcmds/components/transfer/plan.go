@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transfer
+
+import (
+	"fmt"
+
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/output"
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/processing"
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/plan"
+	"github.com/open-component-model/ocm/pkg/out"
+)
+
+var planOutputs = output.NewOutputs(getRegular, output.Outputs{
+	"wide": getWide,
+}).AddManifestOutputs()
+
+// planOutput hides the sort option of the table outputs, the
+// plan is always shown in processing order.
+type planOutput struct {
+	output.Output
+}
+
+func getRegular(opts *output.Options) output.Output {
+	return &planOutput{(&output.TableOutput{
+		Headers: output.Fields("COMPONENT", "VERSION", "ACTION", "COPIED", "SIZE", "MESSAGE"),
+		Options: opts,
+		Mapping: mapRegularOutput,
+	}).New()}
+}
+
+func getWide(opts *output.Options) output.Output {
+	return &planOutput{(&output.TableOutput{
+		Headers: output.Fields("COMPONENT", "VERSION", "ACTION", "KIND", "INDEX", "NAME", "TYPE", "MODE", "ARTIFACT ACTION", "TARGET", "SIZE"),
+		Options: opts,
+		Chain:   processing.Explode(explodeArtifacts),
+		Mapping: mapWideOutput,
+	}).New()}
+}
+
+// planEntry is the output element for a planned component version.
+type planEntry struct {
+	*plan.Version
+}
+
+var _ output.Manifest = (*planEntry)(nil)
+
+func (e *planEntry) AsManifest() interface{} {
+	return e.Version
+}
+
+func mapRegularOutput(e interface{}) interface{} {
+	v := e.(*planEntry)
+	n := 0
+	for _, list := range [][]*plan.Artifact{v.Resources, v.Sources} {
+		for _, a := range list {
+			if a.Action == plan.ACTION_COPY {
+				n++
+			}
+		}
+	}
+	return []string{v.Component, v.Version.Version, v.Action, fmt.Sprintf("%d", n), formatSize(v.GetSize()), v.Message}
+}
+
+// artifactEntry is a table row for a single resource or source of
+// a planned component version.
+type artifactEntry struct {
+	version  *planEntry
+	kind     string
+	artifact *plan.Artifact
+}
+
+func explodeArtifacts(e interface{}) []interface{} {
+	v := e.(*planEntry)
+	var list []interface{}
+	for _, a := range v.Resources {
+		list = append(list, &artifactEntry{v, "resource", a})
+	}
+	for _, a := range v.Sources {
+		list = append(list, &artifactEntry{v, "source", a})
+	}
+	if len(list) == 0 {
+		list = append(list, &artifactEntry{version: v})
+	}
+	return list
+}
+
+func mapWideOutput(e interface{}) interface{} {
+	r := e.(*artifactEntry)
+	v := r.version
+	if r.artifact == nil {
+		return []string{v.Component, v.Version.Version, v.Action, "", "", "", "", "", "", "", ""}
+	}
+	a := r.artifact
+	size := ""
+	if a.Action == plan.ACTION_COPY {
+		size = formatSize(a.Size, a.Size != blobaccess.BLOB_UNKNOWN_SIZE)
+	}
+	return []string{v.Component, v.Version.Version, v.Action, r.kind, fmt.Sprintf("%d", a.Index), a.Name, a.Type, a.Mode, a.Action, a.Target, size}
+}
+
+func formatSize(size int64, complete bool) string {
+	switch {
+	case complete:
+		return fmt.Sprintf("%d", size)
+	case size == 0:
+		return "unknown"
+	default:
+		return fmt.Sprintf(">=%d", size)
+	}
+}
+
+// outputPlan renders the plan recorded by a dry-run using the selected
+// output mode. Table outputs are completed by the estimated total size.
+func outputPlan(opts *output.Options, p *plan.Plan) error {
+	for _, v := range p.GetVersions() {
+		opts.Output.Add(&planEntry{v})
+	}
+	opts.Output.Close()
+	err := opts.Output.Out()
+	if err != nil {
+		return err
+	}
+	if _, ok := opts.Output.(*planOutput); ok {
+		size, complete := p.GetSize()
+		s := formatSize(size, complete)
+		if complete || size > 0 {
+			s += " bytes"
+		}
+		out.Outf(opts.Context, "estimated size of copied artifacts: %s\n", s)
+	}
+	return nil
+}
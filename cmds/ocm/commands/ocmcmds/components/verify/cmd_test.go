@@ -25,9 +25,11 @@ import (
 	"github.com/open-component-model/ocm/pkg/contexts/ocm"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/ociartifact"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/signingattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/trustpolicyattr"
 	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/signing/trustpolicy"
 	"github.com/open-component-model/ocm/pkg/mime"
 	"github.com/open-component-model/ocm/pkg/signing/handlers/rsa"
 )
@@ -153,6 +155,45 @@ applying to version "github.com/mandelsoft/ref:v1"[github.com/mandelsoft/ref:v1]
   reference 0:  github.com/mandelsoft/test:v1: digest SHA-256:01de99400030e8336020059a435cea4e7fe8f21aad4faf619da882134b85569d[jsonNormalisation/v1]
   resource 0:  "name"="otherdata": digest SHA-256:54b8007913ec5a907ca69001d59518acfd106f7b02f892eabf9cae3f8b2414b4[genericBlobDigest/v1]
 successfully verified github.com/mandelsoft/ref:v1 (digest SHA-256:` + digest + `)
+`))
+	})
+
+	It("evaluates trust policy", func() {
+		buf := bytes.NewBuffer(nil)
+
+		src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+		cv := Must(src.LookupComponentVersion(COMPONENTB, VERSION))
+		opts := NewOptions(
+			Sign(signingattr.Get(env.OCMContext()).GetSigner(SIGN_ALGO), SIGNATURE),
+			Resolver(src),
+			PrivateKey(SIGNATURE, priv),
+			Update(), VerifyDigests(),
+		)
+		MustBeSuccessful(opts.Complete(DefaultContext))
+		Must(Apply(nil, nil, cv, opts))
+		MustBeSuccessful(cv.Close())
+		MustBeSuccessful(src.Close())
+
+		MustBeSuccessful(env.ConfigContext().ApplyConfig(trustpolicyattr.New(&trustpolicy.Rule{
+			Name:       "all",
+			Components: []string{"*"},
+			Signatures: []string{SIGNATURE},
+		}), "test"))
+
+		Expect(env.CatchOutput(buf).Execute("verify", "components", "-s", SIGNATURE, "-k", PUBKEY, "--repo", ARCH, COMPONENTB+":"+VERSION)).To(HaveOccurred())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+applying to version "github.com/mandelsoft/ref:v1"[github.com/mandelsoft/ref:v1]...
+  no digest found for "github.com/mandelsoft/test:v1"
+  applying to version "github.com/mandelsoft/test:v1"[github.com/mandelsoft/ref:v1]...
+    resource 0:  "name"="testdata": digest SHA-256:810ff2fb242a5dee4220f2cb0e6a519891fb67f2f828a6cab4ef8894633b1f50[genericBlobDigest/v1]
+    resource 1:  "name"="value": digest SHA-256:0c4abdb72cf59cb4b77f4aacb4775f9f546ebc3face189b2224a966c8826ca9f[ociArtifactDigest/v1]
+    resource 2:  "name"="ref": digest SHA-256:c2d2dca275c33c1270dea6168a002d67c0e98780d7a54960758139ae19984bd7[ociArtifactDigest/v1]
+  trust policy rule "all" failed for github.com/mandelsoft/test:v1: 0 of 1 required trusted signatures (signature "test" not found)
+  reference 0:  github.com/mandelsoft/test:v1: digest SHA-256:01de99400030e8336020059a435cea4e7fe8f21aad4faf619da882134b85569d[jsonNormalisation/v1]
+  resource 0:  "name"="otherdata": digest SHA-256:54b8007913ec5a907ca69001d59518acfd106f7b02f892eabf9cae3f8b2414b4[genericBlobDigest/v1]
+trust policy rule "all" passed for github.com/mandelsoft/ref:v1 (signatures test)
+failed verifying signature of github.com/mandelsoft/ref:v1: trust policy violated: github.com/mandelsoft/test:v1 (rule "all"): 0 of 1 required trusted signatures (signature "test" not found)
+finished with 1 error(s)
 `))
 	})
 })
@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clean
+
+import (
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/names"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs"
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/clictx"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/out"
+)
+
+var (
+	Names = names.CommonTransportArchive
+	Verb  = verbs.Clean
+)
+
+type Command struct {
+	utils.BaseCommand
+
+	Archive string
+	dryrun  bool
+	rules   ctf.RetentionRules
+}
+
+// NewCommand creates a new ctf command.
+func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
+	return utils.SetupCommand(&Command{BaseCommand: utils.NewBaseCommand(ctx)}, utils.Names(Names, names...)...)
+}
+
+func (o *Command) ForName(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "[<options>] <ctf>",
+		Args:  cobra.ExactArgs(1),
+		Short: "garbage collect and prune transport archive",
+		Long: `
+Remove all blobs of a Common Transport Archive, which are not referenced
+anymore by any artifact (manifests, indices, config and layer blobs,
+including local blobs of component versions) listed in its index.
+
+Optionally, old component versions can be pruned before. A component
+version is kept, if it is selected by any of the given retention rules:
+
+- <code>--keep-last</code> keeps the latest <em>n</em> versions of every component.
+- <code>--keep-major-latest</code> keeps the latest version of every major
+  release of a component.
+
+Versions not conforming to semver are never pruned. Artifacts referring
+to a pruned component version (for example signatures or SBOMs) are
+removed, also. Component versions (transitively) referenced by a retained
+component version are kept, even if they are not selected by a rule.
+
+With option <code>--dry-run</code> the archive is not modified, the
+versions and blobs to be removed are just reported.
+`,
+		Example: `
+$ ocm clean ctf ctf.tgz
+$ ocm clean ctf --keep-last 3 --dry-run ctf.tgz
+`,
+	}
+}
+
+func (o *Command) AddFlags(fs *pflag.FlagSet) {
+	o.BaseCommand.AddFlags(fs)
+	fs.BoolVarP(&o.dryrun, "dry-run", "s", false, "only report blobs and versions to be removed")
+	fs.IntVarP(&o.rules.KeepLast, "keep-last", "N", 0, "keep latest n versions of every component")
+	fs.BoolVarP(&o.rules.KeepMajorLatest, "keep-major-latest", "M", false, "keep latest version of every major release")
+}
+
+func (o *Command) Complete(args []string) error {
+	if o.rules.KeepLast < 0 {
+		return errors.ErrInvalid("version count", strconv.Itoa(o.rules.KeepLast))
+	}
+	o.Archive = args[0]
+	return nil
+}
+
+func (o *Command) Run() (err error) {
+	mode := accessobj.ACC_WRITABLE
+	if o.dryrun {
+		mode = accessobj.ACC_READONLY
+	}
+	repo, err := ctf.Open(o.Context.OCMContext(), mode, o.Archive, 0, o.FileSystem())
+	if err != nil {
+		return errors.Wrapf(err, "cannot open archive")
+	}
+	defer errors.PropagateError(&err, repo.Close)
+
+	var result *ctf.PruneResult
+	if o.rules.IsEmpty() {
+		var gc *ctf.GCResult
+		gc, err = ctf.GC(repo, o.dryrun)
+		if err == nil {
+			result = &ctf.PruneResult{GCResult: gc}
+		}
+	} else {
+		result, err = ctf.Prune(repo, o.rules, o.dryrun)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, nv := range result.Pruned {
+		if o.dryrun {
+			out.Outf(o.Context, "would prune %s\n", nv)
+		} else {
+			out.Outf(o.Context, "pruned %s\n", nv)
+		}
+	}
+	for _, nv := range result.Referenced {
+		out.Outf(o.Context, "keeping %s (referenced by retained versions)\n", nv)
+	}
+	for _, d := range result.Missing {
+		out.Errf(o.Context, "Warning: referenced blob %s is missing\n", d)
+	}
+	if o.dryrun {
+		out.Outf(o.Context, "Would remove %d blobs [%.3f MB]\n", len(result.Orphaned), float64(result.Size())/1024/1024)
+	} else {
+		out.Outf(o.Context, "Successfully deleted %d blobs [%.3f MB]\n", len(result.Orphaned), float64(result.Size())/1024/1024)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clean_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/cmds/ocm/testhelper"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const ARCH = "/tmp/ctf"
+const PROVIDER = "mandelsoft"
+const COMPONENT = "github.com/mandelsoft/test"
+
+var _ = Describe("Test Environment", func() {
+	var env *TestEnv
+
+	BeforeEach(func() {
+		env = NewTestEnv()
+
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			for _, v := range []string{"1.0.0", "1.1.0", "2.0.0"} {
+				env.Component(COMPONENT, func() {
+					env.Version(v, func() {
+						env.Provider(PROVIDER)
+						env.Resource("testdata", "", "PlainText", metav1.LocalRelation, func() {
+							env.BlobStringData(mime.MIME_TEXT, "testdata "+v)
+						})
+					})
+				})
+			}
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	versions := func() []string {
+		repo := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(repo)
+		c := Must(repo.LookupComponent(COMPONENT))
+		defer Close(c)
+		return Must(c.ListVersions())
+	}
+
+	It("reports nothing for clean archive", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("clean", "ctf", ARCH)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+Successfully deleted 0 blobs [0.000 MB]
+`))
+	})
+
+	It("reports pruned versions in dry-run mode", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("clean", "ctf", "--dry-run", "--keep-major-latest", ARCH)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+would prune github.com/mandelsoft/test:1.0.0
+Would remove 4 blobs [0.003 MB]
+`))
+		Expect(versions()).To(ConsistOf("1.0.0", "1.1.0", "2.0.0"))
+	})
+
+	It("prunes versions", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("clean", "ctf", "--keep-last", "1", ARCH)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+pruned github.com/mandelsoft/test:1.1.0
+pruned github.com/mandelsoft/test:1.0.0
+Successfully deleted 8 blobs [0.006 MB]
+`))
+		Expect(versions()).To(ConsistOf("2.0.0"))
+	})
+
+	It("rejects invalid version count", func() {
+		ExpectError(env.Execute("clean", "ctf", "--keep-last", "-1", ARCH)).To(MatchError("version count \"-1\" is invalid"))
+	})
+})
@@ -7,6 +7,7 @@ package ctf
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/ctf/clean"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/ctf/transfer"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/names"
 	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
@@ -21,5 +22,6 @@ func NewCommand(ctx clictx.Context) *cobra.Command {
 		Short: "Commands acting on common transport archives",
 	}, Names...)
 	cmd.AddCommand(transfer.NewCommand(ctx, transfer.Verb))
+	cmd.AddCommand(clean.NewCommand(ctx, clean.Verb))
 	return cmd
 }
@@ -19,4 +19,5 @@ var (
 	Plugins                = []string{"plugins", "plugin", "p"}
 	Action                 = []string{"action"}
 	RoutingSlips           = []string{"routingslips", "routingslip", "rs"}
+	SBOM                   = []string{"sbom", "sboms"}
 )
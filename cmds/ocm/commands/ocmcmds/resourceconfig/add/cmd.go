@@ -66,7 +66,7 @@ This command accepts additional resource specification files describing the sour
 to add to a component version.
 
 ` + (&template.Options{}).Usage() +
-		inputs.Usage(inputs.DefaultInputTypeScheme) +
+		inputs.Usage(inputs.For(o.Context)) +
 		ocm.AccessUsage(o.OCMContext().AccessMethods(), true)
 }
 
@@ -15,7 +15,10 @@ import (
 	"github.com/open-component-model/ocm/pkg/common/accessio"
 	"github.com/open-component-model/ocm/pkg/common/accessobj"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/labels/routingslip"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/labels/routingslip/types/approval"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/labels/routingslip/types/comment"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/labels/routingslip/types/deployment"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/labels/routingslip/types/scan"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
 )
 
@@ -74,6 +77,28 @@ var _ = Describe("Test Environment", func() {
 		Expect(Must(slip.Get(0).Payload.Evaluate(env.OCMContext())).Describe(env.OCMContext())).To(Equal("Comment: first entry"))
 	})
 
+	DescribeTable("adds predefined entry types by explicit field options", func(typ string, desc string, args ...string) {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute(append([]string{"add", "routingslip", ARCH, PROVIDER, typ}, args...)...)).To(Succeed())
+		repo := Must(ctf.Open(env, accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(repo, "repo")
+		cv := Must(repo.LookupComponentVersion(COMP, VERSION))
+		defer Close(cv, "cv")
+		slip := Must(routingslip.GetSlip(cv, PROVIDER))
+		Expect(slip.Len()).To(Equal(1))
+		Expect(Must(slip.Get(0).Payload.Evaluate(env.OCMContext())).Describe(env.OCMContext())).To(Equal(desc))
+	},
+		Entry("scan", scan.Type, "Scan by trivy: passed (result sha256:3a6eb0790f39ac87c94f3856b2dd2c5d110e6811602261a9a923d3bb23adc8b7)",
+			"--tool", "trivy", "--verdict", "passed", "--resultDigest", "sha256:3a6eb0790f39ac87c94f3856b2dd2c5d110e6811602261a9a923d3bb23adc8b7"),
+		Entry("approval", approval.Type, "Approved by alice (ticket REL-1)", "--approver", "alice", "--ticket", "REL-1"),
+		Entry("deployment", deployment.Type, "Deployed to production at 2024-01-02T10:00:00Z", "--target", "production", "--timestamp", "2024-01-02T10:00:00Z"),
+	)
+
+	It("fails for incomplete predefined entry", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("add", "routingslip", ARCH, PROVIDER, scan.Type, "--tool", "trivy")).To(MatchError(`"verdict" required`))
+	})
+
 	It("adds dynamic entry by generic entry option", func() {
 		buf := bytes.NewBuffer(nil)
 		Expect(env.CatchOutput(buf).Execute("add", "routingslip", ARCH, PROVIDER, "arbitrary", "--entry", "comment: first entry")).To(Succeed())
@@ -10,6 +10,7 @@ import (
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/names"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/routingslips/add"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/routingslips/get"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/routingslips/verify"
 	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
 	"github.com/open-component-model/ocm/pkg/contexts/clictx"
 )
@@ -28,4 +29,5 @@ func NewCommand(ctx clictx.Context) *cobra.Command {
 func AddCommands(ctx clictx.Context, cmd *cobra.Command) {
 	cmd.AddCommand(add.NewCommand(ctx, add.Verb))
 	cmd.AddCommand(get.NewCommand(ctx, get.Verb))
+	cmd.AddCommand(verify.NewCommand(ctx, verify.Verb))
 }
@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package verify
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/open-component-model/ocm/cmds/ocm/commands/common/options/keyoption"
+	ocmcommon "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/handlers/comphdlr"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/lookupoption"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/repooption"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/names"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs"
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/output"
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/clictx"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/signingattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/labels/routingslip"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/out"
+	"github.com/open-component-model/ocm/pkg/signing"
+	utils2 "github.com/open-component-model/ocm/pkg/utils"
+)
+
+var (
+	Names = names.RoutingSlips
+	Verb  = verbs.Verify
+)
+
+type Command struct {
+	utils.BaseCommand
+
+	Comp          string
+	Slips         []string
+	IntegrityOnly bool
+}
+
+// NewCommand creates a new routing slip verify command.
+func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
+	return utils.SetupCommand(&Command{BaseCommand: utils.NewBaseCommand(ctx, repooption.New(), lookupoption.New(), keyoption.New())}, utils.Names(Names, names...)...)
+}
+
+func (o *Command) ForName(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "[<options>] <component-version> {<routing-slip>}",
+		Args:  cobra.MinimumNArgs(1),
+		Short: "verify routing slips of a component version",
+		Long: `
+Verify all or the selected routing slips of a component version.
+For every routing slip the digest chain of all entries and the links to
+entries of other routing slips are checked. This can be used to check the
+integrity of the routing slips, for example, after a transfer.
+
+Additionally, the signatures of the leaf entries are verified, if not
+disabled with option <code>--integrity-only</code>. The public key for a
+routing slip is looked up with the name of the routing slip. If no key is
+given, a certificate provided with a signature is used, if it can be
+validated with the known root certificates.
+` + keyoption.Usage(),
+		Example: `
+$ ocm verify routingslips --public-key acme.org=acme.pub ghcr.io/mandelsoft/ocm//ocmdemoinstaller:0.0.1-dev acme.org
+`,
+	}
+}
+
+func (o *Command) AddFlags(fs *pflag.FlagSet) {
+	o.BaseCommand.AddFlags(fs)
+	fs.BoolVarP(&o.IntegrityOnly, "integrity-only", "", false, "check digests and links, only (no signature verification)")
+}
+
+func (o *Command) Complete(args []string) error {
+	o.Comp = args[0]
+	o.Slips = args[1:]
+	return nil
+}
+
+func (o *Command) Run() error {
+	session := ocm.NewSession(nil)
+	defer session.Close()
+
+	err := o.ProcessOnOptions(ocmcommon.CompleteOptionsWithSession(o, session))
+	if err != nil {
+		return err
+	}
+	handler := comphdlr.NewTypeHandler(o.Context.OCM(), session, repooption.From(o).Repository)
+	return utils.HandleOutput(&action{cmd: o}, handler, utils.StringElemSpecs(o.Comp)...)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type action struct {
+	data comphdlr.Objects
+	cmd  *Command
+}
+
+var _ output.Output = (*action)(nil)
+
+func (a *action) Add(e interface{}) error {
+	if len(a.data) > 0 {
+		return errors.New("found multiple component versions")
+	}
+	o, ok := e.(*comphdlr.Object)
+	if !ok {
+		return fmt.Errorf("object of type %T is not a valid comphdlr.Object", e)
+	}
+	a.data = append(a.data, o)
+	return nil
+}
+
+func (a *action) Close() error {
+	return nil
+}
+
+func (a *action) Out() error {
+	if len(a.data) == 0 {
+		return fmt.Errorf("no component version selected")
+	}
+
+	cv := a.data[0].ComponentVersion
+	label, err := routingslip.Get(cv)
+	if err != nil {
+		return err
+	}
+
+	slips := a.cmd.Slips
+	if len(slips) == 0 {
+		slips = utils2.StringMapKeys(label)
+		if len(slips) == 0 {
+			out.Outf(a.cmd, "no routing slips found for %s\n", common.VersionedElementKey(cv))
+			return nil
+		}
+	}
+
+	keys := keyoption.From(a.cmd)
+	registry := signing.RegistryWithPreferredKeys(signingattr.Get(cv.GetContext()), keys.Keys)
+
+	list := errors.ErrListf("routing slip verification for %s", common.VersionedElementKey(cv))
+	for _, n := range slips {
+		slip, err := label.Query(n)
+		if err == nil && slip == nil {
+			err = errors.ErrNotFound(routingslip.KIND_ROUTING_SLIP, n)
+		}
+		if err == nil {
+			err = slip.VerifyWith(cv.GetContext(), registry, keys.RootCerts, !a.cmd.IntegrityOnly)
+		}
+		if err != nil {
+			out.Outf(a.cmd, "routing slip %s: failed: %s\n", n, err)
+			list.Add(errors.Wrapf(err, "routing slip %s", n))
+		} else {
+			out.Outf(a.cmd, "routing slip %s: %d entries verified\n", n, slip.Len())
+		}
+	}
+	return list.Result()
+}
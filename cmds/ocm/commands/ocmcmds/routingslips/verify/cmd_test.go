@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package verify_test
+
+import (
+	"bytes"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/cmds/ocm/testhelper"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/signingattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/labels/routingslip"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/labels/routingslip/types/approval"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/labels/routingslip/types/comment"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/labels/routingslip/types/scan"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/finalizer"
+	"github.com/open-component-model/ocm/pkg/signing/handlers/rsa"
+)
+
+const ARCH = "/tmp/ca"
+const VERSION = "v1"
+const COMP = "test.de/x"
+const PROVIDER = "acme.org"
+const OTHER = "other.org"
+
+const PUBKEY = "/tmp/pub"
+const OTHERKEY = "/tmp/other"
+
+var _ = Describe("Test Environment", func() {
+	var env *TestEnv
+	var e1b *routingslip.HistoryEntry
+
+	BeforeEach(func() {
+		env = NewTestEnv()
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.Component(COMP, func() {
+				env.Version(VERSION, func() {
+					env.Provider(PROVIDER)
+				})
+			})
+		})
+		priv, pub := Must2(rsa.Handler{}.CreateKeyPair())
+		signingattr.Get(env).RegisterPrivateKey(PROVIDER, priv)
+		MustBeSuccessful(vfs.WriteFile(env.FileSystem(), PUBKEY, Must(rsa.KeyData(pub)), os.ModePerm))
+		_, opub := Must2(rsa.Handler{}.CreateKeyPair())
+		MustBeSuccessful(vfs.WriteFile(env.FileSystem(), OTHERKEY, Must(rsa.KeyData(opub)), os.ModePerm))
+		env.RSAKeyPair(OTHER)
+
+		repo := Must(ctf.Open(env, accessobj.ACC_WRITABLE, ARCH, 0, env))
+		defer Close(repo)
+		cv := Must(repo.LookupComponentVersion(COMP, VERSION))
+		defer Close(cv)
+
+		Must(routingslip.AddEntry(cv, PROVIDER, rsa.Algorithm, comment.New("first entry"), nil))
+		e1b = Must(routingslip.AddEntry(cv, PROVIDER, rsa.Algorithm, scan.New("trivy", "passed", ""), nil))
+		Must(routingslip.AddEntry(cv, OTHER, rsa.Algorithm, approval.New("alice", "REL-1"), []routingslip.Link{{Name: PROVIDER, Digest: e1b.Digest}}))
+		MustBeSuccessful(cv.Update())
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("verifies all routing slips", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("verify", "routingslips", "-k", PROVIDER+"="+PUBKEY, ARCH)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+routing slip acme.org: 2 entries verified
+routing slip other.org: 1 entries verified
+`))
+	})
+
+	It("verifies selected routing slip", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("verify", "routingslips", ARCH, OTHER)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+routing slip other.org: 1 entries verified
+`))
+	})
+
+	It("fails for wrong key", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("verify", "routingslips", "-k", PROVIDER+"="+OTHERKEY, ARCH, PROVIDER)).To(MatchError(
+			`routing slip verification for test.de/x:v1: routing slip acme.org: cannot verify entry ` + e1b.Digest.String() + `: signature verification failed, crypto/rsa: verification error`))
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+routing slip acme.org: failed: cannot verify entry ` + e1b.Digest.String() + `: signature verification failed, crypto/rsa: verification error
+`))
+	})
+
+	It("fails for unknown routing slip", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("verify", "routingslips", ARCH, "unknown.org")).To(HaveOccurred())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+routing slip unknown.org: failed: routing slip "unknown.org" not found
+`))
+	})
+
+	It("detects broken link integrity", func() {
+		var finalize finalizer.Finalizer
+		defer Defer(finalize.Finalize)
+
+		repo := Must(ctf.Open(env, accessobj.ACC_WRITABLE, ARCH, 0, env))
+		finalize.Close(repo)
+		cv := Must(repo.LookupComponentVersion(COMP, VERSION))
+		finalize.Close(cv)
+		label := Must(routingslip.Get(cv))
+		label[PROVIDER][0].Payload = Must(routingslip.ToGenericEntry(comment.New("manipulated")))
+		MustBeSuccessful(routingslip.Set(cv, label))
+		MustBeSuccessful(cv.Update())
+		MustBeSuccessful(finalize.Finalize())
+
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("verify", "routingslips", "--integrity-only", ARCH, OTHER)).To(HaveOccurred())
+		Expect(buf.String()).To(ContainSubstring("routing slip other.org: failed: content digest"))
+		Expect(buf.String()).To(ContainSubstring("in acme.org"))
+	})
+})
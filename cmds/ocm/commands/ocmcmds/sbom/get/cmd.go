@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/spf13/cobra"
+
+	"github.com/open-component-model/ocm/cmds/ocm/commands/common/options/destoption"
+	ocmcommon "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/handlers/comphdlr"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/lookupoption"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/repooption"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/names"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs"
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/output"
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
+	"github.com/open-component-model/ocm/pkg/contexts/clictx"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/utils/sbom"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/out"
+)
+
+var (
+	Names = names.SBOM
+	Verb  = verbs.Get
+)
+
+type Command struct {
+	utils.BaseCommand
+
+	Ref string
+}
+
+// NewCommand creates a new sbom command.
+func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
+	return utils.SetupCommand(
+		&Command{
+			BaseCommand: utils.NewBaseCommand(ctx,
+				repooption.New(),
+				lookupoption.New(),
+				destoption.New(),
+				NewOption(),
+			),
+		},
+		utils.Names(Names, names...)...,
+	)
+}
+
+func (o *Command) ForName(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "[<options>] <component-reference>",
+		Args:  cobra.ExactArgs(1),
+		Short: "get the software bill of materials for a component version",
+		Long: `
+Generate a software bill of materials (SBOM) for the reference closure of
+a component version. The SBOM is written as JSON document to the file given
+by option <code>--outfile</code> or to the standard output.
+
+The format is selected with option <code>--format</code>. Supported formats
+are <code>cyclonedx</code> (CycloneDX 1.5) and <code>spdx</code> (SPDX 2.3).
+
+Component versions and their resources are mapped to components (or
+packages), component references to dependencies. Resource digests are
+provided as hashes (or checksums), labels, resource types and access
+specifications as properties (or annotations).
+
+With option <code>--embed</code> the content of SBOMs found as resources
+of type <code>sbom</code> is embedded into the generated document, if it
+uses the requested format.
+`,
+		Example: `
+$ ocm get sbom ghcr.io/mandelsoft/kubelink:1.5.0
+$ ocm get sbom --format spdx --embed -O kubelink.spdx.json --repo OCIRegistry::ghcr.io mandelsoft/kubelink:1.5.0
+`,
+	}
+}
+
+func (o *Command) Complete(args []string) error {
+	o.Ref = args[0]
+	return nil
+}
+
+func (o *Command) Run() error {
+	session := ocm.NewSession(nil)
+	defer session.Close()
+
+	err := o.ProcessOnOptions(ocmcommon.CompleteOptionsWithSession(o, session))
+	if err != nil {
+		return err
+	}
+
+	objs, err := comphdlr.Evaluate(o.Context.OCM(), session, repooption.From(o).Repository, []string{o.Ref}, &output.Options{Context: o.Context})
+	if err != nil {
+		return errors.Wrapf(err, "%s", o.Ref)
+	}
+	if len(objs) > 1 {
+		return fmt.Errorf("%s: multiple component versions selected", o.Ref)
+	}
+
+	data, err := sbom.Generate(objs[0].ComponentVersion, From(o).Format, From(o), sbom.Resolver(lookupoption.From(o)))
+	if err != nil {
+		return err
+	}
+
+	dest := destoption.From(o)
+	if dest.Destination == "" {
+		out.Outf(o.Context, "%s\n", string(data))
+		return nil
+	}
+	err = dest.PathFilesystem.MkdirAll(path.Dir(dest.Destination), 0o770)
+	if err != nil {
+		return err
+	}
+	err = vfs.WriteFile(dest.PathFilesystem, dest.Destination, data, 0o660)
+	if err != nil {
+		return err
+	}
+	out.Outf(o.Context, "%s: sbom written\n", dest.Destination)
+	return nil
+}
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package get_test
+
+import (
+	"bytes"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/cmds/ocm/testhelper"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const (
+	ARCH     = "/tmp/ctf"
+	OUT      = "/tmp/sbom.json"
+	PROVIDER = "acme.org"
+	COMP     = "acme.org/comp"
+	SUB      = "acme.org/sub"
+	VERSION  = "1.0.0"
+)
+
+var _ = Describe("Test Environment", func() {
+	var env *TestEnv
+
+	BeforeEach(func() {
+		env = NewTestEnv()
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.ComponentVersion(SUB, VERSION, func() {
+				env.Provider(PROVIDER)
+			})
+			env.ComponentVersion(COMP, VERSION, func() {
+				env.Provider(PROVIDER)
+				env.Resource("text", VERSION, resourcetypes.PLAIN_TEXT, metav1.LocalRelation, func() {
+					env.BlobStringData(mime.MIME_TEXT, "text")
+				})
+				env.Reference("sub", SUB, VERSION)
+			})
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("writes cyclonedx sbom to stdout", func() {
+		var doc map[string]interface{}
+
+		buf := bytes.NewBuffer(nil)
+		MustBeSuccessful(env.CatchOutput(buf).Execute("get", "sbom", "--repo", ARCH, COMP+":"+VERSION))
+		MustBeSuccessful(json.Unmarshal(buf.Bytes(), &doc))
+		Expect(doc["bomFormat"]).To(Equal("CycloneDX"))
+		Expect(doc["components"]).To(HaveLen(2))
+		Expect(doc["dependencies"]).To(HaveLen(2))
+	})
+
+	It("writes spdx sbom to file", func() {
+		var doc map[string]interface{}
+
+		buf := bytes.NewBuffer(nil)
+		MustBeSuccessful(env.CatchOutput(buf).Execute("get", "sbom", "--repo", ARCH, "--format", "spdx", "-O", OUT, COMP+":"+VERSION))
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(OUT + ": sbom written"))
+		MustBeSuccessful(json.Unmarshal(Must(vfs.ReadFile(env.FileSystem(), OUT)), &doc))
+		Expect(doc["spdxVersion"]).To(Equal("SPDX-2.3"))
+		Expect(doc["packages"]).To(HaveLen(3))
+	})
+
+	It("rejects unknown formats", func() {
+		Expect(env.Execute("get", "sbom", "--repo", ARCH, "--format", "xml", COMP+":"+VERSION)).To(MatchError(ContainSubstring("xml")))
+	})
+})
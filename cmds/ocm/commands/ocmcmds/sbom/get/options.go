@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"strings"
+
+	"github.com/spf13/pflag"
+	"golang.org/x/exp/slices"
+
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/options"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/utils/sbom"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/optionutils"
+)
+
+func From(o options.OptionSetProvider) *Option {
+	var opt *Option
+	o.AsOptionSet().Get(&opt)
+	return opt
+}
+
+var _ options.Options = (*Option)(nil)
+
+type Option struct {
+	Format string
+	Embed  bool
+}
+
+func NewOption() *Option {
+	return &Option{}
+}
+
+func (o *Option) ApplyTo(opts *sbom.Options) {
+	optionutils.ApplyOption(&o.Embed, &opts.Embed)
+}
+
+func (o *Option) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.Format, "format", "", sbom.CYCLONEDX, "sbom format ("+strings.Join(sbom.Formats, ", ")+")")
+	fs.BoolVarP(&o.Embed, "embed", "", false, "embed SBOMs found as resources of type "+resourcetypes.SBOM)
+}
+
+func (o *Option) Complete() error {
+	o.Format = strings.ToLower(o.Format)
+	if !slices.Contains(sbom.Formats, o.Format) {
+		return errors.ErrNotSupported("sbom format", o.Format)
+	}
+	return nil
+}
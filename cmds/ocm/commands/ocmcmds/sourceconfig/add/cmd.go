@@ -65,7 +65,7 @@ This command accepts additional source specification files describing the source
 to add to a component version.
 
 ` + (&template.Options{}).Usage() +
-		inputs.Usage(inputs.DefaultInputTypeScheme) +
+		inputs.Usage(inputs.For(o.Context)) +
 		ocm.AccessUsage(o.OCMContext().AccessMethods(), true)
 }
 
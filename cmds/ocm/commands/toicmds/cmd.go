@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/config"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/installation"
 	_package "github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/package"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/verbs/bootstrap"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/verbs/describe"
@@ -39,6 +40,7 @@ installation data by th generic command.
 
 	cmd.AddCommand(_package.NewCommand(ctx))
 	cmd.AddCommand(config.NewCommand(ctx))
+	cmd.AddCommand(installation.NewCommand(ctx))
 
 	cmd.AddCommand(bootstrap.NewCommand(ctx))
 	cmd.AddCommand(describe.NewCommand(ctx))
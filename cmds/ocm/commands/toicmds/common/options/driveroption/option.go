@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driveroption
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/options"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/listformat"
+	defaultd "github.com/open-component-model/ocm/pkg/toi/drivers/default"
+	"github.com/open-component-model/ocm/pkg/toi/drivers/docker"
+	"github.com/open-component-model/ocm/pkg/toi/drivers/k8s"
+	"github.com/open-component-model/ocm/pkg/toi/install"
+	"github.com/open-component-model/ocm/pkg/utils"
+)
+
+const (
+	DRIVER_DOCKER     = "docker"
+	DRIVER_KUBERNETES = "kubernetes"
+)
+
+func From(o options.OptionSetProvider) *Option {
+	var opt *Option
+	o.AsOptionSet().Get(&opt)
+	return opt
+}
+
+func New() *Option {
+	return &Option{}
+}
+
+type Option struct {
+	Driver string
+	Config map[string]string
+}
+
+func (o *Option) AddFlags(fs *pflag.FlagSet) {
+	fs.StringToStringVarP(&o.Config, "config", "", nil, "driver config")
+	fs.StringVarP(&o.Driver, "driver", "", DRIVER_DOCKER, "execution driver ("+DRIVER_DOCKER+", "+DRIVER_KUBERNETES+")")
+}
+
+func (o *Option) Complete() error {
+	switch o.Driver {
+	case "", DRIVER_DOCKER, DRIVER_KUBERNETES:
+	default:
+		return errors.ErrInvalid("driver", o.Driver)
+	}
+	return nil
+}
+
+// GetDriver provides the selected driver configured with the given driver config.
+func (o *Option) GetDriver() (install.Driver, error) {
+	driver := defaultd.New()
+	if o.Driver == DRIVER_KUBERNETES {
+		driver = k8s.New()
+	}
+	if o.Config != nil {
+		err := driver.SetConfig(o.Config)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return driver, nil
+}
+
+func (o *Option) Usage() string {
+	s := `
+Using the option <code>--driver</code> the execution environment can be
+selected. By default, the executor image is executed with a local
+docker daemon (<code>` + DRIVER_DOCKER + `</code>). With <code>` + DRIVER_KUBERNETES + `</code>
+it is executed as Kubernetes Job. The input files are provided by a secret.
+If the executor declares outputs, it is run as init container and the outputs
+are stored in a config map by a collector container using <code>kubectl</code>.
+Therefore, the used service account must be able to get and patch config maps
+in the target namespace. The image used for the collector container (providing
+<code>sh</code> and <code>kubectl</code>) must be configured with the option
+<code>COLLECTOR_IMAGE</code>, preferably pinned by digest.
+
+Using the option <code>--config</code> it is possible to configure options
+for the execution environment. The following options are possible for the
+docker driver:
+` + listformat.FormatListElements("", listformat.StringElementList(utils.StringMapKeys(docker.Options))) + `
+
+and for the kubernetes driver:
+` + listformat.FormatListElements("", listformat.StringElementList(utils.StringMapKeys(k8s.Options))) + `
+`
+	return s
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package stateoption
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/options"
+	"github.com/open-component-model/ocm/pkg/contexts/clictx"
+	"github.com/open-component-model/ocm/pkg/toi/install"
+)
+
+func From(o options.OptionSetProvider) *Option {
+	var opt *Option
+	o.AsOptionSet().Get(&opt)
+	return opt
+}
+
+func New() *Option {
+	return &Option{}
+}
+
+type Option struct {
+	Path  string
+	Store install.Store
+}
+
+func (o *Option) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.Path, "state", "", "", "directory for installation records")
+}
+
+func (o *Option) Configure(ctx clictx.Context) error {
+	if o.Store != nil {
+		return nil
+	}
+	if o.Path == "" {
+		p, err := install.DefaultStateDir()
+		if err != nil {
+			return err
+		}
+		o.Path = p
+	}
+	o.Store = install.NewFileStore(ctx.FileSystem(), o.Path)
+	return nil
+}
+
+func (o *Option) Usage() string {
+	s := `
+The installation records are stored in the directory given by option
+<code>--state</code>. By default, the directory <code>~/.ocm/toi/installations</code>
+is used. Every record is stored as dedicated YAML file named after the installation.
+`
+	return s
+}
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package installation
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/installation/status"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/installation/uninstall"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/installation/upgrade"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/names"
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
+	"github.com/open-component-model/ocm/pkg/contexts/clictx"
+)
+
+var Names = names.Installation
+
+// NewCommand creates a new command.
+func NewCommand(ctx clictx.Context) *cobra.Command {
+	cmd := utils.MassageCommand(&cobra.Command{
+		Short: "TOI Commands acting on installations",
+	}, Names...)
+	AddCommands(ctx, cmd)
+	return cmd
+}
+
+func AddCommands(ctx clictx.Context, cmd *cobra.Command) {
+	cmd.AddCommand(status.NewCommand(ctx))
+	cmd.AddCommand(upgrade.NewCommand(ctx))
+	cmd.AddCommand(uninstall.NewCommand(ctx))
+}
@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/common/options/stateoption"
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/output"
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/processing"
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
+	"github.com/open-component-model/ocm/pkg/contexts/clictx"
+	utils2 "github.com/open-component-model/ocm/pkg/utils"
+)
+
+var Names = []string{"status"}
+
+type Command struct {
+	utils.BaseCommand
+
+	Names []string
+}
+
+// NewCommand creates a new installation status command.
+func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
+	return utils.SetupCommand(
+		&Command{
+			BaseCommand: utils.NewBaseCommand(ctx, stateoption.New(), output.OutputOptions(outputs)),
+		},
+		utils.Names(Names, names...)...,
+	)
+}
+
+func (o *Command) ForName(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "[<options>] {<installation>}",
+		Short: "show status of TOI installations",
+		Long: `
+Show the installation records stored for TOI package installations executed
+with <CMD>ocm bootstrap package</CMD>. If no installation is specified, all
+recorded installations are listed.
+
+An installation record describes the last successfully executed action
+together with the used component version, executor image, the digest of the
+parameter input and the outputs provided by the executor. The complete record,
+including parameters, outputs and package specification is shown with
+output format <code>yaml</code> or <code>json</code>.
+`,
+		Example: `
+$ ocm bootstrap status
+$ ocm bootstrap status -o yaml acme.org/demo
+`,
+	}
+}
+
+func (o *Command) Complete(args []string) error {
+	o.Names = args
+	return nil
+}
+
+func (o *Command) Run() error {
+	hdlr := NewTypeHandler(stateoption.From(o).Store)
+	return utils.HandleArgs(output.From(o), hdlr, o.Names...)
+}
+
+/////////////////////////////////////////////////////////////////////////////
+
+func TableOutput(opts *output.Options, mapping processing.MappingFunction, wide ...string) *output.TableOutput {
+	def := &output.TableOutput{
+		Headers: output.Fields("INSTALLATION", "COMPONENT", "VERSION", "ACTION", "TIMESTAMP", wide),
+		Options: opts,
+		Mapping: mapping,
+	}
+	return def
+}
+
+/////////////////////////////////////////////////////////////////////////////
+
+var outputs = output.NewOutputs(getRegular, output.Outputs{
+	"wide": getWide,
+}).AddManifestOutputs()
+
+func getRegular(opts *output.Options) output.Output {
+	return TableOutput(opts, mapGetRegularOutput).New()
+}
+
+func getWide(opts *output.Options) output.Output {
+	return TableOutput(opts, mapGetWideOutput, "EXECUTOR", "PARAMETERS", "OUTPUTS").New()
+}
+
+func mapGetRegularOutput(e interface{}) interface{} {
+	i := Elem(e)
+	ts := ""
+	if i.Timestamp != nil {
+		ts = i.Timestamp.String()
+	}
+	return []string{i.Name, i.Component, i.Version, i.Action, ts}
+}
+
+func mapGetWideOutput(e interface{}) interface{} {
+	i := Elem(e)
+	return output.Fields(mapGetRegularOutput(e), i.Executor, i.ParametersHash, strings.Join(utils2.StringMapKeys(i.Outputs), ","))
+}
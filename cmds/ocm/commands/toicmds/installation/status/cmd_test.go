@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package status_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/cmds/ocm/testhelper"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	v1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/toi/install"
+)
+
+const STATE = "/state"
+
+var _ = Describe("Test Environment", func() {
+	var env *TestEnv
+
+	ts := v1.NewTimestampPFor(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC))
+
+	BeforeEach(func() {
+		env = NewTestEnv()
+		store := install.NewFileStore(env.FileSystem(), STATE)
+		MustBeSuccessful(store.Put(&install.Installation{
+			Name:           "acme.org/demo",
+			Component:      "acme.org/demo",
+			Version:        "1.0.0",
+			Action:         "install",
+			Executor:       "ghcr.io/acme/executor:1.0.0",
+			ParametersHash: "sha256:0123",
+			Outputs:        map[string][]byte{"url": []byte("http://demo"), "token": []byte("xyz")},
+			Timestamp:      ts,
+		}))
+		MustBeSuccessful(store.Put(&install.Installation{
+			Name:      "demo2",
+			Component: "acme.org/demo",
+			Version:   "1.1.0",
+			Action:    "upgrade",
+			Executor:  "ghcr.io/acme/executor:1.1.0",
+			Timestamp: ts,
+		}))
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("lists installations", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("bootstrap", "status", "--state", STATE)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+INSTALLATION  COMPONENT     VERSION ACTION  TIMESTAMP
+acme.org/demo acme.org/demo 1.0.0   install 2024-03-01T10:00:00Z
+demo2         acme.org/demo 1.1.0   upgrade 2024-03-01T10:00:00Z
+`))
+	})
+
+	It("shows dedicated installation", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("bootstrap", "status", "--state", STATE, "-o", "wide", "acme.org/demo")).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+INSTALLATION  COMPONENT     VERSION ACTION  TIMESTAMP            EXECUTOR                    PARAMETERS  OUTPUTS
+acme.org/demo acme.org/demo 1.0.0   install 2024-03-01T10:00:00Z ghcr.io/acme/executor:1.0.0 sha256:0123 token,url
+`))
+	})
+
+	It("fails for unknown installation", func() {
+		Expect(env.Execute("bootstrap", "status", "--state", STATE, "unknown")).To(MatchError(`error processing "unknown": installation "unknown" not found`))
+	})
+})
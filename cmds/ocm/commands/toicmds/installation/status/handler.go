@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/output"
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
+	"github.com/open-component-model/ocm/pkg/toi/install"
+)
+
+func Elem(e interface{}) *install.Installation {
+	return e.(*Object).Installation
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Object struct {
+	Installation *install.Installation
+}
+
+func (o *Object) AsManifest() interface{} {
+	return o.Installation
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type TypeHandler struct {
+	store install.Store
+}
+
+func NewTypeHandler(store install.Store) utils.TypeHandler {
+	return &TypeHandler{
+		store: store,
+	}
+}
+
+func (h *TypeHandler) Close() error {
+	return nil
+}
+
+func (h *TypeHandler) All() ([]output.Object, error) {
+	list, err := h.store.List()
+	if err != nil {
+		return nil, err
+	}
+	result := []output.Object{}
+	for _, i := range list {
+		result = append(result, &Object{i})
+	}
+	return result, nil
+}
+
+func (h *TypeHandler) Get(elemspec utils.ElemSpec) ([]output.Object, error) {
+	i, err := h.store.Get(elemspec.String())
+	if err != nil {
+		return nil, err
+	}
+	return []output.Object{&Object{i}}, nil
+}
@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package uninstall
+
+import (
+	"encoding/json"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	ocmcommon "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/lookupoption"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/common/options/driveroption"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/common/options/stateoption"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/package/bootstrap"
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/clictx"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/out"
+	"github.com/open-component-model/ocm/pkg/toi/install"
+)
+
+const ACTION_UNINSTALL = "uninstall"
+
+var Names = []string{"uninstall"}
+
+type Command struct {
+	utils.BaseCommand
+	Installation string
+	Action       string
+	Keep         bool
+
+	CredentialsFile string
+	OutputFile      string
+	Credentials     blobaccess.DataSource
+}
+
+// NewCommand creates a new installation uninstall command.
+func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
+	return utils.SetupCommand(&Command{BaseCommand: utils.NewBaseCommand(ctx, lookupoption.New(), driveroption.New(), stateoption.New())}, utils.Names(Names, names...)...)
+}
+
+func (o *Command) ForName(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "[<options>] <installation>",
+		Args:  cobra.ExactArgs(1),
+		Short: "uninstall TOI installation",
+		Long: `
+Uninstall a recorded TOI installation (see <CMD>ocm bootstrap status</CMD>).
+The action <code>` + ACTION_UNINSTALL + `</code> is executed for the recorded
+component version, which is taken from the repository used for the
+installation. The recorded parameters are used and the recorded installation,
+including the outputs of the last execution, is passed to the executor as
+input <code>state</code>.
+
+The credentials are handled like for command <CMD>ocm bootstrap package</CMD>.
+After a successful execution the installation record is deleted, if not
+requested otherwise with option <code>--keep-record</code>.
+`,
+		Example: `
+$ ocm bootstrap uninstall acme.org/demo
+`,
+	}
+}
+
+func (o *Command) AddFlags(fs *pflag.FlagSet) {
+	o.BaseCommand.AddFlags(fs)
+	fs.StringVarP(&o.Action, "action", "a", ACTION_UNINSTALL, "action to execute")
+	fs.StringVarP(&o.CredentialsFile, "credentials", "c", "", "credentials file")
+	fs.StringVarP(&o.OutputFile, "outputs", "o", "", "output file/directory")
+	fs.BoolVarP(&o.Keep, "keep-record", "", false, "keep installation record")
+}
+
+func (o *Command) Complete(args []string) error {
+	var err error
+
+	o.Installation = args[0]
+	o.Credentials, err = bootstrap.ReadInput(o.FileSystem(), "credentials", &o.CredentialsFile, bootstrap.DEFAULT_CREDENTIALS_FILE)
+	return err
+}
+
+func (o *Command) Run() (err error) {
+	session := ocm.NewSession(nil)
+	defer errors.PropagateError(&err, session.Close)
+
+	err = o.ProcessOnOptions(ocmcommon.CompleteOptionsWithSession(o, session))
+	if err != nil {
+		return err
+	}
+
+	store := stateoption.From(o).Store
+	inst, err := store.Get(o.Installation)
+	if err != nil {
+		return err
+	}
+	cv, err := LookupComponentVersion(o.OCMContext(), session, inst)
+	if err != nil {
+		return err
+	}
+
+	driver, err := driveroption.From(o).GetDriver()
+	if err != nil {
+		return err
+	}
+
+	var params blobaccess.DataSource
+	if inst.Parameters != "" {
+		params = blobaccess.DataAccessForString(inst.Parameters, "recorded parameters")
+	}
+
+	out.Outf(o, "uninstalling installation %s of %s\n", inst.Name, inst.ComponentVersion())
+	result, _, err := install.ExecuteInstallation(common.NewPrinter(o.StdOut()), driver, o.Action, inst.Package, o.Credentials, params, o.OCMContext(), cv, lookupoption.From(o), inst)
+	if err != nil {
+		return err
+	}
+	if !o.Keep {
+		err = store.Delete(inst.Name)
+		if err != nil {
+			return errors.Wrapf(err, "cannot delete installation record %q", inst.Name)
+		}
+	}
+	return bootstrap.WriteOutputs(o, o.OutputFile, result.Outputs)
+}
+
+// LookupComponentVersion provides the component version of an installation
+// record from the recorded repository.
+func LookupComponentVersion(octx ocm.Context, session ocm.Session, inst *install.Installation) (ocm.ComponentVersionAccess, error) {
+	if inst.Repository == nil {
+		return nil, errors.Newf("no repository recorded for installation %q", inst.Name)
+	}
+	data, err := json.Marshal(inst.Repository)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := octx.RepositorySpecForConfig(data, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "recorded repository of installation %q", inst.Name)
+	}
+	repo, err := session.LookupRepository(octx, spec)
+	if err != nil {
+		return nil, err
+	}
+	return session.LookupComponentVersion(repo, inst.Component, inst.Version)
+}
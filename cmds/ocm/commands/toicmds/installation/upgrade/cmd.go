@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	ocmcommon "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/handlers/comphdlr"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/lookupoption"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/repooption"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/common/options/driveroption"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/common/options/stateoption"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/package/bootstrap"
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/output"
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/clictx"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/out"
+	"github.com/open-component-model/ocm/pkg/toi/install"
+	utils2 "github.com/open-component-model/ocm/pkg/utils"
+)
+
+const ACTION_UPGRADE = "upgrade"
+
+var Names = []string{"upgrade"}
+
+type Command struct {
+	utils.BaseCommand
+	Installation string
+	Ref          string
+	Action       string
+
+	CredentialsFile string
+	ParameterFile   string
+	OutputFile      string
+	Credentials     blobaccess.DataSource
+	Parameters      blobaccess.DataSource
+}
+
+// NewCommand creates a new installation upgrade command.
+func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
+	return utils.SetupCommand(&Command{BaseCommand: utils.NewBaseCommand(ctx, repooption.New(), lookupoption.New(), driveroption.New(), stateoption.New())}, utils.Names(Names, names...)...)
+}
+
+func (o *Command) ForName(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "[<options>] <installation> <component-reference>",
+		Args:  cobra.ExactArgs(2),
+		Short: "upgrade TOI installation to another component version",
+		Long: `
+Upgrade a recorded TOI installation (see <CMD>ocm bootstrap status</CMD>)
+to the given component version. The package resource is selected with the
+resource identity used for the recorded installation.
+
+The changes between the package specification of the recorded installation
+and the one of the new component version are reported before the executor
+is called.
+
+By default, the action <code>` + ACTION_UPGRADE + `</code> is executed. Packages
+handling upgrades with their install action can be upgraded using option
+<code>--action install</code>.
+
+If no parameter file is given (option -p) the parameters of the recorded
+installation are reused. The credentials are handled like for command
+<CMD>ocm bootstrap package</CMD>. The recorded installation is passed to the
+executor as input <code>state</code>. After a successful execution the
+installation record is updated.
+`,
+		Example: `
+$ ocm bootstrap upgrade acme.org/demo ghcr.io/acme//acme.org/demo:1.1.0
+`,
+	}
+}
+
+func (o *Command) AddFlags(fs *pflag.FlagSet) {
+	o.BaseCommand.AddFlags(fs)
+	fs.StringVarP(&o.Action, "action", "a", ACTION_UPGRADE, "action to execute")
+	fs.StringVarP(&o.CredentialsFile, "credentials", "c", "", "credentials file")
+	fs.StringVarP(&o.ParameterFile, "parameters", "p", "", "parameter file (default: recorded parameters)")
+	fs.StringVarP(&o.OutputFile, "outputs", "o", "", "output file/directory")
+}
+
+func (o *Command) Complete(args []string) error {
+	var err error
+
+	o.Installation = args[0]
+	o.Ref = args[1]
+	o.Credentials, err = bootstrap.ReadInput(o.FileSystem(), "credentials", &o.CredentialsFile, bootstrap.DEFAULT_CREDENTIALS_FILE)
+	if err != nil {
+		return err
+	}
+	if o.ParameterFile != "" {
+		o.Parameters, err = bootstrap.ReadInput(o.FileSystem(), "parameter", &o.ParameterFile, "")
+	}
+	return err
+}
+
+func (o *Command) Run() error {
+	session := ocm.NewSession(nil)
+	defer session.Close()
+
+	err := o.ProcessOnOptions(ocmcommon.CompleteOptionsWithSession(o, session))
+	if err != nil {
+		return err
+	}
+
+	inst, err := stateoption.From(o).Store.Get(o.Installation)
+	if err != nil {
+		return err
+	}
+	if o.Parameters == nil && inst.Parameters != "" {
+		o.Parameters = blobaccess.DataAccessForString(inst.Parameters, "recorded parameters")
+	}
+	handler := comphdlr.NewTypeHandler(o.Context.OCM(), session, repooption.From(o).Repository)
+	return utils.HandleOutput(&action{cmd: o, inst: inst}, handler, utils.StringElemSpecs(o.Ref)...)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type action struct {
+	data comphdlr.Objects
+	cmd  *Command
+	inst *install.Installation
+}
+
+var _ output.Output = (*action)(nil)
+
+func (a *action) Add(e interface{}) error {
+	if len(a.data) > 0 {
+		return errors.New("found multiple component versions")
+	}
+	o, ok := e.(*comphdlr.Object)
+	if !ok {
+		return fmt.Errorf("object of type %T is not a valid comphdlr.Object", e)
+	}
+	a.data = append(a.data, o)
+	return nil
+}
+
+func (a *action) Close() error {
+	return nil
+}
+
+func (a *action) Out() error {
+	driver, err := driveroption.From(a.cmd).GetDriver()
+	if err != nil {
+		return err
+	}
+
+	cv := a.data[0].ComponentVersion
+	out.Outf(a.cmd, "upgrading installation %s from %s to %s\n", a.inst.Name, a.inst.ComponentVersion(), common.VersionedElementKey(cv))
+
+	spec, err := install.GetPackageSpecification(cv, a.inst.Package)
+	if err != nil {
+		return err
+	}
+	diffs, err := install.DiffPackageSpecifications(a.inst.Spec, spec)
+	if err != nil {
+		return err
+	}
+	if len(diffs) == 0 {
+		out.Outf(a.cmd, "package specification unchanged\n")
+	} else {
+		out.Outf(a.cmd, "package specification changes:\n%s\n", utils2.JoinIndentLines(diffs, "  "))
+	}
+
+	result, inst, err := install.ExecuteInstallation(common.NewPrinter(a.cmd.StdOut()), driver, a.cmd.Action, a.inst.Package, a.cmd.Credentials, a.cmd.Parameters, a.cmd.OCMContext(), cv, lookupoption.From(a.cmd), a.inst)
+	if err != nil {
+		return err
+	}
+	err = stateoption.From(a.cmd).Store.Put(inst)
+	if err != nil {
+		return errors.Wrapf(err, "cannot store installation record %q", inst.Name)
+	}
+	return bootstrap.WriteOutputs(a.cmd, a.cmd.OutputFile, result.Outputs)
+}
@@ -10,3 +10,4 @@ import (
 
 var Package = []string{"package", "pkg", "componentversion", "cv", "component", "comp", "c"}
 var Configuration = names.Configuration
+var Installation = []string{"installation", "installations", "inst"}
@@ -15,6 +15,8 @@ import (
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/handlers/comphdlr"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/lookupoption"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/common/options/repooption"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/common/options/driveroption"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/common/options/stateoption"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/names"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs"
 	"github.com/open-component-model/ocm/cmds/ocm/pkg/output"
@@ -27,12 +29,9 @@ import (
 	"github.com/open-component-model/ocm/pkg/contexts/ocm"
 	v1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
 	"github.com/open-component-model/ocm/pkg/errors"
-	"github.com/open-component-model/ocm/pkg/listformat"
 	"github.com/open-component-model/ocm/pkg/out"
 	"github.com/open-component-model/ocm/pkg/runtime"
 	"github.com/open-component-model/ocm/pkg/toi"
-	defaultd "github.com/open-component-model/ocm/pkg/toi/drivers/default"
-	"github.com/open-component-model/ocm/pkg/toi/drivers/docker"
 	"github.com/open-component-model/ocm/pkg/toi/drivers/filesystem"
 	"github.com/open-component-model/ocm/pkg/toi/install"
 	utils2 "github.com/open-component-model/ocm/pkg/utils"
@@ -41,6 +40,9 @@ import (
 const (
 	DEFAULT_CREDENTIALS_FILE = "TOICredentials"
 	DEFAULT_PARAMETER_FILE   = "TOIParameters"
+
+	DRIVER_DOCKER     = driveroption.DRIVER_DOCKER
+	DRIVER_KUBERNETES = driveroption.DRIVER_KUBERNETES
 )
 
 var (
@@ -50,22 +52,22 @@ var (
 
 type Command struct {
 	utils.BaseCommand
-	Action string
-	Ref    string
-	Id     v1.Identity
+	Action       string
+	Ref          string
+	Id           v1.Identity
+	Installation string
 
 	CredentialsFile string
 	ParameterFile   string
 	OutputFile      string
 	Credentials     blobaccess.DataSource
 	Parameters      blobaccess.DataSource
-	Config          map[string]string
 	EnvDir          string
 }
 
 // NewCommand creates a new bootstrap component command.
 func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
-	return utils.SetupCommand(&Command{BaseCommand: utils.NewBaseCommand(ctx, repooption.New(), lookupoption.New())}, utils.Names(Names, names...)...)
+	return utils.SetupCommand(&Command{BaseCommand: utils.NewBaseCommand(ctx, repooption.New(), lookupoption.New(), driveroption.New(), stateoption.New())}, utils.Names(Names, names...)...)
 }
 
 func (o *Command) ForName(name string) *cobra.Command {
@@ -149,10 +151,14 @@ The *ForwardSpec* uses the following format:
 If provided by the package it is possible to download template versions
 for the parameter and credentials file using the command <CMD>ocm bootstrap configuration</CMD>.
 
-Using the option <code>--config</code> it is possible to configure options
-for the execution environment (so far only docker is supported).
-The following options are possible:
-` + listformat.FormatListElements("", listformat.StringElementList(utils2.StringMapKeys(docker.Options))) + `
+After a successful execution an installation record is stored, which keeps
+the component version, the used executor, the parameters and the provided
+outputs. By default, the installation is named after the component. Another
+name can be given with option <code>--installation</code>. If a record for
+this installation already exists, it is passed to the executor as input
+<code>state</code>. The records are used by the commands
+<CMD>ocm bootstrap status</CMD>, <CMD>ocm bootstrap upgrade</CMD> and
+<CMD>ocm bootstrap uninstall</CMD>.
 
 Using the option <code>--create-env  &lt;toi root folder></code> it is possible to
 create a local execution environment for an executor according to the executor
@@ -174,11 +180,11 @@ $ ocm toi bootstrap package ghcr.io/mandelsoft/ocm//ocmdemoinstaller:0.0.1-dev
 
 func (o *Command) AddFlags(fs *pflag.FlagSet) {
 	o.BaseCommand.AddFlags(fs)
-	fs.StringToStringVarP(&o.Config, "config", "", nil, "driver config")
 	fs.StringVarP(&o.CredentialsFile, "credentials", "c", "", "credentials file")
 	fs.StringVarP(&o.ParameterFile, "parameters", "p", "", "parameter file")
 	fs.StringVarP(&o.OutputFile, "outputs", "o", "", "output file/directory")
 	fs.StringVarP(&o.EnvDir, "create-env", "C", "", "create local filesystem contract to call executor command locally")
+	fs.StringVarP(&o.Installation, "installation", "I", "", "name of installation record")
 }
 
 func (o *Command) Complete(args []string) error {
@@ -188,32 +194,31 @@ func (o *Command) Complete(args []string) error {
 	if err != nil {
 		return errors.Wrapf(err, "bootstrap resource identity pattern")
 	}
-	if len(o.CredentialsFile) == 0 {
-		if ok, _ := vfs.FileExists(o.FileSystem(), DEFAULT_CREDENTIALS_FILE); ok {
-			o.CredentialsFile = DEFAULT_CREDENTIALS_FILE
-		}
-	}
 	o.Id = id
-	if len(o.CredentialsFile) > 0 {
-		data, err := utils2.ReadFile(o.CredentialsFile, o.Context.FileSystem())
-		if err != nil {
-			return errors.Wrapf(err, "failed reading credentials file %q", o.CredentialsFile)
-		}
-		o.Credentials = blobaccess.DataAccessForBytes(data, o.CredentialsFile)
+	o.Credentials, err = ReadInput(o.FileSystem(), "credentials", &o.CredentialsFile, DEFAULT_CREDENTIALS_FILE)
+	if err != nil {
+		return err
 	}
-	if len(o.ParameterFile) == 0 {
-		if ok, _ := vfs.FileExists(o.FileSystem(), DEFAULT_PARAMETER_FILE); ok {
-			o.ParameterFile = DEFAULT_PARAMETER_FILE
+	o.Parameters, err = ReadInput(o.FileSystem(), "parameter", &o.ParameterFile, DEFAULT_PARAMETER_FILE)
+	return err
+}
+
+// ReadInput provides the content of an input file. If no file is given,
+// the default file is used, if present.
+func ReadInput(fs vfs.FileSystem, kind string, file *string, def string) (blobaccess.DataSource, error) {
+	if len(*file) == 0 {
+		if ok, _ := vfs.FileExists(fs, def); ok {
+			*file = def
 		}
 	}
-	if len(o.ParameterFile) > 0 {
-		data, err := utils2.ReadFile(o.ParameterFile, o.Context.FileSystem())
-		if err != nil {
-			return errors.Wrapf(err, "failed reading parameter file %q", o.ParameterFile)
-		}
-		o.Parameters = blobaccess.DataAccessForBytes(data, o.ParameterFile)
+	if len(*file) == 0 {
+		return nil, nil
 	}
-	return nil
+	data, err := utils2.ReadFile(*file, fs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading %s file %q", kind, *file)
+	}
+	return blobaccess.DataAccessForBytes(data, *file), nil
 }
 
 func (o *Command) Run() error {
@@ -261,36 +266,64 @@ type Binary struct {
 }
 
 func (a *action) Out() error {
-	driver := defaultd.New()
+	var driver install.Driver
+	var err error
 
+	dopt := driveroption.From(a.cmd)
 	if a.cmd.EnvDir != "" {
 		driver = filesystem.New(a.cmd.FileSystem())
-		if a.cmd.Config == nil {
-			a.cmd.Config = map[string]string{}
+		if dopt.Config == nil {
+			dopt.Config = map[string]string{}
 		}
-		if a.cmd.Config[filesystem.OptionTargetPath] == "" {
-			a.cmd.Config[filesystem.OptionTargetPath] = a.cmd.EnvDir
+		if dopt.Config[filesystem.OptionTargetPath] == "" {
+			dopt.Config[filesystem.OptionTargetPath] = a.cmd.EnvDir
 		}
+		err = driver.SetConfig(dopt.Config)
+	} else {
+		driver, err = dopt.GetDriver()
+	}
+	if err != nil {
+		return err
 	}
 
-	if a.cmd.Config != nil {
-		err := driver.SetConfig(a.cmd.Config)
-		if err != nil {
+	cv := a.data[0].ComponentVersion
+	name := a.cmd.Installation
+	if name == "" {
+		name = cv.GetName()
+	}
+	store := stateoption.From(a.cmd).Store
+	state, err := store.Get(name)
+	if err != nil {
+		if !errors.IsErrNotFound(err) {
 			return err
 		}
+		state = nil
 	}
 
-	common.NewPrinter(a.cmd.StdOut())
-	result, err := install.Execute(common.NewPrinter(a.cmd.StdOut()), driver, a.cmd.Action, a.cmd.Id, a.cmd.Credentials, a.cmd.Parameters, a.cmd.OCMContext(), a.data[0].ComponentVersion, lookupoption.From(a.cmd))
+	result, inst, err := install.ExecuteInstallation(common.NewPrinter(a.cmd.StdOut()), driver, a.cmd.Action, a.cmd.Id, a.cmd.Credentials, a.cmd.Parameters, a.cmd.OCMContext(), cv, lookupoption.From(a.cmd), state)
 	if err != nil {
 		return err
 	}
 
-	if a.cmd.OutputFile != "" {
-		if ok, _ := vfs.IsDir(a.cmd.FileSystem(), a.cmd.OutputFile); ok {
-			out.Outf(a.cmd, "writing outputs to directory %q...", a.cmd.OutputFile)
-			for n, o := range result.Outputs {
-				err := vfs.WriteFile(a.cmd.FileSystem(), vfs.Join(a.cmd.FileSystem(), a.cmd.OutputFile, n), o, 0o600)
+	if a.cmd.EnvDir == "" {
+		inst.Name = name
+		err = store.Put(inst)
+		if err != nil {
+			return errors.Wrapf(err, "cannot store installation record %q", name)
+		}
+	}
+	return WriteOutputs(a.cmd, a.cmd.OutputFile, result.Outputs)
+}
+
+// WriteOutputs writes the outputs of an execution to the given file or directory.
+// If no file is given, they are printed.
+func WriteOutputs(ctx clictx.Context, file string, outputs map[string][]byte) error {
+	fs := ctx.FileSystem()
+	if file != "" {
+		if ok, _ := vfs.IsDir(fs, file); ok {
+			out.Outf(ctx, "writing outputs to directory %q...", file)
+			for n, o := range outputs {
+				err := vfs.WriteFile(fs, vfs.Join(fs, file, n), o, 0o600)
 				if err != nil {
 					return errors.Wrapf(err, "cannot write output %q", n)
 				}
@@ -300,7 +333,7 @@ func (a *action) Out() error {
 	}
 
 	data := map[string]interface{}{}
-	for n, o := range result.Outputs {
+	for n, o := range outputs {
 		var tmp interface{}
 		err := runtime.DefaultYAMLEncoding.Unmarshal(o, &tmp)
 		if err == nil {
@@ -310,14 +343,14 @@ func (a *action) Out() error {
 		}
 	}
 
-	outputs, err := runtime.DefaultYAMLEncoding.Marshal(map[string]interface{}{"outputs": data})
+	result, err := runtime.DefaultYAMLEncoding.Marshal(map[string]interface{}{"outputs": data})
 	if err != nil {
 		return errors.Wrapf(err, "cannot marshal outputs")
 	}
-	if a.cmd.OutputFile != "" {
-		vfs.WriteFile(a.cmd.FileSystem(), a.cmd.OutputFile, outputs, 0o600)
+	if file != "" {
+		vfs.WriteFile(fs, file, result, 0o600)
 	} else {
-		out.Outf(a.cmd, "Provided outputs:\n%s\n", outputs)
+		out.Outf(ctx, "Provided outputs:\n%s\n", result)
 	}
 	return nil
 }
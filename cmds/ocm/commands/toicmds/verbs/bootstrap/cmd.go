@@ -7,6 +7,9 @@ package bootstrap
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/installation/status"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/installation/uninstall"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/installation/upgrade"
 	components "github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/package/bootstrap"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs"
 	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
@@ -19,5 +22,8 @@ func NewCommand(ctx clictx.Context) *cobra.Command {
 		Short: "bootstrap components",
 	}, verbs.Bootstrap)
 	cmd.AddCommand(components.NewCommand(ctx))
+	cmd.AddCommand(status.NewCommand(ctx))
+	cmd.AddCommand(upgrade.NewCommand(ctx))
+	cmd.AddCommand(uninstall.NewCommand(ctx))
 	return cmd
 }
@@ -7,6 +7,7 @@ package add
 import (
 	"github.com/spf13/cobra"
 
+	keystore "github.com/open-component-model/ocm/cmds/ocm/commands/misccmds/keystore/add"
 	components "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/components/add"
 	references "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/references/add"
 	resourceconfig "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/resourceconfig/add"
@@ -32,5 +33,6 @@ func NewCommand(ctx clictx.Context) *cobra.Command {
 	cmd.AddCommand(references.NewCommand(ctx))
 	cmd.AddCommand(components.NewCommand(ctx))
 	cmd.AddCommand(routingslips.NewCommand(ctx))
+	cmd.AddCommand(keystore.NewCommand(ctx))
 	return cmd
 }
@@ -8,6 +8,9 @@ import (
 	"github.com/spf13/cobra"
 
 	config "github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/config/bootstrap"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/installation/status"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/installation/uninstall"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/installation/upgrade"
 	_package "github.com/open-component-model/ocm/cmds/ocm/commands/toicmds/package/bootstrap"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs"
 	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
@@ -21,5 +24,8 @@ func NewCommand(ctx clictx.Context) *cobra.Command {
 	}, verbs.Bootstrap)
 	cmd.AddCommand(_package.NewCommand(ctx))
 	cmd.AddCommand(config.NewCommand(ctx))
+	cmd.AddCommand(status.NewCommand(ctx))
+	cmd.AddCommand(upgrade.NewCommand(ctx))
+	cmd.AddCommand(uninstall.NewCommand(ctx))
 	return cmd
 }
@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package diff
+
+import (
+	"github.com/spf13/cobra"
+
+	components "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/components/diff"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs"
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
+	"github.com/open-component-model/ocm/pkg/contexts/clictx"
+)
+
+// NewCommand creates a new command.
+func NewCommand(ctx clictx.Context) *cobra.Command {
+	cmd := utils.MassageCommand(&cobra.Command{
+		Short: "Compare component versions",
+	}, verbs.Diff)
+	cmd.AddCommand(components.NewCommand(ctx))
+	return cmd
+}
@@ -8,12 +8,14 @@ import (
 	"github.com/spf13/cobra"
 
 	credentials "github.com/open-component-model/ocm/cmds/ocm/commands/misccmds/credentials/get"
+	keystore "github.com/open-component-model/ocm/cmds/ocm/commands/misccmds/keystore/get"
 	artifacts "github.com/open-component-model/ocm/cmds/ocm/commands/ocicmds/artifacts/get"
 	components "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/components/get"
 	plugins "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/plugins/get"
 	references "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/references/get"
 	resources "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/resources/get"
 	routingslips "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/routingslips/get"
+	sbom "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/sbom/get"
 	sources "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/sources/get"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs"
 	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
@@ -33,5 +35,7 @@ func NewCommand(ctx clictx.Context) *cobra.Command {
 	cmd.AddCommand(credentials.NewCommand(ctx))
 	cmd.AddCommand(plugins.NewCommand(ctx))
 	cmd.AddCommand(routingslips.NewCommand(ctx))
+	cmd.AddCommand(sbom.NewCommand(ctx))
+	cmd.AddCommand(keystore.NewCommand(ctx))
 	return cmd
 }
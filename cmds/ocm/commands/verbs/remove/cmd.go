@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remove
+
+import (
+	"github.com/spf13/cobra"
+
+	keystore "github.com/open-component-model/ocm/cmds/ocm/commands/misccmds/keystore/remove"
+	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs"
+	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
+	"github.com/open-component-model/ocm/pkg/contexts/clictx"
+)
+
+// NewCommand creates a new command.
+func NewCommand(ctx clictx.Context) *cobra.Command {
+	cmd := utils.MassageCommand(&cobra.Command{
+		Short: "Remove elements",
+	}, verbs.Remove)
+	cmd.AddCommand(keystore.NewCommand(ctx))
+	return cmd
+}
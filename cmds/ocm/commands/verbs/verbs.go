@@ -20,4 +20,7 @@ const (
 	Clean     = "clean"
 	Install   = "install"
 	Execute   = "execute"
+	Remove    = "remove"
+	Rotate    = "rotate"
+	Diff      = "diff"
 )
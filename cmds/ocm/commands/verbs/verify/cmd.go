@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	components "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/components/verify"
+	routingslips "github.com/open-component-model/ocm/cmds/ocm/commands/ocmcmds/routingslips/verify"
 	"github.com/open-component-model/ocm/cmds/ocm/commands/verbs"
 	"github.com/open-component-model/ocm/cmds/ocm/pkg/utils"
 	"github.com/open-component-model/ocm/pkg/contexts/clictx"
@@ -19,5 +20,6 @@ func NewCommand(ctx clictx.Context) *cobra.Command {
 		Short: "Verify component version signatures",
 	}, verbs.Verify)
 	cmd.AddCommand(components.NewCommand(ctx))
+	cmd.AddCommand(routingslips.NewCommand(ctx))
 	return cmd
 }
@@ -441,7 +441,9 @@ execution and reading provided executor outputs after the execution.
     │   ├── config      configuration from package specification
     │   ├── ocmrepo     OCM filesystem repository containing the complete
     │   │               component version of the package
-    │   └── parameters  merged complete parameter file
+    │   ├── parameters  merged complete parameter file
+    │   └── state       optional installation record of the previous
+    │                   execution, including its outputs
     ├── outputs
     │   ├── &lt;out>       any number of arbitrary output data provided
     │   │               by executor
@@ -460,6 +462,12 @@ files to logical outputs in the <code>outputs</code> section.
 Basically the output may contain any data, but is strongly recommended
 to use yaml or json files, only. This enables further formal processing
 by the TOI toolset.
+
+The outputs of an execution are kept together with the used component version
+and parameters in an installation record (see <CMD>ocm bootstrap status</CMD>).
+For subsequent actions on this installation (for example <code>upgrade</code>
+or <code>uninstall</code>) this record is passed to the executor as input
+<code>state</code>.
 `,
 	}
 }
@@ -0,0 +1,413 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azureblob
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5" //nolint:gosec // md5 is the content checksum used by the blob service
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/azureblob/identity"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/logging"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+// API_VERSION is the used version of the blob service REST API.
+const API_VERSION = "2021-08-06"
+
+const (
+	// BLOCK_SIZE is the default size of the blocks used to upload
+	// larger blobs.
+	BLOCK_SIZE = 8 * 1024 * 1024
+	// TIMEOUT is the timeout for a single request. Because larger blobs are
+	// uploaded in blocks, it also limits the upload of a single block.
+	// For downloads only the wait time for the response is limited.
+	TIMEOUT = 5 * time.Minute
+)
+
+// ServiceURL provides the blob service URL for a storage account.
+// If no endpoint is given, the public Azure endpoint
+// https://<account>.blob.core.windows.net is used.
+// Emulators like Azurite use path-style URLs, here the endpoint must
+// include the account name (for example http://127.0.0.1:10000/devstoreaccount1).
+func ServiceURL(account, endpoint string) string {
+	if endpoint == "" {
+		return "https://" + account + ".blob.core.windows.net"
+	}
+	return strings.TrimSuffix(endpoint, "/")
+}
+
+// Properties describes the properties of a stored blob.
+type Properties struct {
+	ContentType string
+	Size        int64
+	ETag        string
+	// ContentMD5 is the base64 encoded MD5 hash of the content, if known.
+	ContentMD5 string
+}
+
+// Client provides access to the blobs of a storage account.
+type Client struct {
+	serviceURL string
+	account    string
+	creds      common.Properties
+	blockSize  int64
+	client     *http.Client
+	stream     *http.Client
+}
+
+// NewClient provides a client for the blob service of the given storage
+// account. The credential properties are described by the package
+// github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/azureblob/identity.
+func NewClient(account, endpoint string, creds common.Properties) (*Client, error) {
+	if account == "" {
+		return nil, errors.ErrRequired("storage account name")
+	}
+	u := ServiceURL(account, endpoint)
+	if _, err := url.Parse(u); err != nil {
+		return nil, errors.ErrInvalidWrap(err, "blob service url", u)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = TIMEOUT
+	return &Client{
+		serviceURL: u,
+		account:    account,
+		creds:      creds,
+		blockSize:  BLOCK_SIZE,
+		client:     &http.Client{Transport: transport, Timeout: TIMEOUT},
+		stream:     &http.Client{Transport: transport},
+	}, nil
+}
+
+// WithBlockSize sets the size of the blocks used to upload blobs.
+// Blobs not larger than the block size are uploaded with a single request.
+func (c *Client) WithBlockSize(size int64) *Client {
+	if size > 0 {
+		c.blockSize = size
+	}
+	return c
+}
+
+func (c *Client) ServiceURL() string {
+	return c.serviceURL
+}
+
+// BlobURL provides the URL of a blob (without authorization).
+func (c *Client) BlobURL(container, blob string) string {
+	return c.serviceURL + "/" + container + "/" + escapePath(blob)
+}
+
+// GetProperties provides the properties of a blob.
+func (c *Client) GetProperties(container, blob string) (*Properties, error) {
+	resp, err := c.request(c.client, http.MethodHead, container, blob, nil, nil, -1, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	if err := c.checkStatus(resp, container, blob, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return properties(resp), nil
+}
+
+// Download provides a blob access for the content of a blob.
+// If the blob service provides a content MD5 hash, the content is verified.
+// If no media type is given, the content type of the blob is used.
+func (c *Client) Download(container, blob string, mediaType string) (_ blobaccess.BlobAccess, rerr error) {
+	log := logging.Context().Logger(identity.REALM).WithValues("container", container, "blob", blob)
+
+	resp, err := c.request(c.stream, http.MethodGet, container, blob, nil, nil, -1, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer errors.PropagateError(&rerr, resp.Body.Close)
+	if err := c.checkStatus(resp, container, blob, http.StatusOK); err != nil {
+		return nil, err
+	}
+	props := properties(resp)
+
+	temp, err := blobaccess.NewTempFile("", "azureblob*")
+	if err != nil {
+		return nil, err
+	}
+	defer errors.PropagateError(&rerr, temp.Close)
+
+	h := md5.New() //nolint:gosec // see above
+	if _, err := io.Copy(io.MultiWriter(temp.Writer(), h), resp.Body); err != nil {
+		return nil, errors.Wrapf(err, "cannot download %s", c.BlobURL(container, blob))
+	}
+	if props.ContentMD5 != "" {
+		found := base64.StdEncoding.EncodeToString(h.Sum(nil))
+		if found != props.ContentMD5 {
+			return nil, errors.Newf("MD5 mismatch for blob %s in container %s: expected %s, found %s", blob, container, props.ContentMD5, found)
+		}
+		log.Debug("MD5 checksum verified")
+	} else {
+		log.Debug("no checksum provided for {{blob}}")
+	}
+	if mediaType == "" {
+		mediaType = props.ContentType
+	}
+	if mediaType == "" {
+		mediaType = mime.MIME_OCTET
+	}
+	return temp.AsBlob(mediaType), nil
+}
+
+// Upload stores the given blob as block blob. If a blob with the same
+// content already exists, nothing is done.
+func (c *Client) Upload(container, blob string, data blobaccess.BlobAccess) (rerr error) {
+	log := logging.Context().Logger(identity.REALM).WithValues("container", container, "blob", blob)
+
+	size, sum, err := md5sum(data)
+	if err != nil {
+		return err
+	}
+
+	props, err := c.GetProperties(container, blob)
+	if err != nil && !errors.IsErrNotFound(err) {
+		return err
+	}
+	if props != nil && props.ContentMD5 == sum {
+		log.Debug("blob already exists, skipping upload")
+		return nil
+	}
+
+	rd, err := data.Reader()
+	if err != nil {
+		return err
+	}
+	defer errors.PropagateError(&rerr, rd.Close)
+
+	log.Debug("uploading")
+	if size > c.blockSize {
+		err = c.uploadBlocks(container, blob, data.MimeType(), size, sum, rd)
+	} else {
+		header := http.Header{}
+		header.Set("x-ms-blob-type", "BlockBlob")
+		header.Set("Content-MD5", sum)
+		if data.MimeType() != "" {
+			header.Set("Content-Type", data.MimeType())
+		}
+		err = c.put(container, blob, nil, header, size, rd)
+	}
+	if err != nil {
+		return err
+	}
+	log.Debug("successfully uploaded")
+	return nil
+}
+
+// uploadBlocks uploads the content as sequence of blocks (Put Block),
+// which are finally committed with Put Block List. This is required for blobs
+// larger than the maximum size of a single Put Blob request.
+func (c *Client) uploadBlocks(container, blob string, mediaType string, size int64, sum string, rd io.Reader) error {
+	log := logging.Context().Logger(identity.REALM).WithValues("container", container, "blob", blob)
+
+	buf := make([]byte, c.blockSize)
+	list := &bytes.Buffer{}
+	list.WriteString(`<?xml version="1.0" encoding="utf-8"?><BlockList>`)
+	for i := 0; size > 0; i++ {
+		n := int64(len(buf))
+		if size < n {
+			n = size
+		}
+		if _, err := io.ReadFull(rd, buf[:n]); err != nil {
+			return errors.Wrapf(err, "cannot read blob content")
+		}
+		size -= n
+
+		// all block ids of a blob must have the same length
+		id := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("block-%08d", i)))
+		h := md5.Sum(buf[:n]) //nolint:gosec // see above
+		header := http.Header{}
+		header.Set("Content-MD5", base64.StdEncoding.EncodeToString(h[:]))
+		log.Trace("uploading block", "block", i, "size", n)
+		err := c.put(container, blob, url.Values{"comp": {"block"}, "blockid": {id}}, header, n, bytes.NewReader(buf[:n]))
+		if err != nil {
+			return errors.Wrapf(err, "cannot upload block %d", i)
+		}
+		list.WriteString("<Latest>" + id + "</Latest>")
+	}
+	list.WriteString("</BlockList>")
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/xml")
+	// the service does not calculate the MD5 hash for blobs composed of blocks
+	header.Set("x-ms-blob-content-md5", sum)
+	if mediaType != "" {
+		header.Set("x-ms-blob-content-type", mediaType)
+	}
+	return c.put(container, blob, url.Values{"comp": {"blocklist"}}, header, int64(list.Len()), list)
+}
+
+func (c *Client) put(container, blob string, query url.Values, header http.Header, size int64, body io.Reader) error {
+	resp, err := c.request(c.client, http.MethodPut, container, blob, query, header, size, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return c.checkStatus(resp, container, blob, http.StatusCreated)
+}
+
+func (c *Client) checkStatus(resp *http.Response, container, blob string, expected int) error {
+	if resp.StatusCode == expected {
+		return nil
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return errors.ErrNotFound("blob", blob, container)
+	}
+	buf := &bytes.Buffer{}
+	_, _ = io.Copy(buf, io.LimitReader(resp.Body, 2000))
+	return fmt.Errorf("http (%d) - %s %s: %s", resp.StatusCode, resp.Request.Method, c.BlobURL(container, blob), buf.String())
+}
+
+func (c *Client) request(client *http.Client, method, container, blob string, query url.Values, header http.Header, size int64, body io.Reader) (*http.Response, error) {
+	u, err := url.Parse(c.BlobURL(container, blob))
+	if err != nil {
+		return nil, err
+	}
+	sas := c.creds[identity.ATTR_SAS_TOKEN]
+	if c.creds[identity.ATTR_ACCOUNT_KEY] == "" && sas != "" {
+		u.RawQuery = strings.TrimPrefix(sas, "?")
+	}
+	if len(query) > 0 {
+		if u.RawQuery != "" {
+			u.RawQuery += "&"
+		}
+		u.RawQuery += query.Encode()
+	}
+	req, err := http.NewRequestWithContext(context.Background(), method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if size >= 0 {
+		req.ContentLength = size
+		if size == 0 {
+			req.Body = http.NoBody
+		}
+	}
+	req.Header.Set("x-ms-version", API_VERSION)
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+
+	switch {
+	case c.creds[identity.ATTR_ACCOUNT_KEY] != "":
+		key, err := base64.StdEncoding.DecodeString(c.creds[identity.ATTR_ACCOUNT_KEY])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid account key")
+		}
+		req.Header.Set("Authorization", "SharedKey "+c.account+":"+signature(req, c.account, key))
+	case sas != "":
+		// already part of the query
+	case c.creds[identity.ATTR_TOKEN] != "":
+		req.Header.Set("Authorization", "Bearer "+c.creds[identity.ATTR_TOKEN])
+	}
+	return client.Do(req)
+}
+
+// signature provides the shared key signature for a request according to
+// https://learn.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key.
+func signature(req *http.Request, account string, key []byte) string {
+	length := ""
+	if req.ContentLength > 0 {
+		length = strconv.FormatInt(req.ContentLength, 10)
+	}
+	h := req.Header
+	fields := []string{
+		req.Method,
+		h.Get("Content-Encoding"),
+		h.Get("Content-Language"),
+		length,
+		h.Get("Content-MD5"),
+		h.Get("Content-Type"),
+		"", // Date, x-ms-date is used instead
+		h.Get("If-Modified-Since"),
+		h.Get("If-Match"),
+		h.Get("If-None-Match"),
+		h.Get("If-Unmodified-Since"),
+		h.Get("Range"),
+	}
+
+	var names []string
+	for k := range h {
+		if n := strings.ToLower(k); strings.HasPrefix(n, "x-ms-") {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		fields = append(fields, n+":"+strings.TrimSpace(h.Get(n)))
+	}
+
+	resource := "/" + account + req.URL.EscapedPath()
+	query := req.URL.Query()
+	var params []string
+	for k := range query {
+		params = append(params, k)
+	}
+	sort.Strings(params)
+	for _, k := range params {
+		values := query[k]
+		sort.Strings(values)
+		resource += "\n" + strings.ToLower(k) + ":" + strings.Join(values, ",")
+	}
+	fields = append(fields, resource)
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(strings.Join(fields, "\n")))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func properties(resp *http.Response) *Properties {
+	size, err := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64)
+	if err != nil {
+		size = blobaccess.BLOB_UNKNOWN_SIZE
+	}
+	return &Properties{
+		ContentType: resp.Header.Get("Content-Type"),
+		Size:        size,
+		ETag:        resp.Header.Get("ETag"),
+		ContentMD5:  resp.Header.Get("Content-MD5"),
+	}
+}
+
+func md5sum(data blobaccess.DataAccess) (_ int64, _ string, rerr error) {
+	rd, err := data.Reader()
+	if err != nil {
+		return 0, "", err
+	}
+	defer errors.PropagateError(&rerr, rd.Close)
+	h := md5.New() //nolint:gosec // see above
+	n, err := io.Copy(h, rd)
+	if err != nil {
+		return 0, "", err
+	}
+	return n, base64.StdEncoding.EncodeToString(h.Sum(nil)), nil
+}
+
+func escapePath(p string) string {
+	parts := strings.Split(p, "/")
+	for i, s := range parts {
+		parts[i] = url.PathEscape(s)
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azureblob_test
+
+import (
+	"bytes"
+	"crypto/md5" //nolint:gosec // used by the blob service
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/azureblob"
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const (
+	ACCOUNT   = "devstoreaccount1"
+	CONTAINER = "blobs"
+	BLOB      = "dir/blob"
+	CONTENT   = "0123456789"
+)
+
+type blob struct {
+	data  []byte
+	ctype string
+	md5   string
+}
+
+// fakeServer is a minimal stand-in for the blob service (like Azurite)
+// supporting block uploads.
+type fakeServer struct {
+	lock   sync.Mutex
+	blobs  map[string]*blob
+	blocks map[string][]byte
+	puts   []string
+}
+
+func (s *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		comp := r.URL.Query().Get("comp")
+		s.puts = append(s.puts, comp)
+		switch comp {
+		case "block":
+			sum := md5.Sum(data) //nolint:gosec // see above
+			if base64.StdEncoding.EncodeToString(sum[:]) != r.Header.Get("Content-MD5") {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			s.blocks[r.URL.Query().Get("blockid")] = data
+		case "blocklist":
+			var list struct {
+				Latest []string
+			}
+			if xml.Unmarshal(data, &list) != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			content := &bytes.Buffer{}
+			for _, id := range list.Latest {
+				content.Write(s.blocks[id])
+			}
+			s.blobs[r.URL.Path] = &blob{content.Bytes(), r.Header.Get("x-ms-blob-content-type"), r.Header.Get("x-ms-blob-content-md5")}
+		default:
+			s.blobs[r.URL.Path] = &blob{data, r.Header.Get("Content-Type"), r.Header.Get("Content-MD5")}
+		}
+		w.WriteHeader(http.StatusCreated)
+	default:
+		b := s.blobs[r.URL.Path]
+		if b == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", b.ctype)
+		w.Header().Set("Content-MD5", b.md5)
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			w.Write(b.data)
+		}
+	}
+}
+
+var _ = Describe("client", func() {
+	var (
+		fake   *fakeServer
+		server *httptest.Server
+		client *azureblob.Client
+	)
+
+	BeforeEach(func() {
+		fake = &fakeServer{blobs: map[string]*blob{}, blocks: map[string][]byte{}}
+		server = httptest.NewServer(fake)
+		client = Must(azureblob.NewClient(ACCOUNT, server.URL+"/"+ACCOUNT, common.Properties{}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("uploads small blob with single request", func() {
+		MustBeSuccessful(client.Upload(CONTAINER, BLOB, blobaccess.ForString(mime.MIME_TEXT, CONTENT)))
+		Expect(fake.puts).To(Equal([]string{""}))
+
+		b := Must(client.Download(CONTAINER, BLOB, ""))
+		defer Close(b)
+		Expect(b.MimeType()).To(Equal(mime.MIME_TEXT))
+		Expect(string(Must(b.Get()))).To(Equal(CONTENT))
+	})
+
+	It("uploads blob in blocks", func() {
+		client.WithBlockSize(4)
+		MustBeSuccessful(client.Upload(CONTAINER, BLOB, blobaccess.ForString(mime.MIME_TEXT, CONTENT)))
+		Expect(fake.puts).To(Equal([]string{"block", "block", "block", "blocklist"}))
+
+		b := Must(client.Download(CONTAINER, BLOB, ""))
+		defer Close(b)
+		Expect(b.MimeType()).To(Equal(mime.MIME_TEXT))
+		Expect(string(Must(b.Get()))).To(Equal(CONTENT))
+
+		MustBeSuccessful(client.Upload(CONTAINER, BLOB, blobaccess.ForString(mime.MIME_TEXT, CONTENT)))
+		Expect(len(fake.puts)).To(Equal(4))
+	})
+})
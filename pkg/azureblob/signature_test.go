@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azureblob
+
+import (
+	"encoding/base64"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+)
+
+// The expected signatures have been calculated with the shared key
+// authorization of the storage package of github.com/Azure/azure-sdk-for-go
+// (v68.0.0) for identical requests.
+var _ = Describe("shared key signature", func() {
+	key := Must(base64.StdEncoding.DecodeString("a2V5LWZvci10ZXN0aW5nLW9ubHk="))
+
+	request := func(url string, header map[string]string) *http.Request {
+		req := Must(http.NewRequest(http.MethodPut, url, nil))
+		req.ContentLength = 4
+		req.Header.Set("x-ms-version", "2021-08-06")
+		req.Header.Set("x-ms-date", "Sun, 18 Oct 2026 18:17:08 GMT")
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		return req
+	}
+
+	It("signs put blob request", func() {
+		req := request("https://myaccount.blob.core.windows.net/blobs/dir/test%20file.txt", map[string]string{
+			"x-ms-blob-type":         "BlockBlob",
+			"x-ms-blob-content-md5":  "CY9rzUYh03PK3k6DJie09g==",
+			"x-ms-blob-content-type": "text/plain",
+		})
+		Expect(signature(req, "myaccount", key)).To(Equal("ayohXWjZd4LfgSVmQw8+upnfsxTTcrR0OXUle2UjWuA="))
+	})
+
+	It("signs request with query parameters", func() {
+		req := request("https://myaccount.blob.core.windows.net/blobs/dir/test%20file.txt?blockid=YmxvY2stMDAwMDA%3D&comp=block", nil)
+		Expect(signature(req, "myaccount", key)).To(Equal("TFOSo08sLDNrFS/+O6LntCG8eRp1l3NTmp/BlJwvMq8="))
+	})
+})
@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/go-git/go-git/v5/storage/memory"
+
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/blobaccess/bpi"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/git/identity"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/optionutils"
+	"github.com/open-component-model/ocm/pkg/utils"
+)
+
+func DataAccessForGit(repoURL string, opts ...Option) (bpi.DataAccess, error) {
+	blobAccess, err := BlobAccessForGit(repoURL, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return blobAccess, nil
+}
+
+// BlobAccessForGit provides a blob access for the file tree of a commit
+// of a git repository as tar archive. The commit is determined by
+// an explicit commit id, a ref or, by default, the HEAD of the
+// repository.
+func BlobAccessForGit(repoURL string, opts ...Option) (_ bpi.BlobAccess, rerr error) {
+	eff := optionutils.EvalOptions(opts...)
+	log := eff.Logger("repository", repoURL)
+
+	ep, err := transport.NewEndpoint(repoURL)
+	if err != nil {
+		return nil, errors.ErrInvalidWrap(err, "repository url", repoURL)
+	}
+	creds, err := eff.GetCredentials(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	if creds == nil {
+		log.Debug("no credentials found for repository {{repository}}", "repository", repoURL)
+	}
+	auth, err := AuthMethod(ep, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := git.Clone(memory.NewStorage(), nil, &git.CloneOptions{
+		URL:    repoURL,
+		Auth:   auth,
+		Mirror: true,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot clone repository %q", repoURL)
+	}
+
+	rev := "HEAD"
+	switch {
+	case eff.Commit != "":
+		rev = eff.Commit
+	case eff.Ref != "":
+		rev = eff.Ref
+	}
+	hash, err := repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot resolve %q in repository %q", rev, repoURL)
+	}
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get commit %s", hash)
+	}
+	log.Debug("using commit {{commit}}", "commit", hash.String())
+
+	temp, err := blobaccess.NewTempFile("", "gitblob*.tar")
+	if err != nil {
+		return nil, err
+	}
+	defer errors.PropagateError(&rerr, temp.Close)
+
+	if utils.AsBool(eff.CompressWithGzip) {
+		if eff.MimeType == "" {
+			eff.MimeType = mime.MIME_TGZ
+		}
+		gw := gzip.NewWriter(temp.Writer())
+		if err := WriteArchive(commit, eff.Path, gw); err != nil {
+			return nil, err
+		}
+		if err := gw.Close(); err != nil {
+			return nil, fmt.Errorf("unable to close gzip writer: %w", err)
+		}
+	} else {
+		if eff.MimeType == "" {
+			eff.MimeType = mime.MIME_TAR
+		}
+		if err := WriteArchive(commit, eff.Path, temp.Writer()); err != nil {
+			return nil, err
+		}
+	}
+	return temp.AsBlob(eff.MimeType), nil
+}
+
+func BlobAccessProviderForGit(repoURL string, opts ...Option) bpi.BlobAccessProvider {
+	return bpi.BlobAccessProviderFunction(func() (bpi.BlobAccess, error) {
+		return BlobAccessForGit(repoURL, opts...)
+	})
+}
+
+// WriteArchive writes the file tree of the given commit as tar archive.
+// If a path is given, only the file or directory tree with this path is
+// included. The file paths in the archive are always relative to the
+// root of the repository.
+func WriteArchive(commit *object.Commit, path string, w io.Writer) error {
+	tree, err := commit.Tree()
+	if err != nil {
+		return errors.Wrapf(err, "cannot get file tree of commit %s", commit.Hash)
+	}
+
+	path = strings.Trim(path, "/")
+	found := path == ""
+	tw := tar.NewWriter(w)
+	err = tree.Files().ForEach(func(f *object.File) error {
+		if path != "" && f.Name != path && !strings.HasPrefix(f.Name, path+"/") {
+			return nil
+		}
+		found = true
+		return addFile(tw, f, commit)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "cannot archive commit %s", commit.Hash)
+	}
+	if !found {
+		return errors.ErrNotFound("path", path, commit.Hash.String())
+	}
+	return tw.Close()
+}
+
+func addFile(tw *tar.Writer, f *object.File, commit *object.Commit) error {
+	header := &tar.Header{
+		Name:    f.Name,
+		ModTime: commit.Committer.When,
+		Mode:    0o644,
+	}
+	switch f.Mode {
+	case filemode.Symlink:
+		target, err := f.Contents()
+		if err != nil {
+			return err
+		}
+		header.Typeflag = tar.TypeSymlink
+		header.Linkname = target
+		header.Mode = 0o777
+		return tw.WriteHeader(header)
+	case filemode.Executable:
+		header.Mode = 0o755
+	}
+	header.Typeflag = tar.TypeReg
+	header.Size = f.Size
+
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	r, err := f.Reader()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	_, err = io.Copy(tw, r)
+	return err
+}
+
+// AuthMethod provides the git authentication method for the given
+// repository endpoint based on the given credentials.
+func AuthMethod(ep *transport.Endpoint, creds credentials.Credentials) (transport.AuthMethod, error) {
+	if creds == nil {
+		return nil, nil
+	}
+	user := creds.GetProperty(identity.ATTR_USERNAME)
+	switch ep.Protocol {
+	case "ssh":
+		key := creds.GetProperty(identity.ATTR_PRIVATE_KEY)
+		if key == "" {
+			return nil, nil
+		}
+		if user == "" {
+			user = ep.User
+		}
+		if user == "" {
+			user = "git"
+		}
+		auth, err := gitssh.NewPublicKeys(user, []byte(key), creds.GetProperty(identity.ATTR_PASSWORD))
+		if err != nil {
+			return nil, errors.ErrInvalidWrap(err, "ssh private key")
+		}
+		return auth, nil
+	case "http", "https":
+		if token := creds.GetProperty(identity.ATTR_TOKEN); token != "" {
+			if user == "" {
+				user = "git"
+			}
+			return &githttp.BasicAuth{Username: user, Password: token}, nil
+		}
+		if user != "" {
+			return &githttp.BasicAuth{Username: user, Password: creds.GetProperty(identity.ATTR_PASSWORD)}, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,11 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	ocmlog "github.com/open-component-model/ocm/pkg/logging"
+)
+
+var REALM = ocmlog.DefineSubRealm("blob access for git", "blobaccess/git")
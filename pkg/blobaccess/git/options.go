@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"github.com/mandelsoft/logging"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/git/identity"
+	ocmlog "github.com/open-component-model/ocm/pkg/logging"
+	"github.com/open-component-model/ocm/pkg/optionutils"
+	"github.com/open-component-model/ocm/pkg/utils"
+)
+
+type Option = optionutils.Option[*Options]
+
+type Options struct {
+	CredentialContext credentials.Context
+	LoggingContext    logging.Context
+	// Credentials allows to pass credentials for the repository access.
+	Credentials credentials.Credentials
+	// Ref is the ref (branch, tag or revision expression) used to
+	// determine the commit, if no explicit commit is given.
+	Ref string
+	// Commit is the commit id to deliver.
+	Commit string
+	// Path is an optional path filter. If given, only the file or the
+	// directory tree with this path is included in the archive.
+	Path string
+	// CompressWithGzip defines whether the archive should be compressed.
+	CompressWithGzip *bool
+	// MimeType defines the media type of the archive.
+	MimeType string
+}
+
+func (o *Options) Logger(keyValuePairs ...interface{}) logging.Logger {
+	return ocmlog.LogContext(o.LoggingContext, o.CredentialContext).Logger(REALM).WithValues(keyValuePairs...)
+}
+
+func (o *Options) GetCredentials(repoURL string) (credentials.Credentials, error) {
+	switch {
+	case o.Credentials != nil:
+		return o.Credentials, nil
+	case o.CredentialContext != nil:
+		id := identity.GetConsumerId(repoURL)
+		if id == nil {
+			return nil, nil
+		}
+		return credentials.CredentialsForConsumer(o.CredentialContext, id, identity.IdentityMatcher)
+	default:
+		return nil, nil
+	}
+}
+
+func (o *Options) ApplyTo(opts *Options) {
+	if opts == nil {
+		return
+	}
+	if o.CredentialContext != nil {
+		opts.CredentialContext = o.CredentialContext
+	}
+	if o.LoggingContext != nil {
+		opts.LoggingContext = o.LoggingContext
+	}
+	if o.Credentials != nil {
+		opts.Credentials = o.Credentials
+	}
+	if o.Ref != "" {
+		opts.Ref = o.Ref
+	}
+	if o.Commit != "" {
+		opts.Commit = o.Commit
+	}
+	if o.Path != "" {
+		opts.Path = o.Path
+	}
+	if o.CompressWithGzip != nil {
+		opts.CompressWithGzip = utils.BoolP(*o.CompressWithGzip)
+	}
+	if o.MimeType != "" {
+		opts.MimeType = o.MimeType
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type context struct {
+	credentials.Context
+}
+
+func (o context) ApplyTo(opts *Options) {
+	opts.CredentialContext = o
+}
+
+func WithCredentialContext(ctx credentials.ContextProvider) Option {
+	return context{ctx.CredentialsContext()}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type loggingContext struct {
+	logging.Context
+}
+
+func (o loggingContext) ApplyTo(opts *Options) {
+	opts.LoggingContext = o
+}
+
+func WithLoggingContext(ctx logging.ContextProvider) Option {
+	return loggingContext{ctx.LoggingContext()}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type creds struct {
+	credentials.Credentials
+}
+
+func (o creds) ApplyTo(opts *Options) {
+	opts.Credentials = o.Credentials
+}
+
+func WithCredentials(c credentials.Credentials) Option {
+	return creds{c}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type ref string
+
+func (o ref) ApplyTo(opts *Options) {
+	opts.Ref = string(o)
+}
+
+func WithRef(r string) Option {
+	return ref(r)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type commit string
+
+func (o commit) ApplyTo(opts *Options) {
+	opts.Commit = string(o)
+}
+
+func WithCommit(c string) Option {
+	return commit(c)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type path string
+
+func (o path) ApplyTo(opts *Options) {
+	opts.Path = string(o)
+}
+
+func WithPath(p string) Option {
+	return path(p)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type compress bool
+
+func (o compress) ApplyTo(opts *Options) {
+	opts.CompressWithGzip = utils.BoolP(o)
+}
+
+func WithCompressWithGzip(b ...bool) Option {
+	return compress(utils.OptionalDefaultedBool(true, b...))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type mimeType string
+
+func (o mimeType) ApplyTo(opts *Options) {
+	opts.MimeType = string(o)
+}
+
+func WithMimeType(mime string) Option {
+	return mimeType(mime)
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package s3
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	awscreds "github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// NewClient provides an S3 client for the given bucket.
+// If no region is given, the region of the bucket is determined.
+// If an endpoint is given (for example for MinIO), it is used instead
+// of the AWS endpoints together with path style addressing.
+// It returns the client and the effective region.
+func NewClient(ctx context.Context, region, bucket, endpoint string, creds *AWSCreds) (*s3.Client, string, error) {
+	opts := []func(*config.LoadOptions) error{
+		config.WithRegion(region),
+	}
+	var awsCred aws.CredentialsProvider = aws.AnonymousCredentials{}
+	if creds != nil {
+		awsCred = awscreds.StaticCredentialsProvider{
+			Value: aws.Credentials{
+				AccessKeyID:     creds.AccessKeyID,
+				SecretAccessKey: creds.AccessSecret,
+				SessionToken:    creds.SessionToken,
+			},
+		}
+	}
+	opts = append(opts, config.WithCredentialsProvider(awsCred))
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load configuration for AWS: %w", err)
+	}
+
+	withEndpoint := func(o *s3.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+			o.UsePathStyle = true
+		}
+	}
+
+	if region == "" {
+		var err error
+		// deliberately use a different client so the real one will use the right region.
+		// Region has to be provided to get the region of the specified bucket. We use the
+		// global "default" of us-west-1 here. This will be updated to the right region
+		// once we retrieve it or die trying.
+		cfg.Region = defaultRegion
+		region, err = manager.GetBucketRegion(ctx, s3.NewFromConfig(cfg, withEndpoint), bucket, func(o *s3.Options) {
+			o.Region = defaultRegion
+		})
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to find bucket region: %w", err)
+		}
+		cfg.Region = region
+	}
+
+	client := s3.NewFromConfig(cfg, withEndpoint, func(o *s3.Options) {
+		// Pass in creds because of https://github.com/aws/aws-sdk-go-v2/issues/1797
+		o.Credentials = awsCred
+		o.Region = region
+	})
+	return client, region, nil
+}
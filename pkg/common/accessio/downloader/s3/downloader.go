@@ -10,8 +10,6 @@ import (
 	"io"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
-	awscreds "github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 )
@@ -21,6 +19,7 @@ const defaultRegion = "us-west-1"
 // Downloader is a downloader capable of downloading S3 Objects.
 type Downloader struct {
 	region, bucket, key, version string
+	endpoint                     string
 	creds                        *AWSCreds
 }
 
@@ -34,6 +33,13 @@ func NewDownloader(region, bucket, key, version string, creds *AWSCreds) *Downlo
 	}
 }
 
+// WithEndpoint sets an S3 compatible endpoint (for example MinIO)
+// used instead of the AWS endpoints.
+func (s *Downloader) WithEndpoint(endpoint string) *Downloader {
+	s.endpoint = endpoint
+	return s
+}
+
 // AWSCreds groups AWS related credential values together.
 type AWSCreds struct {
 	AccessKeyID  string
@@ -43,45 +49,11 @@ type AWSCreds struct {
 
 func (s *Downloader) Download(w io.WriterAt) error {
 	ctx := context.Background()
-	opts := []func(*config.LoadOptions) error{
-		config.WithRegion(s.region),
-	}
-	var awsCred aws.CredentialsProvider = aws.AnonymousCredentials{}
-	if s.creds != nil {
-		awsCred = awscreds.StaticCredentialsProvider{
-			Value: aws.Credentials{
-				AccessKeyID:     s.creds.AccessKeyID,
-				SecretAccessKey: s.creds.AccessSecret,
-			},
-		}
-	}
-	opts = append(opts, config.WithCredentialsProvider(awsCred))
-	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	client, region, err := NewClient(ctx, s.region, s.bucket, s.endpoint, s.creds)
 	if err != nil {
-		return fmt.Errorf("failed to load configuration for AWS: %w", err)
+		return err
 	}
-
-	if s.region == "" {
-		var err error
-		// deliberately use a different client so the real one will use the right region.
-		// Region has to be provided to get the region of the specified bucket. We use the
-		// global "default" of us-west-1 here. This will be updated to the right region
-		// once we retrieve it or die trying.
-		cfg.Region = defaultRegion
-		s.region, err = manager.GetBucketRegion(ctx, s3.NewFromConfig(cfg), s.bucket, func(o *s3.Options) {
-			o.Region = defaultRegion
-		})
-		if err != nil {
-			return fmt.Errorf("failed to find bucket region: %w", err)
-		}
-		cfg.Region = s.region
-	}
-
-	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
-		// Pass in creds because of https://github.com/aws/aws-sdk-go-v2/issues/1797
-		o.Credentials = awsCred
-		o.Region = s.region
-	})
+	s.region = region
 	downloader := manager.NewDownloader(client)
 
 	input := &s3.GetObjectInput{
@@ -10,6 +10,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/mandelsoft/logging"
 )
@@ -44,6 +45,7 @@ type FlushingPrinter interface {
 }
 
 type printerState struct {
+	lock    sync.Mutex
 	pending bool
 }
 
@@ -54,7 +56,7 @@ type printer struct {
 }
 
 func NewPrinter(writer io.Writer) Printer {
-	return &printer{writer: writer, state: &printerState{true}}
+	return &printer{writer: writer, state: &printerState{pending: true}}
 }
 
 func AssurePrinter(p Printer) Printer {
@@ -81,6 +83,12 @@ func (p *printer) Write(data []byte) (int, error) {
 	if p.writer == nil {
 		return 0, nil
 	}
+	p.state.lock.Lock()
+	defer p.state.lock.Unlock()
+	return p.write(data)
+}
+
+func (p *printer) write(data []byte) (int, error) {
 	s := strings.ReplaceAll(string(data), "\n", "\n"+p.gap)
 	if strings.HasSuffix(s, "\n"+p.gap) {
 		p.state.pending = true
@@ -93,6 +101,8 @@ func (p *printer) printf(msg string, args ...interface{}) (int, error) {
 	if p == nil || p.writer == nil {
 		return 0, nil
 	}
+	p.state.lock.Lock()
+	defer p.state.lock.Unlock()
 	if p.gap == "" {
 		return fmt.Fprintf(p.writer, msg, args...)
 	}
@@ -101,7 +111,7 @@ func (p *printer) printf(msg string, args ...interface{}) (int, error) {
 	}
 	data := fmt.Sprintf(msg, args...)
 	p.state.pending = false
-	return p.Write([]byte(data))
+	return p.write([]byte(data))
 }
 
 func (p *printer) Printf(msg string, args ...interface{}) (int, error) {
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"strings"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+	"github.com/open-component-model/ocm/pkg/listformat"
+	"github.com/open-component-model/ocm/pkg/logging"
+)
+
+// CONSUMER_TYPE is the Azure Blob Storage type.
+const CONSUMER_TYPE = "AzureBlobStorage"
+
+// used identity properties.
+const (
+	ID_TYPE       = hostpath.ID_TYPE
+	ID_HOSTNAME   = hostpath.ID_HOSTNAME
+	ID_PORT       = hostpath.ID_PORT
+	ID_PATHPREFIX = hostpath.ID_PATHPREFIX
+	ID_SCHEME     = hostpath.ID_SCHEME
+)
+
+// used credential properties.
+const (
+	ATTR_ACCOUNT_KEY = "accountKey"
+	ATTR_SAS_TOKEN   = "sasToken"
+	ATTR_TOKEN       = cpi.ATTR_TOKEN
+)
+
+// Logging Realm.
+var REALM = logging.DefineSubRealm("Azure Blob Storage", "azureblob")
+
+func init() {
+	attrs := listformat.FormatListElements("", listformat.StringElementDescriptionList{
+		ATTR_ACCOUNT_KEY, "the (base64 encoded) storage account key used for shared key authorization",
+		ATTR_SAS_TOKEN, "a shared access signature (query string) granting access to the container or blob",
+		ATTR_TOKEN, "an OAuth bearer token issued by Microsoft Entra ID",
+	})
+
+	cpi.RegisterStandardIdentity(CONSUMER_TYPE, IdentityMatcher, `Azure Blob Storage credential matcher
+
+It matches the <code>`+CONSUMER_TYPE+`</code> consumer type and additionally acts like 
+the <code>`+hostpath.IDENTITY_TYPE+`</code> type.
+The host is the blob service endpoint of the storage account and the
+path is composed of the (optional) endpoint path, the container and
+the blob name.
+If several credential properties are given, the first one of
+account key, SAS token and bearer token is used.`,
+		attrs)
+}
+
+var identityMatcher = hostpath.IdentityMatcher(CONSUMER_TYPE)
+
+func IdentityMatcher(pattern, cur, id cpi.ConsumerIdentity) bool {
+	return identityMatcher(pattern, cur, id)
+}
+
+// GetConsumerId provides the consumer identity for a blob in a container
+// of the storage account described by the given service URL.
+// It returns nil for an invalid URL.
+func GetConsumerId(serviceURL, container, blob string) cpi.ConsumerIdentity {
+	u := strings.TrimSuffix(serviceURL, "/") + "/" + container
+	if blob != "" {
+		u += "/" + blob
+	}
+	return hostpath.GetConsumerIdentity(CONSUMER_TYPE, u)
+}
+
+func GetCredentials(ctx cpi.ContextProvider, serviceURL, container, blob string) common.Properties {
+	id := GetConsumerId(serviceURL, container, blob)
+	if id == nil {
+		return nil
+	}
+	credentials, err := cpi.CredentialsForConsumer(ctx.CredentialsContext(), id, identityMatcher)
+	if credentials == nil || err != nil {
+		return nil
+	}
+	return credentials.Properties()
+}
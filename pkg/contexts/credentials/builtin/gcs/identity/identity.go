@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"strings"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+	"github.com/open-component-model/ocm/pkg/listformat"
+	"github.com/open-component-model/ocm/pkg/logging"
+)
+
+// CONSUMER_TYPE is the Google Cloud Storage type.
+const CONSUMER_TYPE = "GoogleCloudStorage"
+
+// used identity properties.
+const (
+	ID_TYPE       = hostpath.ID_TYPE
+	ID_HOSTNAME   = hostpath.ID_HOSTNAME
+	ID_PORT       = hostpath.ID_PORT
+	ID_PATHPREFIX = hostpath.ID_PATHPREFIX
+	ID_SCHEME     = hostpath.ID_SCHEME
+)
+
+// used credential properties.
+const (
+	ATTR_SERVICE_ACCOUNT_KEY = "serviceAccountKey"
+	ATTR_TOKEN               = cpi.ATTR_TOKEN
+)
+
+// Logging Realm.
+var REALM = logging.DefineSubRealm("Google Cloud Storage", "gcs")
+
+func init() {
+	attrs := listformat.FormatListElements("", listformat.StringElementDescriptionList{
+		ATTR_SERVICE_ACCOUNT_KEY, "the JSON key of a Google service account",
+		ATTR_TOKEN, "an OAuth2 access token (alternatively)",
+	})
+
+	cpi.RegisterStandardIdentity(CONSUMER_TYPE, IdentityMatcher, `Google Cloud Storage credential matcher
+
+It matches the <code>`+CONSUMER_TYPE+`</code> consumer type and additionally acts like 
+the <code>`+hostpath.IDENTITY_TYPE+`</code> type.
+The host is the storage endpoint (by default <code>storage.googleapis.com</code>)
+and the path is composed of the bucket and the object name.`,
+		attrs)
+}
+
+var identityMatcher = hostpath.IdentityMatcher(CONSUMER_TYPE)
+
+func IdentityMatcher(pattern, cur, id cpi.ConsumerIdentity) bool {
+	return identityMatcher(pattern, cur, id)
+}
+
+// GetConsumerId provides the consumer identity for an object in a bucket
+// served by the given storage endpoint.
+// It returns nil for an invalid URL.
+func GetConsumerId(endpoint, bucket, object string) cpi.ConsumerIdentity {
+	u := strings.TrimSuffix(endpoint, "/") + "/" + bucket
+	if object != "" {
+		u += "/" + object
+	}
+	return hostpath.GetConsumerIdentity(CONSUMER_TYPE, u)
+}
+
+func GetCredentials(ctx cpi.ContextProvider, endpoint, bucket, object string) common.Properties {
+	id := GetConsumerId(endpoint, bucket, object)
+	if id == nil {
+		return nil
+	}
+	credentials, err := cpi.CredentialsForConsumer(ctx.CredentialsContext(), id, identityMatcher)
+	if credentials == nil || err != nil {
+		return nil
+	}
+	return credentials.Properties()
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+	"github.com/open-component-model/ocm/pkg/listformat"
+)
+
+// CONSUMER_TYPE is the git repository type.
+const CONSUMER_TYPE = "Git"
+
+// used identity properties.
+const (
+	ID_TYPE       = hostpath.ID_TYPE
+	ID_HOSTNAME   = hostpath.ID_HOSTNAME
+	ID_PORT       = hostpath.ID_PORT
+	ID_PATHPREFIX = hostpath.ID_PATHPREFIX
+	ID_SCHEME     = hostpath.ID_SCHEME
+)
+
+// used credential properties.
+const (
+	ATTR_USERNAME    = cpi.ATTR_USERNAME
+	ATTR_PASSWORD    = cpi.ATTR_PASSWORD
+	ATTR_TOKEN       = cpi.ATTR_TOKEN
+	ATTR_PRIVATE_KEY = cpi.ATTR_PRIVATE_KEY
+)
+
+func init() {
+	attrs := listformat.FormatListElements("", listformat.StringElementDescriptionList{
+		ATTR_USERNAME, "the basic auth user name (or the ssh user name)",
+		ATTR_PASSWORD, "the basic auth password (or the password for the ssh private key)",
+		ATTR_TOKEN, "HTTP token authentication",
+		ATTR_PRIVATE_KEY, "the ssh private key used for ssh based repository access",
+	})
+
+	cpi.RegisterStandardIdentity(CONSUMER_TYPE, IdentityMatcher, `Git repository credential matcher
+
+It matches the <code>`+CONSUMER_TYPE+`</code> consumer type and additionally acts like 
+the <code>`+hostpath.IDENTITY_TYPE+`</code> type.
+Besides regular URLs, scp-like ssh repository references
+(<code>user@host:path</code>) are supported.`,
+		attrs)
+}
+
+var identityMatcher = hostpath.IdentityMatcher(CONSUMER_TYPE)
+
+func IdentityMatcher(pattern, cur, id cpi.ConsumerIdentity) bool {
+	return identityMatcher(pattern, cur, id)
+}
+
+// GetConsumerId provides the consumer identity for a git repository URL.
+// It returns nil for an invalid URL.
+func GetConsumerId(repoURL string) cpi.ConsumerIdentity {
+	ep, err := transport.NewEndpoint(repoURL)
+	if err != nil {
+		return nil
+	}
+	id := cpi.NewConsumerIdentity(CONSUMER_TYPE)
+	if ep.Host != "" {
+		id[ID_HOSTNAME] = ep.Host
+		switch {
+		case ep.Port != 0:
+			id[ID_PORT] = strconv.Itoa(ep.Port)
+		case ep.Protocol == "https":
+			id[ID_PORT] = "443"
+		case ep.Protocol == "http":
+			id[ID_PORT] = "80"
+		case ep.Protocol == "ssh":
+			id[ID_PORT] = "22"
+		}
+	}
+	if ep.Protocol != "" {
+		id[ID_SCHEME] = ep.Protocol
+	}
+	path := strings.Trim(ep.Path, "/")
+	if path != "" {
+		id[ID_PATHPREFIX] = path
+	}
+	return id
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package identity_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/git/identity"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+)
+
+var _ = Describe("git credential management", func() {
+	It("maps http urls", func() {
+		Expect(GetConsumerId("https://gitea.acme.org/org/repo.git")).To(Equal(credentials.ConsumerIdentity{
+			ID_TYPE:       CONSUMER_TYPE,
+			ID_SCHEME:     "https",
+			ID_HOSTNAME:   "gitea.acme.org",
+			ID_PORT:       "443",
+			ID_PATHPREFIX: "org/repo.git",
+		}))
+	})
+
+	It("maps scp-like ssh urls", func() {
+		Expect(GetConsumerId("git@gitlab.acme.org:org/repo.git")).To(Equal(credentials.ConsumerIdentity{
+			ID_TYPE:       CONSUMER_TYPE,
+			ID_SCHEME:     "ssh",
+			ID_HOSTNAME:   "gitlab.acme.org",
+			ID_PORT:       "22",
+			ID_PATHPREFIX: "org/repo.git",
+		}))
+	})
+
+	It("matches path prefix", func() {
+		pat := GetConsumerId("ssh://git@gitlab.acme.org:2222/org/repo.git")
+		id := credentials.ConsumerIdentity{
+			ID_TYPE:       CONSUMER_TYPE,
+			ID_HOSTNAME:   "gitlab.acme.org",
+			ID_PATHPREFIX: "org",
+		}
+		Expect(IdentityMatcher(pat, nil, id)).To(BeTrue())
+		id[ID_PORT] = "22"
+		Expect(IdentityMatcher(pat, nil, id)).To(BeFalse())
+		id[ID_PORT] = "2222"
+		id[ID_PATHPREFIX] = "other"
+		Expect(IdentityMatcher(pat, nil, id)).To(BeFalse())
+	})
+})
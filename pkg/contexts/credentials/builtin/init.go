@@ -5,8 +5,12 @@
 package builtin
 
 import (
+	_ "github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/azureblob/identity"
+	_ "github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/gcs/identity"
+	_ "github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/git/identity"
 	_ "github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/github"
 	_ "github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/helm/identity"
+	_ "github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/maven/identity"
 	_ "github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/oci/identity"
 	_ "github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/wget/identity"
 )
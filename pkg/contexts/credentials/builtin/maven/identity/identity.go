@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+	"github.com/open-component-model/ocm/pkg/listformat"
+	"github.com/open-component-model/ocm/pkg/logging"
+)
+
+const (
+	// CONSUMER_TYPE is the Maven repository type.
+	CONSUMER_TYPE = "MavenRepository"
+
+	// ATTR_USERNAME is the username attribute.
+	ATTR_USERNAME = cpi.ATTR_USERNAME
+	// ATTR_PASSWORD is the password attribute.
+	ATTR_PASSWORD = cpi.ATTR_PASSWORD
+)
+
+// Logging Realm.
+var REALM = logging.DefineSubRealm("Maven repository", "maven")
+
+func init() {
+	attrs := listformat.FormatListElements("", listformat.StringElementDescriptionList{
+		ATTR_USERNAME, "the basic auth user name",
+		ATTR_PASSWORD, "the basic auth password",
+	})
+
+	cpi.RegisterStandardIdentity(CONSUMER_TYPE, hostpath.IdentityMatcher(CONSUMER_TYPE), `Maven repository
+
+It matches the <code>`+CONSUMER_TYPE+`</code> consumer type and additionally acts like 
+the <code>`+hostpath.IDENTITY_TYPE+`</code> type.
+The path is composed of the repository path and the group id
+(with dots replaced by slashes).`,
+		attrs)
+}
+
+// GetConsumerId provides the consumer identity for the artifacts of a
+// group in a Maven repository.
+func GetConsumerId(rawURL string, groupId string) cpi.ConsumerIdentity {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil
+	}
+	u.Path = path.Join(u.Path, strings.ReplaceAll(groupId, ".", "/"))
+	return hostpath.GetConsumerIdentity(CONSUMER_TYPE, u.String())
+}
+
+func GetCredentials(ctx cpi.ContextProvider, repoUrl string, groupId string) common.Properties {
+	id := GetConsumerId(repoUrl, groupId)
+	if id == nil {
+		return nil
+	}
+	credentials, err := cpi.CredentialsForConsumer(ctx.CredentialsContext(), id)
+	if credentials == nil || err != nil {
+		return nil
+	}
+	return credentials.Properties()
+}
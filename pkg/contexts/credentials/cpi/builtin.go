@@ -10,22 +10,43 @@ import (
 
 const AliasRepositoryType = internal.AliasRepositoryType
 
-type AliasRegistry = internal.AliasRegistry
+type (
+	AliasRegistry = internal.AliasRegistry
+	AliasResolver = internal.AliasResolver
+)
 
 type aliasRegistry struct {
 	RepositoryType
 	setter internal.SetAliasFunction
+	getter internal.GetAliasFunction
 }
 
-var _ AliasRegistry = &aliasRegistry{}
+var (
+	_ AliasRegistry = &aliasRegistry{}
+	_ AliasResolver = &aliasRegistry{}
+)
 
-func NewAliasRegistry(t RepositoryType, setter internal.SetAliasFunction) RepositoryType {
+// NewAliasRegistry provides a repository type handling aliases.
+// The optional getter is used to explain the resolution of aliases.
+func NewAliasRegistry(t RepositoryType, setter internal.SetAliasFunction, getter ...internal.GetAliasFunction) RepositoryType {
+	var g internal.GetAliasFunction
+	if len(getter) > 0 {
+		g = getter[0]
+	}
 	return &aliasRegistry{
 		RepositoryType: t,
 		setter:         setter,
+		getter:         g,
 	}
 }
 
 func (a *aliasRegistry) SetAlias(ctx Context, name string, spec RepositorySpec, creds CredentialsSource) error {
 	return a.setter(ctx, name, spec, creds)
 }
+
+func (a *aliasRegistry) GetAlias(ctx Context, name string) (RepositorySpec, bool) {
+	if a.getter == nil {
+		return nil, false
+	}
+	return a.getter(ctx, name)
+}
@@ -45,6 +45,8 @@ type (
 	StringUsageContext       = internal.StringUsageContext
 	IdentityMatcher          = internal.IdentityMatcher
 	IdentityMatcherInfo      = internal.IdentityMatcherInfo
+	Explanation              = internal.Explanation
+	MatchEvaluation          = internal.MatchEvaluation
 	IdentityMatcherRegistry  = internal.IdentityMatcherRegistry
 )
 
@@ -43,6 +43,8 @@ type (
 	StringUsageContext       = internal.StringUsageContext
 	IdentityMatcher          = internal.IdentityMatcher
 	IdentityMatcherInfo      = internal.IdentityMatcherInfo
+	Explanation              = internal.Explanation
+	MatchEvaluation          = internal.MatchEvaluation
 	IdentityMatcherInfos     = internal.IdentityMatcherInfos
 	IdentityMatcherRegistry  = internal.IdentityMatcherRegistry
 )
@@ -11,3 +11,12 @@ type SetAliasFunction func(ctx Context, name string, spec RepositorySpec, creds
 type AliasRegistry interface {
 	SetAlias(ctx Context, name string, spec RepositorySpec, creds CredentialsSource) error
 }
+
+type GetAliasFunction func(ctx Context, name string) (RepositorySpec, bool)
+
+// AliasResolver is an optional interface of an AliasRegistry
+// providing access to the repository specification of an alias.
+// It is used to explain the resolution of credentials.
+type AliasResolver interface {
+	GetAlias(ctx Context, name string) (RepositorySpec, bool)
+}
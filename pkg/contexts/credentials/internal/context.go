@@ -55,6 +55,9 @@ type Context interface {
 	UnregisterConsumerProvider(id ProviderIdentity)
 
 	GetCredentialsForConsumer(ConsumerIdentity, ...IdentityMatcher) (CredentialsSource, error)
+	// ExplainCredentialsForConsumer does the same as GetCredentialsForConsumer,
+	// but describes the resolution steps.
+	ExplainCredentialsForConsumer(ConsumerIdentity, ...IdentityMatcher) (*Explanation, error)
 	SetCredentialsForConsumer(identity ConsumerIdentity, creds CredentialsSource)
 	SetCredentialsForConsumerWithProvider(pid ProviderIdentity, identity ConsumerIdentity, creds CredentialsSource)
 
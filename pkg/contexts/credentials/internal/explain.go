@@ -0,0 +1,277 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/open-component-model/ocm/pkg/runtime"
+	"github.com/open-component-model/ocm/pkg/utils"
+)
+
+// PROVIDER_EXPLICIT is the provider name used for consumer
+// settings without dedicated provider.
+const PROVIDER_EXPLICIT = "explicit"
+
+// MatchEvaluation describes a single evaluation of the identity matcher
+// for a consumer identity offered by a consumer provider.
+type MatchEvaluation struct {
+	// Provider is the identity of the consumer provider offering the identity.
+	Provider string
+	// Identity is the offered consumer identity.
+	Identity ConsumerIdentity
+	// Selected indicates that the matcher accepted the identity
+	// as better match than the formerly selected one.
+	Selected bool
+	// Score is the number of identity attributes matching the request.
+	Score int
+	// Partial indicates that all identity attributes match the request,
+	// but the identity does not describe all attributes of the request.
+	Partial bool
+}
+
+// Explanation describes the resolution of credentials for a consumer
+// identity as done by Context.GetCredentialsForConsumer.
+type Explanation struct {
+	Consumer ConsumerIdentity
+	// Matchers are the names of the evaluated identity matchers.
+	Matchers []string
+	// Providers are the consulted consumer providers.
+	Providers []string
+	// Evaluations lists all matcher evaluations in evaluation order
+	// as observed during the regular matching of consumer identities.
+	Evaluations []*MatchEvaluation
+	// Winner is the evaluation providing the credentials. It is nil,
+	// if no credentials are found or the default credentials are used.
+	Winner *MatchEvaluation
+	// Default indicates that the credentials configured for the
+	// empty identity are used.
+	Default bool
+	// Source is the found credentials source.
+	Source CredentialsSource
+	// Resolution describes the resolution steps of the credentials source.
+	Resolution []string
+}
+
+// matcher wraps an identity matcher to record its decisions.
+func (e *Explanation) matcher(m IdentityMatcher) IdentityMatcher {
+	return func(pattern, cur, id ConsumerIdentity) bool {
+		r := m(pattern, cur, id)
+		eval := &MatchEvaluation{
+			Identity: id.Copy(),
+			Selected: r,
+		}
+		eval.Score, eval.Partial = score(pattern, id)
+		e.Evaluations = append(e.Evaluations, eval)
+		return r
+	}
+}
+
+func (e *Explanation) winner(id ConsumerIdentity) *MatchEvaluation {
+	for i := len(e.Evaluations) - 1; i >= 0; i-- {
+		if eval := e.Evaluations[i]; eval.Selected && eval.Identity.Equals(id) {
+			return eval
+		}
+	}
+	return nil
+}
+
+// score provides the number of identity attributes matching the pattern
+// and whether the identity is only a partial match for the pattern.
+// Hierarchical attribute values (like path prefixes) match, if the
+// value is a path prefix of the requested value.
+func score(pattern, id ConsumerIdentity) (int, bool) {
+	n := 0
+	for k, v := range id {
+		if c, ok := pattern[k]; ok && (c == v || strings.HasPrefix(c, v+"/")) {
+			n++
+		}
+	}
+	return n, n == len(id) && !pattern.Equals(id)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// names provides the names of the consulted consumer providers.
+func (p *consumerProviderRegistry) names() []string {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	ids := make([]string, 0, len(p.providers))
+	for id := range p.providers {
+		ids = append(ids, string(id))
+	}
+	sort.Strings(ids)
+	return append([]string{PROVIDER_EXPLICIT}, ids...)
+}
+
+// providerFor provides the name of the consumer provider offering
+// the given consumer identity.
+func (p *consumerProviderRegistry) providerFor(id ConsumerIdentity) string {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if c := p.explicit.data[string(id.Key())]; c != nil {
+		if c.providerId != "" {
+			return string(c.providerId)
+		}
+		return PROVIDER_EXPLICIT
+	}
+	for _, n := range utils.StringMapKeys(p.providers) {
+		if _, ok := p.providers[n].Get(id); ok {
+			return string(n)
+		}
+	}
+	return "<unknown>"
+}
+
+func (c *_context) ExplainCredentialsForConsumer(identity ConsumerIdentity, matchers ...IdentityMatcher) (*Explanation, error) {
+	err := c.Update()
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Explanation{Consumer: identity.Copy()}
+	for _, m := range matchers {
+		if m != nil {
+			e.Matchers = append(e.Matchers, c.matcherName(m))
+		}
+	}
+	if len(e.Matchers) == 0 {
+		if c.consumerIdentityMatchers.Get(identity.Type()) != nil {
+			e.Matchers = []string{identity.Type()}
+		} else {
+			e.Matchers = []string{"partial"}
+		}
+	}
+
+	var cur ConsumerIdentity
+	e.Providers = c.consumerProviders.names()
+	e.Source, cur = c.consumerProviders.Match(identity, nil, e.matcher(c.defaultMatcher(identity, matchers...)))
+	for _, eval := range e.Evaluations {
+		eval.Provider = c.consumerProviders.providerFor(eval.Identity)
+	}
+	if e.Source != nil {
+		e.Winner = e.winner(cur)
+	} else {
+		e.Source, _ = c.consumerProviders.Get(emptyIdentity)
+		e.Default = e.Source != nil
+	}
+	if e.Source == nil {
+		return e, ErrUnknownConsumer(identity.String())
+	}
+	e.Resolution = ExplainCredentialsSource(c, e.Source)
+	return e, nil
+}
+
+// matcherName tries to find the name of a registered
+// identity matcher.
+func (c *_context) matcherName(m IdentityMatcher) string {
+	ptr := reflect.ValueOf(m).Pointer()
+	for _, i := range c.consumerIdentityMatchers.List() {
+		if i.Matcher != nil && reflect.ValueOf(i.Matcher).Pointer() == ptr {
+			return i.Type
+		}
+	}
+	return "<custom>"
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// ExplainCredentialsSource describes the resolution steps of a
+// credentials source, without revealing credential values.
+func ExplainCredentialsSource(ctx Context, src CredentialsSource) []string {
+	return explainSource(ctx, "", src)
+}
+
+func explainSource(ctx Context, prefix string, src CredentialsSource) []string {
+	switch s := src.(type) {
+	case CredentialsChain:
+		var result []string
+		for i, e := range s {
+			result = append(result, explainSource(ctx, fmt.Sprintf("%s[%d] ", prefix, i), e)...)
+		}
+		return result
+	case CredentialsSpec:
+		return explainSpec(ctx, prefix, s)
+	case Credentials:
+		return []string{fmt.Sprintf("%sdirect credentials (attributes: %s)", prefix, strings.Join(utils.StringMapKeys(s.PropertyNames()), ", "))}
+	default:
+		return []string{fmt.Sprintf("%s%T", prefix, src)}
+	}
+}
+
+func explainSpec(ctx Context, prefix string, spec CredentialsSpec) []string {
+	repo := spec.GetRepositorySpec(ctx)
+	if repo == nil {
+		return []string{fmt.Sprintf("%scredentials %q from unknown repository", prefix, spec.GetCredentialsName())}
+	}
+	result := []string{fmt.Sprintf("%scredentials %q from repository %s", prefix, spec.GetCredentialsName(), describeRepository(repo))}
+
+	// follow alias chain
+	seen := map[string]bool{}
+	for repo != nil && repo.GetType() == AliasRepositoryType {
+		name := aliasName(repo)
+		if name == "" || seen[name] {
+			break
+		}
+		seen[name] = true
+		var ok bool
+		if r, _ := ctx.RepositoryTypes().GetType(AliasRepositoryType).(AliasResolver); r != nil {
+			repo, ok = r.GetAlias(ctx, name)
+		}
+		if !ok {
+			result = append(result, fmt.Sprintf("%s  alias %q is unknown", prefix, name))
+			break
+		}
+		result = append(result, fmt.Sprintf("%s  alias %q resolved to %s", prefix, name, describeRepository(repo)))
+	}
+	return result
+}
+
+func aliasName(spec RepositorySpec) string {
+	u, err := runtime.ToUnstructuredObject(spec)
+	if err != nil {
+		return ""
+	}
+	name, _ := u["alias"].(string)
+	return name
+}
+
+// describeRepository provides a short description of a repository
+// specification consisting of its type and simple string fields.
+// Fields possibly containing secrets or inline data are omitted.
+func describeRepository(spec RepositorySpec) string {
+	u, err := runtime.ToUnstructuredObject(spec)
+	if err != nil {
+		return spec.GetType()
+	}
+	var fields []string
+	for _, k := range utils.StringMapKeys(u) {
+		if k == runtime.ATTR_TYPE {
+			continue
+		}
+		if s, ok := u[k].(string); ok && !sensitiveField(k, s) {
+			fields = append(fields, k+"="+s)
+		}
+	}
+	if len(fields) == 0 {
+		return spec.GetType()
+	}
+	return fmt.Sprintf("%s(%s)", spec.GetType(), strings.Join(fields, ", "))
+}
+
+func sensitiveField(name, value string) bool {
+	name = strings.ToLower(name)
+	for _, p := range []string{"secret", "password", "token", "passphrase", "auth"} {
+		if strings.Contains(name, p) {
+			return true
+		}
+	}
+	return strings.HasPrefix(strings.TrimSpace(value), "{") || strings.Contains(value, "\n")
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package internal_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/repositories/aliases"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/repositories/memory"
+)
+
+// provider is a consumer provider offering a single consumer identity.
+type provider struct {
+	id    credentials.ConsumerIdentity
+	creds credentials.CredentialsSource
+}
+
+func (p *provider) Unregister(id cpi.ProviderIdentity) {}
+
+func (p *provider) Get(id credentials.ConsumerIdentity) (credentials.CredentialsSource, bool) {
+	if id.Equals(p.id) {
+		return p.creds, true
+	}
+	return nil, false
+}
+
+func (p *provider) Match(pattern credentials.ConsumerIdentity, cur credentials.ConsumerIdentity, m credentials.IdentityMatcher) (credentials.CredentialsSource, credentials.ConsumerIdentity) {
+	if m(pattern, cur, p.id) {
+		return p.creds, p.id
+	}
+	return nil, cur
+}
+
+var _ = Describe("explain credentials", func() {
+	props := common.Properties{
+		"user":     "USER",
+		"password": "PASSWORD",
+	}
+
+	var ctx credentials.Context
+
+	BeforeEach(func() {
+		ctx = credentials.New()
+	})
+
+	It("explains alias resolution", func() {
+		repo := Must(ctx.RepositoryForSpec(memory.NewRepositorySpec("myrepo")))
+		MustBeSuccessful(repo.WriteCredentials("cred", credentials.NewCredentials(props)))
+		MustBeSuccessful(ctx.SetAlias("test", memory.NewRepositorySpec("myrepo")))
+
+		id := credentials.NewConsumerIdentity("test", "host", "acme.org")
+		ctx.SetCredentialsForConsumer(id, credentials.NewCredentialsSpec("cred", aliases.NewRepositorySpec("test")))
+		ctx.SetCredentialsForConsumer(credentials.NewConsumerIdentity("test", "host", "other.org"), credentials.NewCredentials(props))
+
+		req := credentials.NewConsumerIdentity("test", "host", "acme.org", "path", "a")
+		e := Must(ctx.ExplainCredentialsForConsumer(req))
+		Expect(e.Matchers).To(Equal([]string{"partial"}))
+		Expect(e.Providers).To(ContainElement("explicit"))
+		Expect(e.Evaluations).To(HaveLen(2))
+		Expect(e.Winner).NotTo(BeNil())
+		Expect(e.Winner.Identity).To(Equal(id))
+		Expect(e.Winner.Score).To(Equal(2))
+		Expect(e.Winner.Partial).To(BeTrue())
+		Expect(e.Resolution).To(Equal([]string{
+			`credentials "cred" from repository Alias(alias=test)`,
+			`  alias "test" resolved to Memory(repoName=myrepo)`,
+		}))
+		creds := Must(e.Source.Credentials(ctx))
+		Expect(creds.Properties()).To(Equal(props))
+	})
+
+	It("observes regular matching of consumer providers", func() {
+		id := credentials.NewConsumerIdentity("test", "host", "acme.org", "path", "a")
+		pcreds := credentials.NewCredentials(common.Properties{"user": "PROVIDED"})
+		ctx.RegisterConsumerProvider("custom", &provider{id: id, creds: pcreds})
+		ctx.SetCredentialsForConsumer(credentials.NewConsumerIdentity("test", "host", "acme.org"), credentials.NewCredentials(props))
+
+		req := credentials.NewConsumerIdentity("test", "host", "acme.org", "path", "a", "port", "443")
+		e := Must(ctx.ExplainCredentialsForConsumer(req))
+		Expect(e.Providers).To(Equal([]string{"explicit", "custom"}))
+		Expect(e.Evaluations).To(HaveLen(2))
+		Expect(e.Winner).NotTo(BeNil())
+		Expect(e.Winner.Provider).To(Equal("custom"))
+		Expect(e.Winner.Identity).To(Equal(id))
+		Expect(e.Source).To(Equal(Must(ctx.GetCredentialsForConsumer(req))))
+	})
+
+	It("explains default credentials", func() {
+		ctx.SetCredentialsForConsumer(credentials.ConsumerIdentity{}, credentials.NewCredentials(props))
+
+		e := Must(ctx.ExplainCredentialsForConsumer(credentials.NewConsumerIdentity("test", "host", "acme.org"), credentials.CompleteMatch))
+		Expect(e.Matchers).To(Equal([]string{"exact"}))
+		Expect(e.Winner).To(BeNil())
+		Expect(e.Default).To(BeTrue())
+		Expect(e.Resolution).To(Equal([]string{"direct credentials (attributes: password, user)"}))
+	})
+
+	It("explains unknown consumer", func() {
+		e, err := ctx.ExplainCredentialsForConsumer(credentials.NewConsumerIdentity("test", "host", "acme.org"))
+		Expect(err).To(MatchError(ContainSubstring("is unknown")))
+		Expect(e.Source).To(BeNil())
+	})
+})
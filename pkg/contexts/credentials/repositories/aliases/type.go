@@ -17,7 +17,7 @@ const (
 )
 
 func init() {
-	cpi.RegisterRepositoryType(cpi.NewAliasRegistry(cpi.NewRepositoryType[*RepositorySpec](Type), setAlias))
+	cpi.RegisterRepositoryType(cpi.NewAliasRegistry(cpi.NewRepositoryType[*RepositorySpec](Type), setAlias, getAlias))
 	cpi.RegisterRepositoryType(cpi.NewRepositoryType[*RepositorySpec](TypeV1))
 }
 
@@ -31,6 +31,19 @@ func setAlias(ctx cpi.Context, name string, spec cpi.RepositorySpec, creds cpi.C
 	return nil
 }
 
+func getAlias(ctx cpi.Context, name string) (cpi.RepositorySpec, bool) {
+	r := ctx.GetAttributes().GetOrCreateAttribute(ATTR_REPOS, newRepositories)
+	repos, ok := r.(*Repositories)
+	if !ok {
+		return nil, false
+	}
+	alias := repos.GetRepository(name)
+	if alias == nil {
+		return nil, false
+	}
+	return alias.spec, true
+}
+
 // RepositorySpec describes a memory based repository interface.
 type RepositorySpec struct {
 	runtime.ObjectVersionedType `json:",inline"`
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package credentialhelper
+
+import (
+	"github.com/open-component-model/ocm/pkg/listformat"
+)
+
+var usage = `
+This repository type can be used to access credentials provided by
+external credential helpers, as used by docker
+(<code>docker-credential-*</code>) or git (<code>git-credential-*</code>).
+This way credentials stored in the native keychain of the operating system
+(for example with <code>docker-credential-osxkeychain</code>,
+<code>docker-credential-secretservice</code>, <code>docker-credential-pass</code>
+or <code>git-credential-libsecret</code>) can be used by OCM.
+
+If the helper is not given as path and does not start with the protocol
+specific prefix, the prefix is added. The helper is only used to read
+credentials (action <code>get</code>).
+
+The following protocols are supported:
+` + listformat.FormatListElements("", listformat.StringElementDescriptionList{
+	PROTOCOL_DOCKER, "(default) the JSON protocol of docker credential helpers. The helper is queried with the host (and port) of the consumer identity.",
+	PROTOCOL_GIT, "the key/value protocol of git credential helpers. The helper is queried with the scheme, host, port and path prefix of the consumer identity.",
+}) + `
+
+If enabled, the helper is queried for all consumer ids (optionally
+restricted to a set of consumer types) providing a hostname. Found
+credentials are cached for the lifetime of the context. Unknown hosts
+and failed helper calls are queried again with the next request.
+The helper result is mapped to the credential attributes of the consumer
+type:
+
+` + listformat.FormatListElements("", listformat.StringElementDescriptionList{
+	"OCIRegistry", "<code>username</code>, <code>password</code>, or <code>identityToken</code> for token results",
+	"Github", "<code>token</code> (default host <code>github.com</code>)",
+	"S3", "<code>awsAccessKeyID</code> and <code>awsSecretAccessKey</code> (default host <code>s3.amazonaws.com</code>)",
+	"others", "<code>username</code>, <code>password</code>, or <code>token</code> for token results",
+}) + `
+
+The credentials can also be requested by name, which is interpreted as host
+name or URL.
+`
+
+var format = `The repository specification supports the following fields:
+` + listformat.FormatListElements("", listformat.StringElementDescriptionList{
+	"helper", "*string*: the name or path of the credential helper",
+	"args", "*[]string*(optional): additional arguments passed to the helper",
+	"protocol", "*string*(optional): the helper protocol (default: " + PROTOCOL_DOCKER + ")",
+	"consumerTypes", "*[]string*(optional): the consumer types the helper is used for (default: all)",
+	"propagateConsumerIdentity", "*bool*(optional): enable consumer id propagation (default: true)",
+})
@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package credentialhelper
+
+import (
+	"sync"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext"
+	"github.com/open-component-model/ocm/pkg/utils"
+)
+
+const ATTR_REPOS = "github.com/open-component-model/ocm/pkg/contexts/credentials/repositories/credentialhelper"
+
+type Repositories struct {
+	lock  sync.Mutex
+	repos map[string]*Repository
+}
+
+func newRepositories(datacontext.Context) interface{} {
+	return &Repositories{
+		repos: map[string]*Repository{},
+	}
+}
+
+func (r *Repositories) GetRepository(ctx cpi.Context, spec *RepositorySpec) (*Repository, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	key := spec.GetKey()
+	repo := r.repos[key]
+	if repo == nil {
+		repo = NewRepository(ctx, spec.Helper, spec.Args, spec.Protocol, spec.ConsumerTypes, utils.AsBool(spec.PropgateConsumerIdentity, true))
+		r.repos[key] = repo
+	}
+	return repo, nil
+}
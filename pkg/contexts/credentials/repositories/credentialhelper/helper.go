@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package credentialhelper
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+const (
+	// PROTOCOL_DOCKER is the JSON protocol used by docker-credential-* helpers.
+	PROTOCOL_DOCKER = "docker"
+	// PROTOCOL_GIT is the line based protocol used by git-credential-* helpers.
+	PROTOCOL_GIT = "git"
+)
+
+// TIMEOUT is the maximum runtime of a single helper call.
+var TIMEOUT = 30 * time.Second
+
+// Result is the answer of a credential helper.
+type Result struct {
+	Username string
+	Secret   string
+}
+
+// Protocol describes the communication with a credential helper.
+// Get provides nil, if the helper does not know credentials for the
+// given URL.
+type Protocol interface {
+	Prefix() string
+	Get(h *Helper, u *url.URL) (*Result, error)
+}
+
+var protocols = map[string]Protocol{
+	PROTOCOL_DOCKER: dockerProtocol{},
+	PROTOCOL_GIT:    gitProtocol{},
+}
+
+// Helper describes an external credential helper executable.
+type Helper struct {
+	Command  string
+	Args     []string
+	Protocol Protocol
+}
+
+// NewHelper provides a helper description. If the helper name is
+// neither a path nor contains the protocol specific prefix (for example
+// docker-credential-), the prefix is added, as it is done by docker and git.
+func NewHelper(name string, args []string, protocol string) (*Helper, error) {
+	p := protocols[protocol]
+	if p == nil {
+		return nil, errors.ErrNotSupported("credential helper protocol", protocol)
+	}
+	if !strings.Contains(name, "/") && !strings.HasPrefix(name, p.Prefix()) {
+		name = p.Prefix() + name
+	}
+	return &Helper{Command: name, Args: args, Protocol: p}, nil
+}
+
+func (h *Helper) Get(u *url.URL) (*Result, error) {
+	return h.Protocol.Get(h, u)
+}
+
+func (h *Helper) execute(action string, input []byte) ([]byte, []byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, h.Command, append(append([]string{}, h.Args...), action)...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	log.Trace("calling credential helper", "helper", h.Command, "action", action)
+	err := cmd.Run()
+	return stdout.Bytes(), stderr.Bytes(), err
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// TOKEN_USERNAME is the user name used by docker credential helpers
+// to indicate an identity token.
+const TOKEN_USERNAME = "<token>"
+
+// errCredentialsNotFound is the message provided by docker credential
+// helpers, if no credentials are found.
+const errCredentialsNotFound = "credentials not found in native keychain"
+
+type dockerProtocol struct{}
+
+func (dockerProtocol) Prefix() string {
+	return "docker-credential-"
+}
+
+// Get calls the helper with the server address (host[:port]) of the URL.
+func (dockerProtocol) Get(h *Helper, u *url.URL) (*Result, error) {
+	stdout, stderr, err := h.execute("get", []byte(u.Host))
+	if err != nil {
+		msg := strings.TrimSpace(string(stdout) + string(stderr))
+		if strings.Contains(msg, errCredentialsNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "credential helper %q failed: %s", h.Command, msg)
+	}
+	var creds struct {
+		ServerURL string
+		Username  string
+		Secret    string
+	}
+	if err := json.Unmarshal(stdout, &creds); err != nil {
+		return nil, errors.Wrapf(err, "invalid response of credential helper %q", h.Command)
+	}
+	if creds.Username == "" && creds.Secret == "" {
+		return nil, nil
+	}
+	return &Result{Username: creds.Username, Secret: creds.Secret}, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type gitProtocol struct{}
+
+func (gitProtocol) Prefix() string {
+	return "git-credential-"
+}
+
+// Get calls the helper with the protocol, host and path attributes
+// of the URL.
+func (gitProtocol) Get(h *Helper, u *url.URL) (*Result, error) {
+	var in bytes.Buffer
+	err := writeGitAttribute(&in, "protocol", u.Scheme)
+	if err == nil {
+		err = writeGitAttribute(&in, "host", u.Host)
+	}
+	if p := strings.Trim(u.Path, "/"); err == nil && p != "" {
+		err = writeGitAttribute(&in, "path", p)
+	}
+	if err != nil {
+		return nil, err
+	}
+	in.WriteString("\n")
+
+	stdout, stderr, err := h.execute("get", in.Bytes())
+	if err != nil {
+		return nil, errors.Wrapf(err, "credential helper %q failed: %s", h.Command, strings.TrimSpace(string(stderr)))
+	}
+	var r Result
+	scanner := bufio.NewScanner(bytes.NewReader(stdout))
+	for scanner.Scan() {
+		k, v, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		switch k {
+		case "username":
+			r.Username = v
+		case "password":
+			r.Secret = v
+		}
+	}
+	if r.Secret == "" {
+		return nil, nil
+	}
+	return &r, nil
+}
+
+// writeGitAttribute writes an attribute line of the git credential protocol.
+// Like git, it rejects values containing a newline, carriage return or NUL
+// character, which would otherwise inject additional attributes
+// (CVE-2020-5260, CVE-2024-52006).
+func writeGitAttribute(w *bytes.Buffer, key, value string) error {
+	if strings.ContainsAny(value, "\n\r\x00") {
+		return errors.ErrInvalid("credential helper value for "+key, value)
+	}
+	w.WriteString(key + "=" + value + "\n")
+	return nil
+}
@@ -0,0 +1,14 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package credentialhelper
+
+import (
+	ocmlog "github.com/open-component-model/ocm/pkg/logging"
+)
+
+var (
+	REALM = ocmlog.DefineSubRealm("credential helpers as credential repository", "credentials/credentialhelper")
+	log   = ocmlog.DynamicLogger(REALM)
+)
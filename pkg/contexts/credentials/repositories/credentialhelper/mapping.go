@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package credentialhelper
+
+import (
+	"net"
+	"net/url"
+	"path"
+	"sync"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	github "github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/github/identity"
+	oci "github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/oci/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/identity/hostpath"
+	s3 "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/s3/identity"
+)
+
+// Mapping describes how a consumer identity of a dedicated consumer type
+// is mapped to a helper query, and how the helper result is mapped to
+// credential attributes.
+type Mapping struct {
+	// DefaultHost is used, if the consumer identity does not
+	// provide a hostname.
+	DefaultHost string
+	// Username is the credential attribute for the user name.
+	// If empty, the user name is not provided.
+	Username string
+	// Password is the credential attribute for the secret.
+	Password string
+	// Token is the credential attribute for the secret, if the
+	// helper indicates an identity token or provides no user name.
+	Token string
+}
+
+// DefaultMapping is used for consumer types without dedicated mapping.
+// It can be used for all hostpath based consumer identities.
+var DefaultMapping = &Mapping{
+	Username: cpi.ATTR_USERNAME,
+	Password: cpi.ATTR_PASSWORD,
+	Token:    cpi.ATTR_TOKEN,
+}
+
+var (
+	lock     sync.RWMutex
+	mappings = map[string]*Mapping{}
+)
+
+// RegisterMapping registers a dedicated mapping for a consumer type.
+func RegisterMapping(consumerType string, m *Mapping) {
+	lock.Lock()
+	defer lock.Unlock()
+	mappings[consumerType] = m
+}
+
+// GetMapping provides the mapping for a consumer type.
+func GetMapping(consumerType string) *Mapping {
+	lock.RLock()
+	defer lock.RUnlock()
+	if m := mappings[consumerType]; m != nil {
+		return m
+	}
+	return DefaultMapping
+}
+
+func init() {
+	RegisterMapping(oci.CONSUMER_TYPE, &Mapping{
+		Username: oci.ATTR_USERNAME,
+		Password: oci.ATTR_PASSWORD,
+		Token:    oci.ATTR_IDENTITY_TOKEN,
+	})
+	RegisterMapping(github.CONSUMER_TYPE, &Mapping{
+		DefaultHost: "github.com",
+		Password:    github.ATTR_TOKEN,
+		Token:       github.ATTR_TOKEN,
+	})
+	RegisterMapping(s3.CONSUMER_TYPE, &Mapping{
+		DefaultHost: "s3.amazonaws.com",
+		Username:    s3.ATTR_AWS_ACCESS_KEY_ID,
+		Password:    s3.ATTR_AWS_SECRET_ACCESS_KEY,
+		Token:       s3.ATTR_TOKEN,
+	})
+}
+
+// Query provides the URL used to query the helper for a consumer identity
+// together with the (host based) consumer identity the result is valid for.
+// It returns nil, if the identity cannot be mapped.
+func (m *Mapping) Query(id cpi.ConsumerIdentity) (*url.URL, cpi.ConsumerIdentity) {
+	host := id[hostpath.ID_HOSTNAME]
+	if host == "" {
+		host = m.DefaultHost
+	}
+	if id.Type() == "" || host == "" {
+		return nil, nil
+	}
+	scheme := id[hostpath.ID_SCHEME]
+	if scheme == "" {
+		scheme = "https"
+	}
+	u := &url.URL{
+		Scheme: scheme,
+		Host:   host,
+		Path:   path.Join("/", id[hostpath.ID_PATHPREFIX]),
+	}
+	if port := id[hostpath.ID_PORT]; port != "" {
+		u.Host = net.JoinHostPort(host, port)
+	}
+
+	result := cpi.ConsumerIdentity{cpi.ID_TYPE: id.Type()}
+	for _, a := range []string{hostpath.ID_HOSTNAME, hostpath.ID_PORT, hostpath.ID_SCHEME} {
+		result.SetNonEmptyValue(a, id[a])
+	}
+	return u, result
+}
+
+// Credentials maps a helper result to credential attributes.
+func (m *Mapping) Credentials(r *Result) common.Properties {
+	props := common.Properties{}
+	if r.Username == TOKEN_USERNAME || (r.Username == "" && m.Token != "") {
+		props.SetNonEmptyValue(m.Token, r.Secret)
+		return props
+	}
+	if m.Username != "" {
+		props.SetNonEmptyValue(m.Username, r.Username)
+	}
+	props.SetNonEmptyValue(m.Password, r.Secret)
+	return props
+}
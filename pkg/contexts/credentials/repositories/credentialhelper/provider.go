@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package credentialhelper
+
+import (
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+)
+
+type ConsumerProvider struct {
+	repo *Repository
+}
+
+var _ cpi.ConsumerProvider = (*ConsumerProvider)(nil)
+
+func (p *ConsumerProvider) Unregister(id cpi.ProviderIdentity) {
+}
+
+func (p *ConsumerProvider) Match(req cpi.ConsumerIdentity, cur cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	return p.get(req, cur, m)
+}
+
+func (p *ConsumerProvider) Get(req cpi.ConsumerIdentity) (cpi.CredentialsSource, bool) {
+	creds, _ := p.get(req, nil, cpi.CompleteMatch)
+	return creds, creds != nil
+}
+
+// get asks the helper for credentials for the request. The helper
+// is only called, if the host based identity derived from the request
+// would be a better match than the current one.
+func (p *ConsumerProvider) get(req cpi.ConsumerIdentity, cur cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	if !p.repo.handles(req.Type()) {
+		return nil, cur
+	}
+	mapping := GetMapping(req.Type())
+	u, id := mapping.Query(req)
+	if u == nil || !m(req, cur, id) {
+		return nil, cur
+	}
+	res, err := p.repo.get(u)
+	if err != nil {
+		log.Warn("credential helper failed", "helper", p.repo.helper.Command, "url", u.String(), "error", err.Error())
+		return nil, cur
+	}
+	if res == nil {
+		return nil, cur
+	}
+	return cpi.NewCredentials(mapping.Credentials(res)), id
+}
@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package credentialhelper_test
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	github "github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/github/identity"
+	oci "github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/oci/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	me "github.com/open-component-model/ocm/pkg/contexts/credentials/repositories/credentialhelper"
+)
+
+// docker credential helper knowing ghcr.io (user/password)
+// and quay.io (identity token) and counting its calls.
+const dockerHelper = `#!/bin/sh
+read host
+echo "$host" >> "$(dirname "$0")/calls"
+case "$host" in
+  ghcr.io) echo '{"ServerURL":"ghcr.io","Username":"alice","Secret":"secret"}';;
+  quay.io) echo '{"ServerURL":"quay.io","Username":"<token>","Secret":"token"}';;
+  fail.io) echo "helper broken" >&2; exit 2;;
+  *) echo "credentials not found in native keychain"; exit 1;;
+esac
+`
+
+const gitHelper = `#!/bin/sh
+while read line; do
+  [ -z "$line" ] && break
+  case "$line" in
+    host=*) host="${line#host=}";;
+    path=*) path="${line#path=}";;
+  esac
+done
+if [ "$host" = "github.com" ]; then
+  echo "username=bob"
+  echo "password=pat-$path"
+fi
+`
+
+var _ = Describe("credential helper", func() {
+	var ctx credentials.Context
+	var dir string
+
+	BeforeEach(func() {
+		if runtime.GOOS == "windows" {
+			Skip("shell based helpers not supported")
+		}
+		ctx = credentials.New()
+		dir = GinkgoT().TempDir()
+		MustBeSuccessful(os.WriteFile(filepath.Join(dir, "docker-credential-test"), []byte(dockerHelper), 0o755))
+		MustBeSuccessful(os.WriteFile(filepath.Join(dir, "git-credential-test"), []byte(gitHelper), 0o755))
+		GinkgoT().Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	})
+
+	Context("docker", func() {
+		It("looks up credentials by name", func() {
+			spec := me.NewRepositorySpec("test", "", nil, false)
+			repo := Must(ctx.RepositoryForSpec(spec))
+
+			creds := Must(repo.LookupCredentials("ghcr.io"))
+			Expect(creds.Properties()).To(Equal(common.Properties{
+				cpi.ATTR_USERNAME: "alice",
+				cpi.ATTR_PASSWORD: "secret",
+			}))
+			Expect(repo.ExistsCredentials("https://quay.io/repo")).To(BeTrue())
+			Expect(repo.ExistsCredentials("docker.io")).To(BeFalse())
+			ExpectError(repo.LookupCredentials("docker.io")).To(MatchError(ContainSubstring("is unknown")))
+			ExpectError(repo.WriteCredentials("ghcr.io", creds)).To(MatchError(ContainSubstring("not supported")))
+		})
+
+		It("propagates consumer identities", func() {
+			spec := me.NewRepositorySpec("test", me.PROTOCOL_DOCKER, nil)
+			MustBeSuccessful(ctx.RepositoryForSpec(spec))
+
+			id := cpi.NewConsumerIdentity(oci.CONSUMER_TYPE, oci.ID_HOSTNAME, "ghcr.io", oci.ID_PATHPREFIX, "acme/repo")
+			creds := Must(credentials.CredentialsForConsumer(ctx, id))
+			Expect(creds.Properties()).To(Equal(common.Properties{
+				oci.ATTR_USERNAME: "alice",
+				oci.ATTR_PASSWORD: "secret",
+			}))
+
+			id = cpi.NewConsumerIdentity(oci.CONSUMER_TYPE, oci.ID_HOSTNAME, "quay.io")
+			creds = Must(credentials.CredentialsForConsumer(ctx, id))
+			Expect(creds.Properties()).To(Equal(common.Properties{
+				oci.ATTR_IDENTITY_TOKEN: "token",
+			}))
+
+			id = cpi.NewConsumerIdentity(oci.CONSUMER_TYPE, oci.ID_HOSTNAME, "docker.io")
+			Expect(credentials.CredentialsForConsumer(ctx, id)).To(BeNil())
+		})
+
+		It("caches results", func() {
+			spec := me.NewRepositorySpec("test", me.PROTOCOL_DOCKER, nil)
+			MustBeSuccessful(ctx.RepositoryForSpec(spec))
+
+			for i := 0; i < 3; i++ {
+				for _, h := range []string{"ghcr.io", "docker.io"} {
+					id := cpi.NewConsumerIdentity(oci.CONSUMER_TYPE, oci.ID_HOSTNAME, h)
+					MustBeSuccessful(credentials.CredentialsForConsumer(ctx, id))
+				}
+			}
+			data := Must(os.ReadFile(filepath.Join(dir, "calls")))
+			Expect(string(data)).To(Equal("ghcr.io\ndocker.io\ndocker.io\ndocker.io\n"))
+		})
+
+		It("does not cache failures", func() {
+			spec := me.NewRepositorySpec("test", "", nil, false)
+			repo := Must(ctx.RepositoryForSpec(spec))
+
+			for i := 0; i < 2; i++ {
+				ExpectError(repo.LookupCredentials("fail.io")).To(MatchError(ContainSubstring("helper broken")))
+				ExpectError(repo.ExistsCredentials("fail.io")).To(MatchError(ContainSubstring("helper broken")))
+			}
+			data := Must(os.ReadFile(filepath.Join(dir, "calls")))
+			Expect(string(data)).To(Equal("fail.io\nfail.io\nfail.io\nfail.io\n"))
+		})
+
+		It("restricts consumer types", func() {
+			spec := me.NewRepositorySpec("test", me.PROTOCOL_DOCKER, []string{github.CONSUMER_TYPE})
+			MustBeSuccessful(ctx.RepositoryForSpec(spec))
+
+			id := cpi.NewConsumerIdentity(oci.CONSUMER_TYPE, oci.ID_HOSTNAME, "ghcr.io")
+			Expect(credentials.CredentialsForConsumer(ctx, id)).To(BeNil())
+		})
+
+		It("prefers explicit credentials", func() {
+			spec := me.NewRepositorySpec("test", me.PROTOCOL_DOCKER, nil)
+			MustBeSuccessful(ctx.RepositoryForSpec(spec))
+
+			id := cpi.NewConsumerIdentity(oci.CONSUMER_TYPE, oci.ID_HOSTNAME, "ghcr.io", oci.ID_PATHPREFIX, "acme")
+			ctx.SetCredentialsForConsumer(id, credentials.DirectCredentials{oci.ATTR_USERNAME: "other"})
+
+			req := cpi.NewConsumerIdentity(oci.CONSUMER_TYPE, oci.ID_HOSTNAME, "ghcr.io", oci.ID_PATHPREFIX, "acme/repo")
+			creds := Must(credentials.CredentialsForConsumer(ctx, req, oci.IdentityMatcher))
+			Expect(creds.Properties()).To(Equal(common.Properties{oci.ATTR_USERNAME: "other"}))
+		})
+	})
+
+	Context("git", func() {
+		It("maps github token", func() {
+			spec := me.NewRepositorySpec("test", me.PROTOCOL_GIT, nil)
+			MustBeSuccessful(ctx.RepositoryForSpec(spec))
+
+			id := cpi.NewConsumerIdentity(github.CONSUMER_TYPE, github.ID_PATHPREFIX, "acme")
+			creds := Must(credentials.CredentialsForConsumer(ctx, id, github.IdentityMatcher))
+			Expect(creds.Properties()).To(Equal(common.Properties{
+				github.ATTR_TOKEN: "pat-acme",
+			}))
+		})
+
+		It("rejects attribute injection", func() {
+			h := Must(me.NewHelper("test", nil, me.PROTOCOL_GIT))
+			u := &url.URL{Scheme: "https", Host: "evil.org", Path: "/acme\nhost=github.com"}
+			ExpectError(h.Get(u)).To(MatchError(ContainSubstring("credential helper value for path")))
+			u = &url.URL{Scheme: "https", Host: "evil.org\x00github.com"}
+			ExpectError(h.Get(u)).To(MatchError(ContainSubstring("credential helper value for host")))
+			u = &url.URL{Scheme: "https", Host: "evil.org", Path: "/acme\rhost=github.com"}
+			ExpectError(h.Get(u)).To(MatchError(ContainSubstring("credential helper value for path")))
+
+			spec := me.NewRepositorySpec("test", me.PROTOCOL_GIT, nil)
+			MustBeSuccessful(ctx.RepositoryForSpec(spec))
+			id := cpi.NewConsumerIdentity(github.CONSUMER_TYPE, github.ID_HOSTNAME, "evil.org", github.ID_PATHPREFIX, "acme\nhost=github.com")
+			Expect(credentials.CredentialsForConsumer(ctx, id, github.IdentityMatcher)).To(BeNil())
+		})
+	})
+
+	It("rejects unknown protocols", func() {
+		spec := me.NewRepositorySpec("test", "other", nil)
+		ExpectError(ctx.RepositoryForSpec(spec)).To(MatchError(ContainSubstring("not supported")))
+	})
+})
@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package credentialhelper
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+const PROVIDER = "ocm.software/credentialprovider/" + Type
+
+type Repository struct {
+	lock      sync.Mutex
+	ctx       cpi.Context
+	helper    *Helper
+	types     []string
+	propagate bool
+	cache     map[string]*Result
+}
+
+var _ cpi.Repository = (*Repository)(nil)
+
+// NewRepository provides a credential repository for an external
+// credential helper. If types are given, the consumer provider
+// only answers requests for the given consumer types.
+func NewRepository(ctx cpi.Context, helper string, args []string, protocol string, types []string, propagate bool) *Repository {
+	// protocol has already been validated by the spec
+	h, _ := NewHelper(helper, args, protocol)
+	r := &Repository{
+		ctx:       ctx,
+		helper:    h,
+		types:     slices.Clone(types),
+		propagate: propagate,
+		cache:     map[string]*Result{},
+	}
+	if propagate {
+		ctx.RegisterConsumerProvider(cpi.ProviderIdentity(PROVIDER+"/"+protocol+"/"+h.Command), &ConsumerProvider{r})
+	}
+	return r
+}
+
+// get queries the helper for the given URL. Only found
+// credentials are cached, failed or empty lookups are
+// repeated with the next request.
+func (r *Repository) get(u *url.URL) (*Result, error) {
+	key := u.String()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if res, ok := r.cache[key]; ok {
+		return res, nil
+	}
+	res, err := r.helper.Get(u)
+	if err != nil || res == nil {
+		return nil, err
+	}
+	r.cache[key] = res
+	return res, nil
+}
+
+// Reset clears the result cache.
+func (r *Repository) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.cache = map[string]*Result{}
+}
+
+// nameURL maps a credential name to the URL used to query the helper.
+// The name might be a complete URL or just a host name.
+func nameURL(name string) (*url.URL, error) {
+	if !strings.Contains(name, "://") {
+		name = "https://" + name
+	}
+	u, err := url.Parse(name)
+	if err != nil || u.Host == "" {
+		return nil, errors.ErrInvalid("credential name", name)
+	}
+	return u, nil
+}
+
+func (r *Repository) ExistsCredentials(name string) (bool, error) {
+	u, err := nameURL(name)
+	if err != nil {
+		return false, err
+	}
+	res, err := r.get(u)
+	if err != nil {
+		return false, err
+	}
+	return res != nil, nil
+}
+
+func (r *Repository) LookupCredentials(name string) (cpi.Credentials, error) {
+	u, err := nameURL(name)
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.get(u)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, cpi.ErrUnknownCredentials(name)
+	}
+	return cpi.NewCredentials(DefaultMapping.Credentials(res)), nil
+}
+
+func (r *Repository) WriteCredentials(name string, creds cpi.Credentials) (cpi.Credentials, error) {
+	return nil, errors.ErrNotSupported("write", "credentials", Type)
+}
+
+func (r *Repository) handles(typ string) bool {
+	return len(r.types) == 0 || slices.Contains(r.types, typ)
+}
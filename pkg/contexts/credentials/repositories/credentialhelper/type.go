@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package credentialhelper
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/generics"
+	"github.com/open-component-model/ocm/pkg/runtime"
+	"github.com/open-component-model/ocm/pkg/utils"
+)
+
+const (
+	Type   = "CredentialHelper"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	cpi.RegisterRepositoryType(cpi.NewRepositoryType[*RepositorySpec](Type))
+	cpi.RegisterRepositoryType(cpi.NewRepositoryType[*RepositorySpec](TypeV1, cpi.WithDescription(usage), cpi.WithFormatSpec(format)))
+}
+
+// RepositorySpec describes a credential repository based on an external
+// credential helper.
+type RepositorySpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+	Helper                      string   `json:"helper"`
+	Args                        []string `json:"args,omitempty"`
+	Protocol                    string   `json:"protocol,omitempty"`
+	ConsumerTypes               []string `json:"consumerTypes,omitempty"`
+	PropgateConsumerIdentity    *bool    `json:"propagateConsumerIdentity,omitempty"`
+}
+
+// NewRepositorySpec creates a new credential helper RepositorySpec.
+func NewRepositorySpec(helper string, protocol string, types []string, propagate ...bool) *RepositorySpec {
+	var p *bool
+	if len(propagate) > 0 {
+		p = generics.Pointer(utils.OptionalDefaultedBool(true, propagate...))
+	}
+	return &RepositorySpec{
+		ObjectVersionedType:      runtime.NewVersionedTypedObject(Type),
+		Helper:                   helper,
+		Protocol:                 protocol,
+		ConsumerTypes:            slices.Clone(types),
+		PropgateConsumerIdentity: p,
+	}
+}
+
+func (a *RepositorySpec) GetType() string {
+	return Type
+}
+
+func (a *RepositorySpec) Repository(ctx cpi.Context, creds cpi.Credentials) (cpi.Repository, error) {
+	if a.Helper == "" {
+		return nil, errors.ErrRequired("credential helper")
+	}
+	proto := a.Protocol
+	if proto == "" {
+		proto = PROTOCOL_DOCKER
+	}
+	if protocols[proto] == nil {
+		return nil, errors.ErrNotSupported("credential helper protocol", proto)
+	}
+	r := ctx.GetAttributes().GetOrCreateAttribute(ATTR_REPOS, newRepositories)
+	repos, ok := r.(*Repositories)
+	if !ok {
+		return nil, fmt.Errorf("failed to assert type %T to Repositories", r)
+	}
+	spec := *a
+	spec.Protocol = proto
+	spec.Args = slices.Clone(a.Args)
+	spec.ConsumerTypes = slices.Clone(a.ConsumerTypes)
+	return repos.GetRepository(ctx, &spec)
+}
+
+func (a *RepositorySpec) GetKey() string {
+	spec := *a
+	spec.PropgateConsumerIdentity = nil
+	data, err := json.Marshal(&spec)
+	if err == nil {
+		return string(data)
+	}
+	return spec.Helper
+}
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keystore
+
+import (
+	"github.com/open-component-model/ocm/pkg/listformat"
+)
+
+var usage = `
+This repository type can be used to access credentials stored in an
+encrypted local keystore file (default <code>~/.ocm/keystore</code>).
+The file is encrypted with AES-GCM. The key is either taken from a key file
+(as created by <code>ocm create rsakeypair --encryption-key</code>) or derived
+from a passphrase with scrypt.
+
+The passphrase can be passed with the credential attribute
+<code>` + ATTR_PASSPHRASE + `</code> of the repository specification,
+or with the environment variable <code>` + ENV_PASSPHRASE + `</code>.
+
+Every entry has a name and may describe a consumer identity. If enabled,
+the credentials of those entries are automatically assigned to
+matching consumer ids. The entries can be maintained with the commands
+<code>ocm add keystoreentries</code>, <code>ocm get keystoreentries</code>,
+<code>ocm rotate keystoreentries</code> and <code>ocm remove keystoreentries</code>.
+`
+
+var format = `The repository specification supports the following fields:
+` + listformat.FormatListElements("", listformat.StringElementDescriptionList{
+	"keystoreFile", "*string*: the file path to the keystore (default: ~/.ocm/keystore)",
+	"keyFile", "*string*(optional): the file path to a key file used for the encryption",
+	"propagateConsumerIdentity", "*bool*(optional): enable consumer id propagation",
+})
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keystore
+
+import (
+	"sync"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext"
+)
+
+const ATTR_REPOS = "github.com/open-component-model/ocm/pkg/contexts/credentials/repositories/keystore"
+
+type Repositories struct {
+	lock  sync.Mutex
+	repos map[string]*Repository
+}
+
+func newRepositories(datacontext.Context) interface{} {
+	return &Repositories{
+		repos: map[string]*Repository{},
+	}
+}
+
+func (r *Repositories) GetRepository(ctx cpi.Context, path string, secret *Secret, propagate bool) (*Repository, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var err error
+	repo := r.repos[path]
+	if repo == nil {
+		repo, err = NewRepository(ctx, path, secret, propagate)
+		if err == nil {
+			r.repos[path] = repo
+		}
+	}
+	return repo, err
+}
@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keystore
+
+import (
+	"os"
+
+	"github.com/mandelsoft/filepath/pkg/filepath"
+)
+
+const (
+	ConfigDir        = ".ocm"
+	KeystoreFileName = "keystore"
+)
+
+// DefaultKeystore provides the default location of the keystore file
+// (~/.ocm/keystore).
+func DefaultKeystore() (string, error) {
+	d, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(d, ConfigDir, KeystoreFileName), nil
+}
@@ -0,0 +1,340 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keystore
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"os"
+	"time"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/encrypt"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/utils"
+)
+
+const (
+	// PEM_KEYSTORE is the PEM block type used for the keystore file.
+	PEM_KEYSTORE = "OCM KEYSTORE"
+
+	HEADER_KDF  = "kdf"
+	HEADER_SALT = "salt"
+
+	KDF_SCRYPT = "scrypt"
+)
+
+// ENV_PASSPHRASE is the environment variable used to provide the
+// passphrase, if no other key source is configured.
+const ENV_PASSPHRASE = "OCM_KEYSTORE_PASSPHRASE"
+
+// scrypt parameters, see https://pkg.go.dev/golang.org/x/crypto/scrypt.
+const (
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// Secret describes the key material used to encrypt a keystore.
+// Either a (AES) key or a passphrase must be given.
+// For a passphrase the key is derived using scrypt with a
+// random salt stored in the keystore file.
+type Secret struct {
+	Key        []byte
+	Passphrase string
+}
+
+// SecretFromKeyFile provides a secret for a key file as written by
+// encrypt.WriteKey or the command ocm create rsakeypair --encryption-key.
+func SecretFromKeyFile(path string, fss ...vfs.FileSystem) (*Secret, error) {
+	data, err := vfs.ReadFile(utils.FileSystem(fss...), path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read key file %q", path)
+	}
+	key, err := encrypt.KeyFromAny(data)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := encrypt.AlgoForKey(key); err != nil {
+		return nil, errors.Wrapf(err, "invalid key in %q", path)
+	}
+	return &Secret{Key: key}, nil
+}
+
+// SecretFromEnv provides a passphrase secret taken from the environment
+// variable ENV_PASSPHRASE, or nil if it is not set.
+func SecretFromEnv() *Secret {
+	if p := os.Getenv(ENV_PASSPHRASE); p != "" {
+		return &Secret{Passphrase: p}
+	}
+	return nil
+}
+
+func (s *Secret) deriveKey(salt []byte) ([]byte, error) {
+	if len(s.Key) > 0 {
+		return s.Key, nil
+	}
+	if s.Passphrase == "" {
+		return nil, errors.ErrRequired("keystore passphrase or key")
+	}
+	return scrypt.Key([]byte(s.Passphrase), salt, scryptN, scryptR, scryptP, encrypt.AES_256.KeyLength())
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Entry is a named credential set stored in a keystore.
+// If a consumer identity is given, the credentials are
+// provided for matching consumers.
+type Entry struct {
+	Name        string               `json:"name"`
+	ConsumerId  cpi.ConsumerIdentity `json:"consumerId,omitempty"`
+	Credentials common.Properties    `json:"credentials"`
+	Created     time.Time            `json:"created"`
+	Rotated     *time.Time           `json:"rotated,omitempty"`
+}
+
+// EntryName provides the default entry name for a consumer identity.
+func EntryName(id cpi.ConsumerIdentity) string {
+	return id.String()
+}
+
+type content struct {
+	Entries []*Entry `json:"entries"`
+}
+
+// Keystore is the decrypted content of a keystore file.
+type Keystore struct {
+	entries map[string]*Entry
+}
+
+// New provides an empty keystore.
+func New() *Keystore {
+	return &Keystore{entries: map[string]*Entry{}}
+}
+
+// Names provides the sorted list of entry names.
+func (k *Keystore) Names() []string {
+	return utils.StringMapKeys(k.entries)
+}
+
+// Entries provides the entries sorted by name.
+func (k *Keystore) Entries() []*Entry {
+	var list []*Entry
+	for _, n := range k.Names() {
+		list = append(list, k.entries[n])
+	}
+	return list
+}
+
+func (k *Keystore) Get(name string) *Entry {
+	return k.entries[name]
+}
+
+// Lookup provides the name of the entry with the given consumer identity.
+func (k *Keystore) Lookup(id cpi.ConsumerIdentity) string {
+	for n, e := range k.entries {
+		if e.ConsumerId != nil && e.ConsumerId.Equals(id) {
+			return n
+		}
+	}
+	return ""
+}
+
+// Add adds a new entry. If no name is given, it is derived from the
+// consumer identity. An entry with the same name must not exist.
+func (k *Keystore) Add(name string, id cpi.ConsumerIdentity, creds common.Properties) (*Entry, error) {
+	if name == "" {
+		if len(id) == 0 {
+			return nil, errors.ErrRequired("entry name or consumer identity")
+		}
+		name = EntryName(id)
+	}
+	if k.entries[name] != nil {
+		return nil, errors.ErrAlreadyExists("keystore entry", name)
+	}
+	if len(id) > 0 {
+		if n := k.Lookup(id); n != "" {
+			return nil, errors.ErrAlreadyExists("keystore entry for consumer", id.String())
+		}
+	}
+	e := &Entry{
+		Name:        name,
+		ConsumerId:  id.Copy(),
+		Credentials: creds.Copy(),
+		Created:     time.Now().UTC().Truncate(time.Second),
+	}
+	k.entries[name] = e
+	return e, nil
+}
+
+// Rotate replaces the credentials of an existing entry.
+func (k *Keystore) Rotate(name string, creds common.Properties) (*Entry, error) {
+	e := k.entries[name]
+	if e == nil {
+		return nil, errors.ErrNotFound("keystore entry", name)
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	e.Credentials = creds.Copy()
+	e.Rotated = &now
+	return e, nil
+}
+
+// Remove removes an entry. It reports whether the entry existed.
+func (k *Keystore) Remove(name string) bool {
+	if k.entries[name] == nil {
+		return false
+	}
+	delete(k.entries, name)
+	return true
+}
+
+// Encrypt provides the encrypted PEM representation of the keystore.
+func (k *Keystore) Encrypt(secret *Secret) ([]byte, error) {
+	c := &content{Entries: k.Entries()}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := map[string]string{}
+	var salt []byte
+	if len(secret.Key) == 0 {
+		salt = make([]byte, 16)
+		if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+			return nil, err
+		}
+		headers[HEADER_KDF] = KDF_SCRYPT
+		headers[HEADER_SALT] = base64.StdEncoding.EncodeToString(salt)
+	}
+	key, err := secret.deriveKey(salt)
+	if err != nil {
+		return nil, err
+	}
+	algo, err := encrypt.AlgoForKey(key)
+	if err != nil {
+		return nil, err
+	}
+	headers[encrypt.ALGO] = algo.String()
+	cipherText, err := encrypt.Encrypt(key, data)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{
+		Type:    PEM_KEYSTORE,
+		Headers: headers,
+		Bytes:   cipherText,
+	}), nil
+}
+
+// Decrypt decrypts a keystore from its PEM representation.
+func Decrypt(data []byte, secret *Secret) (*Keystore, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != PEM_KEYSTORE {
+		return nil, errors.ErrInvalid("keystore format")
+	}
+	var salt []byte
+	switch kdf := block.Headers[HEADER_KDF]; kdf {
+	case "":
+		if len(secret.Key) == 0 {
+			return nil, errors.Newf("keystore requires a key file")
+		}
+	case KDF_SCRYPT:
+		if secret.Passphrase == "" {
+			return nil, errors.Newf("keystore requires a passphrase")
+		}
+		s, err := base64.StdEncoding.DecodeString(block.Headers[HEADER_SALT])
+		if err != nil {
+			return nil, errors.ErrInvalidWrap(err, "keystore salt")
+		}
+		salt = s
+		// ignore a key, the passphrase is required here
+		secret = &Secret{Passphrase: secret.Passphrase}
+	default:
+		return nil, errors.ErrNotSupported("key derivation function", kdf)
+	}
+	key, err := secret.deriveKey(salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(block.Bytes) < 12 {
+		return nil, errors.ErrInvalid("keystore content")
+	}
+	plain, err := encrypt.Decrypt(key, block.Bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot decrypt keystore (wrong passphrase or key?)")
+	}
+	var c content
+	if err := json.Unmarshal(plain, &c); err != nil {
+		return nil, errors.Wrapf(err, "invalid keystore content")
+	}
+	k := New()
+	for _, e := range c.Entries {
+		if e.Credentials == nil {
+			e.Credentials = common.Properties{}
+		}
+		k.entries[e.Name] = e
+	}
+	return k, nil
+}
+
+// Load reads a keystore file. If the file does not exist,
+// an empty keystore is provided.
+func Load(path string, secret *Secret, fss ...vfs.FileSystem) (*Keystore, error) {
+	fs := utils.FileSystem(fss...)
+	data, err := vfs.ReadFile(fs, path)
+	if err != nil {
+		if vfs.IsErrNotExist(err) {
+			return New(), nil
+		}
+		return nil, errors.Wrapf(err, "cannot read keystore %q", path)
+	}
+	k, err := Decrypt(data, secret)
+	if err != nil {
+		return nil, errors.Wrapf(err, "keystore %q", path)
+	}
+	return k, nil
+}
+
+// Save writes the keystore to a file readable only for the owner.
+// It is re-encrypted with a fresh nonce (and salt) on every write.
+// A temporary file is written first and renamed to the target to avoid
+// corrupted keystores and to enforce the permissions for existing files.
+func (k *Keystore) Save(path string, secret *Secret, fss ...vfs.FileSystem) (rerr error) {
+	fs := utils.FileSystem(fss...)
+	data, err := k.Encrypt(secret)
+	if err != nil {
+		return err
+	}
+	dir := vfs.Dir(fs, path)
+	if err := fs.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	// temp files are created with mode 0600
+	f, err := vfs.TempFile(fs, dir, vfs.Base(fs, path)+".*.tmp")
+	if err != nil {
+		return errors.Wrapf(err, "cannot write keystore %q", path)
+	}
+	tmp := f.Name()
+	defer func() {
+		if rerr != nil {
+			fs.Remove(tmp)
+		}
+	}()
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return errors.Wrapf(err, "cannot write keystore %q", path)
+	}
+	return errors.Wrapf(fs.Rename(tmp, path), "cannot write keystore %q", path)
+}
@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keystore_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/oci/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	me "github.com/open-component-model/ocm/pkg/contexts/credentials/repositories/keystore"
+	"github.com/open-component-model/ocm/pkg/encrypt"
+)
+
+var _ = Describe("keystore", func() {
+	props := common.Properties{
+		cpi.ATTR_USERNAME: "alice",
+		cpi.ATTR_PASSWORD: "secret",
+	}
+	consumer := cpi.NewConsumerIdentity(identity.CONSUMER_TYPE, identity.ID_HOSTNAME, "ghcr.io")
+
+	var path string
+	passphrase := &me.Secret{Passphrase: "passphrase"}
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "keystore")
+	})
+
+	Context("file", func() {
+		It("encrypts and decrypts with passphrase", func() {
+			k := me.New()
+			MustBeSuccessful(k.Add("", consumer, props))
+			MustBeSuccessful(k.Add("plain", nil, props))
+			MustBeSuccessful(k.Save(path, passphrase))
+
+			data := Must(os.ReadFile(path))
+			Expect(string(data)).NotTo(ContainSubstring("alice"))
+			Expect(string(data)).To(ContainSubstring("kdf: scrypt"))
+
+			r := Must(me.Load(path, passphrase))
+			Expect(r.Names()).To(Equal([]string{"plain", me.EntryName(consumer)}))
+			Expect(r.Get("plain").Credentials).To(Equal(props))
+			Expect(r.Get(me.EntryName(consumer)).ConsumerId).To(Equal(consumer))
+
+			ExpectError(me.Load(path, &me.Secret{Passphrase: "wrong"})).To(MatchError(ContainSubstring("wrong passphrase or key")))
+		})
+
+		It("replaces existing file readable only for the owner", func() {
+			MustBeSuccessful(os.WriteFile(path, []byte("old"), 0o644))
+			k := me.New()
+			MustBeSuccessful(k.Add("plain", nil, props))
+			MustBeSuccessful(k.Save(path, passphrase))
+
+			Expect(Must(os.Stat(path)).Mode().Perm()).To(Equal(os.FileMode(0o600)))
+			Expect(Must(os.ReadDir(filepath.Dir(path)))).To(HaveLen(1))
+			r := Must(me.Load(path, passphrase))
+			Expect(r.Get("plain").Credentials).To(Equal(props))
+		})
+
+		It("encrypts and decrypts with key", func() {
+			key := Must(encrypt.NewKey(encrypt.AES_256))
+			keyfile := filepath.Join(GinkgoT().TempDir(), "key")
+			MustBeSuccessful(os.WriteFile(keyfile, encrypt.KeyToPem(key), 0o600))
+			secret := Must(me.SecretFromKeyFile(keyfile))
+
+			k := me.New()
+			MustBeSuccessful(k.Add("plain", nil, props))
+			MustBeSuccessful(k.Save(path, secret))
+
+			r := Must(me.Load(path, secret))
+			Expect(r.Get("plain").Credentials).To(Equal(props))
+			ExpectError(me.Load(path, passphrase)).To(MatchError(ContainSubstring("requires a key file")))
+		})
+
+		It("rotates and removes entries", func() {
+			k := me.New()
+			MustBeSuccessful(k.Add("plain", nil, props))
+			ExpectError(k.Add("plain", nil, props)).To(MatchError(ContainSubstring("already exists")))
+
+			e := Must(k.Rotate("plain", common.Properties{cpi.ATTR_TOKEN: "token"}))
+			Expect(e.Rotated).NotTo(BeNil())
+			Expect(e.Credentials).To(Equal(common.Properties{cpi.ATTR_TOKEN: "token"}))
+			ExpectError(k.Rotate("other", props)).To(MatchError(ContainSubstring("not found")))
+
+			Expect(k.Remove("plain")).To(BeTrue())
+			Expect(k.Remove("plain")).To(BeFalse())
+		})
+	})
+
+	Context("repository", func() {
+		var ctx credentials.Context
+
+		BeforeEach(func() {
+			ctx = credentials.New()
+			k := me.New()
+			MustBeSuccessful(k.Add("", consumer, props))
+			MustBeSuccessful(k.Add("plain", nil, props))
+			MustBeSuccessful(k.Save(path, passphrase))
+		})
+
+		It("serializes repo spec", func() {
+			spec := me.NewRepositorySpec("/tmp/keystore", "/tmp/key")
+			data := Must(json.Marshal(spec))
+			Expect(string(data)).To(Equal(`{"type":"Keystore","keystoreFile":"/tmp/keystore","keyFile":"/tmp/key"}`))
+
+			s := Must(ctx.RepositorySpecForConfig(data, nil))
+			Expect(reflect.TypeOf(s).String()).To(Equal("*keystore.RepositorySpec"))
+		})
+
+		It("retrieves credentials", func() {
+			repo := Must(ctx.RepositoryForSpec(me.NewRepositorySpec(path, ""), credentials.DirectCredentials{me.ATTR_PASSPHRASE: "passphrase"}))
+			Expect(reflect.TypeOf(repo).String()).To(Equal("*keystore.Repository"))
+			Expect(Must(repo.ExistsCredentials("plain"))).To(BeTrue())
+			Expect(Must(repo.LookupCredentials("plain")).Properties()).To(Equal(props))
+		})
+
+		It("uses passphrase from environment", func() {
+			GinkgoT().Setenv(me.ENV_PASSPHRASE, "passphrase")
+			repo := Must(ctx.RepositoryForSpec(me.NewRepositorySpec(path, "")))
+			Expect(Must(repo.LookupCredentials("plain")).Properties()).To(Equal(props))
+		})
+
+		It("propagates consumer ids", func() {
+			MustBeSuccessful(ctx.RepositoryForSpec(me.NewRepositorySpec(path, ""), credentials.DirectCredentials{me.ATTR_PASSPHRASE: "passphrase"}))
+			id := cpi.NewConsumerIdentity(identity.CONSUMER_TYPE, identity.ID_HOSTNAME, "ghcr.io", identity.ID_PATHPREFIX, "acme")
+			creds := Must(cpi.CredentialsForConsumer(ctx, id, identity.IdentityMatcher))
+			Expect(creds.Properties()).To(Equal(props))
+		})
+
+		It("writes credentials", func() {
+			repo := Must(ctx.RepositoryForSpec(me.NewRepositorySpec(path, ""), credentials.DirectCredentials{me.ATTR_PASSPHRASE: "passphrase"}))
+			MustBeSuccessful(repo.WriteCredentials("new", credentials.DirectCredentials{cpi.ATTR_TOKEN: "token"}))
+			Expect(Must(repo.LookupCredentials("new")).Properties()).To(Equal(common.Properties{cpi.ATTR_TOKEN: "token"}))
+
+			k := Must(me.Load(path, passphrase))
+			Expect(k.Names()).To(ContainElement("new"))
+		})
+	})
+})
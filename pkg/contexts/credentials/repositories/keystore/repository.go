@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keystore
+
+import (
+	"sync"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+const PROVIDER = "ocm.software/credentialprovider/" + Type
+
+type Repository struct {
+	lock      sync.RWMutex
+	ctx       cpi.Context
+	path      string
+	secret    *Secret
+	propagate bool
+	keystore  *Keystore
+}
+
+var _ cpi.Repository = (*Repository)(nil)
+
+// NewRepository provides a credential repository for an encrypted
+// keystore file.
+func NewRepository(ctx cpi.Context, path string, secret *Secret, propagate bool) (*Repository, error) {
+	r := &Repository{
+		ctx:       ctx,
+		path:      path,
+		secret:    secret,
+		propagate: propagate,
+	}
+	err := r.Read(true)
+	if err != nil {
+		return nil, err
+	}
+	if propagate {
+		ctx.RegisterConsumerProvider(cpi.ProviderIdentity(PROVIDER+"/"+path), &ConsumerProvider{r})
+	}
+	return r, nil
+}
+
+func (r *Repository) Read(force bool) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !force && r.keystore != nil {
+		return nil
+	}
+	k, err := Load(r.path, r.secret)
+	if err != nil {
+		return err
+	}
+	r.keystore = k
+	return nil
+}
+
+func (r *Repository) ExistsCredentials(name string) (bool, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.keystore.Get(name) != nil, nil
+}
+
+func (r *Repository) LookupCredentials(name string) (cpi.Credentials, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	e := r.keystore.Get(name)
+	if e == nil {
+		return nil, cpi.ErrUnknownCredentials(name)
+	}
+	return cpi.NewCredentials(e.Credentials), nil
+}
+
+// WriteCredentials stores the credentials under the given name.
+// An existing entry is rotated, otherwise a new entry without
+// consumer identity is created.
+func (r *Repository) WriteCredentials(name string, creds cpi.Credentials) (cpi.Credentials, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	k, err := Load(r.path, r.secret)
+	if err != nil {
+		return nil, err
+	}
+	props := creds.Properties()
+	if k.Get(name) != nil {
+		_, err = k.Rotate(name, props)
+	} else {
+		_, err = k.Add(name, nil, props)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := k.Save(r.path, r.secret); err != nil {
+		return nil, errors.Wrapf(err, "cannot write keystore %q", r.path)
+	}
+	r.keystore = k
+	return cpi.NewCredentials(props), nil
+}
+
+func (r *Repository) entries() []*Entry {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.keystore.Entries()
+}
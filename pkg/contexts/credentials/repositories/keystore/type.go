@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keystore
+
+import (
+	"fmt"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/generics"
+	"github.com/open-component-model/ocm/pkg/runtime"
+	"github.com/open-component-model/ocm/pkg/utils"
+)
+
+const (
+	Type   = "Keystore"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+// ATTR_PASSPHRASE is the credential attribute used to pass the
+// passphrase to the repository.
+const ATTR_PASSPHRASE = "passphrase"
+
+func init() {
+	cpi.RegisterRepositoryType(cpi.NewRepositoryType[*RepositorySpec](Type))
+	cpi.RegisterRepositoryType(cpi.NewRepositoryType[*RepositorySpec](TypeV1, cpi.WithDescription(usage), cpi.WithFormatSpec(format)))
+}
+
+// RepositorySpec describes an encrypted keystore based credential repository.
+type RepositorySpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+	KeystoreFile                string `json:"keystoreFile,omitempty"`
+	KeyFile                     string `json:"keyFile,omitempty"`
+	PropgateConsumerIdentity    *bool  `json:"propagateConsumerIdentity,omitempty"`
+}
+
+// NewRepositorySpec creates a new keystore RepositorySpec.
+// If no path is given, the default keystore location is used.
+func NewRepositorySpec(path string, keyfile string, propagate ...bool) *RepositorySpec {
+	var p *bool
+	if path == "" {
+		d, err := DefaultKeystore()
+		if err == nil {
+			path = d
+		}
+	}
+	if len(propagate) > 0 {
+		p = generics.Pointer(utils.OptionalDefaultedBool(true, propagate...))
+	}
+	return &RepositorySpec{
+		ObjectVersionedType:      runtime.NewVersionedTypedObject(Type),
+		KeystoreFile:             path,
+		KeyFile:                  keyfile,
+		PropgateConsumerIdentity: p,
+	}
+}
+
+func (a *RepositorySpec) GetType() string {
+	return Type
+}
+
+func (a *RepositorySpec) Repository(ctx cpi.Context, creds cpi.Credentials) (cpi.Repository, error) {
+	r := ctx.GetAttributes().GetOrCreateAttribute(ATTR_REPOS, newRepositories)
+	repos, ok := r.(*Repositories)
+	if !ok {
+		return nil, fmt.Errorf("failed to assert type %T to Repositories", r)
+	}
+	path := a.KeystoreFile
+	if path == "" {
+		d, err := DefaultKeystore()
+		if err != nil {
+			return nil, err
+		}
+		path = d
+	}
+	path, err := utils.ResolvePath(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot resolve keystore path %q", a.KeystoreFile)
+	}
+	secret, err := a.secret(creds)
+	if err != nil {
+		return nil, err
+	}
+	return repos.GetRepository(ctx, path, secret, utils.AsBool(a.PropgateConsumerIdentity, true))
+}
+
+// secret determines the key material. A key file has precedence
+// over a passphrase given by credentials or by the environment.
+func (a *RepositorySpec) secret(creds cpi.Credentials) (*Secret, error) {
+	if a.KeyFile != "" {
+		path, err := utils.ResolvePath(a.KeyFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot resolve key file path %q", a.KeyFile)
+		}
+		return SecretFromKeyFile(path)
+	}
+	if creds != nil && creds.GetProperty(ATTR_PASSPHRASE) != "" {
+		return &Secret{Passphrase: creds.GetProperty(ATTR_PASSPHRASE)}, nil
+	}
+	if s := SecretFromEnv(); s != nil {
+		return s, nil
+	}
+	return nil, errors.Newf("no key file or passphrase given for keystore (use %s)", ENV_PASSPHRASE)
+}
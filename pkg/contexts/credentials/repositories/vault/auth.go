@@ -6,8 +6,11 @@ package vault
 
 import (
 	"context"
+	"os"
 	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/vault-client-go"
 	"github.com/hashicorp/vault-client-go/schema"
@@ -24,6 +27,22 @@ type AuthMethod interface {
 	GetToken(ctx context.Context, client *vault.Client, ns string, creds cpi.Credentials) (string, error)
 }
 
+// Lease describes a token provided by a vault login.
+// A zero duration means that the token does not expire.
+type Lease struct {
+	Token     string
+	Duration  time.Duration
+	Renewable bool
+}
+
+// LoginMethod is an optional interface for an AuthMethod, which logs
+// in to vault and provides tokens with a limited lifetime.
+// Such tokens are renewed or requested again before they expire.
+type LoginMethod interface {
+	AuthMethod
+	Login(ctx context.Context, client *vault.Client, ns string, creds cpi.Credentials) (*Lease, error)
+}
+
 type AuthMethods struct {
 	lock    sync.Mutex
 	methods map[string]AuthMethod
@@ -69,6 +88,69 @@ func RegisterAuthMethod(m AuthMethod) {
 func init() {
 	RegisterAuthMethod(&approle{})
 	RegisterAuthMethod(&token{})
+	RegisterAuthMethod(&kubernetes{})
+	RegisterAuthMethod(&jwt{name: identity.AUTH_JWT})
+	RegisterAuthMethod(&jwt{name: identity.AUTH_OIDC})
+	RegisterAuthMethod(&userpass{})
+}
+
+func lease(resp *vault.Response[map[string]interface{}], err error) (*Lease, error) {
+	if err != nil {
+		return nil, err
+	}
+	if resp.Auth == nil || resp.Auth.ClientToken == "" {
+		return nil, errors.Newf("no token provided by login")
+	}
+	return &Lease{
+		Token:     resp.Auth.ClientToken,
+		Duration:  time.Duration(resp.Auth.LeaseDuration) * time.Second,
+		Renewable: resp.Auth.Renewable,
+	}, nil
+}
+
+func token4Login(ctx context.Context, m LoginMethod, client *vault.Client, ns string, creds cpi.Credentials) (string, error) {
+	l, err := m.Login(ctx, client, ns, creds)
+	if err != nil {
+		return "", err
+	}
+	return l.Token, nil
+}
+
+// mountPath provides the mount path of the auth method.
+// It defaults to the name of the method.
+func mountPath(m AuthMethod, creds cpi.Credentials) string {
+	if p := creds.GetProperty(identity.ATTR_MOUNTPATH); p != "" {
+		return p
+	}
+	return m.GetName()
+}
+
+// getJWT provides the JWT configured by the credentials. It is either given
+// directly, or read from a file or an environment variable.
+// The file is read for every login, because the tokens may be rotated.
+func getJWT(creds cpi.Credentials, deffile string) (string, error) {
+	if t := creds.GetProperty(identity.ATTR_JWT); t != "" {
+		return t, nil
+	}
+	if env := creds.GetProperty(identity.ATTR_JWT_ENV); env != "" {
+		t := os.Getenv(env)
+		if t == "" {
+			return "", errors.Newf("environment variable %q for JWT not set", env)
+		}
+		return t, nil
+	}
+	file := creds.GetProperty(identity.ATTR_JWT_FILE)
+	if file == "" {
+		file = deffile
+	}
+	if file == "" {
+		return "", errors.ErrRequired("credential property", identity.ATTR_JWT)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", errors.Wrapf(err, "cannot read JWT file %q", file)
+	}
+	return strings.TrimSpace(string(data)), nil
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -92,19 +174,19 @@ func (a *approle) Validate(creds cpi.Credentials) error {
 }
 
 func (a *approle) GetToken(ctx context.Context, client *vault.Client, ns string, creds cpi.Credentials) (string, error) {
+	return token4Login(ctx, a, client, ns, creds)
+}
+
+func (a *approle) Login(ctx context.Context, client *vault.Client, ns string, creds cpi.Credentials) (*Lease, error) {
 	req := schema.AppRoleLoginRequest{
 		RoleId:   creds.GetProperty(identity.ATTR_ROLEID),
 		SecretId: creds.GetProperty(identity.ATTR_SECRETID),
 	}
-	resp, err := client.Auth.AppRoleLogin(
+	return lease(client.Auth.AppRoleLogin(
 		ctx,
 		req,
 		vault.WithNamespace(ns),
-	)
-	if err != nil {
-		return "", err
-	}
-	return resp.Auth.ClientToken, nil
+	))
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -127,3 +209,124 @@ func (a *token) Validate(creds cpi.Credentials) error {
 func (a *token) GetToken(ctx context.Context, client *vault.Client, ns string, creds cpi.Credentials) (string, error) {
 	return creds.GetProperty(identity.ATTR_TOKEN), nil
 }
+
+////////////////////////////////////////////////////////////////////////////////
+
+// DEFAULT_SERVICE_ACCOUNT_TOKEN_FILE is the location of the service account
+// token mounted into a Kubernetes pod.
+const DEFAULT_SERVICE_ACCOUNT_TOKEN_FILE = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+type kubernetes struct{}
+
+var _ LoginMethod = (*kubernetes)(nil)
+
+func (a *kubernetes) GetName() string {
+	return identity.AUTH_KUBERNETES
+}
+
+func (a *kubernetes) Validate(creds cpi.Credentials) error {
+	if !creds.ExistsProperty(identity.ATTR_ROLE) {
+		return errors.ErrRequired("credential property", identity.ATTR_ROLE, a.GetName())
+	}
+	return nil
+}
+
+func (a *kubernetes) GetToken(ctx context.Context, client *vault.Client, ns string, creds cpi.Credentials) (string, error) {
+	return token4Login(ctx, a, client, ns, creds)
+}
+
+func (a *kubernetes) Login(ctx context.Context, client *vault.Client, ns string, creds cpi.Credentials) (*Lease, error) {
+	t, err := getJWT(creds, DEFAULT_SERVICE_ACCOUNT_TOKEN_FILE)
+	if err != nil {
+		return nil, err
+	}
+	req := schema.KubernetesLoginRequest{
+		Jwt:  t,
+		Role: creds.GetProperty(identity.ATTR_ROLE),
+	}
+	return lease(client.Auth.KubernetesLogin(
+		ctx,
+		req,
+		vault.WithMountPath(mountPath(a, creds)),
+		vault.WithNamespace(ns),
+	))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// jwt implements the jwt and oidc auth methods. Both use the
+// same login endpoint, they just differ in the default mount path.
+type jwt struct {
+	name string
+}
+
+var _ LoginMethod = (*jwt)(nil)
+
+func (a *jwt) GetName() string {
+	return a.name
+}
+
+func (a *jwt) Validate(creds cpi.Credentials) error {
+	if !creds.ExistsProperty(identity.ATTR_JWT) && !creds.ExistsProperty(identity.ATTR_JWT_FILE) && !creds.ExistsProperty(identity.ATTR_JWT_ENV) {
+		return errors.ErrRequired("credential property", identity.ATTR_JWT+", "+identity.ATTR_JWT_FILE+" or "+identity.ATTR_JWT_ENV, a.GetName())
+	}
+	return nil
+}
+
+func (a *jwt) GetToken(ctx context.Context, client *vault.Client, ns string, creds cpi.Credentials) (string, error) {
+	return token4Login(ctx, a, client, ns, creds)
+}
+
+func (a *jwt) Login(ctx context.Context, client *vault.Client, ns string, creds cpi.Credentials) (*Lease, error) {
+	t, err := getJWT(creds, "")
+	if err != nil {
+		return nil, err
+	}
+	req := schema.JwtLoginRequest{
+		Jwt:  t,
+		Role: creds.GetProperty(identity.ATTR_ROLE),
+	}
+	return lease(client.Auth.JwtLogin(
+		ctx,
+		req,
+		vault.WithMountPath(mountPath(a, creds)),
+		vault.WithNamespace(ns),
+	))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type userpass struct{}
+
+var _ LoginMethod = (*userpass)(nil)
+
+func (a *userpass) GetName() string {
+	return identity.AUTH_USERPASS
+}
+
+func (a *userpass) Validate(creds cpi.Credentials) error {
+	if !creds.ExistsProperty(identity.ATTR_USERNAME) {
+		return errors.ErrRequired("credential property", identity.ATTR_USERNAME, a.GetName())
+	}
+	if !creds.ExistsProperty(identity.ATTR_PASSWORD) {
+		return errors.ErrRequired("credential property", identity.ATTR_PASSWORD, a.GetName())
+	}
+	return nil
+}
+
+func (a *userpass) GetToken(ctx context.Context, client *vault.Client, ns string, creds cpi.Credentials) (string, error) {
+	return token4Login(ctx, a, client, ns, creds)
+}
+
+func (a *userpass) Login(ctx context.Context, client *vault.Client, ns string, creds cpi.Credentials) (*Lease, error) {
+	req := schema.UserpassLoginRequest{
+		Password: creds.GetProperty(identity.ATTR_PASSWORD),
+	}
+	return lease(client.Auth.UserpassLogin(
+		ctx,
+		creds.GetProperty(identity.ATTR_USERNAME),
+		req,
+		vault.WithMountPath(mountPath(a, creds)),
+		vault.WithNamespace(ns),
+	))
+}
@@ -0,0 +1,246 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/hashicorp/vault-client-go"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/repositories/vault/identity"
+)
+
+type fakeVault struct {
+	lock     sync.Mutex
+	requests []string
+	logins   int
+	renew    bool
+	lease    int
+	body     map[string]interface{}
+}
+
+func (f *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.requests = append(f.requests, r.URL.Path)
+	f.body = map[string]interface{}{}
+	json.NewDecoder(r.Body).Decode(&f.body)
+
+	var token string
+	switch {
+	case r.URL.Path == "/v1/auth/token/renew-self":
+		if !f.renew {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		token = r.Header.Get("X-Vault-Token")
+	case strings.HasPrefix(r.URL.Path, "/v1/auth/") && strings.Contains(r.URL.Path, "/login"):
+		f.logins++
+		token = fmt.Sprintf("token-%d", f.logins)
+	case r.URL.Path == "/v1/secret/data/ocm/test":
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"data":{"data":{"username":"bob","password":"pw-%d"},"metadata":{}}}`, f.logins)
+		return
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"data":null,"auth":{"client_token":%q,"lease_duration":%d,"renewable":true}}`, token, f.lease)
+}
+
+var _ = Describe("auth methods", func() {
+	var fake *fakeVault
+	var server *httptest.Server
+	var client *vault.Client
+	var now time.Time
+	var cache *tokenCache
+
+	ctx := context.Background()
+
+	BeforeEach(func() {
+		var err error
+		fake = &fakeVault{lease: 60, renew: true}
+		server = httptest.NewServer(fake)
+		client, err = vault.New(vault.WithAddress(server.URL))
+		Expect(err).To(Succeed())
+		now = time.Now()
+		cache = &tokenCache{now: func() time.Time { return now }}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("logs in with userpass", func() {
+		creds := cpi.DirectCredentials{
+			identity.ATTR_AUTHMETH: identity.AUTH_USERPASS,
+			identity.ATTR_USERNAME: "alice",
+			identity.ATTR_PASSWORD: "secret",
+		}
+		Expect(methods.Get(identity.AUTH_USERPASS).Validate(creds)).To(Succeed())
+		Expect(cache.GetToken(ctx, client, "", creds)).To(Equal("token-1"))
+		Expect(fake.requests).To(Equal([]string{"/v1/auth/userpass/login/alice"}))
+		Expect(fake.body).To(Equal(map[string]interface{}{"password": "secret"}))
+	})
+
+	It("logs in with kubernetes using a token file", func() {
+		file := filepath.Join(GinkgoT().TempDir(), "token")
+		Expect(os.WriteFile(file, []byte("sa-token\n"), 0o600)).To(Succeed())
+		creds := cpi.DirectCredentials{
+			identity.ATTR_AUTHMETH: identity.AUTH_KUBERNETES,
+			identity.ATTR_ROLE:     "ci",
+			identity.ATTR_JWT_FILE: file,
+		}
+		Expect(methods.Get(identity.AUTH_KUBERNETES).Validate(creds)).To(Succeed())
+		Expect(cache.GetToken(ctx, client, "", creds)).To(Equal("token-1"))
+		Expect(fake.requests).To(Equal([]string{"/v1/auth/kubernetes/login"}))
+		Expect(fake.body).To(Equal(map[string]interface{}{"jwt": "sa-token", "role": "ci"}))
+	})
+
+	It("logs in with oidc using an environment variable and mount path", func() {
+		GinkgoT().Setenv("OCM_TEST_VAULT_JWT", "id-token")
+		creds := cpi.DirectCredentials{
+			identity.ATTR_AUTHMETH:  identity.AUTH_OIDC,
+			identity.ATTR_ROLE:      "ci",
+			identity.ATTR_JWT_ENV:   "OCM_TEST_VAULT_JWT",
+			identity.ATTR_MOUNTPATH: "github",
+		}
+		Expect(methods.Get(identity.AUTH_OIDC).Validate(creds)).To(Succeed())
+		Expect(cache.GetToken(ctx, client, "", creds)).To(Equal("token-1"))
+		Expect(fake.requests).To(Equal([]string{"/v1/auth/github/login"}))
+		Expect(fake.body).To(Equal(map[string]interface{}{"jwt": "id-token", "role": "ci"}))
+	})
+
+	It("validates credentials", func() {
+		Expect(methods.Get(identity.AUTH_JWT).Validate(cpi.DirectCredentials{})).To(MatchError(ContainSubstring("jwt, jwtFile or jwtEnv")))
+		Expect(methods.Get(identity.AUTH_KUBERNETES).Validate(cpi.DirectCredentials{})).To(MatchError(ContainSubstring("role")))
+		Expect(methods.Get(identity.AUTH_USERPASS).Validate(cpi.DirectCredentials{identity.ATTR_USERNAME: "alice"})).To(MatchError(ContainSubstring("password")))
+	})
+
+	Context("token cache", func() {
+		creds := cpi.DirectCredentials{
+			identity.ATTR_AUTHMETH: identity.AUTH_JWT,
+			identity.ATTR_JWT:      "id-token",
+		}
+
+		It("reuses a valid token", func() {
+			Expect(cache.GetToken(ctx, client, "", creds)).To(Equal("token-1"))
+			now = now.Add(20 * time.Second)
+			Expect(cache.GetToken(ctx, client, "", creds)).To(Equal("token-1"))
+			Expect(fake.requests).To(HaveLen(1))
+		})
+
+		It("renews an expiring token", func() {
+			Expect(cache.GetToken(ctx, client, "", creds)).To(Equal("token-1"))
+			now = now.Add(40 * time.Second)
+			Expect(cache.GetToken(ctx, client, "", creds)).To(Equal("token-1"))
+			Expect(fake.requests).To(Equal([]string{"/v1/auth/jwt/login", "/v1/auth/token/renew-self"}))
+			now = now.Add(20 * time.Second)
+			Expect(cache.GetToken(ctx, client, "", creds)).To(Equal("token-1"))
+			Expect(fake.requests).To(HaveLen(2))
+		})
+
+		It("logs in again if renewal fails", func() {
+			Expect(cache.GetToken(ctx, client, "", creds)).To(Equal("token-1"))
+			fake.renew = false
+			now = now.Add(40 * time.Second)
+			Expect(cache.GetToken(ctx, client, "", creds)).To(Equal("token-2"))
+			Expect(fake.requests).To(Equal([]string{"/v1/auth/jwt/login", "/v1/auth/token/renew-self", "/v1/auth/jwt/login"}))
+		})
+
+		It("logs in again after expiration", func() {
+			Expect(cache.GetToken(ctx, client, "", creds)).To(Equal("token-1"))
+			now = now.Add(2 * time.Minute)
+			Expect(cache.GetToken(ctx, client, "", creds)).To(Equal("token-2"))
+			Expect(fake.requests).To(Equal([]string{"/v1/auth/jwt/login", "/v1/auth/jwt/login"}))
+		})
+
+		It("logs in again for changed credentials", func() {
+			Expect(cache.GetToken(ctx, client, "", creds)).To(Equal("token-1"))
+			other := cpi.DirectCredentials(common.Properties(creds).Copy())
+			other[identity.ATTR_JWT] = "other"
+			Expect(cache.GetToken(ctx, client, "", other)).To(Equal("token-2"))
+		})
+
+		It("keeps static tokens", func() {
+			static := cpi.DirectCredentials{
+				identity.ATTR_AUTHMETH: identity.AUTH_TOKEN,
+				identity.ATTR_TOKEN:    "static",
+			}
+			Expect(cache.GetToken(ctx, client, "", static)).To(Equal("static"))
+			now = now.Add(24 * time.Hour)
+			Expect(cache.GetToken(ctx, client, "", static)).To(Equal("static"))
+			Expect(fake.requests).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("provider", func() {
+	var fake *fakeVault
+	var server *httptest.Server
+	var now time.Time
+	var repo *Repository
+
+	BeforeEach(func() {
+		fake = &fakeVault{lease: 60}
+		server = httptest.NewServer(fake)
+		now = time.Now()
+
+		cctx := cpi.New()
+		id, err := identity.GetConsumerId(server.URL, "", "secret", "ocm")
+		Expect(err).To(Succeed())
+		cctx.SetCredentialsForConsumer(id, cpi.DirectCredentials{
+			identity.ATTR_AUTHMETH: identity.AUTH_USERPASS,
+			identity.ATTR_USERNAME: "alice",
+			identity.ATTR_PASSWORD: "secret",
+		})
+		repo, err = NewRepository(cctx, NewRepositorySpec(server.URL, WithSecretsEngine("secret"), WithPath("ocm"), WithSecrets("test")))
+		Expect(err).To(Succeed())
+		repo.tokens.now = func() time.Time { return now }
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("reads secrets again after token expiration", func() {
+		creds, err := repo.LookupCredentials("test")
+		Expect(err).To(Succeed())
+		Expect(creds.GetProperty("password")).To(Equal("pw-1"))
+		Expect(fake.requests).To(Equal([]string{"/v1/auth/userpass/login/alice", "/v1/secret/data/ocm/test"}))
+
+		now = now.Add(20 * time.Second)
+		creds, err = repo.LookupCredentials("test")
+		Expect(err).To(Succeed())
+		Expect(creds.GetProperty("password")).To(Equal("pw-1"))
+		Expect(fake.requests).To(HaveLen(2))
+
+		now = now.Add(2 * time.Minute)
+		creds, err = repo.LookupCredentials("test")
+		Expect(err).To(Succeed())
+		Expect(creds.GetProperty("password")).To(Equal("pw-2"))
+		Expect(fake.requests).To(Equal([]string{
+			"/v1/auth/userpass/login/alice", "/v1/secret/data/ocm/test",
+			"/v1/auth/userpass/login/alice", "/v1/secret/data/ocm/test",
+		}))
+	})
+})
@@ -5,9 +5,16 @@
 package vault
 
 import (
+	"context"
 	"sync"
+	"time"
 
+	"github.com/hashicorp/vault-client-go"
+	"github.com/hashicorp/vault-client-go/schema"
+
+	"github.com/open-component-model/ocm/pkg/common"
 	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/repositories/vault/identity"
 	"github.com/open-component-model/ocm/pkg/contexts/datacontext"
 	"github.com/open-component-model/ocm/pkg/errors"
 )
@@ -45,3 +52,104 @@ func (r *Repositories) GetRepository(ctx cpi.Context, spec *RepositorySpec) (*Re
 	}
 	return repo, err
 }
+
+////////////////////////////////////////////////////////////////////////////////
+
+// RENEWAL_MARGIN is the remaining lifetime of a cached token, which
+// triggers its renewal.
+const RENEWAL_MARGIN = 30 * time.Second
+
+// tokenCache keeps the token of a repository. Tokens provided by a
+// LoginMethod are renewed before they expire. If this is not possible,
+// a new login is done.
+type tokenCache struct {
+	lock      sync.Mutex
+	creds     common.Properties
+	token     string
+	expires   time.Time
+	renewable bool
+	now       func() time.Time
+}
+
+func (c *tokenCache) time() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
+}
+
+func (c *tokenCache) set(creds common.Properties, l *Lease) string {
+	c.creds = creds
+	c.token = l.Token
+	c.renewable = l.Renewable
+	if l.Duration > 0 {
+		c.expires = c.time().Add(l.Duration)
+	} else {
+		c.expires = time.Time{}
+	}
+	return c.token
+}
+
+func (c *tokenCache) valid(creds common.Properties) bool {
+	if c.token == "" || !c.creds.Equals(creds) {
+		return false
+	}
+	return c.expires.IsZero() || c.expires.Sub(c.time()) > RENEWAL_MARGIN
+}
+
+// Valid reports whether the cached token is still usable without
+// renewal or new login.
+func (c *tokenCache) Valid() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.valid(c.creds)
+}
+
+// GetToken provides a valid token for the given credentials.
+func (c *tokenCache) GetToken(ctx context.Context, client *vault.Client, ns string, creds cpi.Credentials) (string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	props := creds.Properties()
+	if c.valid(props) {
+		return c.token, nil
+	}
+
+	name := creds.GetProperty(identity.ATTR_AUTHMETH)
+	m := methods.Get(name)
+	if m == nil {
+		return "", errors.ErrInvalid(identity.ATTR_AUTHMETH, name)
+	}
+	lm, ok := m.(LoginMethod)
+	if !ok {
+		t, err := m.GetToken(ctx, client, ns, creds)
+		if err != nil {
+			return "", err
+		}
+		return c.set(props, &Lease{Token: t}), nil
+	}
+
+	if c.token != "" && c.renewable && c.creds.Equals(props) && c.time().Before(c.expires) {
+		l, err := lease(client.Auth.TokenRenewSelf(ctx, schema.TokenRenewSelfRequest{},
+			vault.WithToken(c.token),
+			vault.WithNamespace(ns),
+		))
+		if err == nil && (l.Duration == 0 || l.Duration > RENEWAL_MARGIN) {
+			log.Debug("vault token renewed", "method", name, "duration", l.Duration)
+			return c.set(props, l), nil
+		}
+		if err != nil {
+			log.Debug("vault token renewal failed, login again", "method", name, "error", err.Error())
+		} else {
+			log.Debug("vault token reached maximum lifetime, login again", "method", name)
+		}
+	}
+
+	l, err := lm.Login(ctx, client, ns, creds)
+	if err != nil {
+		c.token = ""
+		return "", err
+	}
+	log.Debug("vault login", "method", name, "duration", l.Duration)
+	return c.set(props, l), nil
+}
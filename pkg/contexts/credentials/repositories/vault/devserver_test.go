@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package vault_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/hashicorp/vault-client-go"
+	"github.com/hashicorp/vault-client-go/schema"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	me "github.com/open-component-model/ocm/pkg/contexts/credentials/repositories/vault"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/repositories/vault/identity"
+)
+
+const ROOT_TOKEN = "root"
+
+const POLICY = `
+path "secret/*" {
+  capabilities = ["read", "list"]
+}
+`
+
+func freePort() int {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	ExpectWithOffset(1, err).To(Succeed())
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+// This test requires the vault binary in the search path. It starts a
+// local vault dev server.
+var _ = Describe("vault dev server", func() {
+	var url string
+	var cmd *exec.Cmd
+	var client *vault.Client
+
+	ctx := context.Background()
+
+	BeforeEach(func() {
+		path, err := exec.LookPath("vault")
+		if err != nil {
+			Skip("vault binary not found")
+		}
+		addr := fmt.Sprintf("127.0.0.1:%d", freePort())
+		url = "http://" + addr
+		cmd = exec.Command(path, "server", "-dev", "-dev-root-token-id="+ROOT_TOKEN, "-dev-listen-address="+addr)
+		Expect(cmd.Start()).To(Succeed())
+
+		client, err = vault.New(vault.WithAddress(url))
+		Expect(err).To(Succeed())
+		Expect(client.SetToken(ROOT_TOKEN)).To(Succeed())
+		Eventually(func() error {
+			_, err := client.System.ReadHealthStatus(ctx)
+			return err
+		}, 10*time.Second, 100*time.Millisecond).Should(Succeed())
+
+		_, err = client.System.PoliciesWriteAclPolicy(ctx, "ocm", schema.PoliciesWriteAclPolicyRequest{Policy: POLICY})
+		Expect(err).To(Succeed())
+		_, err = client.System.AuthEnableMethod(ctx, identity.AUTH_USERPASS, schema.AuthEnableMethodRequest{Type: identity.AUTH_USERPASS})
+		Expect(err).To(Succeed())
+		_, err = client.Auth.UserpassWriteUser(ctx, "alice", schema.UserpassWriteUserRequest{Password: "secret", TokenPolicies: []string{"ocm"}})
+		Expect(err).To(Succeed())
+		_, err = client.Secrets.KvV2Write(ctx, "ocm/test", schema.KvV2WriteRequest{Data: map[string]interface{}{"username": "bob", "password": "pw"}},
+			vault.WithMountPath("secret"))
+		Expect(err).To(Succeed())
+	})
+
+	AfterEach(func() {
+		if cmd != nil && cmd.Process != nil {
+			cmd.Process.Kill()
+			cmd.Wait()
+		}
+	})
+
+	It("reads secrets with userpass login", func() {
+		cctx := credentials.New()
+		id, err := identity.GetConsumerId(url, "", "secret", "ocm")
+		Expect(err).To(Succeed())
+		cctx.SetCredentialsForConsumer(id, credentials.DirectCredentials{
+			identity.ATTR_AUTHMETH: identity.AUTH_USERPASS,
+			identity.ATTR_USERNAME: "alice",
+			identity.ATTR_PASSWORD: "secret",
+		})
+
+		repo, err := cctx.RepositoryForSpec(me.NewRepositorySpec(url, me.WithSecretsEngine("secret"), me.WithPath("ocm")))
+		Expect(err).To(Succeed())
+		creds, err := repo.LookupCredentials("test")
+		Expect(err).To(Succeed())
+		Expect(creds.Properties()).To(Equal(credentials.DirectCredentials{"username": "bob", "password": "pw"}.Properties()))
+	})
+})
@@ -29,15 +29,26 @@ const (
 
 // credential properties.
 const (
-	ATTR_AUTHMETH = "authmeth"
-	ATTR_TOKEN    = cpi.ATTR_TOKEN
-	ATTR_ROLEID   = "roleid"
-	ATTR_SECRETID = "secretid"
+	ATTR_AUTHMETH  = "authmeth"
+	ATTR_TOKEN     = cpi.ATTR_TOKEN
+	ATTR_ROLEID    = "roleid"
+	ATTR_SECRETID  = "secretid"
+	ATTR_ROLE      = "role"
+	ATTR_JWT       = "jwt"
+	ATTR_JWT_FILE  = "jwtFile"
+	ATTR_JWT_ENV   = "jwtEnv"
+	ATTR_USERNAME  = cpi.ATTR_USERNAME
+	ATTR_PASSWORD  = cpi.ATTR_PASSWORD
+	ATTR_MOUNTPATH = "mountPath"
 )
 
 const (
-	AUTH_APPROLE = "approle"
-	AUTH_TOKEN   = "token"
+	AUTH_APPROLE    = "approle"
+	AUTH_TOKEN      = "token"
+	AUTH_KUBERNETES = "kubernetes"
+	AUTH_JWT        = "jwt"
+	AUTH_OIDC       = "oidc"
+	AUTH_USERPASS   = "userpass"
 )
 
 var identityMatcher = hostpath.IdentityMatcher(CONSUMER_TYPE)
@@ -58,7 +69,13 @@ func init() {
 		ATTR_TOKEN, "vault token",
 		ATTR_ROLEID, "applrole role id",
 		ATTR_SECRETID, "applrole secret id",
-		ATTR_SECRETID, "applrole secret id",
+		ATTR_ROLE, "role used for kubernetes, jwt and oidc login",
+		ATTR_JWT, "JWT used for kubernetes, jwt and oidc login",
+		ATTR_JWT_FILE, "file containing the JWT (kubernetes default: /var/run/secrets/kubernetes.io/serviceaccount/token)",
+		ATTR_JWT_ENV, "environment variable containing the JWT",
+		ATTR_USERNAME, "userpass user name",
+		ATTR_PASSWORD, "userpass password",
+		ATTR_MOUNTPATH, "(optional) mount path of the auth method (default: name of auth method)",
 	})
 	ids := listformat.FormatListElements("", listformat.StringElementDescriptionList{
 		ID_HOSTNAME, "vault server host",
@@ -75,7 +92,9 @@ This matcher matches credentials for a HashiCorp vault instance.
 It uses the following identity attributes:
 `+ids,
 		attrs+`
-The only supported auth methods, so far, are <code>token</code> and <code>approle</code>.
+The supported auth methods are <code>token</code>, <code>approle</code>,
+<code>kubernetes</code>, <code>jwt</code>, <code>oidc</code> and <code>userpass</code>.
+Tokens provided by a login are renewed or requested again before they expire.
 `)
 }
 
@@ -60,7 +60,9 @@ func NewConsumerProvider(repo *Repository) (*ConsumerProvider, error) {
 func (p *ConsumerProvider) update() error {
 	var err error
 
-	if p.updated {
+	// the secrets are read again, if the token lease of the
+	// repository is expired or close to expire.
+	if p.updated && p.repository.tokens.Valid() {
 		return nil
 	}
 	p.updated = true
@@ -155,8 +157,7 @@ func (p *ConsumerProvider) validateCreds(creds cpi.Credentials) error {
 }
 
 func (p *ConsumerProvider) getToken(ctx context.Context, client *vault.Client, creds cpi.Credentials) (string, error) {
-	m := creds.GetProperty(identity.ATTR_AUTHMETH)
-	return methods.Get(m).GetToken(ctx, client, p.repository.spec.Namespace, creds)
+	return p.repository.tokens.GetToken(ctx, client, p.repository.spec.Namespace, creds)
 }
 
 func (p *ConsumerProvider) error(err error, msg string, secret string, keypairs ...interface{}) {
@@ -16,6 +16,7 @@ type Repository struct {
 	spec     *RepositorySpec
 	id       cpi.ConsumerIdentity
 	provider *ConsumerProvider
+	tokens   tokenCache
 }
 
 var (
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package artdesc
+
+import (
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+const (
+	// MediaTypeEmptyJSON is the media type of the empty config blob
+	// used for artifacts without config (OCI image spec 1.1).
+	MediaTypeEmptyJSON = "application/vnd.oci.empty.v1+json"
+)
+
+// EmptyJSON is the content of the empty config blob.
+var EmptyJSON = []byte("{}")
+
+// EmptyJSONBlob provides the empty config blob.
+func EmptyJSONBlob() blobaccess.BlobAccess {
+	return blobaccess.ForData(MediaTypeEmptyJSON, EmptyJSON)
+}
+
+// GetSubject provides the subject of an artifact, or nil,
+// if it has no subject.
+func GetSubject(a ArtifactDescriptor) *Descriptor {
+	if a.IsManifest() {
+		m, _ := a.Manifest()
+		return m.Subject
+	}
+	if a.IsIndex() {
+		i, _ := a.Index()
+		return i.Subject
+	}
+	return nil
+}
+
+// SetSubject sets the subject of an artifact.
+func SetSubject(a ArtifactDescriptor, subject *Descriptor) error {
+	if a.IsManifest() {
+		m, _ := a.Manifest()
+		m.Subject = subject
+		return nil
+	}
+	if a.IsIndex() {
+		i, _ := a.Index()
+		i.Subject = subject
+		return nil
+	}
+	return errors.ErrInvalid("oci artifact")
+}
+
+// GetArtifactType provides the artifact type of an artifact.
+// For manifests without explicit artifact type, the config media type
+// is used, as described by the OCI distribution spec for the
+// referrers API.
+func GetArtifactType(a ArtifactDescriptor) string {
+	if a.IsManifest() {
+		m, _ := a.Manifest()
+		if m.ArtifactType != "" {
+			return m.ArtifactType
+		}
+		return m.Config.MediaType
+	}
+	if a.IsIndex() {
+		i, _ := a.Index()
+		return i.ArtifactType
+	}
+	return ""
+}
+
+// GetAnnotations provides the annotations of an artifact.
+func GetAnnotations(a ArtifactDescriptor) map[string]string {
+	if a.IsManifest() {
+		m, _ := a.Manifest()
+		return m.Annotations
+	}
+	if a.IsIndex() {
+		i, _ := a.Index()
+		return i.Annotations
+	}
+	return nil
+}
+
+// ReferrerDescriptor provides the descriptor used to describe an artifact
+// with the given digest and blob size in a referrers list.
+func ReferrerDescriptor(a ArtifactDescriptor, dig digest.Digest, size int64) *Descriptor {
+	return &Descriptor{
+		MediaType:    a.Artifact().MimeType(),
+		Digest:       dig,
+		Size:         size,
+		ArtifactType: GetArtifactType(a),
+		Annotations:  GetAnnotations(a),
+	}
+}
+
+// ReferrersTag provides the tag used to store the referrers index
+// for a subject in registries not supporting the referrers API
+// (tag schema fallback of the OCI distribution spec 1.1).
+func ReferrersTag(subject digest.Digest) string {
+	tag := subject.Algorithm().String() + "-" + subject.Encoded()
+	if len(tag) > 128 {
+		tag = tag[:128]
+	}
+	return tag
+}
+
+// FilterReferrers filters a list of referrer descriptors
+// by an artifact type. An empty type matches all.
+func FilterReferrers(list []Descriptor, artifactType string) []Descriptor {
+	if artifactType == "" {
+		return list
+	}
+	var result []Descriptor
+	for _, d := range list {
+		if d.ArtifactType == artifactType {
+			result = append(result, d)
+		}
+	}
+	return result
+}
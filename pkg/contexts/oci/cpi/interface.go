@@ -40,6 +40,7 @@ type (
 	BlobSink                         = internal.BlobSink
 	NamespaceLister                  = internal.NamespaceLister
 	NamespaceAccess                  = internal.NamespaceAccess
+	ReferrersLister                  = internal.ReferrersLister
 	ManifestAccess                   = internal.ManifestAccess
 	IndexAccess                      = internal.IndexAccess
 	BlobAccess                       = internal.BlobAccess
@@ -72,6 +73,8 @@ func UniformRepositorySpecForHostURL(typ string, host string) *UniformRepository
 	return internal.UniformRepositorySpecForHostURL(typ, host)
 }
 
+var ErrReferrersNotSupported = internal.ErrReferrersNotSupported
+
 const (
 	KIND_OCIARTIFACT = internal.KIND_OCIARTIFACT
 	KIND_MEDIATYPE   = blobaccess.KIND_MEDIATYPE
@@ -115,3 +115,10 @@ func (i *namespaceAccessImpl) AddArtifact(artifact cpi.Artifact, tags ...string)
 func (i *namespaceAccessImpl) NewArtifact(arts ...cpi.Artifact) (cpi.ArtifactAccess, error) {
 	return i.NamespaceContainer.NewArtifact(i, arts...)
 }
+
+func (i *namespaceAccessImpl) ListReferrers(subject digest.Digest, artifactType string) ([]cpi.Descriptor, error) {
+	if l, ok := i.NamespaceContainer.(cpi.ReferrersLister); ok {
+		return l.ListReferrers(subject, artifactType)
+	}
+	return nil, cpi.ErrReferrersNotSupported
+}
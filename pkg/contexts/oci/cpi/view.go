@@ -250,6 +250,21 @@ func (n *namespaceAccessView) NewArtifact(artifact ...Artifact) (acc internal.Ar
 	return acc, err
 }
 
+// ListReferrers implements the optional interface ReferrersLister.
+// If the implementation does not support referrers,
+// ErrReferrersNotSupported is returned.
+func (n *namespaceAccessView) ListReferrers(subject digest.Digest, artifactType string) (list []artdesc.Descriptor, err error) {
+	l, ok := n.impl.(internal.ReferrersLister)
+	if !ok {
+		return nil, internal.ErrReferrersNotSupported
+	}
+	err = n.Execute(func() error {
+		list, err = l.ListReferrers(subject, artifactType)
+		return err
+	})
+	return list, err
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 type _ArtifactAccessView interface {
@@ -20,3 +20,7 @@ const (
 func ErrUnknownArtifact(name, version string) error {
 	return errors.ErrUnknown(KIND_OCIARTIFACT, fmt.Sprintf("%s:%s", name, version))
 }
+
+// ErrReferrersNotSupported is returned by a ReferrersLister, if
+// the underlying storage does not support the referrers API.
+var ErrReferrersNotSupported = errors.Newf("referrers API not supported")
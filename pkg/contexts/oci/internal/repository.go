@@ -83,6 +83,17 @@ type NamespaceAccess interface {
 	NamespaceAccessImpl
 }
 
+// ReferrersLister is an optional interface of a NamespaceAccess
+// supporting the discovery of artifacts referring to a subject
+// manifest (OCI distribution spec 1.1).
+type ReferrersLister interface {
+	// ListReferrers lists the descriptors of the manifests referring
+	// to the given subject, optionally restricted to an artifact type.
+	// If the storage does not support the referrers API,
+	// ErrReferrersNotSupported is returned.
+	ListReferrers(subject digest.Digest, artifactType string) ([]artdesc.Descriptor, error)
+}
+
 type Artifact artdesc.ArtifactDescriptor
 
 type ArtifactAccessImpl interface {
@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package referrers
+
+import (
+	"slices"
+
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/finalizer"
+)
+
+// Well-known artifact types used for referrers.
+const (
+	ARTIFACT_TYPE_OCM_SIGNATURE = "application/vnd.ocm.software.signature.v1+json"
+	ARTIFACT_TYPE_SPDX          = "application/spdx+json"
+	ARTIFACT_TYPE_CYCLONEDX     = "application/vnd.cyclonedx+json"
+	ARTIFACT_TYPE_INTOTO        = "application/vnd.in-toto+json"
+)
+
+// IsNativelySupported checks whether the namespace supports the
+// referrers API. Otherwise, the tag schema fallback is used.
+func IsNativelySupported(ns cpi.NamespaceAccess, subject digest.Digest) (bool, error) {
+	l, ok := ns.(cpi.ReferrersLister)
+	if !ok {
+		return false, nil
+	}
+	_, err := l.ListReferrers(subject, "")
+	if err != nil {
+		if errors.Is(err, cpi.ErrReferrersNotSupported) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// List lists the descriptors of all artifacts referring to the given
+// subject, optionally restricted to an artifact type. If the
+// namespace does not support the referrers API, the tag schema
+// fallback is used.
+func List(ns cpi.NamespaceAccess, subject digest.Digest, artifactType string) ([]cpi.Descriptor, error) {
+	if l, ok := ns.(cpi.ReferrersLister); ok {
+		list, err := l.ListReferrers(subject, artifactType)
+		if err == nil || !errors.Is(err, cpi.ErrReferrersNotSupported) {
+			return list, err
+		}
+	}
+	index, err := getFallbackIndex(ns, subject)
+	if err != nil || index == nil {
+		return nil, err
+	}
+	return artdesc.FilterReferrers(index.Manifests, artifactType), nil
+}
+
+// SubjectDescriptor provides the descriptor for an artifact
+// used as subject.
+func SubjectDescriptor(art cpi.ArtifactAccess) (*cpi.Descriptor, error) {
+	blob, err := art.Blob()
+	if err != nil {
+		return nil, err
+	}
+	defer blob.Close()
+	return artdesc.DefaultBlobDescriptor(blob), nil
+}
+
+// Attach adds the given artifact to the namespace, referring to the
+// given subject. The blobs of the artifact must already be added.
+// If the namespace does not support the referrers API, the referrers
+// index for the tag schema fallback is updated.
+func Attach(ns cpi.NamespaceAccess, subject *cpi.Descriptor, art cpi.Artifact, tags ...string) (*cpi.Descriptor, error) {
+	if subject == nil {
+		return nil, errors.ErrRequired("subject")
+	}
+	if err := artdesc.SetSubject(art, subject); err != nil {
+		return nil, err
+	}
+	blob, err := ns.AddArtifact(art, tags...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot add referrer")
+	}
+	defer blob.Close()
+	desc := artdesc.ReferrerDescriptor(art, blob.Digest(), blob.Size())
+	return desc, Register(ns, subject.Digest, desc)
+}
+
+// AttachBlob creates and attaches an artifact with the given artifact type
+// and an empty config, which describes a single blob (for example an SBOM
+// or signature).
+func AttachBlob(ns cpi.NamespaceAccess, subject *cpi.Descriptor, artifactType string, blob blobaccess.BlobAccess, annotations map[string]string) (*cpi.Descriptor, error) {
+	config := artdesc.EmptyJSONBlob()
+	if err := ns.AddBlob(config); err != nil {
+		return nil, errors.Wrapf(err, "cannot add config blob")
+	}
+	if err := ns.AddBlob(blob); err != nil {
+		return nil, errors.Wrapf(err, "cannot add referrer blob")
+	}
+	m := artdesc.NewManifest()
+	m.ArtifactType = artifactType
+	m.Config = *artdesc.DefaultBlobDescriptor(config)
+	m.Layers = []cpi.Descriptor{*artdesc.DefaultBlobDescriptor(blob)}
+	if len(annotations) > 0 {
+		m.Annotations = annotations
+	}
+	return Attach(ns, subject, m)
+}
+
+// Register registers an already added referrer for a subject. This is
+// only required for namespaces without referrers API support, where
+// the referrers index of the tag schema fallback is updated.
+func Register(ns cpi.NamespaceAccess, subject digest.Digest, desc *cpi.Descriptor) error {
+	ok, err := IsNativelySupported(ns, subject)
+	if err != nil || ok {
+		return err
+	}
+	index, err := getFallbackIndex(ns, subject)
+	if err != nil {
+		return err
+	}
+	if index == nil {
+		index = artdesc.NewIndex()
+	} else if slices.ContainsFunc(index.Manifests, func(d cpi.Descriptor) bool { return d.Digest == desc.Digest }) {
+		return nil
+	}
+	index.Manifests = append(index.Manifests, *desc)
+	blob, err := ns.AddArtifact(index, artdesc.ReferrersTag(subject))
+	if err != nil {
+		return errors.Wrapf(err, "cannot update referrers index for %s", subject)
+	}
+	return blob.Close()
+}
+
+func getFallbackIndex(ns cpi.NamespaceAccess, subject digest.Digest) (result *artdesc.Index, err error) {
+	var finalize finalizer.Finalizer
+	defer finalize.FinalizeWithErrorPropagation(&err)
+
+	tag := artdesc.ReferrersTag(subject)
+	ok, err := ns.HasArtifact(tag)
+	if err != nil || !ok {
+		return nil, err
+	}
+	art, err := ns.GetArtifact(tag)
+	if err != nil {
+		return nil, err
+	}
+	finalize.Close(art)
+	if !art.IsIndex() {
+		return nil, errors.Newf("referrers tag %s does not describe an index", tag)
+	}
+	index := *art.IndexAccess().GetDescriptor()
+	index.Manifests = slices.Clone(index.Manifests)
+	return &index, nil
+}
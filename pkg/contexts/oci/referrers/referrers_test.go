@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package referrers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/contexts/oci/testhelper"
+	. "github.com/open-component-model/ocm/pkg/env/builder"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/referrers"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/artifactset"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/transfer"
+	"github.com/open-component-model/ocm/pkg/finalizer"
+)
+
+const (
+	OCIPATH = "/tmp/oci"
+	ASPATH  = "/tmp/set"
+	SBOM    = `{"spdxVersion":"SPDX-2.3"}`
+)
+
+func attach(ns cpi.NamespaceAccess) (*cpi.Descriptor, *cpi.Descriptor) {
+	var finalize finalizer.Finalizer
+	defer Defer(finalize.Finalize)
+
+	art := Must(ns.GetArtifact(OCIVERSION))
+	finalize.Close(art)
+	subject := Must(referrers.SubjectDescriptor(art))
+	sbom := blobaccess.ForString(referrers.ARTIFACT_TYPE_SPDX, SBOM)
+	desc := Must(referrers.AttachBlob(ns, subject, referrers.ARTIFACT_TYPE_SPDX, sbom, map[string]string{"purpose": "test"}))
+	return subject, desc
+}
+
+func check(ns cpi.NamespaceAccess, subject, desc *cpi.Descriptor) {
+	list := Must(referrers.List(ns, subject.Digest, ""))
+	Expect(list).To(Equal([]cpi.Descriptor{*desc}))
+	Expect(list[0].ArtifactType).To(Equal(referrers.ARTIFACT_TYPE_SPDX))
+	Expect(list[0].Annotations).To(Equal(map[string]string{"purpose": "test"}))
+
+	Expect(Must(referrers.List(ns, subject.Digest, referrers.ARTIFACT_TYPE_SPDX))).To(HaveLen(1))
+	Expect(Must(referrers.List(ns, subject.Digest, referrers.ARTIFACT_TYPE_CYCLONEDX))).To(BeEmpty())
+
+	ref := Must(ns.GetArtifact(desc.Digest.String()))
+	defer Close(ref)
+	Expect(ref.Digest()).To(Equal(desc.Digest))
+	Expect(artdesc.GetSubject(ref.GetDescriptor())).To(Equal(subject))
+	m := ref.ManifestAccess().GetDescriptor()
+	Expect(m.Config.MediaType).To(Equal(artdesc.MediaTypeEmptyJSON))
+	blob := Must(ref.ManifestAccess().GetBlob(m.Layers[0].Digest))
+	defer Close(blob)
+	Expect(string(Must(blob.Get()))).To(Equal(SBOM))
+}
+
+var _ = Describe("referrers", func() {
+	var env *Builder
+
+	BeforeEach(func() {
+		env = NewBuilder()
+		env.OCICommonTransport(OCIPATH, accessio.FormatDirectory, func() {
+			OCIManifest1(env)
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("attaches and lists referrers in ctf", func() {
+		repo := Must(ctf.Open(env.OCIContext(), accessobj.ACC_WRITABLE, OCIPATH, 0, env))
+		defer Close(repo)
+		ns := Must(repo.LookupNamespace(OCINAMESPACE))
+		defer Close(ns)
+
+		Expect(Must(referrers.IsNativelySupported(ns, ""))).To(BeTrue())
+		subject, desc := attach(ns)
+		check(ns, subject, desc)
+		Expect(Must(ns.HasArtifact(artdesc.ReferrersTag(subject.Digest)))).To(BeFalse())
+
+		// attaching the same artifact again keeps a single entry
+		MustBeSuccessful(referrers.Register(ns, subject.Digest, desc))
+		Expect(Must(referrers.List(ns, subject.Digest, ""))).To(HaveLen(1))
+	})
+
+	It("uses the tag schema fallback", func() {
+		set := Must(artifactset.Create(accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, ASPATH, 0o700, accessio.FormatDirectory, env))
+		defer Close(set)
+
+		repo := Must(ctf.Open(env.OCIContext(), accessobj.ACC_READONLY, OCIPATH, 0, env))
+		defer Close(repo)
+		art := Must(repo.LookupArtifact(OCINAMESPACE, OCIVERSION))
+		defer Close(art)
+		MustBeSuccessful(transfer.TransferArtifact(art, set, OCIVERSION))
+
+		Expect(Must(referrers.IsNativelySupported(set, ""))).To(BeFalse())
+		subject, desc := attach(set)
+		check(set, subject, desc)
+		Expect(Must(set.HasArtifact(artdesc.ReferrersTag(subject.Digest)))).To(BeTrue())
+
+		MustBeSuccessful(referrers.Register(set, subject.Digest, desc))
+		Expect(Must(referrers.List(set, subject.Digest, ""))).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package artifactset_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/contexts/oci/testhelper"
+	. "github.com/open-component-model/ocm/pkg/env/builder"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/oci"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/artifactset"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/transfer/filters"
+	"github.com/open-component-model/ocm/pkg/finalizer"
+)
+
+const OCIPATH = "/tmp/oci"
+
+var _ = Describe("artifact synthesis", func() {
+	var env *Builder
+	var idesc *artdesc.Descriptor
+	var repo oci.Repository
+	var ns oci.NamespaceAccess
+	var art oci.ArtifactAccess
+
+	BeforeEach(func() {
+		env = NewBuilder()
+		env.OCICommonTransport(OCIPATH, accessio.FormatDirectory, func() {
+			idesc = OCIIndex1(env)
+		})
+		repo = Must(ctf.Open(env.OCIContext(), accessobj.ACC_READONLY, OCIPATH, 0, env))
+		ns = Must(repo.LookupNamespace(OCINAMESPACE3))
+		art = Must(ns.GetArtifact(OCIINDEXVERSION))
+	})
+
+	AfterEach(func() {
+		Close(art, "artifact")
+		Close(ns, "namespace")
+		Close(repo, "repository")
+		env.Cleanup()
+	})
+
+	mainArtifact := func(blob artifactset.ArtifactBlob) *artdesc.Artifact {
+		var finalize finalizer.Finalizer
+		defer Defer(finalize.Finalize)
+
+		set := Must(artifactset.OpenFromBlob(accessobj.ACC_READONLY, blob))
+		finalize.Close(set)
+		main := Must(set.GetArtifact(set.GetMain().String()))
+		finalize.Close(main)
+		return main.GetDescriptor()
+	}
+
+	It("keeps index media type", func() {
+		blob := Must(artifactset.SynthesizeArtifactBlobForArtifact(art, OCIINDEXVERSION))
+		defer Close(blob, "blob")
+
+		Expect(blob.MimeType()).To(Equal(artifactset.MediaType(artdesc.MediaTypeImageIndex)))
+		main := mainArtifact(blob)
+		Expect(main.IsIndex()).To(BeTrue())
+		Expect(main.Digest()).To(Equal(idesc.Digest))
+		Expect(Must(main.Index()).Manifests).To(HaveLen(3))
+	})
+
+	It("keeps index media type for filtered index", func() {
+		blob := Must(artifactset.SynthesizeArtifactBlobForArtifact(art, OCIINDEXVERSION, Must(filters.Platforms("linux/arm64", "darwin/arm64"))))
+		defer Close(blob, "blob")
+
+		Expect(blob.MimeType()).To(Equal(artifactset.MediaType(artdesc.MediaTypeImageIndex)))
+		main := mainArtifact(blob)
+		Expect(main.IsIndex()).To(BeTrue())
+		Expect(main.Digest()).NotTo(Equal(idesc.Digest))
+		Expect(Must(main.Index()).Manifests).To(HaveLen(2))
+	})
+
+	It("uses manifest media type for index reduced to a single manifest", func() {
+		blob := Must(artifactset.SynthesizeArtifactBlobForArtifact(art, OCIINDEXVERSION, Must(filters.Platforms("linux/amd64"))))
+		defer Close(blob, "blob")
+
+		Expect(blob.MimeType()).To(Equal(artifactset.MediaType(artdesc.MediaTypeImageManifest)))
+		main := mainArtifact(blob)
+		Expect(main.IsManifest()).To(BeTrue())
+	})
+})
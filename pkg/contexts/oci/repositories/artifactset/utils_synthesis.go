@@ -6,6 +6,7 @@ package artifactset
 
 import (
 	"fmt"
+	"strings"
 
 	. "github.com/open-component-model/ocm/pkg/finalizer"
 
@@ -110,10 +111,85 @@ func SynthesizeArtifactBlobForArtifact(art cpi.ArtifactAccess, ref string, filte
 
 		set.Annotate(MAINARTIFACT_ANNOTATION, dig.String())
 
+		if *dig != art.Digest() {
+			// the filter has modified the main artifact, a reduced index or
+			// a single remaining manifest, which determines the media type.
+			main, err := set.GetArtifact(dig.String())
+			if err != nil {
+				return "", err
+			}
+			defer main.Close()
+			return main.GetDescriptor().MimeType(), nil
+		}
 		return blob.MimeType(), nil
 	})
 }
 
+// IsArtifactSetMediaType checks whether the given media type
+// describes an artifact set archive blob.
+func IsArtifactSetMediaType(mime string) bool {
+	return artdesc.IsOCIMediaType(mime) && (strings.HasSuffix(mime, "+tar") || strings.HasSuffix(mime, "+tar+gzip"))
+}
+
+// FilterArtifactBlob provides an artifact set blob for the main artifact
+// of the given artifact set blob, which contains only the manifests of
+// the main index accepted by the filter. The index is rewritten to list
+// only the retained manifests. If the main artifact is no index or
+// all manifests are accepted, the original blob is returned and the
+// returned flag is false.
+func FilterArtifactBlob(blob blobaccess.BlobAccess, filter filters.Filter) (ArtifactBlob, bool, error) {
+	if filter == nil || !IsArtifactSetMediaType(blob.MimeType()) {
+		b, err := blob.Dup()
+		return b, false, err
+	}
+
+	var finalize Finalizer
+	defer finalize.Finalize()
+
+	set, err := OpenFromBlob(accessobj.ACC_READONLY, blob)
+	if err != nil {
+		return nil, false, err
+	}
+	finalize.Close(set)
+
+	main := set.GetMain()
+	if main == "" {
+		return nil, false, errors.Newf("artifact set without main artifact")
+	}
+	art, err := set.GetArtifact(main.String())
+	if err != nil {
+		return nil, false, err
+	}
+	finalize.Close(art)
+
+	if !art.IsIndex() {
+		b, err := blob.Dup()
+		return b, false, err
+	}
+	filtered := false
+	for _, d := range art.IndexAccess().GetDescriptor().Manifests {
+		nested, err := art.IndexAccess().GetArtifact(d.Digest)
+		if err != nil {
+			return nil, false, err
+		}
+		ok := filter.Accept(nested, d.Platform)
+		nested.Close()
+		if !ok {
+			filtered = true
+			break
+		}
+	}
+	if !filtered {
+		b, err := blob.Dup()
+		return b, false, err
+	}
+	result, err := SynthesizeArtifactBlobForArtifact(art, main.String(), filter)
+	if err != nil {
+		return nil, false, err
+	}
+	return result, true, nil
+}
+
 // ArtifactFactory add an artifact to the given set and provides descriptor metadata.
 type ArtifactFactory func(set *ArtifactSet) (digest.Digest, string, error)
 
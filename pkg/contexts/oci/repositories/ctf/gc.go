@@ -0,0 +1,214 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctf
+
+import (
+	"sort"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf/index"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+// ArtifactRef describes an artifact of a namespace of a CTF.
+type ArtifactRef struct {
+	Namespace string
+	Digest    digest.Digest
+}
+
+// BlobInfo describes a blob of a CTF.
+type BlobInfo struct {
+	Digest digest.Digest
+	Size   int64
+}
+
+// GCResult describes the outcome of a garbage collection.
+type GCResult struct {
+	// DryRun indicates that nothing has been deleted.
+	DryRun bool
+	// Dropped lists the artifacts removed from the index.
+	Dropped []ArtifactRef
+	// Orphaned lists the blobs not referenced by the index anymore.
+	// Without dry-run they have been deleted.
+	Orphaned []BlobInfo
+	// Missing lists the blobs referenced by the index, which are
+	// not present in the archive.
+	Missing []digest.Digest
+}
+
+// Size provides the accumulated size of the orphaned blobs.
+func (r *GCResult) Size() int64 {
+	size := int64(0)
+	for _, b := range r.Orphaned {
+		size += b.Size
+	}
+	return size
+}
+
+// GC removes all blobs not reachable from the artifact index.
+// Additionally, the given artifacts are dropped from the index before
+// the reachability is computed, together with all artifacts of the
+// namespace referring to them. In dry-run mode nothing is modified,
+// the result just reports what would be deleted.
+func (r *Repository) GC(dryRun bool, drop ...ArtifactRef) (*GCResult, error) {
+	if r.IsClosed() {
+		return nil, cpi.ErrClosed
+	}
+	return r.impl.gc(dryRun, drop...)
+}
+
+func (r *RepositoryImpl) gc(dryRun bool, drop ...ArtifactRef) (*GCResult, error) {
+	if !dryRun && r.IsReadOnly() {
+		return nil, accessio.ErrReadOnly
+	}
+
+	r.base.Lock()
+	defer r.base.Unlock()
+
+	result := &GCResult{DryRun: dryRun}
+	idx := r.getIndex()
+
+	dropped := map[ArtifactRef]bool{}
+	for _, d := range drop {
+		r.addDropped(idx, d, dropped, result)
+	}
+	if !dryRun {
+		for _, d := range result.Dropped {
+			idx.DeleteArtifactInfo(d.Namespace, d.Digest)
+		}
+	}
+
+	marks := &gcMarks{
+		artifacts: map[digest.Digest]bool{},
+		reachable: map[digest.Digest]bool{},
+		missing:   map[digest.Digest]bool{},
+	}
+	for _, m := range idx.GetDescriptor().Index {
+		if dropped[ArtifactRef{m.Repository, m.Digest}] {
+			continue
+		}
+		if err := r.markArtifact(m.Digest, marks); err != nil {
+			return nil, err
+		}
+	}
+
+	fs := r.base.Access().GetFileSystem()
+	dir := r.base.BlobPath("")
+	var entries []vfs.FileInfo
+	if ok, err := vfs.DirExists(fs, dir); ok {
+		entries, err = vfs.ReadDir(fs, dir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot read blob directory")
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		d := common.PathToDigest(e.Name())
+		if d == "" || marks.reachable[d] {
+			continue
+		}
+		result.Orphaned = append(result.Orphaned, BlobInfo{Digest: d, Size: e.Size()})
+		if !dryRun {
+			if err := fs.Remove(r.base.BlobPath(e.Name())); err != nil {
+				return nil, errors.Wrapf(err, "cannot delete blob %s", d)
+			}
+		}
+	}
+	for d := range marks.missing {
+		result.Missing = append(result.Missing, d)
+	}
+	sort.Slice(result.Missing, func(i, j int) bool { return result.Missing[i] < result.Missing[j] })
+	return result, nil
+}
+
+// addDropped marks an artifact and, recursively, all its referrers
+// in the same namespace as dropped.
+func (r *RepositoryImpl) addDropped(idx *index.RepositoryIndex, ref ArtifactRef, dropped map[ArtifactRef]bool, result *GCResult) {
+	if dropped[ref] || idx.GetArtifactInfo(ref.Namespace, "@"+ref.Digest.String()) == nil {
+		return
+	}
+	dropped[ref] = true
+	result.Dropped = append(result.Dropped, ref)
+
+	// tag schema fallback index for referrers
+	if m := idx.GetArtifactInfo(ref.Namespace, artdesc.ReferrersTag(ref.Digest)); m != nil {
+		r.addDropped(idx, ArtifactRef{ref.Namespace, m.Digest}, dropped, result)
+	}
+	for _, d := range idx.GetReferrers(ref.Namespace, ref.Digest) {
+		r.addDropped(idx, ArtifactRef{ref.Namespace, d}, dropped, result)
+	}
+}
+
+type gcMarks struct {
+	artifacts map[digest.Digest]bool
+	reachable map[digest.Digest]bool
+	missing   map[digest.Digest]bool
+}
+
+// markArtifact marks the blob of an artifact and all blobs
+// referenced by it as reachable.
+func (r *RepositoryImpl) markArtifact(d digest.Digest, marks *gcMarks) error {
+	if marks.artifacts[d] {
+		return nil
+	}
+	marks.artifacts[d] = true
+	_, acc, err := r.base.GetBlobData(d)
+	if err != nil {
+		if errors.IsErrNotFound(err) {
+			marks.missing[d] = true
+			return nil
+		}
+		return err
+	}
+	marks.reachable[d] = true
+	data, err := blobaccess.BlobData(acc)
+	if err != nil {
+		return errors.Wrapf(err, "cannot read artifact %s", d)
+	}
+	art, err := artdesc.Decode(data)
+	if err != nil {
+		return errors.Wrapf(err, "invalid artifact %s", d)
+	}
+	if art.IsIndex() {
+		idx, _ := art.Index()
+		for _, m := range idx.Manifests {
+			if err := r.markArtifact(m.Digest, marks); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	m, err := art.Manifest()
+	if err != nil {
+		return errors.Wrapf(err, "invalid artifact %s", d)
+	}
+	r.markBlob(m.Config.Digest, marks)
+	for _, l := range m.Layers {
+		r.markBlob(l.Digest, marks)
+	}
+	return nil
+}
+
+func (r *RepositoryImpl) markBlob(d digest.Digest, marks *gcMarks) {
+	if marks.reachable[d] || marks.missing[d] {
+		return
+	}
+	if ok, _ := vfs.FileExists(r.base.Access().GetFileSystem(), r.base.DigestPath(d)); ok {
+		marks.reachable[d] = true
+	} else {
+		marks.missing[d] = true
+	}
+}
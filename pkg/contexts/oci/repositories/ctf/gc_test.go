@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctf_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf/testhelper"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/oci"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/referrers"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/finalizer"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const NAMESPACE = "mandelsoft/test"
+
+var _ = Describe("ctf garbage collection", func() {
+	var fs vfs.FileSystem
+	var orphan blobaccess.BlobAccess
+	var sbom blobaccess.BlobAccess
+	var subject digest.Digest
+
+	blobPath := func(d digest.Digest) string {
+		return "test/" + ctf.BlobsDirectoryName + "/" + d.Algorithm().String() + "." + d.Encoded()
+	}
+
+	BeforeEach(func() {
+		var finalize finalizer.Finalizer
+		defer Defer(finalize.Finalize)
+
+		fs = memoryfs.New()
+		orphan = blobaccess.ForString(mime.MIME_TEXT, "orphaned")
+		sbom = blobaccess.ForString(mime.MIME_JSON, "{\"sbom\": true}")
+
+		r := Must(ctf.Create(oci.DefaultContext(), accessobj.ACC_CREATE, "test", 0o700, accessio.PathFileSystem(fs), accessobj.FormatDirectory))
+		finalize.Close(r)
+		n := Must(r.LookupNamespace(NAMESPACE))
+		finalize.Close(n)
+		DefaultManifestFill(n)
+		MustBeSuccessful(n.AddBlob(orphan))
+
+		art := Must(n.GetArtifact(TAG))
+		finalize.Close(art)
+		desc := Must(referrers.SubjectDescriptor(art))
+		subject = desc.Digest
+		Must(referrers.AttachBlob(n, desc, referrers.ARTIFACT_TYPE_SPDX, sbom, nil))
+	})
+
+	It("reports orphaned blobs in dry-run mode", func() {
+		r := Must(ctf.Open(oci.DefaultContext(), accessobj.ACC_READONLY, "test", 0, accessio.PathFileSystem(fs)))
+		defer Close(r)
+
+		result := Must(r.GC(true))
+		Expect(result.DryRun).To(BeTrue())
+		Expect(result.Orphaned).To(Equal([]ctf.BlobInfo{{Digest: orphan.Digest(), Size: orphan.Size()}}))
+		Expect(result.Size()).To(Equal(orphan.Size()))
+		Expect(result.Missing).To(BeEmpty())
+		Expect(vfs.FileExists(fs, blobPath(orphan.Digest()))).To(BeTrue())
+	})
+
+	It("rejects deletion for read-only archives", func() {
+		r := Must(ctf.Open(oci.DefaultContext(), accessobj.ACC_READONLY, "test", 0, accessio.PathFileSystem(fs)))
+		defer Close(r)
+
+		ExpectError(r.GC(false)).To(Equal(accessio.ErrReadOnly))
+	})
+
+	It("deletes orphaned blobs", func() {
+		r := Must(ctf.Open(oci.DefaultContext(), accessobj.ACC_WRITABLE, "test", 0, accessio.PathFileSystem(fs)))
+		result := Must(r.GC(false))
+		MustBeSuccessful(r.Close())
+
+		Expect(result.Orphaned).To(Equal([]ctf.BlobInfo{{Digest: orphan.Digest(), Size: orphan.Size()}}))
+		Expect(vfs.FileExists(fs, blobPath(orphan.Digest()))).To(BeFalse())
+		Expect(vfs.FileExists(fs, blobPath(sbom.Digest()))).To(BeTrue())
+		Expect(vfs.FileExists(fs, blobPath(subject))).To(BeTrue())
+		Expect(vfs.FileExists(fs, blobPath(digest.Digest("sha256:"+DIGEST_LAYER)))).To(BeTrue())
+		Expect(vfs.FileExists(fs, blobPath(digest.Digest("sha256:"+DIGEST_CONFIG)))).To(BeTrue())
+	})
+
+	It("drops artifacts together with their referrers", func() {
+		r := Must(ctf.Open(oci.DefaultContext(), accessobj.ACC_WRITABLE, "test", 0, accessio.PathFileSystem(fs)))
+		result := Must(r.GC(false, ctf.ArtifactRef{Namespace: NAMESPACE, Digest: subject}))
+		MustBeSuccessful(r.Close())
+
+		Expect(result.Dropped).To(HaveLen(2))
+		Expect(result.Dropped[0]).To(Equal(ctf.ArtifactRef{Namespace: NAMESPACE, Digest: subject}))
+		Expect(result.Orphaned).To(HaveLen(6))
+
+		r = Must(ctf.Open(oci.DefaultContext(), accessobj.ACC_READONLY, "test", 0, accessio.PathFileSystem(fs)))
+		defer Close(r)
+		Expect(r.ExistsArtifact(NAMESPACE, TAG)).To(BeFalse())
+		Expect(Must(r.NamespaceLister().GetNamespaces("", true))).To(BeEmpty())
+		entries := Must(vfs.ReadDir(fs, "test/"+ctf.BlobsDirectoryName))
+		Expect(entries).To(BeEmpty())
+	})
+})
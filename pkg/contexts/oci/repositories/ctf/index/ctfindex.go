@@ -22,6 +22,9 @@ type ArtifactMeta struct {
 	Repository string        `json:"repository"`
 	Tag        string        `json:"tag,omitempty"`
 	Digest     digest.Digest `json:"digest,omitempty"`
+	// Subject is the digest of the artifact this artifact refers to
+	// (OCI referrers).
+	Subject digest.Digest `json:"subject,omitempty"`
 }
 
 func Decode(data []byte) (*ArtifactIndex, error) {
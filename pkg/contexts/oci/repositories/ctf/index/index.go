@@ -98,6 +98,41 @@ func (r *RepositoryIndex) addArtifactInfo(m ArtifactMeta) {
 	}
 }
 
+// DeleteArtifactInfo removes all entries (digest and tags) of an artifact
+// from a repository. It reports whether the artifact has been found.
+func (r *RepositoryIndex) DeleteArtifactInfo(repo string, digest digest.Digest) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	repos := r.byRepository[repo]
+	if repos == nil {
+		return false
+	}
+	found := false
+	for t, m := range repos {
+		if m.Digest == digest {
+			delete(repos, t)
+			found = true
+		}
+	}
+	if len(repos) == 0 {
+		delete(r.byRepository, repo)
+	}
+
+	var list []*ArtifactMeta
+	for _, m := range r.byDigest[digest] {
+		if m.Repository != repo {
+			list = append(list, m)
+		}
+	}
+	if len(list) == 0 {
+		delete(r.byDigest, digest)
+	} else {
+		r.byDigest[digest] = list
+	}
+	return found
+}
+
 func (r *RepositoryIndex) HasArtifact(repo, tag string) bool {
 	r.lock.RLock()
 	defer r.lock.RUnlock()
@@ -152,6 +187,24 @@ func (r *RepositoryIndex) GetArtifacts(repo string) []string {
 	return result
 }
 
+// GetReferrers provides the digests of all artifacts of a repository
+// referring to the given subject.
+func (r *RepositoryIndex) GetReferrers(repo string, subject digest.Digest) []digest.Digest {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var result []digest.Digest
+	found := map[digest.Digest]bool{}
+	for _, m := range r.byRepository[repo] {
+		if m.Subject == subject && !found[m.Digest] {
+			found[m.Digest] = true
+			result = append(result, m.Digest)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
 func (r *RepositoryIndex) GetArtifactInfos(digest digest.Digest) []*ArtifactMeta {
 	r.lock.RLock()
 	defer r.lock.RUnlock()
@@ -211,6 +264,7 @@ func (r *RepositoryIndex) GetDescriptor() *ArtifactIndex {
 					Repository: vers.Repository,
 					Tag:        vers.Tag,
 					Digest:     vers.Digest,
+					Subject:    vers.Subject,
 				}
 				index.Index = append(index.Index, *d)
 			}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/open-component-model/ocm/pkg/blobaccess"
 	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
 	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
 	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi/support"
 	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf/index"
@@ -84,14 +85,47 @@ func (n *namespaceContainer) AddArtifact(artifact cpi.Artifact, tags ...string)
 	if err != nil {
 		return nil, err
 	}
-	n.repo.getIndex().AddArtifactInfo(&index.ArtifactMeta{
+	meta := &index.ArtifactMeta{
 		Repository: n.impl.GetNamespace(),
 		Tag:        "",
 		Digest:     blob.Digest(),
-	})
+	}
+	// use the stored blob, the artifact may be any kind of
+	// artifact descriptor implementation.
+	data, err := blob.Get()
+	if err != nil {
+		return nil, err
+	}
+	if art, err := artdesc.Decode(data); err == nil {
+		if subject := artdesc.GetSubject(art); subject != nil {
+			meta.Subject = subject.Digest
+		}
+	}
+	n.repo.getIndex().AddArtifactInfo(meta)
 	return blob, n.AddTags(blob.Digest(), tags...)
 }
 
+// ListReferrers uses the subject relations stored in the CTF index.
+func (n *namespaceContainer) ListReferrers(subject digest.Digest, artifactType string) ([]cpi.Descriptor, error) {
+	var result []cpi.Descriptor
+	for _, d := range n.repo.getIndex().GetReferrers(n.impl.GetNamespace(), subject) {
+		_, acc, err := n.repo.base.GetBlobData(d)
+		if err != nil {
+			return nil, err
+		}
+		data, err := blobaccess.BlobData(acc)
+		if err != nil {
+			return nil, err
+		}
+		art, err := artdesc.Decode(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid referrer %s", d)
+		}
+		result = append(result, *artdesc.ReferrerDescriptor(art, d, int64(len(data))))
+	}
+	return artdesc.FilterReferrers(result, artifactType), nil
+}
+
 func (n *namespaceContainer) AddTags(digest digest.Digest, tags ...string) error {
 	return n.repo.getIndex().AddTagsFor(n.impl.GetNamespace(), digest, tags...)
 }
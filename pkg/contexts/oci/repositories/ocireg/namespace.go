@@ -24,14 +24,15 @@ import (
 )
 
 type NamespaceContainer struct {
-	impl     support.NamespaceAccessImpl
-	repo     *RepositoryImpl
-	resolver resolve.Resolver
-	lister   resolve.Lister
-	fetcher  resolve.Fetcher
-	pusher   resolve.Pusher
-	blobs    *BlobContainers
-	checked  bool
+	impl      support.NamespaceAccessImpl
+	repo      *RepositoryImpl
+	resolver  resolve.Resolver
+	lister    resolve.Lister
+	referrers resolve.ReferrersLister
+	fetcher   resolve.Fetcher
+	pusher    resolve.Pusher
+	blobs     *BlobContainers
+	checked   bool
 }
 
 var _ support.NamespaceContainer = (*NamespaceContainer)(nil)
@@ -54,13 +55,18 @@ func NewNamespace(repo *RepositoryImpl, name string) (cpi.NamespaceAccess, error
 	if err != nil {
 		return nil, err
 	}
+	referrers, err := resolver.Referrers(context.Background(), ref)
+	if err != nil {
+		return nil, err
+	}
 	c := &NamespaceContainer{
-		repo:     repo,
-		resolver: resolver,
-		lister:   lister,
-		fetcher:  fetcher,
-		pusher:   pusher,
-		blobs:    NewBlobContainers(repo.GetContext(), fetcher, pusher),
+		repo:      repo,
+		resolver:  resolver,
+		lister:    lister,
+		referrers: referrers,
+		fetcher:   fetcher,
+		pusher:    pusher,
+		blobs:     NewBlobContainers(repo.GetContext(), fetcher, pusher),
 	}
 	return support.NewNamespaceAccess(name, c, repo)
 }
@@ -147,6 +153,18 @@ func (n *NamespaceContainer) ListTags() ([]string, error) {
 	return n.lister.List(dummyContext)
 }
 
+func (n *NamespaceContainer) ListReferrers(subject digest.Digest, artifactType string) ([]cpi.Descriptor, error) {
+	n.repo.GetContext().Logger().Debug("list referrers", "subject", subject, "artifactType", artifactType)
+	list, err := n.referrers.ListReferrers(dummyContext, subject, artifactType)
+	if err != nil {
+		if errors.Is(err, resolve.ErrReferrersNotSupported) {
+			return nil, cpi.ErrReferrersNotSupported
+		}
+		return nil, err
+	}
+	return list, nil
+}
+
 func (n *NamespaceContainer) GetArtifact(i support.NamespaceAccessImpl, vers string) (cpi.ArtifactAccess, error) {
 	ref := n.repo.GetRef(n.impl.GetNamespace(), vers)
 	n.repo.GetContext().Logger().Debug("get artifact", "ref", ref)
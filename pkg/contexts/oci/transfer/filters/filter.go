@@ -6,11 +6,13 @@ package filters
 
 import (
 	"encoding/json"
+	"strings"
 
 	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
 
 	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
 	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
 	"github.com/open-component-model/ocm/pkg/utils"
 )
 
@@ -131,3 +133,29 @@ func (f *platform) Accept(art cpi.ArtifactAccess, platform *artdesc.Platform) bo
 	}
 	return true
 }
+
+// ParsePlatform parses a platform specification of the form
+// <os>/<architecture> into a platform filter.
+// An empty os or architecture matches any value.
+func ParsePlatform(spec string) (Filter, error) {
+	p := strings.Split(strings.TrimSpace(spec), "/")
+	if len(p) != 2 || (p[0] == "" && p[1] == "") {
+		return nil, errors.ErrInvalid("platform", spec)
+	}
+	return Platform(p[0], p[1]), nil
+}
+
+// Platforms provides a filter accepting artifacts for any of the
+// given platform specifications (see ParsePlatform).
+// If no platform is given, nil is returned.
+func Platforms(specs ...string) (Filter, error) {
+	var list []Filter
+	for _, s := range specs {
+		f, err := ParsePlatform(s)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, f)
+	}
+	return Or(list...), nil
+}
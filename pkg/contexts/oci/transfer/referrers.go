@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transfer
+
+import (
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/referrers"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/finalizer"
+	"github.com/open-component-model/ocm/pkg/logging"
+)
+
+// TransferReferrers transfers all artifacts referring to the given subject
+// (for example signatures, SBOMs or attestations) from the source namespace
+// to the target namespace. Referrers of referrers are transferred, also.
+// If the target does not support the referrers API, the referrers index
+// of the tag schema fallback is maintained.
+func TransferReferrers(src, tgt cpi.NamespaceAccess, subject digest.Digest) error {
+	return transferReferrers(src, tgt, subject, map[digest.Digest]bool{})
+}
+
+func transferReferrers(src, tgt cpi.NamespaceAccess, subject digest.Digest, done map[digest.Digest]bool) (err error) {
+	if done[subject] {
+		return nil
+	}
+	done[subject] = true
+
+	list, err := referrers.List(src, subject, "")
+	if err != nil {
+		return errors.Wrapf(err, "listing referrers for %s", subject)
+	}
+
+	var finalize finalizer.Finalizer
+	defer finalize.FinalizeWithErrorPropagation(&err)
+
+	for _, d := range list {
+		loop := finalize.Nested()
+		logging.Logger().Debug("transfer OCI referrer", "subject", subject, "digest", d.Digest, "artifactType", d.ArtifactType)
+		art, err := src.GetArtifact(d.Digest.String())
+		if err != nil {
+			return errors.Wrapf(err, "getting referrer %s", d.Digest)
+		}
+		loop.Close(art)
+		err = TransferArtifact(art, tgt)
+		if err != nil {
+			return errors.Wrapf(err, "transferring referrer %s", d.Digest)
+		}
+		err = referrers.Register(tgt, subject, &d)
+		if err != nil {
+			return err
+		}
+		err = transferReferrers(src, tgt, d.Digest, done)
+		if err != nil {
+			return err
+		}
+		err = loop.Finalize()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
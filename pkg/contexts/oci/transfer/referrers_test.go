@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transfer_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/contexts/oci/testhelper"
+	. "github.com/open-component-model/ocm/pkg/env/builder"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/referrers"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/artifactset"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/transfer"
+)
+
+var _ = Describe("transfer OCI referrers", func() {
+	var env *Builder
+	var subject, sbom, sig *cpi.Descriptor
+
+	BeforeEach(func() {
+		env = NewBuilder()
+		env.OCICommonTransport(OCIPATH, accessio.FormatDirectory, func() {
+			OCIManifest1(env)
+		})
+
+		repo := Must(ctf.Open(env.OCIContext(), accessobj.ACC_WRITABLE, OCIPATH, 0, env))
+		defer Close(repo)
+		ns := Must(repo.LookupNamespace(OCINAMESPACE))
+		defer Close(ns)
+		art := Must(ns.GetArtifact(OCIVERSION))
+		defer Close(art)
+
+		subject = Must(referrers.SubjectDescriptor(art))
+		sbom = Must(referrers.AttachBlob(ns, subject, referrers.ARTIFACT_TYPE_SPDX, blobaccess.ForString(referrers.ARTIFACT_TYPE_SPDX, "{}"), nil))
+		// signature of the SBOM
+		sig = Must(referrers.AttachBlob(ns, sbom, referrers.ARTIFACT_TYPE_OCM_SIGNATURE, blobaccess.ForString(referrers.ARTIFACT_TYPE_OCM_SIGNATURE, "signature"), nil))
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	transferTo := func(tgt cpi.NamespaceAccess) {
+		repo := Must(ctf.Open(env.OCIContext(), accessobj.ACC_READONLY, OCIPATH, 0, env))
+		defer Close(repo)
+		ns := Must(repo.LookupNamespace(OCINAMESPACE))
+		defer Close(ns)
+		art := Must(ns.GetArtifact(OCIVERSION))
+		defer Close(art)
+
+		MustBeSuccessful(transfer.TransferArtifact(art, tgt, OCIVERSION))
+		MustBeSuccessful(transfer.TransferReferrers(ns, tgt, art.Digest()))
+
+		Expect(Must(referrers.List(tgt, subject.Digest, ""))).To(Equal([]cpi.Descriptor{*sbom}))
+		Expect(Must(referrers.List(tgt, sbom.Digest, ""))).To(Equal([]cpi.Descriptor{*sig}))
+	}
+
+	It("transfers referrers to ctf", func() {
+		tgt := Must(ctf.Create(env.OCIContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+		defer Close(tgt, "target")
+		ns := Must(tgt.LookupNamespace(OCINAMESPACE))
+		defer Close(ns, "target namespace")
+
+		transferTo(ns)
+		Expect(Must(ns.HasArtifact(artdesc.ReferrersTag(subject.Digest)))).To(BeFalse())
+	})
+
+	It("transfers referrers to artifact set using the tag schema fallback", func() {
+		set := Must(artifactset.Create(accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+		defer Close(set, "target")
+
+		transferTo(set)
+		Expect(Must(set.HasArtifact(artdesc.ReferrersTag(subject.Digest)))).To(BeTrue())
+		Expect(Must(set.HasArtifact(artdesc.ReferrersTag(sbom.Digest)))).To(BeTrue())
+	})
+})
@@ -184,5 +184,40 @@ var _ = Describe("transfer OCI artifacts", func() {
 			data := Must(blob.Get())
 			Expect(string(data)).To(Equal(OCILAYER))
 		})
+
+		It("transfers index for platform list", func() {
+			var finalize finalizer.Finalizer
+			defer Defer(finalize.Finalize)
+
+			src := Must(ctf.Open(env.OCIContext(), accessobj.ACC_READONLY, OCIPATH, 0, env))
+			finalize.Close(src, "source")
+			art := Must(src.LookupArtifact(OCINAMESPACE3, OCIINDEXVERSION))
+			finalize.Close(art, "source artifact")
+
+			tgt := Must(ctf.Create(env.OCIContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0700, accessio.FormatDirectory, env))
+			defer Close(tgt, "target")
+			ns := Must(tgt.LookupNamespace(OCINAMESPACE3))
+			defer Close(ns, "target namespace")
+
+			filter := Must(filters.Platforms("linux/amd64", "darwin/arm64"))
+			dig := Must(transfer.TransferArtifactWithFilter(art, ns, filter, OCIINDEXVERSION))
+			Expect(*dig).NotTo(Equal(idesc.Digest))
+
+			MustBeSuccessful(finalize.Finalize())
+
+			tart := Must(ns.GetArtifact(OCIINDEXVERSION))
+			defer Close(tart, "target index artifact")
+
+			Expect(tart.Digest()).To(Equal(*dig))
+			Expect(tart.IsIndex()).To(BeTrue())
+			manifests := tart.IndexAccess().GetDescriptor().Manifests
+			Expect(len(manifests)).To(Equal(2))
+			Expect(manifests[0].Platform).To(Equal(&artdesc.Platform{OS: "linux", Architecture: "amd64"}))
+			Expect(manifests[1].Platform).To(Equal(&artdesc.Platform{OS: "darwin", Architecture: "arm64"}))
+		})
+
+		It("rejects invalid platforms", func() {
+			ExpectError(filters.Platforms("linux/amd64", "linux")).To(MatchError("platform \"linux\" is invalid"))
+		})
 	})
 })
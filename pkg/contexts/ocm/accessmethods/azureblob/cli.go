@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azureblob
+
+import (
+	"github.com/open-component-model/ocm/pkg/cobrautils/flagsets"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/azureblob/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/options"
+)
+
+func ConfigHandler() flagsets.ConfigOptionTypeSetHandler {
+	return flagsets.NewConfigOptionTypeSetHandler(
+		Type, AddConfig,
+		options.AccountOption,
+		options.ContainerOption,
+		options.ReferenceOption,
+		options.EndpointOption,
+		options.MediatypeOption,
+	)
+}
+
+func AddConfig(opts flagsets.ConfigOptions, config flagsets.Config) error {
+	flagsets.AddFieldByOptionP(opts, options.AccountOption, config, "accountName")
+	flagsets.AddFieldByOptionP(opts, options.ContainerOption, config, "container")
+	flagsets.AddFieldByOptionP(opts, options.ReferenceOption, config, "blobName")
+	flagsets.AddFieldByOptionP(opts, options.EndpointOption, config, "endpoint")
+	flagsets.AddFieldByOptionP(opts, options.MediatypeOption, config, "mediaType")
+	return nil
+}
+
+var usage = `
+This method implements the access of a blob stored in a container
+of an Azure Blob Storage account.
+If the blob service provides an MD5 hash for the blob, the downloaded
+content is verified.
+`
+
+var formatV1 = `
+The type specific specification fields are:
+
+- **<code>accountName</code>** *string*
+
+  The name of the storage account.
+
+- **<code>container</code>** *string*
+
+  The name of the container.
+
+- **<code>blobName</code>** *string*
+
+  The name of the blob in the container.
+
+- **<code>endpoint</code>** (optional) *string*
+
+  The blob service URL. By default, the public endpoint
+  <code>https://&lt;accountName>.blob.core.windows.net</code> is used.
+  For emulators like Azurite using path-style URLs, the account name must
+  be part of the URL (for example <code>http://127.0.0.1:10000/devstoreaccount1</code>).
+
+- **<code>mediaType</code>** (optional) *string*
+
+  The media type of the content (default <code>application/octet-stream</code>).
+
+It uses the consumer identity type ` + identity.CONSUMER_TYPE + ` with the fields
+for a hostpath identity matcher (see <CMD>ocm get credentials</CMD>).`
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azureblob
+
+import (
+	"fmt"
+
+	"github.com/open-component-model/ocm/pkg/azureblob"
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/azureblob/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi/accspeccpi"
+	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+// Type is the access type of Azure Blob Storage.
+const (
+	Type   = "azureblob"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	accspeccpi.RegisterAccessType(accspeccpi.NewAccessSpecType[*AccessSpec](Type, accspeccpi.WithDescription(usage)))
+	accspeccpi.RegisterAccessType(accspeccpi.NewAccessSpecType[*AccessSpec](TypeV1, accspeccpi.WithFormatSpec(formatV1), accspeccpi.WithConfigHandler(ConfigHandler())))
+}
+
+func Is(spec accspeccpi.AccessSpec) bool {
+	return spec != nil && spec.GetKind() == Type
+}
+
+// AccessSpec describes the access for a blob stored in a container
+// of an Azure storage account.
+type AccessSpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+
+	// AccountName is the name of the storage account.
+	AccountName string `json:"accountName"`
+	// Container is the name of the container.
+	Container string `json:"container"`
+	// BlobName is the name of the blob in the container.
+	BlobName string `json:"blobName"`
+	// Endpoint is an optional blob service URL used instead of the
+	// public Azure endpoint of the storage account.
+	Endpoint string `json:"endpoint,omitempty"`
+	// MediaType is the optional media type of the blob.
+	MediaType string `json:"mediaType,omitempty"`
+}
+
+var _ accspeccpi.AccessSpec = (*AccessSpec)(nil)
+
+// New creates a new Azure Blob Storage access spec version v1.
+func New(account, container, blob, endpoint, mediaType string) *AccessSpec {
+	return &AccessSpec{
+		ObjectVersionedType: runtime.NewVersionedTypedObject(Type),
+		AccountName:         account,
+		Container:           container,
+		BlobName:            blob,
+		Endpoint:            endpoint,
+		MediaType:           mediaType,
+	}
+}
+
+func (a *AccessSpec) Describe(_ accspeccpi.Context) string {
+	return fmt.Sprintf("Azure blob %s in container %s of account %s", a.BlobName, a.Container, a.AccountName)
+}
+
+func (_ *AccessSpec) IsLocal(accspeccpi.Context) bool {
+	return false
+}
+
+func (a *AccessSpec) GlobalAccessSpec(_ accspeccpi.Context) accspeccpi.AccessSpec {
+	return a
+}
+
+func (a *AccessSpec) GetReferenceHint(_ accspeccpi.ComponentVersionAccess) string {
+	return a.BlobName
+}
+
+func (_ *AccessSpec) GetType() string {
+	return Type
+}
+
+func (a *AccessSpec) AccessMethod(c accspeccpi.ComponentVersionAccess) (accspeccpi.AccessMethod, error) {
+	return accspeccpi.AccessMethodForImplementation(newMethod(c, a))
+}
+
+func (a *AccessSpec) GetInexpensiveContentVersionIdentity(access accspeccpi.ComponentVersionAccess) string {
+	client, err := a.client(access.GetContext())
+	if err != nil {
+		return ""
+	}
+	props, err := client.GetProperties(a.Container, a.BlobName)
+	if err != nil {
+		return ""
+	}
+	return props.ContentMD5
+}
+
+// ServiceURL provides the effective blob service URL.
+func (a *AccessSpec) ServiceURL() string {
+	return azureblob.ServiceURL(a.AccountName, a.Endpoint)
+}
+
+func (a *AccessSpec) client(ctx accspeccpi.Context) (*azureblob.Client, error) {
+	creds := identity.GetCredentials(ctx, a.ServiceURL(), a.Container, a.BlobName)
+	return azureblob.NewClient(a.AccountName, a.Endpoint, creds)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func newMethod(c accspeccpi.ComponentVersionAccess, a *AccessSpec) (accspeccpi.AccessMethodImpl, error) {
+	if a.Container == "" || a.BlobName == "" {
+		return nil, fmt.Errorf("container and blob name required")
+	}
+	client, err := a.client(c.GetContext())
+	if err != nil {
+		return nil, err
+	}
+	mediaType := a.MediaType
+	if mediaType == "" {
+		mediaType = mime.MIME_OCTET
+	}
+	factory := func() (blobaccess.BlobAccess, error) {
+		return client.Download(a.Container, a.BlobName, mediaType)
+	}
+	return &accessMethod{
+		AccessMethodImpl: accspeccpi.NewDefaultMethodImpl(c, a, "", mediaType, factory),
+		spec:             a,
+	}, nil
+}
+
+type accessMethod struct {
+	accspeccpi.AccessMethodImpl
+	spec *AccessSpec
+}
+
+func (m *accessMethod) GetConsumerId(uctx ...credentials.UsageContext) credentials.ConsumerIdentity {
+	return identity.GetConsumerId(m.spec.ServiceURL(), m.spec.Container, m.spec.BlobName)
+}
+
+func (m *accessMethod) GetIdentityMatcher() string {
+	return identity.CONSUMER_TYPE
+}
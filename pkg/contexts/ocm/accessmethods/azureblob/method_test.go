@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azureblob_test
+
+import (
+	"crypto/md5" //nolint:gosec // used by the blob service
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/azureblob/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	me "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/azureblob"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+const (
+	ACCOUNT   = "devstoreaccount1"
+	CONTAINER = "blobs"
+	BLOB      = "dir/test.txt"
+	CONTENT   = "azure content"
+	KEY       = "a2V5"
+)
+
+var _ = Describe("Method", func() {
+	var (
+		ctx    ocm.Context
+		server *httptest.Server
+		md5sum string
+		auth   string
+	)
+
+	BeforeEach(func() {
+		ctx = ocm.New()
+		sum := md5.Sum([]byte(CONTENT)) //nolint:gosec // see above
+		md5sum = base64.StdEncoding.EncodeToString(sum[:])
+		auth = ""
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			auth = r.Header.Get("Authorization")
+			if r.URL.Path != "/"+ACCOUNT+"/"+CONTAINER+"/"+BLOB {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", mime.MIME_TEXT)
+			w.Header().Set("Content-MD5", md5sum)
+			w.WriteHeader(http.StatusOK)
+			if r.Method == http.MethodGet {
+				w.Write([]byte(CONTENT))
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("serializes spec", func() {
+		spec := me.New(ACCOUNT, CONTAINER, BLOB, "", mime.MIME_TEXT)
+		data := Must(runtime.DefaultJSONEncoding.Marshal(spec))
+		Expect(string(data)).To(StringEqualWithContext(`{"type":"azureblob","accountName":"devstoreaccount1","container":"blobs","blobName":"dir/test.txt","mediaType":"text/plain"}`))
+		Expect(spec.ServiceURL()).To(Equal("https://devstoreaccount1.blob.core.windows.net"))
+	})
+
+	It("accesses blob", func() {
+		spec := me.New(ACCOUNT, CONTAINER, BLOB, server.URL+"/"+ACCOUNT, mime.MIME_TEXT)
+
+		Expect(spec.GetInexpensiveContentVersionIdentity(&cpi.DummyComponentVersionAccess{Context: ctx})).To(Equal(md5sum))
+		m := Must(spec.AccessMethod(&cpi.DummyComponentVersionAccess{Context: ctx}))
+		defer Close(m)
+		Expect(m.MimeType()).To(Equal(mime.MIME_TEXT))
+		Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+		Expect(auth).To(Equal(""))
+	})
+
+	It("uses shared key authorization", func() {
+		spec := me.New(ACCOUNT, CONTAINER, BLOB, server.URL+"/"+ACCOUNT, "")
+		ctx.CredentialsContext().SetCredentialsForConsumer(
+			identity.GetConsumerId(spec.ServiceURL(), CONTAINER, ""),
+			credentials.DirectCredentials{identity.ATTR_ACCOUNT_KEY: KEY},
+		)
+
+		m := Must(spec.AccessMethod(&cpi.DummyComponentVersionAccess{Context: ctx}))
+		defer Close(m)
+		Expect(m.MimeType()).To(Equal(mime.MIME_OCTET))
+		Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+		Expect(strings.HasPrefix(auth, "SharedKey "+ACCOUNT+":")).To(BeTrue())
+	})
+
+	It("detects checksum mismatch", func() {
+		md5sum = base64.StdEncoding.EncodeToString([]byte("other"))
+		spec := me.New(ACCOUNT, CONTAINER, BLOB, server.URL+"/"+ACCOUNT, "")
+
+		m := Must(spec.AccessMethod(&cpi.DummyComponentVersionAccess{Context: ctx}))
+		defer Close(m)
+		_, err := m.Get()
+		Expect(err).To(MatchError(ContainSubstring("MD5 mismatch for blob dir/test.txt in container blobs")))
+	})
+
+	It("provides consumer id", func() {
+		spec := me.New(ACCOUNT, CONTAINER, BLOB, "", "")
+		m := Must(spec.AccessMethod(&cpi.DummyComponentVersionAccess{Context: ctx}))
+		defer Close(m)
+		Expect(credentials.GetProvidedConsumerId(m)).To(Equal(credentials.ConsumerIdentity{
+			"type":       identity.CONSUMER_TYPE,
+			"hostname":   "devstoreaccount1.blob.core.windows.net",
+			"scheme":     "https",
+			"port":       "443",
+			"pathprefix": "blobs/dir/test.txt",
+		}))
+	})
+})
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gcs
+
+import (
+	"github.com/open-component-model/ocm/pkg/cobrautils/flagsets"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/gcs/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/options"
+	"github.com/open-component-model/ocm/pkg/gcs"
+)
+
+func ConfigHandler() flagsets.ConfigOptionTypeSetHandler {
+	return flagsets.NewConfigOptionTypeSetHandler(
+		Type, AddConfig,
+		options.BucketOption,
+		options.ReferenceOption,
+		options.VersionOption,
+		options.EndpointOption,
+		options.MediatypeOption,
+	)
+}
+
+func AddConfig(opts flagsets.ConfigOptions, config flagsets.Config) error {
+	flagsets.AddFieldByOptionP(opts, options.BucketOption, config, "bucket")
+	flagsets.AddFieldByOptionP(opts, options.ReferenceOption, config, "object")
+	flagsets.AddFieldByOptionP(opts, options.VersionOption, config, "generation")
+	flagsets.AddFieldByOptionP(opts, options.EndpointOption, config, "endpoint")
+	flagsets.AddFieldByOptionP(opts, options.MediatypeOption, config, "mediaType")
+	return nil
+}
+
+var usage = `
+This method implements the access of an object stored in a
+Google Cloud Storage bucket.
+If the storage service provides an MD5 hash for the object, the downloaded
+content is verified.
+`
+
+var formatV1 = `
+The type specific specification fields are:
+
+- **<code>bucket</code>** *string*
+
+  The name of the bucket.
+
+- **<code>object</code>** *string*
+
+  The name of the object in the bucket.
+
+- **<code>generation</code>** (optional) *string*
+
+  The generation of the object. By default, the latest generation is used.
+
+- **<code>endpoint</code>** (optional) *string*
+
+  The storage endpoint (default <code>` + gcs.DEFAULT_ENDPOINT + `</code>).
+  Any server implementing the Google Cloud Storage JSON API, for example
+  the fake-gcs-server, can be used.
+
+- **<code>mediaType</code>** (optional) *string*
+
+  The media type of the content (default <code>application/octet-stream</code>).
+
+It uses the consumer identity type ` + identity.CONSUMER_TYPE + ` with the fields
+for a hostpath identity matcher (see <CMD>ocm get credentials</CMD>).`
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gcs
+
+import (
+	"fmt"
+
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/gcs/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi/accspeccpi"
+	"github.com/open-component-model/ocm/pkg/gcs"
+	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+// Type is the access type of Google Cloud Storage.
+const (
+	Type   = "gcs"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	accspeccpi.RegisterAccessType(accspeccpi.NewAccessSpecType[*AccessSpec](Type, accspeccpi.WithDescription(usage)))
+	accspeccpi.RegisterAccessType(accspeccpi.NewAccessSpecType[*AccessSpec](TypeV1, accspeccpi.WithFormatSpec(formatV1), accspeccpi.WithConfigHandler(ConfigHandler())))
+}
+
+func Is(spec accspeccpi.AccessSpec) bool {
+	return spec != nil && spec.GetKind() == Type
+}
+
+// AccessSpec describes the access for an object stored in a
+// Google Cloud Storage bucket.
+type AccessSpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+
+	// Bucket is the name of the bucket.
+	Bucket string `json:"bucket"`
+	// Object is the name of the object in the bucket.
+	Object string `json:"object"`
+	// Generation is the optional generation of the object.
+	Generation string `json:"generation,omitempty"`
+	// Endpoint is an optional storage endpoint used instead of the
+	// public Google Cloud Storage endpoint.
+	Endpoint string `json:"endpoint,omitempty"`
+	// MediaType is the optional media type of the object.
+	MediaType string `json:"mediaType,omitempty"`
+}
+
+var _ accspeccpi.AccessSpec = (*AccessSpec)(nil)
+
+// New creates a new Google Cloud Storage access spec version v1.
+func New(bucket, object, generation, endpoint, mediaType string) *AccessSpec {
+	return &AccessSpec{
+		ObjectVersionedType: runtime.NewVersionedTypedObject(Type),
+		Bucket:              bucket,
+		Object:              object,
+		Generation:          generation,
+		Endpoint:            endpoint,
+		MediaType:           mediaType,
+	}
+}
+
+func (a *AccessSpec) Describe(_ accspeccpi.Context) string {
+	return fmt.Sprintf("GCS object %s in bucket %s", a.Object, a.Bucket)
+}
+
+func (_ *AccessSpec) IsLocal(accspeccpi.Context) bool {
+	return false
+}
+
+func (a *AccessSpec) GlobalAccessSpec(_ accspeccpi.Context) accspeccpi.AccessSpec {
+	return a
+}
+
+func (a *AccessSpec) GetReferenceHint(_ accspeccpi.ComponentVersionAccess) string {
+	return a.Object
+}
+
+func (_ *AccessSpec) GetType() string {
+	return Type
+}
+
+func (a *AccessSpec) AccessMethod(c accspeccpi.ComponentVersionAccess) (accspeccpi.AccessMethod, error) {
+	return accspeccpi.AccessMethodForImplementation(newMethod(c, a))
+}
+
+func (a *AccessSpec) GetInexpensiveContentVersionIdentity(access accspeccpi.ComponentVersionAccess) string {
+	if a.Generation != "" {
+		return a.Generation
+	}
+	client, err := a.client(access.GetContext())
+	if err != nil {
+		return ""
+	}
+	meta, err := client.GetObject(a.Bucket, a.Object, "")
+	if err != nil {
+		return ""
+	}
+	return meta.Generation
+}
+
+func (a *AccessSpec) client(ctx accspeccpi.Context) (*gcs.Client, error) {
+	creds := identity.GetCredentials(ctx, gcs.Endpoint(a.Endpoint), a.Bucket, a.Object)
+	return gcs.NewClient(a.Endpoint, creds)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func newMethod(c accspeccpi.ComponentVersionAccess, a *AccessSpec) (accspeccpi.AccessMethodImpl, error) {
+	if a.Bucket == "" || a.Object == "" {
+		return nil, fmt.Errorf("bucket and object name required")
+	}
+	client, err := a.client(c.GetContext())
+	if err != nil {
+		return nil, err
+	}
+	mediaType := a.MediaType
+	if mediaType == "" {
+		mediaType = mime.MIME_OCTET
+	}
+	factory := func() (blobaccess.BlobAccess, error) {
+		return client.Download(a.Bucket, a.Object, a.Generation, mediaType)
+	}
+	return &accessMethod{
+		AccessMethodImpl: accspeccpi.NewDefaultMethodImpl(c, a, "", mediaType, factory),
+		spec:             a,
+	}, nil
+}
+
+type accessMethod struct {
+	accspeccpi.AccessMethodImpl
+	spec *AccessSpec
+}
+
+func (m *accessMethod) GetConsumerId(uctx ...credentials.UsageContext) credentials.ConsumerIdentity {
+	return identity.GetConsumerId(gcs.Endpoint(m.spec.Endpoint), m.spec.Bucket, m.spec.Object)
+}
+
+func (m *accessMethod) GetIdentityMatcher() string {
+	return identity.CONSUMER_TYPE
+}
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gcs_test
+
+import (
+	"crypto/md5" //nolint:gosec // used by the storage service
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/gcs/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	me "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/gcs"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/gcs"
+	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+const (
+	BUCKET  = "blobs"
+	OBJECT  = "dir/test.txt"
+	CONTENT = "gcs content"
+	TOKEN   = "token"
+)
+
+var _ = Describe("Method", func() {
+	var (
+		ctx    ocm.Context
+		server *httptest.Server
+		md5sum string
+		auth   string
+	)
+
+	BeforeEach(func() {
+		ctx = ocm.New()
+		sum := md5.Sum([]byte(CONTENT)) //nolint:gosec // see above
+		md5sum = base64.StdEncoding.EncodeToString(sum[:])
+		auth = ""
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			auth = r.Header.Get("Authorization")
+			if r.URL.EscapedPath() != "/storage/v1/b/"+BUCKET+"/o/dir%2Ftest.txt" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if r.URL.Query().Get("alt") == "media" {
+				Expect(r.URL.Query().Get("generation")).To(Equal("42"))
+				w.Write([]byte(CONTENT))
+				return
+			}
+			json.NewEncoder(w).Encode(&gcs.Object{
+				Bucket:      BUCKET,
+				Name:        OBJECT,
+				ContentType: mime.MIME_TEXT,
+				Generation:  "42",
+				MD5Hash:     md5sum,
+			})
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("serializes spec", func() {
+		spec := me.New(BUCKET, OBJECT, "42", "", mime.MIME_TEXT)
+		data := Must(runtime.DefaultJSONEncoding.Marshal(spec))
+		Expect(string(data)).To(StringEqualWithContext(`{"type":"gcs","bucket":"blobs","object":"dir/test.txt","generation":"42","mediaType":"text/plain"}`))
+	})
+
+	It("accesses object", func() {
+		spec := me.New(BUCKET, OBJECT, "", server.URL, mime.MIME_TEXT)
+
+		Expect(spec.GetInexpensiveContentVersionIdentity(&cpi.DummyComponentVersionAccess{Context: ctx})).To(Equal("42"))
+		m := Must(spec.AccessMethod(&cpi.DummyComponentVersionAccess{Context: ctx}))
+		defer Close(m)
+		Expect(m.MimeType()).To(Equal(mime.MIME_TEXT))
+		Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+		Expect(auth).To(Equal(""))
+	})
+
+	It("uses token authorization", func() {
+		spec := me.New(BUCKET, OBJECT, "", server.URL, "")
+		ctx.CredentialsContext().SetCredentialsForConsumer(
+			identity.GetConsumerId(server.URL, BUCKET, ""),
+			credentials.DirectCredentials{identity.ATTR_TOKEN: TOKEN},
+		)
+
+		m := Must(spec.AccessMethod(&cpi.DummyComponentVersionAccess{Context: ctx}))
+		defer Close(m)
+		Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+		Expect(auth).To(Equal("Bearer " + TOKEN))
+	})
+
+	It("detects checksum mismatch", func() {
+		md5sum = base64.StdEncoding.EncodeToString([]byte("other"))
+		spec := me.New(BUCKET, OBJECT, "", server.URL, "")
+
+		m := Must(spec.AccessMethod(&cpi.DummyComponentVersionAccess{Context: ctx}))
+		defer Close(m)
+		_, err := m.Get()
+		Expect(err).To(MatchError(ContainSubstring("MD5 mismatch for object dir/test.txt in bucket blobs")))
+	})
+
+	It("provides consumer id", func() {
+		spec := me.New(BUCKET, OBJECT, "", "", "")
+		m := Must(spec.AccessMethod(&cpi.DummyComponentVersionAccess{Context: ctx}))
+		defer Close(m)
+		Expect(credentials.GetProvidedConsumerId(m)).To(Equal(credentials.ConsumerIdentity{
+			"type":       identity.CONSUMER_TYPE,
+			"hostname":   "storage.googleapis.com",
+			"scheme":     "https",
+			"port":       "443",
+			"pathprefix": "blobs/dir/test.txt",
+		}))
+	})
+})
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"github.com/open-component-model/ocm/pkg/cobrautils/flagsets"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/git/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/options"
+)
+
+func ConfigHandler() flagsets.ConfigOptionTypeSetHandler {
+	return flagsets.NewConfigOptionTypeSetHandler(
+		Type, AddConfig,
+		options.RepositoryOption,
+		options.ReferenceOption,
+		options.CommitOption,
+		options.PathOption,
+	)
+}
+
+func AddConfig(opts flagsets.ConfigOptions, config flagsets.Config) error {
+	flagsets.AddFieldByOptionP(opts, options.RepositoryOption, config, "repoUrl")
+	flagsets.AddFieldByOptionP(opts, options.ReferenceOption, config, "ref")
+	flagsets.AddFieldByOptionP(opts, options.CommitOption, config, "commit")
+	flagsets.AddFieldByOptionP(opts, options.PathOption, config, "path")
+	return nil
+}
+
+var usage = `
+This method implements the access of the file tree of a commit stored in
+an arbitrary git repository (for example GitLab, Gitea or plain ssh based
+git servers). The content is provided as tar archive.
+`
+
+var formatV1 = `
+The type specific specification fields are:
+
+- **<code>repoUrl</code>**  *string*
+
+  Repository URL. Besides regular <code>https</code>, <code>ssh</code> and
+  <code>file</code> URLs, scp-like ssh references (<code>user@host:path</code>)
+  are supported.
+
+- **<code>ref</code>** (optional) *string*
+
+  Original ref (branch, tag or revision expression) used to get the commit
+  from. It is used to determine the commit, if no commit is given.
+  If both are omitted, the HEAD of the repository is used.
+
+- **<code>commit</code>** (optional) *string*
+
+  The sha/id of the git commit.
+
+- **<code>path</code>** (optional) *string*
+
+  Path filter for the content of the commit. If given, only the file or
+  directory tree with this path is included in the archive.
+
+It uses the consumer identity type ` + identity.CONSUMER_TYPE + ` with the fields
+for a hostpath identity matcher (see <CMD>ocm get credentials</CMD>).`
@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/blobaccess/git"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/git/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi/accspeccpi"
+	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+// Type is the access type for a commit of a git repository.
+const (
+	Type   = "git"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	accspeccpi.RegisterAccessType(accspeccpi.NewAccessSpecType[*AccessSpec](Type, accspeccpi.WithDescription(usage)))
+	accspeccpi.RegisterAccessType(accspeccpi.NewAccessSpecType[*AccessSpec](TypeV1, accspeccpi.WithFormatSpec(formatV1), accspeccpi.WithConfigHandler(ConfigHandler())))
+}
+
+func Is(spec accspeccpi.AccessSpec) bool {
+	return spec != nil && spec.GetKind() == Type
+}
+
+// New creates a new git access spec version v1.
+func New(repoURL, ref, commit string, path ...string) *AccessSpec {
+	p := ""
+	if len(path) > 0 {
+		p = path[0]
+	}
+	return &AccessSpec{
+		ObjectVersionedType: runtime.NewVersionedTypedObject(Type),
+		RepoURL:             repoURL,
+		Ref:                 ref,
+		Commit:              commit,
+		Path:                p,
+	}
+}
+
+// AccessSpec describes the access for a commit of a git repository.
+type AccessSpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+
+	// RepoURL is the repository URL.
+	RepoURL string `json:"repoUrl"`
+	// Ref is the original ref used to get the commit from.
+	// It is used to determine the commit, if no commit is specified.
+	Ref string `json:"ref,omitempty"`
+	// Commit defines the hash of the commit.
+	Commit string `json:"commit,omitempty"`
+	// Path is an optional path filter for the content of the commit.
+	Path string `json:"path,omitempty"`
+}
+
+var _ accspeccpi.AccessSpec = (*AccessSpec)(nil)
+
+func (a *AccessSpec) Describe(ctx accspeccpi.Context) string {
+	ref := a.Commit
+	if ref == "" {
+		ref = a.Ref
+	}
+	if a.Path != "" {
+		return fmt.Sprintf("git commit %s[%s]:%s", a.RepoURL, ref, a.Path)
+	}
+	return fmt.Sprintf("git commit %s[%s]", a.RepoURL, ref)
+}
+
+func (_ *AccessSpec) IsLocal(accspeccpi.Context) bool {
+	return false
+}
+
+func (a *AccessSpec) GlobalAccessSpec(ctx accspeccpi.Context) accspeccpi.AccessSpec {
+	return a
+}
+
+func (a *AccessSpec) AccessMethod(c accspeccpi.ComponentVersionAccess) (accspeccpi.AccessMethod, error) {
+	if a.RepoURL == "" {
+		return nil, fmt.Errorf("repository url required for git access")
+	}
+	return accspeccpi.AccessMethodForImplementation(&accessMethod{comp: c, spec: a}, nil)
+}
+
+func (a *AccessSpec) GetInexpensiveContentVersionIdentity(access accspeccpi.ComponentVersionAccess) string {
+	// a ref may move, only a commit describes a stable content
+	if a.Commit == "" {
+		return ""
+	}
+	if a.Path != "" {
+		return a.Commit + ":" + a.Path
+	}
+	return a.Commit
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type accessMethod struct {
+	lock sync.Mutex
+	blob blobaccess.BlobAccess
+	comp accspeccpi.ComponentVersionAccess
+	spec *AccessSpec
+}
+
+var _ accspeccpi.AccessMethodImpl = (*accessMethod)(nil)
+
+func (_ *accessMethod) IsLocal() bool {
+	return false
+}
+
+func (m *accessMethod) GetKind() string {
+	return Type
+}
+
+func (m *accessMethod) AccessSpec() accspeccpi.AccessSpec {
+	return m.spec
+}
+
+func (m *accessMethod) MimeType() string {
+	return mime.MIME_TAR
+}
+
+func (m *accessMethod) Get() ([]byte, error) {
+	return blobaccess.BlobData(m.getBlob())
+}
+
+func (m *accessMethod) Reader() (io.ReadCloser, error) {
+	return blobaccess.BlobReader(m.getBlob())
+}
+
+func (m *accessMethod) getBlob() (blobaccess.BlobAccess, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.blob != nil {
+		return m.blob, nil
+	}
+
+	blob, err := git.BlobAccessForGit(m.spec.RepoURL,
+		git.WithRef(m.spec.Ref),
+		git.WithCommit(m.spec.Commit),
+		git.WithPath(m.spec.Path),
+		git.WithMimeType(m.MimeType()),
+		git.WithCredentialContext(m.comp.GetContext()),
+		git.WithLoggingContext(m.comp.GetContext()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	m.blob = blob
+	return m.blob, nil
+}
+
+func (m *accessMethod) Close() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var err error
+	if m.blob != nil {
+		err = m.blob.Close()
+		m.blob = nil
+	}
+	return err
+}
+
+func (m *accessMethod) GetConsumerId(uctx ...credentials.UsageContext) credentials.ConsumerIdentity {
+	return identity.GetConsumerId(m.spec.RepoURL)
+}
+
+func (m *accessMethod) GetIdentityMatcher() string {
+	return identity.CONSUMER_TYPE
+}
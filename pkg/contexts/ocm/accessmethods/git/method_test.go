@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package git_test
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	me "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/git"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+type repoFiles map[string]string
+
+func commit(repo *gogit.Repository, dir string, files repoFiles, msg string) plumbing.Hash {
+	wt := Must(repo.Worktree())
+	for n, c := range files {
+		p := filepath.Join(dir, n)
+		MustBeSuccessful(os.MkdirAll(filepath.Dir(p), 0o755))
+		MustBeSuccessful(os.WriteFile(p, []byte(c), 0o644))
+		Must(wt.Add(n))
+	}
+	return Must(wt.Commit(msg, &gogit.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@acme.org", When: time.Unix(1700000000, 0)},
+	}))
+}
+
+func content(m cpi.AccessMethod) repoFiles {
+	r := Must(m.Reader())
+	defer r.Close()
+	result := repoFiles{}
+	tr := tar.NewReader(r)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		MustBeSuccessful(err)
+		result[h.Name] = string(Must(io.ReadAll(tr)))
+	}
+	return result
+}
+
+var _ = Describe("Method", func() {
+	var (
+		ctx    ocm.Context
+		tmp    string
+		url    string
+		first  plumbing.Hash
+		second plumbing.Hash
+	)
+
+	BeforeEach(func() {
+		ctx = ocm.New()
+		tmp = Must(os.MkdirTemp("", "gitaccess"))
+		work := filepath.Join(tmp, "work")
+		repo := Must(gogit.PlainInit(work, false))
+		first = commit(repo, work, repoFiles{"README.md": "first", "docs/a.txt": "a"}, "first")
+		Must(repo.CreateTag("v1.0.0", first, nil))
+		second = commit(repo, work, repoFiles{"README.md": "second", "src/main.go": "package main"}, "second")
+
+		url = filepath.Join(tmp, "repo.git")
+		Must(gogit.PlainClone(url, true, &gogit.CloneOptions{URL: work}))
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmp)
+	})
+
+	It("accesses HEAD", func() {
+		spec := me.New(url, "", "")
+		m := Must(spec.AccessMethod(&cpi.DummyComponentVersionAccess{Context: ctx}))
+		defer Close(m)
+		Expect(m.MimeType()).To(Equal(mime.MIME_TAR))
+		Expect(content(m)).To(Equal(repoFiles{
+			"README.md":   "second",
+			"docs/a.txt":  "a",
+			"src/main.go": "package main",
+		}))
+	})
+
+	It("accesses ref", func() {
+		spec := me.New(url, "v1.0.0", "")
+		m := Must(spec.AccessMethod(&cpi.DummyComponentVersionAccess{Context: ctx}))
+		defer Close(m)
+		Expect(content(m)).To(Equal(repoFiles{
+			"README.md":  "first",
+			"docs/a.txt": "a",
+		}))
+	})
+
+	It("accesses commit with path filter", func() {
+		spec := me.New(url, "master", first.String(), "docs")
+		Expect(spec.GetInexpensiveContentVersionIdentity(nil)).To(Equal(first.String() + ":docs"))
+		m := Must(spec.AccessMethod(&cpi.DummyComponentVersionAccess{Context: ctx}))
+		defer Close(m)
+		Expect(content(m)).To(Equal(repoFiles{
+			"docs/a.txt": "a",
+		}))
+	})
+
+	It("fails for unknown path", func() {
+		spec := me.New(url, "", second.String(), "unknown")
+		m := Must(spec.AccessMethod(&cpi.DummyComponentVersionAccess{Context: ctx}))
+		defer Close(m)
+		ExpectError(m.Get()).To(MatchError(ContainSubstring(`path "unknown" not found`)))
+	})
+
+	It("provides consumer id", func() {
+		spec := me.New("git@gitlab.acme.org:org/repo.git", "main", "")
+		m := Must(spec.AccessMethod(&cpi.DummyComponentVersionAccess{Context: ctx}))
+		defer Close(m)
+		Expect(credentials.GetProvidedConsumerId(m)).To(Equal(credentials.ConsumerIdentity{
+			"type":       "Git",
+			"scheme":     "ssh",
+			"hostname":   "gitlab.acme.org",
+			"port":       "22",
+			"pathprefix": "org/repo.git",
+		}))
+	})
+})
@@ -5,11 +5,15 @@
 package accessmethods
 
 import (
+	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/azureblob"
+	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/gcs"
+	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/git"
 	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/github"
 	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/helm"
 	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/localblob"
 	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/localfsblob"
 	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/localociblob"
+	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/maven"
 	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/none"
 	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/npm"
 	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/ociartifact"
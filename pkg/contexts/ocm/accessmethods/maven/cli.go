@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package maven
+
+import (
+	"github.com/open-component-model/ocm/pkg/cobrautils/flagsets"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/maven/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/options"
+	"github.com/open-component-model/ocm/pkg/maven"
+)
+
+func ConfigHandler() flagsets.ConfigOptionTypeSetHandler {
+	return flagsets.NewConfigOptionTypeSetHandler(
+		Type, AddConfig,
+		options.RepositoryOption,
+		options.GroupOption,
+		options.ArtifactOption,
+		options.VersionOption,
+		options.ClassifierOption,
+		options.ExtensionOption,
+	)
+}
+
+func AddConfig(opts flagsets.ConfigOptions, config flagsets.Config) error {
+	flagsets.AddFieldByOptionP(opts, options.RepositoryOption, config, "repoUrl")
+	flagsets.AddFieldByOptionP(opts, options.GroupOption, config, "groupId")
+	flagsets.AddFieldByOptionP(opts, options.ArtifactOption, config, "artifactId")
+	flagsets.AddFieldByOptionP(opts, options.VersionOption, config, "version")
+	flagsets.AddFieldByOptionP(opts, options.ClassifierOption, config, "classifier")
+	flagsets.AddFieldByOptionP(opts, options.ExtensionOption, config, "extension")
+	return nil
+}
+
+var usage = `
+This method implements the access of a file of a Maven artifact
+stored in a Maven repository.
+The downloaded content is verified with the checksum sidecar file
+(<code>.sha256</code> or <code>.sha1</code>) provided by the repository.
+Files without sidecar file are rejected.
+`
+
+var formatV1 = `
+The type specific specification fields are:
+
+- **<code>repoUrl</code>** *string*
+
+  URL of the Maven repository. Besides http(s) URLs, file URLs
+  can be used for repositories stored in the filesystem.
+
+- **<code>groupId</code>** *string*
+
+  The groupId of the Maven artifact.
+
+- **<code>artifactId</code>** *string*
+
+  The artifactId of the Maven artifact.
+
+- **<code>version</code>** *string*
+
+  The version of the Maven artifact.
+
+- **<code>classifier</code>** (optional) *string*
+
+  The classifier of the Maven artifact file.
+
+- **<code>extension</code>** (optional) *string*
+
+  The file extension of the Maven artifact file (default ` + maven.DEFAULT_EXTENSION + `).
+
+It uses the consumer identity type ` + identity.CONSUMER_TYPE + ` with the fields
+for a hostpath identity matcher (see <CMD>ocm get credentials</CMD>).`
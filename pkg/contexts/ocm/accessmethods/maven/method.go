@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package maven
+
+import (
+	"fmt"
+
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/maven/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext/attrs/vfsattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi/accspeccpi"
+	"github.com/open-component-model/ocm/pkg/maven"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+// Type is the access type of a Maven repository.
+const (
+	Type   = "maven"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	accspeccpi.RegisterAccessType(accspeccpi.NewAccessSpecType[*AccessSpec](Type, accspeccpi.WithDescription(usage)))
+	accspeccpi.RegisterAccessType(accspeccpi.NewAccessSpecType[*AccessSpec](TypeV1, accspeccpi.WithFormatSpec(formatV1), accspeccpi.WithConfigHandler(ConfigHandler())))
+}
+
+func Is(spec accspeccpi.AccessSpec) bool {
+	return spec != nil && spec.GetKind() == Type
+}
+
+// AccessSpec describes the access for a file of a Maven artifact
+// in a Maven repository.
+type AccessSpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+
+	// RepoURL is the base URL of the Maven repository.
+	RepoURL string `json:"repoUrl"`
+
+	// GroupId of the Maven artifact.
+	GroupId string `json:"groupId"`
+	// ArtifactId of the Maven artifact.
+	ArtifactId string `json:"artifactId"`
+	// Version of the Maven artifact.
+	Version string `json:"version"`
+	// Classifier of the Maven artifact file.
+	Classifier string `json:"classifier,omitempty"`
+	// Extension of the Maven artifact file (default jar).
+	Extension string `json:"extension,omitempty"`
+}
+
+var _ accspeccpi.AccessSpec = (*AccessSpec)(nil)
+
+// New creates a new Maven repository access spec version v1.
+// The optional classifier list may contain the classifier
+// and the file extension.
+func New(repoURL, groupId, artifactId, version string, classifier ...string) *AccessSpec {
+	return NewForCoordinates(repoURL, maven.NewCoordinates(groupId, artifactId, version, classifier...))
+}
+
+// NewForCoordinates creates a new Maven repository access spec version v1
+// for given coordinates.
+func NewForCoordinates(repoURL string, coords *maven.Coordinates) *AccessSpec {
+	return &AccessSpec{
+		ObjectVersionedType: runtime.NewVersionedTypedObject(Type),
+		RepoURL:             repoURL,
+		GroupId:             coords.GroupId,
+		ArtifactId:          coords.ArtifactId,
+		Version:             coords.Version,
+		Classifier:          coords.Classifier,
+		Extension:           coords.Extension,
+	}
+}
+
+// GetCoordinates provides the Maven coordinates of the described file.
+func (a *AccessSpec) GetCoordinates() *maven.Coordinates {
+	return maven.NewCoordinates(a.GroupId, a.ArtifactId, a.Version, a.Classifier, a.Extension)
+}
+
+func (a *AccessSpec) Describe(_ accspeccpi.Context) string {
+	return fmt.Sprintf("Maven artifact %s in repository %s", a.GetCoordinates().String(), a.RepoURL)
+}
+
+func (_ *AccessSpec) IsLocal(accspeccpi.Context) bool {
+	return false
+}
+
+func (a *AccessSpec) GlobalAccessSpec(_ accspeccpi.Context) accspeccpi.AccessSpec {
+	return a
+}
+
+func (a *AccessSpec) GetReferenceHint(_ accspeccpi.ComponentVersionAccess) string {
+	return a.GetCoordinates().String()
+}
+
+func (_ *AccessSpec) GetType() string {
+	return Type
+}
+
+func (a *AccessSpec) AccessMethod(c accspeccpi.ComponentVersionAccess) (accspeccpi.AccessMethod, error) {
+	return accspeccpi.AccessMethodForImplementation(newMethod(c, a))
+}
+
+func (a *AccessSpec) GetInexpensiveContentVersionIdentity(access accspeccpi.ComponentVersionAccess) string {
+	repo, err := a.repository(access.GetContext())
+	if err != nil {
+		return ""
+	}
+	_, sum, _ := repo.Checksum(a.GetCoordinates(), a.credentials(access.GetContext()))
+	return sum
+}
+
+func (a *AccessSpec) repository(ctx accspeccpi.Context) (*maven.Repository, error) {
+	return maven.NewUrlRepository(a.RepoURL, vfsattr.Get(ctx))
+}
+
+func (a *AccessSpec) credentials(ctx accspeccpi.Context) common.Properties {
+	return identity.GetCredentials(ctx, a.RepoURL, a.GroupId)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func newMethod(c accspeccpi.ComponentVersionAccess, a *AccessSpec) (accspeccpi.AccessMethodImpl, error) {
+	coords := a.GetCoordinates()
+	if err := coords.Validate(); err != nil {
+		return nil, err
+	}
+	repo, err := a.repository(c.GetContext())
+	if err != nil {
+		return nil, err
+	}
+	factory := func() (blobaccess.BlobAccess, error) {
+		return repo.Download(coords, a.credentials(c.GetContext()))
+	}
+	return &accessMethod{
+		AccessMethodImpl: accspeccpi.NewDefaultMethodImpl(c, a, "", coords.MimeType(), factory),
+		spec:             a,
+	}, nil
+}
+
+type accessMethod struct {
+	accspeccpi.AccessMethodImpl
+	spec *AccessSpec
+}
+
+func (m *accessMethod) GetConsumerId(uctx ...credentials.UsageContext) credentials.ConsumerIdentity {
+	return identity.GetConsumerId(m.spec.RepoURL, m.spec.GroupId)
+}
+
+func (m *accessMethod) GetIdentityMatcher() string {
+	return identity.CONSUMER_TYPE
+}
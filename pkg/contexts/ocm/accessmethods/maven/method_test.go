@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package maven_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext/attrs/vfsattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	me "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/maven"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/maven"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+const (
+	REPO    = "/repo"
+	CONTENT = "jar content"
+)
+
+var _ = Describe("Method", func() {
+	var (
+		ctx    ocm.Context
+		fs     vfs.FileSystem
+		coords *maven.Coordinates
+	)
+
+	BeforeEach(func() {
+		ctx = ocm.New()
+		fs = memoryfs.New()
+		vfsattr.Set(ctx, fs)
+		coords = maven.NewCoordinates("org.acme", "demo", "1.0.0")
+		MustBeSuccessful(fs.MkdirAll(vfs.Dir(fs, REPO+"/"+coords.FilePath()), 0o755))
+		MustBeSuccessful(vfs.WriteFile(fs, REPO+"/"+coords.FilePath(), []byte(CONTENT), 0o644))
+	})
+
+	It("serializes spec", func() {
+		spec := me.New("https://repo.acme.org/maven2", "org.acme", "demo", "1.0.0", "sources", "zip")
+		data := Must(runtime.DefaultJSONEncoding.Marshal(spec))
+		Expect(string(data)).To(StringEqualWithContext(`{"type":"maven","repoUrl":"https://repo.acme.org/maven2","groupId":"org.acme","artifactId":"demo","version":"1.0.0","classifier":"sources","extension":"zip"}`))
+		Expect(spec.GetReferenceHint(nil)).To(Equal("org.acme:demo:1.0.0:sources:zip"))
+	})
+
+	It("rejects artifact without checksum", func() {
+		spec := me.NewForCoordinates("file://"+REPO, coords)
+
+		m := Must(spec.AccessMethod(&cpi.DummyComponentVersionAccess{Context: ctx}))
+		defer Close(m)
+		Expect(m.MimeType()).To(Equal(maven.MIME_JAR))
+		_, err := m.Get()
+		Expect(err).To(MatchError(ContainSubstring("no checksum sidecar file found for org.acme:demo:1.0.0")))
+	})
+
+	It("verifies checksum", func() {
+		sum := sha256.Sum256([]byte(CONTENT))
+		MustBeSuccessful(vfs.WriteFile(fs, REPO+"/"+coords.FilePath()+".sha256", []byte(hex.EncodeToString(sum[:])), 0o644))
+		spec := me.NewForCoordinates(REPO, coords)
+
+		Expect(spec.GetInexpensiveContentVersionIdentity(&cpi.DummyComponentVersionAccess{Context: ctx})).To(Equal(hex.EncodeToString(sum[:])))
+		m := Must(spec.AccessMethod(&cpi.DummyComponentVersionAccess{Context: ctx}))
+		defer Close(m)
+		Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+	})
+
+	It("detects checksum mismatch", func() {
+		MustBeSuccessful(vfs.WriteFile(fs, REPO+"/"+coords.FilePath()+".sha256", []byte("0000"), 0o644))
+		spec := me.NewForCoordinates(REPO, coords)
+
+		m := Must(spec.AccessMethod(&cpi.DummyComponentVersionAccess{Context: ctx}))
+		defer Close(m)
+		_, err := m.Get()
+		Expect(err).To(MatchError(ContainSubstring("SHA-256 checksum mismatch for org.acme:demo:1.0.0")))
+	})
+
+	It("provides consumer id", func() {
+		spec := me.NewForCoordinates("https://repo.acme.org/maven2", coords)
+		m := Must(spec.AccessMethod(&cpi.DummyComponentVersionAccess{Context: ctx}))
+		defer Close(m)
+		Expect(credentials.GetProvidedConsumerId(m)).To(Equal(credentials.ConsumerIdentity{
+			"type":       "MavenRepository",
+			"hostname":   "repo.acme.org",
+			"scheme":     "https",
+			"port":       "443",
+			"pathprefix": "maven2/org/acme",
+		}))
+	})
+})
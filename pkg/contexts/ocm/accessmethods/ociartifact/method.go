@@ -167,6 +167,7 @@ var (
 	_ accspeccpi.AccessMethodImpl          = (*accessMethod)(nil)
 	_ blobaccess.DigestSource              = (*accessMethod)(nil)
 	_ accspeccpi.DigestSource              = (*accessMethod)(nil)
+	_ accspeccpi.SizeSource                = (*accessMethod)(nil)
 	_ credentials.ConsumerIdentityProvider = (*accessMethod)(nil)
 )
 
@@ -325,6 +326,52 @@ func (m *accessMethod) GetDigest() (digest.Digest, error) {
 	return m.digest, err
 }
 
+// GetSize provides the accumulated size of the blobs of the artifact
+// according to its descriptor(s).
+func (m *accessMethod) GetSize() (int64, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	err := m.getArtifact()
+	if err != nil {
+		return blobaccess.BLOB_UNKNOWN_SIZE, err
+	}
+	return artifactSize(m.art)
+}
+
+func artifactSize(art oci.ArtifactAccess) (int64, error) {
+	d := art.GetDescriptor()
+	if d.IsManifest() {
+		m, err := d.Manifest()
+		if err != nil {
+			return blobaccess.BLOB_UNKNOWN_SIZE, err
+		}
+		size := m.Config.Size
+		for _, l := range m.Layers {
+			size += l.Size
+		}
+		return size, nil
+	}
+	idx, err := d.Index()
+	if err != nil {
+		return blobaccess.BLOB_UNKNOWN_SIZE, err
+	}
+	var size int64
+	for _, e := range idx.Manifests {
+		nested, err := art.GetArtifact(e.Digest)
+		if err != nil {
+			return blobaccess.BLOB_UNKNOWN_SIZE, err
+		}
+		s, err := artifactSize(nested)
+		nested.Close()
+		if err != nil {
+			return blobaccess.BLOB_UNKNOWN_SIZE, err
+		}
+		size += e.Size + s
+	}
+	return size, nil
+}
+
 func (m *accessMethod) Get() ([]byte, error) {
 	blob, err := m.getBlob()
 	if err != nil {
@@ -34,6 +34,21 @@ var HostnameOption = RegisterOption(NewStringOptionType("accessHostname", "hostn
 // CommitOption.
 var CommitOption = RegisterOption(NewStringOptionType("commit", "git commit id"))
 
+// PathOption.
+var PathOption = RegisterOption(NewStringOptionType("accessPath", "path filter for repository content"))
+
+// GroupOption.
+var GroupOption = RegisterOption(NewStringOptionType("groupId", "GroupID or namespace"))
+
+// ArtifactOption.
+var ArtifactOption = RegisterOption(NewStringOptionType("artifactId", "ArtifactID or name"))
+
+// ClassifierOption.
+var ClassifierOption = RegisterOption(NewStringOptionType("classifier", "a key word used to further specify the artifact"))
+
+// ExtensionOption.
+var ExtensionOption = RegisterOption(NewStringOptionType("extension", "file extension of the artifact"))
+
 // GlobalAccessOption.
 var GlobalAccessOption = RegisterOption(NewValueMapYAMLOptionType("globalAccess", "access specification for global access"))
 
@@ -43,6 +58,15 @@ var RegionOption = RegisterOption(NewStringOptionType("region", "region name"))
 // BucketOption.
 var BucketOption = RegisterOption(NewStringOptionType("bucket", "bucket name"))
 
+// AccountOption.
+var AccountOption = RegisterOption(NewStringOptionType("accountName", "storage account name"))
+
+// ContainerOption.
+var ContainerOption = RegisterOption(NewStringOptionType("container", "storage container name"))
+
+// EndpointOption.
+var EndpointOption = RegisterOption(NewStringOptionType("endpoint", "storage service endpoint URL"))
+
 // VersionOption.
 var VersionOption = RegisterOption(NewStringOptionType("accessVersion", "version for access specification"))
 
@@ -61,3 +85,24 @@ var HTTPRedirectOption = RegisterOption(NewBoolOptionType("noredirect", "http re
 
 // CommentOption.
 var CommentOption = RegisterOption(NewStringOptionType("comment", "comment field value"))
+
+// ScanToolOption.
+var ScanToolOption = RegisterOption(NewStringOptionType("tool", "name of the scan tool"))
+
+// ScanResultOption.
+var ScanResultOption = RegisterOption(NewStringOptionType("resultDigest", "digest of the scan result"))
+
+// ScanVerdictOption.
+var ScanVerdictOption = RegisterOption(NewStringOptionType("verdict", "verdict of a scan"))
+
+// ApproverOption.
+var ApproverOption = RegisterOption(NewStringOptionType("approver", "name of the approver"))
+
+// TicketOption.
+var TicketOption = RegisterOption(NewStringOptionType("ticket", "ticket reference"))
+
+// TargetOption.
+var TargetOption = RegisterOption(NewStringOptionType("target", "deployment target"))
+
+// TimestampOption.
+var TimestampOption = RegisterOption(NewStringOptionType("timestamp", "timestamp (RFC3339)"))
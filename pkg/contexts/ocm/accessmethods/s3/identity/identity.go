@@ -5,6 +5,7 @@
 package identity
 
 import (
+	"net/url"
 	"path"
 	"strings"
 
@@ -50,6 +51,19 @@ This matcher is a hostpath matcher.`,
 		attrs)
 }
 
+// EndpointHost provides the host (and port) of an endpoint URL
+// as used for the consumer identity.
+func EndpointHost(endpoint string) string {
+	if endpoint == "" {
+		return ""
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return endpoint
+	}
+	return u.Host
+}
+
 func GetConsumerId(host, bucket, key, version string) cpi.ConsumerIdentity {
 	id := cpi.NewConsumerIdentity(CONSUMER_TYPE)
 
@@ -65,7 +65,11 @@ type AccessSpec struct {
 	Version string
 	// MediaType defines the mime type of the object to download.
 	// +optional
-	MediaType  string
+	MediaType string
+	// Endpoint is an S3 compatible endpoint (for example MinIO) used
+	// instead of the AWS endpoints.
+	// +optional
+	Endpoint   string
 	downloader downloader.Downloader
 }
 
@@ -142,7 +146,7 @@ func newMethod(c accspeccpi.ComponentVersionAccess, a *AccessSpec) (*accessMetho
 	}
 	d := a.downloader
 	if d == nil {
-		d = s3.NewDownloader(a.Region, a.Bucket, a.Key, a.Version, awsCreds)
+		d = s3.NewDownloader(a.Region, a.Bucket, a.Key, a.Version, awsCreds).WithEndpoint(a.Endpoint)
 	}
 	w := accessio.NewWriteAtWriter(d.Download)
 	// don't change the spec, leave it empty.
@@ -159,7 +163,7 @@ func newMethod(c accspeccpi.ComponentVersionAccess, a *AccessSpec) (*accessMetho
 }
 
 func getCreds(a *AccessSpec, cctx credentials.Context) (credentials.Credentials, error) {
-	return identity.GetCredentials(cctx, "", a.Bucket, a.Key, a.Version)
+	return identity.GetCredentials(cctx, identity.EndpointHost(a.Endpoint), a.Bucket, a.Key, a.Version)
 }
 
 func (_ *accessMethod) IsLocal() bool {
@@ -175,7 +179,7 @@ func (m *accessMethod) AccessSpec() accspeccpi.AccessSpec {
 }
 
 func (m *accessMethod) GetConsumerId(uctx ...credentials.UsageContext) credentials.ConsumerIdentity {
-	return identity.GetConsumerId("", m.spec.Bucket, m.spec.Key, m.spec.Version)
+	return identity.GetConsumerId(identity.EndpointHost(m.spec.Endpoint), m.spec.Bucket, m.spec.Key, m.spec.Version)
 }
 
 func (m *accessMethod) GetIdentityMatcher() string {
@@ -40,6 +40,9 @@ type AccessSpecV1 struct {
 	// MediaType defines the mime type of the object to download.
 	// +optional
 	MediaType string `json:"mediaType,omitempty"`
+	// Endpoint is an S3 compatible endpoint used instead of the AWS endpoints.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
 }
 
 type converterV1 struct{}
@@ -52,6 +55,7 @@ func (_ converterV1) ConvertFrom(in *AccessSpec) (*AccessSpecV1, error) {
 		Key:                 in.Key,
 		Version:             in.Version,
 		MediaType:           in.MediaType,
+		Endpoint:            in.Endpoint,
 	}, nil
 }
 
@@ -63,6 +67,7 @@ func (_ converterV1) ConvertTo(in *AccessSpecV1) (*AccessSpec, error) {
 		Key:                          in.Key,
 		Version:                      in.Version,
 		MediaType:                    in.MediaType,
+		Endpoint:                     in.Endpoint,
 	}, nil
 }
 
@@ -88,4 +93,9 @@ The type specific specification fields are:
 - **<code>mediaType</code>** (optional) *string*
 
   The media type of the content
+
+- **<code>endpoint</code>** (optional) *string*
+
+  The URL of an S3 compatible service (for example MinIO) used instead
+  of the AWS endpoints. Objects are addressed path style.
 `
@@ -42,6 +42,9 @@ type AccessSpecV2 struct {
 	// MediaType defines the mime type of the object to download.
 	// +optional
 	MediaType string `json:"mediaType,omitempty"`
+	// Endpoint is an S3 compatible endpoint used instead of the AWS endpoints.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
 }
 
 type converterV2 struct{}
@@ -54,6 +57,7 @@ func (_ converterV2) ConvertFrom(in *AccessSpec) (*AccessSpecV2, error) {
 		Key:                 in.Key,
 		Version:             in.Version,
 		MediaType:           in.MediaType,
+		Endpoint:            in.Endpoint,
 	}, nil
 }
 
@@ -65,6 +69,7 @@ func (_ converterV2) ConvertTo(in *AccessSpecV2) (*AccessSpec, error) {
 		Key:                          in.Key,
 		Version:                      in.Version,
 		MediaType:                    in.MediaType,
+		Endpoint:                     in.Endpoint,
 	}, nil
 }
 
@@ -90,4 +95,9 @@ The type specific specification fields are:
 - **<code>mediaType</code>** (optional) *string*
 
   The media type of the content
+
+- **<code>endpoint</code>** (optional) *string*
+
+  The URL of an S3 compatible service (for example MinIO) used instead
+  of the AWS endpoints. Objects are addressed path style.
 `
@@ -13,4 +13,5 @@ import (
 	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/plugincacheattr"
 	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/plugindirattr"
 	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/signingattr"
+	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/trustpolicyattr"
 )
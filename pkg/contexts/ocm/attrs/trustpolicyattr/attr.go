@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trustpolicyattr
+
+import (
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext"
+	ocm "github.com/open-component-model/ocm/pkg/contexts/ocm/context"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/signing/trustpolicy"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+const (
+	ATTR_KEY   = "ocm.software/signing/trustpolicy"
+	ATTR_SHORT = "trustpolicy"
+)
+
+type (
+	Context         = ocm.Context
+	ContextProvider = ocm.ContextProvider
+)
+
+func init() {
+	datacontext.RegisterAttributeType(ATTR_KEY, AttributeType{}, ATTR_SHORT)
+}
+
+type AttributeType struct{}
+
+func (a AttributeType) Name() string {
+	return ATTR_KEY
+}
+
+func (a AttributeType) Description() string {
+	return `
+*JSON*
+Trust policy used to verify component versions given as JSON document
+with the field <code>rules</code>. It uses the format of the config type
+<code>` + ConfigType + `</code>.
+`
+}
+
+func (a AttributeType) Encode(v interface{}, marshaller runtime.Marshaler) ([]byte, error) {
+	if _, ok := v.(*trustpolicy.Policy); !ok {
+		return nil, errors.ErrInvalid("trust policy")
+	}
+	return marshaller.Marshal(v)
+}
+
+func (a AttributeType) Decode(data []byte, unmarshaller runtime.Unmarshaler) (interface{}, error) {
+	var value trustpolicy.Policy
+	err := unmarshaller.Unmarshal(data, &value)
+	if err != nil {
+		return nil, err
+	}
+	return &value, value.Validate()
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Get provides the trust policy configured for a context.
+// If no policy is configured nil is returned.
+func Get(ctx ContextProvider) *trustpolicy.Policy {
+	a := ctx.OCMContext().GetAttributes().GetAttribute(ATTR_KEY)
+	if a == nil {
+		return nil
+	}
+	return a.(*trustpolicy.Policy)
+}
+
+func Set(ctx ContextProvider, policy *trustpolicy.Policy) error {
+	return ctx.OCMContext().GetAttributes().SetAttribute(ATTR_KEY, policy)
+}
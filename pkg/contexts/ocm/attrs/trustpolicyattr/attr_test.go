@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trustpolicyattr_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/contexts/config"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/trustpolicyattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/signing/trustpolicy"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+var _ = Describe("attribute", func() {
+	var cfgctx config.Context
+	var ocmctx ocm.Context
+
+	BeforeEach(func() {
+		ocmctx = ocm.New(datacontext.MODE_EXTENDED)
+		cfgctx = ocmctx.ConfigContext()
+	})
+
+	It("decodes config", func() {
+		data := `
+type: trustpolicy.config.ocm.software
+rules:
+- name: acme
+  components:
+  - acme.org/**
+  signatures:
+  - acme
+  - release
+  minSignatures: 1
+  issuers:
+  - CN=acme.org
+  sameAuthority: true
+acceptUnmatched: true
+`
+		cfg := Must(cfgctx.GetConfigForData([]byte(data), runtime.DefaultYAMLEncoding))
+		MustBeSuccessful(cfgctx.ApplyConfig(cfg, "from test"))
+
+		policy := trustpolicyattr.Get(ocmctx)
+		Expect(policy).NotTo(BeNil())
+		Expect(len(policy.Rules)).To(Equal(1))
+		Expect(policy.AcceptsUnmatched()).To(BeTrue())
+		r := policy.Rules[0]
+		Expect(r.Signatures).To(Equal([]string{"acme", "release"}))
+		Expect(r.Required()).To(Equal(1))
+		Expect(r.SameAuthority).To(BeTrue())
+		Expect(policy.RuleFor("acme.org/test")).To(BeIdenticalTo(r))
+		Expect(policy.RuleFor("other.org/test")).To(BeNil())
+	})
+
+	It("merges config", func() {
+		MustBeSuccessful(cfgctx.ApplyConfig(trustpolicyattr.New(
+			&trustpolicy.Rule{Name: "a", Components: []string{"a"}},
+			&trustpolicy.Rule{Name: "b", Components: []string{"b"}},
+		), "first"))
+		MustBeSuccessful(cfgctx.ApplyConfig(trustpolicyattr.New(
+			&trustpolicy.Rule{Name: "a", Components: []string{"x"}},
+			&trustpolicy.Rule{Name: "c", Components: []string{"*"}},
+		), "second"))
+
+		policy := trustpolicyattr.Get(ocmctx)
+		Expect(len(policy.Rules)).To(Equal(3))
+		Expect(policy.RuleFor("a").Name).To(Equal("c"))
+		Expect(policy.RuleFor("x").Name).To(Equal("a"))
+	})
+
+	It("rejects invalid rules", func() {
+		cfg := trustpolicyattr.New(
+			&trustpolicy.Rule{Name: "a", Components: []string{"a"}, Signatures: []string{"s"}, MinSignatures: 2},
+		)
+		err := cfg.ApplyTo(cfgctx, ocmctx)
+		Expect(err).To(MatchError(ContainSubstring(`minimum number of signatures "2" is invalid`)))
+	})
+})
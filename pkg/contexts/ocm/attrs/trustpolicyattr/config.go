@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trustpolicyattr
+
+import (
+	cfgcpi "github.com/open-component-model/ocm/pkg/contexts/config/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/signing/trustpolicy"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+const (
+	ConfigType   = "trustpolicy" + cfgcpi.OCM_CONFIG_TYPE_SUFFIX
+	ConfigTypeV1 = ConfigType + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	cfgcpi.RegisterConfigType(cfgcpi.NewConfigType[*Config](ConfigType, usage))
+	cfgcpi.RegisterConfigType(cfgcpi.NewConfigType[*Config](ConfigTypeV1, usage))
+}
+
+// Config describes a set of trust policy rules.
+type Config struct {
+	runtime.ObjectVersionedType `json:",inline"`
+	Rules                       []*trustpolicy.Rule `json:"rules,omitempty"`
+	AcceptUnmatched             *bool               `json:"acceptUnmatched,omitempty"`
+}
+
+// New creates a new trust policy config.
+func New(rules ...*trustpolicy.Rule) *Config {
+	return &Config{
+		ObjectVersionedType: runtime.NewVersionedTypedObject(ConfigType),
+		Rules:               rules,
+	}
+}
+
+func (a *Config) GetType() string {
+	return ConfigType
+}
+
+func (a *Config) AddRule(rule *trustpolicy.Rule) {
+	a.Rules = append(a.Rules, rule)
+}
+
+func (a *Config) ApplyTo(ctx cfgcpi.Context, target interface{}) error {
+	t, ok := target.(Context)
+	if !ok {
+		return cfgcpi.ErrNoContext(ConfigType)
+	}
+	policy := trustpolicy.New(a.Rules...)
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	if old := Get(t); old != nil {
+		policy = old.Copy()
+		policy.AddRules(a.Rules...)
+	}
+	if a.AcceptUnmatched != nil {
+		policy.AcceptUnmatched = *a.AcceptUnmatched
+	}
+	return errors.Wrapf(Set(t, policy), "applying config failed")
+}
+
+const usage = `
+The config type <code>` + ConfigType + `</code> can be used to define
+a trust policy used to verify component versions. If configured, the
+verification of a component version evaluates the policy for all component
+versions in the closure of the verified component version.
+
+<pre>
+    type: ` + ConfigType + `
+    rules:
+      - name: &lt;rule name>
+        components:
+          - &lt;component name pattern>
+        signatures:
+          - &lt;signature name>
+        minSignatures: &lt;number of required signatures>
+        issuers:
+          - &lt;distinguished name>
+        rootCertificates:
+          - path: &lt;file path>
+        sameAuthority: &lt;true or false>
+    acceptUnmatched: &lt;true or false>
+</pre>
+
+For every component version the first rule matching the component name is
+evaluated. Component versions without a matching rule are rejected, unless
+the field <code>acceptUnmatched</code> is set to <code>true</code>.
+Rules with an already known name replace the existing rule.
+A rule supports the following fields:
+- <code>name</code> *string*: the name of the rule used for reporting
+- <code>components</code> *string array*: component name patterns, the
+  pattern <code>*</code> matches all components and a trailing <code>/**</code>
+  matches all components with the given prefix.
+- <code>signatures</code> *string array*: the signature names considered by the rule.
+  If not given, all signatures of a component version are considered.
+- <code>minSignatures</code> *int*: the number of considered signatures, which
+  must be valid and trusted (m-of-n). It defaults to the number of given
+  signature names, or one, if no names are given.
+- <code>issuers</code> *string array*: the trusted issuers
+  (distinguished names) of public key certificates. Signatures without
+  certificate (plain public keys) are rejected by such a rule.
+- <code>rootCertificates</code> *key array*: the certificate authorities trusted
+  for the matching components. A signature must provide a certificate
+  chain anchored in one of them. A key value might be given by one of the fields
+  <code>path</code>, <code>data</code> or <code>stringdata</code>
+  (see config type <code>keys.config.ocm.software</code>).
+- <code>sameAuthority</code> *bool*: referenced component versions must
+  be signed by the same authority. The authority of a signature is the issuer
+  of the public key certificate, or the signature name, if no certificate
+  is given.
+`
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azureblob
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/open-component-model/ocm/pkg/azureblob"
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/azureblob/identity"
+	access "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/azureblob"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/logging"
+)
+
+const BLOB_HANDLER_NAME = "ocm/" + access.Type
+
+type blobHandler struct {
+	spec *Config
+}
+
+func NewBlobHandler(spec *Config) cpi.BlobHandler {
+	return &blobHandler{spec}
+}
+
+// StoreBlob uploads the blob into the configured container.
+// The blob name is composed of the configured prefix and the
+// digest of the blob content.
+func (b *blobHandler) StoreBlob(blob cpi.BlobAccess, _ string, _ string, _ cpi.AccessSpec, ctx cpi.StorageContext) (cpi.AccessSpec, error) {
+	if b.spec == nil {
+		return nil, nil
+	}
+	if b.spec.AccountName == "" || b.spec.Container == "" {
+		return nil, fmt.Errorf("azure storage account and container required")
+	}
+
+	dig := blob.Digest()
+	if dig == blobaccess.BLOB_UNKNOWN_DIGEST {
+		var err error
+		dig, err = blobaccess.Digest(blob)
+		if err != nil {
+			return nil, err
+		}
+	}
+	name := path.Join(b.spec.Prefix, dig.Encoded())
+
+	log := logging.Context().Logger(identity.REALM).WithValues("container", b.spec.Container, "blob", name)
+	log.Debug("identified")
+
+	serviceURL := azureblob.ServiceURL(b.spec.AccountName, b.spec.Endpoint)
+	creds := identity.GetCredentials(ctx.GetContext(), serviceURL, b.spec.Container, name)
+	if creds == nil {
+		log.Debug("no credentials found")
+	}
+	client, err := azureblob.NewClient(b.spec.AccountName, b.spec.Endpoint, creds)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Upload(b.spec.Container, name, blob); err != nil {
+		return nil, err
+	}
+	return access.New(b.spec.AccountName, b.spec.Container, name, b.spec.Endpoint, blob.MimeType()), nil
+}
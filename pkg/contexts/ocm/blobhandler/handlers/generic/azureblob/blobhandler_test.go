@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azureblob_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	access "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/azureblob"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/handlers/generic/azureblob"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const (
+	ACCOUNT   = "devstoreaccount1"
+	CONTAINER = "blobs"
+	CONTENT   = "blob content"
+)
+
+type storageContext struct {
+	cpi.StorageContext
+	ctx cpi.Context
+}
+
+func (s *storageContext) GetContext() cpi.Context {
+	return s.ctx
+}
+
+type blob struct {
+	data  []byte
+	ctype string
+	md5   string
+}
+
+// fakeServer is a minimal stand-in for the blob service (like Azurite).
+type fakeServer struct {
+	lock  sync.Mutex
+	blobs map[string]*blob
+	puts  int
+}
+
+func (s *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		s.blobs[r.URL.Path] = &blob{data, r.Header.Get("Content-Type"), r.Header.Get("Content-MD5")}
+		s.puts++
+		w.WriteHeader(http.StatusCreated)
+	default:
+		b := s.blobs[r.URL.Path]
+		if b == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", b.ctype)
+		w.Header().Set("Content-MD5", b.md5)
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			w.Write(b.data)
+		}
+	}
+}
+
+var _ = Describe("Azure Blob Storage upload", func() {
+	var (
+		ctx    ocm.Context
+		sctx   cpi.StorageContext
+		fake   *fakeServer
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		ctx = ocm.New()
+		sctx = &storageContext{ctx: ctx}
+		fake = &fakeServer{blobs: map[string]*blob{}}
+		server = httptest.NewServer(fake)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("uploads blob and reuses existing content", func() {
+		endpoint := server.URL + "/" + ACCOUNT
+		h := azureblob.NewBlobHandler(&azureblob.Config{AccountName: ACCOUNT, Container: CONTAINER, Endpoint: endpoint, Prefix: "ocm"})
+		name := "ocm/" + digest.FromString(CONTENT).Encoded()
+
+		spec := Must(h.StoreBlob(blobaccess.ForString(mime.MIME_TEXT, CONTENT), "", "", nil, sctx))
+		Expect(spec).To(Equal(access.New(ACCOUNT, CONTAINER, name, endpoint, mime.MIME_TEXT)))
+		Expect(string(fake.blobs["/"+ACCOUNT+"/"+CONTAINER+"/"+name].data)).To(Equal(CONTENT))
+
+		Expect(Must(h.StoreBlob(blobaccess.ForString(mime.MIME_TEXT, CONTENT), "", "", nil, sctx))).To(Equal(spec))
+		Expect(fake.puts).To(Equal(1))
+
+		m := Must(spec.AccessMethod(&cpi.DummyComponentVersionAccess{Context: ctx}))
+		defer Close(m)
+		Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+	})
+
+	It("requires container", func() {
+		h := azureblob.NewBlobHandler(&azureblob.Config{AccountName: ACCOUNT})
+		_, err := h.StoreBlob(blobaccess.ForString(mime.MIME_TEXT, CONTENT), "", "", nil, sctx)
+		Expect(err).To(MatchError("azure storage account and container required"))
+	})
+})
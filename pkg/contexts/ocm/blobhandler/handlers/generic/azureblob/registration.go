@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azureblob
+
+import (
+	"fmt"
+
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/registrations"
+)
+
+type Config struct {
+	// AccountName is the name of the storage account.
+	AccountName string `json:"accountName"`
+	// Container is the name of the target container.
+	Container string `json:"container"`
+	// Endpoint is an optional blob service URL.
+	Endpoint string `json:"endpoint,omitempty"`
+	// Prefix is an optional name prefix for the stored blobs.
+	Prefix string `json:"prefix,omitempty"`
+}
+
+func init() {
+	cpi.RegisterBlobHandlerRegistrationHandler(BLOB_HANDLER_NAME, &RegistrationHandler{})
+}
+
+type RegistrationHandler struct{}
+
+var _ cpi.BlobHandlerRegistrationHandler = (*RegistrationHandler)(nil)
+
+func (r *RegistrationHandler) RegisterByName(handler string, ctx cpi.Context, config cpi.BlobHandlerConfig, olist ...cpi.BlobHandlerOption) (bool, error) {
+	if handler != "" {
+		return true, fmt.Errorf("invalid azureblob handler %q", handler)
+	}
+	if config == nil {
+		return true, fmt.Errorf("azure blob storage target specification required")
+	}
+	cfg, err := registrations.DecodeConfig[Config](config)
+	if err != nil {
+		return true, errors.Wrapf(err, "blob handler configuration")
+	}
+
+	ctx.BlobHandlers().Register(NewBlobHandler(cfg), cpi.NewBlobHandlerOptions(olist...))
+
+	return true, nil
+}
+
+func (r *RegistrationHandler) GetHandlers(_ cpi.Context) registrations.HandlerInfos {
+	return registrations.NewLeafHandlerInfo("uploading blobs to Azure Blob Storage", `
+The <code>`+BLOB_HANDLER_NAME+`</code> uploader is able to re-host
+resource blobs into a container of an Azure Blob Storage account.
+The blob name is composed of the configured prefix and the digest
+of the blob content. Existing blobs with the same content are reused.
+By default, it is registered for all artifact and media types, the
+registration may be restricted by the standard uploader options.
+
+It accepts a config with the following fields:
+- <code>accountName</code>: the name of the storage account
+- <code>container</code>: the name of the target container
+- <code>endpoint</code>: (optional) the blob service URL
+- <code>prefix</code>: (optional) the name prefix for the stored blobs
+`,
+	)
+}
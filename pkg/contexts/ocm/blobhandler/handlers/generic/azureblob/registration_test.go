@@ -0,0 +1,22 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azureblob_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/handlers/generic/azureblob"
+	"github.com/open-component-model/ocm/pkg/registrations"
+)
+
+var _ = Describe("Config deserialization Test Environment", func() {
+
+	It("deserializes struct", func() {
+		cfg := Must(registrations.DecodeConfig[azureblob.Config](`{"accountName":"acc","container":"test","prefix":"ocm"}`))
+		Expect(cfg).To(Equal(&azureblob.Config{AccountName: "acc", Container: "test", Prefix: "ocm"}))
+	})
+})
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gcs
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/gcs/identity"
+	access "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/gcs"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/gcs"
+	"github.com/open-component-model/ocm/pkg/logging"
+)
+
+const BLOB_HANDLER_NAME = "ocm/" + access.Type
+
+type blobHandler struct {
+	spec *Config
+}
+
+func NewBlobHandler(spec *Config) cpi.BlobHandler {
+	return &blobHandler{spec}
+}
+
+// StoreBlob uploads the blob into the configured bucket.
+// The object name is composed of the configured prefix and the
+// digest of the blob content.
+func (b *blobHandler) StoreBlob(blob cpi.BlobAccess, _ string, _ string, _ cpi.AccessSpec, ctx cpi.StorageContext) (cpi.AccessSpec, error) {
+	if b.spec == nil {
+		return nil, nil
+	}
+	if b.spec.Bucket == "" {
+		return nil, fmt.Errorf("gcs bucket required")
+	}
+
+	dig := blob.Digest()
+	if dig == blobaccess.BLOB_UNKNOWN_DIGEST {
+		var err error
+		dig, err = blobaccess.Digest(blob)
+		if err != nil {
+			return nil, err
+		}
+	}
+	name := path.Join(b.spec.Prefix, dig.Encoded())
+
+	log := logging.Context().Logger(identity.REALM).WithValues("bucket", b.spec.Bucket, "object", name)
+	log.Debug("identified")
+
+	creds := identity.GetCredentials(ctx.GetContext(), gcs.Endpoint(b.spec.Endpoint), b.spec.Bucket, name)
+	if creds == nil {
+		log.Debug("no credentials found")
+	}
+	client, err := gcs.NewClient(b.spec.Endpoint, creds)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := client.Upload(b.spec.Bucket, name, blob)
+	if err != nil {
+		return nil, err
+	}
+	return access.New(b.spec.Bucket, name, obj.Generation, b.spec.Endpoint, blob.MimeType()), nil
+}
@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gcs_test
+
+import (
+	"crypto/md5" //nolint:gosec // used by the storage service
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	access "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/gcs"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/handlers/generic/gcs"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	mgcs "github.com/open-component-model/ocm/pkg/gcs"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const (
+	BUCKET  = "blobs"
+	CONTENT = "blob content"
+)
+
+type storageContext struct {
+	cpi.StorageContext
+	ctx cpi.Context
+}
+
+func (s *storageContext) GetContext() cpi.Context {
+	return s.ctx
+}
+
+type object struct {
+	meta mgcs.Object
+	data []byte
+}
+
+// fakeServer is a minimal stand-in for the JSON API (like fake-gcs-server).
+type fakeServer struct {
+	lock    sync.Mutex
+	objects map[string]*object
+	uploads int
+}
+
+func (s *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if r.Method == http.MethodPost {
+		data, _ := io.ReadAll(r.Body)
+		sum := md5.Sum(data) //nolint:gosec // see above
+		s.uploads++
+		o := &object{
+			meta: mgcs.Object{
+				Bucket:      BUCKET,
+				Name:        r.URL.Query().Get("name"),
+				ContentType: r.Header.Get("Content-Type"),
+				Size:        strconv.Itoa(len(data)),
+				Generation:  strconv.Itoa(s.uploads),
+				MD5Hash:     base64.StdEncoding.EncodeToString(sum[:]),
+			},
+			data: data,
+		}
+		s.objects[o.meta.Name] = o
+		json.NewEncoder(w).Encode(&o.meta)
+		return
+	}
+	o := s.objects[strings.TrimPrefix(r.URL.Path, "/storage/v1/b/"+BUCKET+"/o/")]
+	if o == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if r.URL.Query().Get("alt") == "media" {
+		w.Write(o.data)
+		return
+	}
+	json.NewEncoder(w).Encode(&o.meta)
+}
+
+var _ = Describe("Google Cloud Storage upload", func() {
+	var (
+		ctx    ocm.Context
+		sctx   cpi.StorageContext
+		fake   *fakeServer
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		ctx = ocm.New()
+		sctx = &storageContext{ctx: ctx}
+		fake = &fakeServer{objects: map[string]*object{}}
+		server = httptest.NewServer(fake)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("uploads blob and reuses existing content", func() {
+		h := gcs.NewBlobHandler(&gcs.Config{Bucket: BUCKET, Endpoint: server.URL, Prefix: "ocm"})
+		name := "ocm/" + digest.FromString(CONTENT).Encoded()
+
+		spec := Must(h.StoreBlob(blobaccess.ForString(mime.MIME_TEXT, CONTENT), "", "", nil, sctx))
+		Expect(spec).To(Equal(access.New(BUCKET, name, "1", server.URL, mime.MIME_TEXT)))
+		Expect(string(fake.objects[name].data)).To(Equal(CONTENT))
+
+		Expect(Must(h.StoreBlob(blobaccess.ForString(mime.MIME_TEXT, CONTENT), "", "", nil, sctx))).To(Equal(spec))
+		Expect(fake.uploads).To(Equal(1))
+
+		m := Must(spec.AccessMethod(&cpi.DummyComponentVersionAccess{Context: ctx}))
+		defer Close(m)
+		Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+	})
+
+	It("requires bucket", func() {
+		h := gcs.NewBlobHandler(&gcs.Config{})
+		_, err := h.StoreBlob(blobaccess.ForString(mime.MIME_TEXT, CONTENT), "", "", nil, sctx)
+		Expect(err).To(MatchError("gcs bucket required"))
+	})
+})
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gcs
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/registrations"
+)
+
+type Config struct {
+	// Bucket is the name of the target bucket.
+	Bucket string `json:"bucket"`
+	// Endpoint is an optional storage endpoint.
+	Endpoint string `json:"endpoint,omitempty"`
+	// Prefix is an optional name prefix for the stored objects.
+	Prefix string `json:"prefix,omitempty"`
+}
+
+type rawConfig Config
+
+func (c *Config) UnmarshalJSON(data []byte) error {
+	err := json.Unmarshal(data, &c.Bucket)
+	if err == nil {
+		return nil
+	}
+	var raw rawConfig
+	err = json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	*c = Config(raw)
+
+	return nil
+}
+
+func init() {
+	cpi.RegisterBlobHandlerRegistrationHandler(BLOB_HANDLER_NAME, &RegistrationHandler{})
+}
+
+type RegistrationHandler struct{}
+
+var _ cpi.BlobHandlerRegistrationHandler = (*RegistrationHandler)(nil)
+
+func (r *RegistrationHandler) RegisterByName(handler string, ctx cpi.Context, config cpi.BlobHandlerConfig, olist ...cpi.BlobHandlerOption) (bool, error) {
+	if handler != "" {
+		return true, fmt.Errorf("invalid gcs handler %q", handler)
+	}
+	if config == nil {
+		return true, fmt.Errorf("gcs target specification required")
+	}
+	cfg, err := registrations.DecodeConfig[Config](config)
+	if err != nil {
+		return true, errors.Wrapf(err, "blob handler configuration")
+	}
+
+	ctx.BlobHandlers().Register(NewBlobHandler(cfg), cpi.NewBlobHandlerOptions(olist...))
+
+	return true, nil
+}
+
+func (r *RegistrationHandler) GetHandlers(_ cpi.Context) registrations.HandlerInfos {
+	return registrations.NewLeafHandlerInfo("uploading blobs to Google Cloud Storage", `
+The <code>`+BLOB_HANDLER_NAME+`</code> uploader is able to re-host
+resource blobs into a Google Cloud Storage bucket.
+The object name is composed of the configured prefix and the digest
+of the blob content. Existing objects with the same content are reused.
+By default, it is registered for all artifact and media types, the
+registration may be restricted by the standard uploader options.
+
+It accepts a plain string for the bucket name or a config with the following fields:
+- <code>bucket</code>: the name of the target bucket
+- <code>endpoint</code>: (optional) the storage endpoint
+- <code>prefix</code>: (optional) the name prefix for the stored objects
+`,
+	)
+}
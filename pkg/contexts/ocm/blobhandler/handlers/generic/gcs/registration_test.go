@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gcs_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/handlers/generic/gcs"
+	"github.com/open-component-model/ocm/pkg/registrations"
+)
+
+var _ = Describe("Config deserialization Test Environment", func() {
+
+	It("deserializes string", func() {
+		cfg := Must(registrations.DecodeConfig[gcs.Config]("test"))
+		Expect(cfg).To(Equal(&gcs.Config{Bucket: "test"}))
+	})
+
+	It("deserializes struct", func() {
+		cfg := Must(registrations.DecodeConfig[gcs.Config](`{"bucket":"test","prefix":"ocm"}`))
+		Expect(cfg).To(Equal(&gcs.Config{Bucket: "test", Prefix: "ocm"}))
+	})
+})
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package maven
+
+import (
+	"fmt"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/maven/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext/attrs/vfsattr"
+	access "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/maven"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	"github.com/open-component-model/ocm/pkg/logging"
+	"github.com/open-component-model/ocm/pkg/maven"
+)
+
+const BLOB_HANDLER_NAME = "ocm/" + resourcetypes.MAVEN_ARTIFACT
+
+type artifactHandler struct {
+	spec *Config
+}
+
+func NewArtifactHandler(repospec *Config) cpi.BlobHandler {
+	return &artifactHandler{repospec}
+}
+
+// StoreBlob uploads the blob to the configured Maven repository.
+// The Maven coordinates are taken from a global maven access specification
+// or from the reference hint (<groupId>:<artifactId>:<version>[:<classifier>[:<extension>]]).
+// If no coordinates can be determined, the blob is not handled.
+func (b *artifactHandler) StoreBlob(blob cpi.BlobAccess, _ string, hint string, global cpi.AccessSpec, ctx cpi.StorageContext) (cpi.AccessSpec, error) {
+	if b.spec == nil {
+		return nil, nil
+	}
+
+	var coords *maven.Coordinates
+	if spec, ok := global.(*access.AccessSpec); ok {
+		coords = spec.GetCoordinates()
+	} else {
+		if hint == "" {
+			return nil, nil
+		}
+		c, err := maven.Parse(hint)
+		if err != nil {
+			return nil, nil
+		}
+		coords = c
+	}
+
+	if b.spec.Url == "" {
+		return nil, fmt.Errorf("maven repository url not provided")
+	}
+
+	log := logging.Context().Logger(identity.REALM).WithValues("artifact", coords.String(), "repository", b.spec.Url)
+	log.Debug("identified")
+
+	repo, err := maven.NewUrlRepository(b.spec.Url, vfsattr.Get(ctx.GetContext()))
+	if err != nil {
+		return nil, err
+	}
+	var creds common.Properties
+	if !repo.IsFileSystem() {
+		creds = identity.GetCredentials(ctx.GetContext(), b.spec.Url, coords.GroupId)
+		if creds == nil {
+			log.Debug("no credentials found")
+		}
+	}
+	if err := repo.Upload(coords, blob, creds); err != nil {
+		return nil, err
+	}
+	return access.NewForCoordinates(b.spec.Url, coords), nil
+}
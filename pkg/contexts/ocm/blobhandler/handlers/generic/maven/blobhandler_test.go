@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package maven_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/contexts/datacontext/attrs/vfsattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	access "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/maven"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/handlers/generic/maven"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	mvn "github.com/open-component-model/ocm/pkg/maven"
+)
+
+const CONTENT = "jar content"
+
+type storageContext struct {
+	cpi.StorageContext
+	ctx cpi.Context
+}
+
+func (s *storageContext) GetContext() cpi.Context {
+	return s.ctx
+}
+
+var _ = Describe("Maven upload", func() {
+	var (
+		ctx    ocm.Context
+		fs     vfs.FileSystem
+		sctx   cpi.StorageContext
+		coords *mvn.Coordinates
+		blob   blobaccess.BlobAccess
+	)
+
+	BeforeEach(func() {
+		ctx = ocm.New()
+		fs = memoryfs.New()
+		vfsattr.Set(ctx, fs)
+		sctx = &storageContext{ctx: ctx}
+		coords = mvn.NewCoordinates("org.acme", "demo", "1.0.0", "sources")
+		blob = blobaccess.ForString(mvn.MIME_JAR, CONTENT)
+	})
+
+	It("uploads with coordinates from global access", func() {
+		h := maven.NewArtifactHandler(&maven.Config{Url: "file:///target"})
+		global := access.NewForCoordinates("https://repo.acme.org/maven2", coords)
+
+		spec := Must(h.StoreBlob(blob, resourcetypes.MAVEN_ARTIFACT, "", global, sctx))
+		Expect(spec).To(Equal(access.NewForCoordinates("file:///target", coords)))
+
+		Expect(string(Must(vfs.ReadFile(fs, "/target/org/acme/demo/1.0.0/demo-1.0.0-sources.jar")))).To(Equal(CONTENT))
+		sum := sha256.Sum256([]byte(CONTENT))
+		Expect(string(Must(vfs.ReadFile(fs, "/target/org/acme/demo/1.0.0/demo-1.0.0-sources.jar.sha256")))).To(Equal(hex.EncodeToString(sum[:])))
+		Expect(vfs.FileExists(fs, "/target/org/acme/demo/1.0.0/demo-1.0.0-sources.jar.sha1")).To(BeTrue())
+
+		m := Must(spec.AccessMethod(&cpi.DummyComponentVersionAccess{Context: ctx}))
+		defer Close(m)
+		Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+	})
+
+	It("uploads with coordinates from hint and accepts identical content", func() {
+		h := maven.NewArtifactHandler(&maven.Config{Url: "/target"})
+
+		spec := Must(h.StoreBlob(blob, resourcetypes.MAVEN_ARTIFACT, coords.String(), nil, sctx))
+		Expect(spec).To(Equal(access.NewForCoordinates("/target", coords)))
+		Expect(Must(h.StoreBlob(blob, resourcetypes.MAVEN_ARTIFACT, coords.String(), nil, sctx))).To(Equal(spec))
+
+		_, err := h.StoreBlob(blobaccess.ForString(mvn.MIME_JAR, "other"), resourcetypes.MAVEN_ARTIFACT, coords.String(), nil, sctx)
+		Expect(err).To(MatchError("org.acme:demo:1.0.0:sources already exists in file:///target with different content"))
+	})
+
+	It("reports existing files without checksum", func() {
+		h := maven.NewArtifactHandler(&maven.Config{Url: "/target"})
+		MustBeSuccessful(fs.MkdirAll("/target/org/acme/demo/1.0.0", 0o755))
+		MustBeSuccessful(vfs.WriteFile(fs, "/target/org/acme/demo/1.0.0/demo-1.0.0-sources.jar", []byte(CONTENT), 0o644))
+
+		_, err := h.StoreBlob(blob, resourcetypes.MAVEN_ARTIFACT, coords.String(), nil, sctx)
+		Expect(err).To(MatchError("org.acme:demo:1.0.0:sources already exists in file:///target without checksum"))
+	})
+
+	It("rejects coordinates escaping the repository", func() {
+		h := maven.NewArtifactHandler(&maven.Config{Url: "/target/repo"})
+
+		global := access.NewForCoordinates("https://repo.acme.org/maven2", mvn.NewCoordinates("org.acme", "..", "1.0.0"))
+		ExpectError(h.StoreBlob(blob, resourcetypes.MAVEN_ARTIFACT, "", global, sctx)).To(MatchError(`artifactId ".." is invalid`))
+
+		for _, hint := range []string{
+			"org..acme:demo:1.0.0",
+			"org/acme:demo:1.0.0",
+			"org.acme:demo/x:1.0.0",
+			"org.acme:demo:..",
+			"org.acme:demo:1.0.0:../x",
+			"org.acme:demo:1.0.0::\\jar",
+		} {
+			Expect(h.StoreBlob(blob, resourcetypes.MAVEN_ARTIFACT, hint, nil, sctx)).To(BeNil(), hint)
+		}
+		Expect(vfs.DirExists(fs, "/target")).To(BeFalse())
+	})
+
+	It("ignores blobs without coordinates", func() {
+		h := maven.NewArtifactHandler(&maven.Config{Url: "/target"})
+		Expect(h.StoreBlob(blob, resourcetypes.MAVEN_ARTIFACT, "demo", nil, sctx)).To(BeNil())
+	})
+})
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package maven
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/registrations"
+)
+
+type Config struct {
+	Url string `json:"url"`
+}
+
+type rawConfig Config
+
+func (c *Config) UnmarshalJSON(data []byte) error {
+	err := json.Unmarshal(data, &c.Url)
+	if err == nil {
+		return nil
+	}
+	var raw rawConfig
+	err = json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	*c = Config(raw)
+
+	return nil
+}
+
+func init() {
+	cpi.RegisterBlobHandlerRegistrationHandler(BLOB_HANDLER_NAME, &RegistrationHandler{})
+}
+
+type RegistrationHandler struct{}
+
+var _ cpi.BlobHandlerRegistrationHandler = (*RegistrationHandler)(nil)
+
+func (r *RegistrationHandler) RegisterByName(handler string, ctx cpi.Context, config cpi.BlobHandlerConfig, olist ...cpi.BlobHandlerOption) (bool, error) {
+	if handler != "" {
+		return true, fmt.Errorf("invalid %s handler %q", resourcetypes.MAVEN_ARTIFACT, handler)
+	}
+	if config == nil {
+		return true, fmt.Errorf("maven target specification required")
+	}
+	cfg, err := registrations.DecodeConfig[Config](config)
+	if err != nil {
+		return true, errors.Wrapf(err, "blob handler configuration")
+	}
+
+	ctx.BlobHandlers().Register(NewArtifactHandler(cfg),
+		cpi.ForArtifactType(resourcetypes.MAVEN_ARTIFACT),
+		cpi.NewBlobHandlerOptions(olist...),
+	)
+
+	return true, nil
+}
+
+func (r *RegistrationHandler) GetHandlers(_ cpi.Context) registrations.HandlerInfos {
+	return registrations.NewLeafHandlerInfo("uploading maven artifacts", `
+The <code>`+BLOB_HANDLER_NAME+`</code> uploader is able to upload files of
+Maven artifacts (resource type <code>`+resourcetypes.MAVEN_ARTIFACT+`</code>)
+into a Maven repository according to the Maven repository layout.
+The coordinates are taken from a <code>maven</code> access specification or
+from the reference hint (<code>groupId:artifactId:version[:classifier[:extension]]</code>).
+Checksum files (sha256 and sha1) are uploaded together with the artifact
+file. The <code>maven-metadata.xml</code> files are not maintained.
+
+It accepts a plain string for the URL or a config with the following field:
+'url': the URL of the Maven repository (http(s) or file URL).
+`,
+	)
+}
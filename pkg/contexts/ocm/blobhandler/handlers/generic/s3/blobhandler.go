@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/common/accessio/downloader/s3"
+	access "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/s3"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/s3/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	ocmerrors "github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/logging"
+)
+
+const BLOB_HANDLER_NAME = "ocm/" + access.Type
+
+var REALM = logging.DefineSubRealm("S3 upload handler", "blobhandler/s3")
+
+type blobHandler struct {
+	spec *Config
+}
+
+func NewBlobHandler(spec *Config) cpi.BlobHandler {
+	return &blobHandler{spec}
+}
+
+// StoreBlob uploads the blob into the configured bucket.
+// The object key is composed of the configured prefix and the
+// digest of the blob content. If the object already exists,
+// it is reused.
+func (b *blobHandler) StoreBlob(blob cpi.BlobAccess, _ string, _ string, _ cpi.AccessSpec, ctx cpi.StorageContext) (_ cpi.AccessSpec, rerr error) {
+	if b.spec == nil {
+		return nil, nil
+	}
+	if b.spec.Bucket == "" {
+		return nil, fmt.Errorf("s3 bucket required")
+	}
+
+	dig := blob.Digest()
+	if dig == blobaccess.BLOB_UNKNOWN_DIGEST {
+		var err error
+		dig, err = blobaccess.Digest(blob)
+		if err != nil {
+			return nil, err
+		}
+	}
+	key := path.Join(b.spec.Prefix, dig.Encoded())
+
+	log := logging.Context().Logger(REALM).WithValues("bucket", b.spec.Bucket, "key", key)
+	log.Debug("identified")
+
+	var awsCreds *s3.AWSCreds
+	creds, err := identity.GetCredentials(ctx.GetContext(), identity.EndpointHost(b.spec.Endpoint), b.spec.Bucket, key, "")
+	if err != nil {
+		return nil, err
+	}
+	if creds != nil && creds.GetProperty(identity.ATTR_AWS_ACCESS_KEY_ID) != "" {
+		awsCreds = &s3.AWSCreds{
+			AccessKeyID:  creds.GetProperty(identity.ATTR_AWS_ACCESS_KEY_ID),
+			AccessSecret: creds.GetProperty(identity.ATTR_AWS_SECRET_ACCESS_KEY),
+			SessionToken: creds.GetProperty(identity.ATTR_TOKEN),
+		}
+	} else {
+		log.Debug("no credentials found")
+	}
+
+	cctx := context.Background()
+	client, region, err := s3.NewClient(cctx, b.spec.Region, b.spec.Bucket, b.spec.Endpoint, awsCreds)
+	if err != nil {
+		return nil, err
+	}
+
+	result := access.New(region, b.spec.Bucket, key, "", blob.MimeType())
+	result.Endpoint = b.spec.Endpoint
+
+	head, err := client.HeadObject(cctx, &awss3.HeadObjectInput{
+		Bucket: aws.String(b.spec.Bucket),
+		Key:    aws.String(key),
+	})
+	if err == nil {
+		log.Debug("object already exists, skipping upload")
+		result.Version = aws.ToString(head.VersionId)
+		return result, nil
+	}
+	var notFound *types.NotFound
+	if !errors.As(err, &notFound) {
+		return nil, ocmerrors.Wrapf(err, "cannot check object %s in bucket %s", key, b.spec.Bucket)
+	}
+
+	rd, err := blob.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer ocmerrors.PropagateError(&rerr, rd.Close)
+
+	log.Debug("uploading")
+	out, err := manager.NewUploader(client).Upload(cctx, &awss3.PutObjectInput{
+		Bucket:      aws.String(b.spec.Bucket),
+		Key:         aws.String(key),
+		Body:        rd,
+		ContentType: aws.String(blob.MimeType()),
+	})
+	if err != nil {
+		return nil, ocmerrors.Wrapf(err, "cannot upload object %s to bucket %s", key, b.spec.Bucket)
+	}
+	log.Debug("successfully uploaded")
+	result.Version = aws.ToString(out.VersionID)
+	return result, nil
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package s3_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	access "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/s3"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/handlers/generic/s3"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const (
+	REGION  = "us-east-1"
+	BUCKET  = "blobs"
+	CONTENT = "blob content"
+)
+
+type storageContext struct {
+	cpi.StorageContext
+	ctx cpi.Context
+}
+
+func (s *storageContext) GetContext() cpi.Context {
+	return s.ctx
+}
+
+// fakeServer is a minimal stand-in for an S3 compatible service
+// (like MinIO) using path style addressing.
+type fakeServer struct {
+	lock    sync.Mutex
+	objects map[string][]byte
+	puts    int
+}
+
+func (s *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		s.objects[r.URL.Path] = data
+		s.puts++
+		w.Header().Set("x-amz-version-id", "v1")
+		w.WriteHeader(http.StatusOK)
+	default:
+		data, ok := s.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("x-amz-version-id", "v1")
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(data))
+	}
+}
+
+var _ = Describe("S3 upload", func() {
+	var (
+		ctx    ocm.Context
+		sctx   cpi.StorageContext
+		fake   *fakeServer
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		ctx = ocm.New()
+		sctx = &storageContext{ctx: ctx}
+		fake = &fakeServer{objects: map[string][]byte{}}
+		server = httptest.NewServer(fake)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("uploads blob and reuses existing object", func() {
+		h := s3.NewBlobHandler(&s3.Config{Region: REGION, Bucket: BUCKET, Endpoint: server.URL, Prefix: "ocm"})
+		key := "ocm/" + digest.FromString(CONTENT).Encoded()
+
+		spec := Must(h.StoreBlob(blobaccess.ForString(mime.MIME_TEXT, CONTENT), "", "", nil, sctx))
+		expected := access.New(REGION, BUCKET, key, "v1", mime.MIME_TEXT)
+		expected.Endpoint = server.URL
+		Expect(spec).To(Equal(expected))
+		Expect(string(fake.objects["/"+BUCKET+"/"+key])).To(Equal(CONTENT))
+
+		Expect(Must(h.StoreBlob(blobaccess.ForString(mime.MIME_TEXT, CONTENT), "", "", nil, sctx))).To(Equal(spec))
+		Expect(fake.puts).To(Equal(1))
+
+		m := Must(spec.AccessMethod(&cpi.DummyComponentVersionAccess{Context: ctx}))
+		defer Close(m)
+		Expect(string(Must(m.Get()))).To(Equal(CONTENT))
+	})
+
+	It("requires bucket", func() {
+		h := s3.NewBlobHandler(&s3.Config{})
+		_, err := h.StoreBlob(blobaccess.ForString(mime.MIME_TEXT, CONTENT), "", "", nil, sctx)
+		Expect(err).To(MatchError("s3 bucket required"))
+	})
+})
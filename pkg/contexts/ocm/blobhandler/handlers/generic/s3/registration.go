@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package s3
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/registrations"
+)
+
+type Config struct {
+	// Region is the optional region of the bucket.
+	Region string `json:"region,omitempty"`
+	// Bucket is the name of the target bucket.
+	Bucket string `json:"bucket"`
+	// Prefix is an optional key prefix for the stored objects.
+	Prefix string `json:"prefix,omitempty"`
+	// Endpoint is an optional S3 compatible endpoint (for example MinIO).
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+type rawConfig Config
+
+func (c *Config) UnmarshalJSON(data []byte) error {
+	err := json.Unmarshal(data, &c.Bucket)
+	if err == nil {
+		return nil
+	}
+	var raw rawConfig
+	err = json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	*c = Config(raw)
+
+	return nil
+}
+
+func init() {
+	cpi.RegisterBlobHandlerRegistrationHandler(BLOB_HANDLER_NAME, &RegistrationHandler{})
+}
+
+type RegistrationHandler struct{}
+
+var _ cpi.BlobHandlerRegistrationHandler = (*RegistrationHandler)(nil)
+
+func (r *RegistrationHandler) RegisterByName(handler string, ctx cpi.Context, config cpi.BlobHandlerConfig, olist ...cpi.BlobHandlerOption) (bool, error) {
+	if handler != "" {
+		return true, fmt.Errorf("invalid s3 handler %q", handler)
+	}
+	if config == nil {
+		return true, fmt.Errorf("s3 target specification required")
+	}
+	cfg, err := registrations.DecodeConfig[Config](config)
+	if err != nil {
+		return true, errors.Wrapf(err, "blob handler configuration")
+	}
+
+	ctx.BlobHandlers().Register(NewBlobHandler(cfg), cpi.NewBlobHandlerOptions(olist...))
+
+	return true, nil
+}
+
+func (r *RegistrationHandler) GetHandlers(_ cpi.Context) registrations.HandlerInfos {
+	return registrations.NewLeafHandlerInfo("uploading blobs to S3", `
+The <code>`+BLOB_HANDLER_NAME+`</code> uploader is able to re-host
+resource blobs into an S3 bucket. The access specification is
+replaced by an <code>s3</code> access specification.
+The object key is composed of the configured prefix and the digest
+of the blob content. Existing objects are reused.
+
+By default, it is registered for all artifact and media types. The
+registration may be restricted by the standard uploader options
+<code>artifactType</code> and <code>mimeType</code>. This way, several
+registrations (for example in an uploader configuration, see
+<CMD>ocm configfile</CMD>) can be used to describe bucket and prefix
+rules per resource type or media type:
+
+<pre>
+    type: uploader.ocm.config.ocm.software
+    registrations:
+      - name: `+BLOB_HANDLER_NAME+`
+        artifactType: vmImage
+        config:
+          bucket: images
+      - name: `+BLOB_HANDLER_NAME+`
+        mimeType: application/x-tar
+        config:
+          bucket: datasets
+          prefix: tar
+</pre>
+
+It accepts a plain string for the bucket name or a config with the following fields:
+- <code>bucket</code>: the name of the target bucket
+- <code>region</code>: (optional) the region of the bucket
+- <code>prefix</code>: (optional) the key prefix for the stored objects
+- <code>endpoint</code>: (optional) the URL of an S3 compatible service (for example MinIO)
+
+The credentials are requested for the consumer type <code>S3</code>.
+`,
+	)
+}
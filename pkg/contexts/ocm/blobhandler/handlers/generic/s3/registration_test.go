@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package s3_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/config"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/handlers/generic/s3"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/registrations"
+)
+
+var _ = Describe("Config deserialization Test Environment", func() {
+
+	It("deserializes string", func() {
+		cfg := Must(registrations.DecodeConfig[s3.Config]("test"))
+		Expect(cfg).To(Equal(&s3.Config{Bucket: "test"}))
+	})
+
+	It("deserializes struct", func() {
+		cfg := Must(registrations.DecodeConfig[s3.Config](`{"bucket":"test","prefix":"ocm","endpoint":"http://localhost:9000"}`))
+		Expect(cfg).To(Equal(&s3.Config{Bucket: "test", Prefix: "ocm", Endpoint: "http://localhost:9000"}))
+	})
+
+	It("registers rules by uploader config", func() {
+		ctx := ocm.New()
+		cfg := config.New()
+		MustBeSuccessful(cfg.AddRegistration(
+			config.Registration{
+				Name:   s3.BLOB_HANDLER_NAME,
+				Config: `{"bucket":"images"}`,
+				HandlerOptions: cpi.BlobHandlerOptions{
+					BlobHandlerKey: cpi.BlobHandlerKey{ArtifactType: "vmImage"},
+				},
+			},
+			config.Registration{
+				Name:   s3.BLOB_HANDLER_NAME,
+				Config: `{"bucket":"datasets"}`,
+				HandlerOptions: cpi.BlobHandlerOptions{
+					BlobHandlerKey: cpi.BlobHandlerKey{MimeType: "application/x-tar"},
+				},
+			},
+		))
+		MustBeSuccessful(ctx.ConfigContext().ApplyConfig(cfg, "s3"))
+
+		Expect(ctx.BlobHandlers().GetHandler(cpi.BlobHandlerKey{ArtifactType: "vmImage"})).To(Equal(s3.NewBlobHandler(&s3.Config{Bucket: "images"})))
+		Expect(ctx.BlobHandlers().GetHandler(cpi.BlobHandlerKey{MimeType: "application/x-tar"})).To(Equal(s3.NewBlobHandler(&s3.Config{Bucket: "datasets"})))
+	})
+})
@@ -5,8 +5,12 @@
 package handlers
 
 import (
+	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/handlers/generic/azureblob"
+	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/handlers/generic/gcs"
+	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/handlers/generic/maven"
 	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/handlers/generic/npm"
 	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/handlers/generic/ocirepo"
+	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/handlers/generic/s3"
 	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/handlers/oci/ocirepo"
 	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/handlers/ocm/comparch"
 )
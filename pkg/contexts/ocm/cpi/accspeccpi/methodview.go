@@ -20,6 +20,13 @@ type DigestSource interface {
 	GetDigest() (digest.Digest, error)
 }
 
+// SizeSource is an optional interface for access method implementations
+// able to provide the size of the described blob without reading it.
+// If the size cannot be determined, blobaccess.BLOB_UNKNOWN_SIZE is returned.
+type SizeSource interface {
+	GetSize() (int64, error)
+}
+
 // AccessMethodView provides access
 // to the implementation object behind an
 // access method.
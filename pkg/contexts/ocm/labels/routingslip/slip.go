@@ -44,13 +44,22 @@ func (s RoutingSlipIndex) Leaves() []digest.Digest {
 }
 
 func (s RoutingSlipIndex) Verify(ctx Context, name string, issuer *pkix.Name, sig bool, acc SlipAccess) error {
+	return s.VerifyWith(ctx, signingattr.Get(ctx), nil, name, issuer, sig, acc)
+}
+
+// VerifyWith verifies the digest chain of the routing slip including
+// the linked entries of other routing slips. If sig is set, additionally
+// the signatures of the leaf entries are verified with the public key
+// found in the given registry for the slip name. If no key is found, a
+// certificate provided with a signature is used, if it can be validated
+// with the given root certificates.
+func (s RoutingSlipIndex) VerifyWith(ctx Context, registry signing.Registry, roots signutils.GenericCertificatePool, name string, issuer *pkix.Name, sig bool, acc SlipAccess) error {
 	if len(s) == 0 {
 		return nil
 	}
 	leaves := s.Leaves()
 
 	if sig {
-		registry := signingattr.Get(ctx)
 		key := registry.GetPublicKey(name)
 		if key == nil {
 			var err error
@@ -59,18 +68,30 @@ func (s RoutingSlipIndex) Verify(ctx Context, name string, issuer *pkix.Name, si
 				return err
 			}
 		}
-		if key == nil {
-			return errors.ErrNotFound(compdesc.KIND_PUBLIC_KEY, name)
-		}
 		for _, d := range leaves {
 			last := s[d]
+			if last.Signature == nil {
+				return fmt.Errorf("entry %s in %s is not signed", d, name)
+			}
 			handler := registry.GetVerifier(last.Signature.Algorithm)
 			if handler == nil {
 				return errors.ErrUnknown(compdesc.KIND_VERIFY_ALGORITHM, last.Signature.Algorithm)
 			}
+			pub := key
+			if pub == nil {
+				var err error
+				pub, err = publicKeyFromSignature(last.Signature, roots, issuer)
+				if err != nil {
+					if errors.IsErrNotFound(err) {
+						return errors.ErrNotFound(compdesc.KIND_PUBLIC_KEY, name)
+					}
+					return errors.Wrapf(err, "cannot verify entry %s", d)
+				}
+			}
 			sctx := &signing.DefaultSigningContext{
 				Hash:      sha256.Handler{}.Crypto(),
-				PublicKey: key,
+				PublicKey: pub,
+				RootCerts: roots,
 				Issuer:    issuer,
 			}
 			err := handler.Verify(last.Digest.Encoded(), last.Signature.ConvertToSigning(), sctx)
@@ -82,11 +103,38 @@ func (s RoutingSlipIndex) Verify(ctx Context, name string, issuer *pkix.Name, si
 
 	found := generics.Set[digest.Digest]{}
 	for _, id := range leaves {
-		s.verify(ctx, name, id, acc, found)
+		err := s.verify(ctx, name, id, acc, found)
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// publicKeyFromSignature provides the public key of a certificate chain
+// provided with a PEM signature, if it can be validated.
+func publicKeyFromSignature(sig *metav1.SignatureSpec, roots signutils.GenericCertificatePool, issuer *pkix.Name) (signutils.GenericPublicKey, error) {
+	if sig.MediaType != signutils.MediaTypePEM {
+		return nil, errors.ErrNotFound(compdesc.KIND_PUBLIC_KEY)
+	}
+	_, _, certs, err := signutils.GetSignatureFromPem([]byte(sig.Value))
+	if err != nil {
+		return nil, err
+	}
+	if len(certs) == 0 {
+		return nil, errors.ErrNotFound(compdesc.KIND_PUBLIC_KEY)
+	}
+	cert, pool, err := signutils.GetCertificate(certs, false)
+	if err != nil {
+		return nil, err
+	}
+	err = signutils.VerifyCertificate(cert, pool, roots, issuer)
+	if err != nil {
+		return nil, errors.Wrapf(err, "public key certificate")
+	}
+	return cert.PublicKey, nil
+}
+
 func (s RoutingSlipIndex) verify(ctx Context, name string, id digest.Digest, acc SlipAccess, found generics.Set[digest.Digest]) error {
 	cur := s[id]
 	if cur == nil {
@@ -127,8 +175,9 @@ func (s RoutingSlipIndex) verify(ctx Context, name string, id digest.Digest, acc
 		if cur.Parent == nil {
 			break
 		}
-		if cur = s[*cur.Parent]; cur == nil {
-			return fmt.Errorf("parent %q of %q not found in %s", cur.Parent, d, name)
+		parent := *cur.Parent
+		if cur = s[parent]; cur == nil {
+			return fmt.Errorf("parent %q of %q not found in %s", parent, d, name)
 		}
 	}
 	return nil
@@ -200,6 +249,15 @@ func (s *RoutingSlip) Verify(ctx Context, name string, sig bool) error {
 	return s.index.Verify(ctx, name, &s.issuer, sig, s.access)
 }
 
+// VerifyWith verifies the routing slip using the keys of the given
+// registry and the given root certificates (see RoutingSlipIndex.VerifyWith).
+func (s *RoutingSlip) VerifyWith(ctx Context, registry signing.Registry, roots signutils.GenericCertificatePool, sig bool) error {
+	if len(s.entries) == 0 {
+		return nil
+	}
+	return s.index.VerifyWith(ctx, registry, roots, s.name, &s.issuer, sig, s.access)
+}
+
 func (s *RoutingSlip) Add(ctx Context, name string, algo string, e Entry, links []Link, parent ...digest.Digest) (*HistoryEntry, error) {
 	registry := signingattr.Get(ctx)
 	handler := registry.GetSigner(algo)
@@ -277,7 +335,7 @@ func (s *RoutingSlip) Add(ctx Context, name string, algo string, e Entry, links
 		if slip == nil {
 			return nil, errors.ErrNotFound(KIND_ROUTING_SLIP, l.Name)
 		}
-		err = slip.Verify(ctx, name, true)
+		err = slip.Verify(ctx, slip.name, true)
 		if err != nil {
 			return nil, err
 		}
@@ -15,10 +15,12 @@ import (
 	"github.com/opencontainers/go-digest"
 	"sigs.k8s.io/yaml"
 
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/signingattr"
 	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/labels/routingslip"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/labels/routingslip/types/comment"
 	"github.com/open-component-model/ocm/pkg/env/builder"
+	"github.com/open-component-model/ocm/pkg/signing"
 	"github.com/open-component-model/ocm/pkg/signing/handlers/rsa"
 )
 
@@ -91,4 +93,44 @@ var _ = Describe("management", func() {
 		Expect(lslip.Len()).To(Equal(1))
 		Expect(lslip.Get(0).Links).To(Equal([]routingslip.Link{{Name: ORG, Digest: d}}))
 	})
+
+	Context("verification", func() {
+		const THIRD = "third.org"
+
+		var label routingslip.LabelValue
+		var slip, lslip *routingslip.RoutingSlip
+
+		BeforeEach(func() {
+			env.RSAKeyPair(THIRD)
+
+			label = routingslip.LabelValue{}
+			slip = Must(routingslip.NewRoutingSlip(ORG, label))
+			MustBeSuccessful(slip.Add(env.OCMContext(), ORG, rsa.Algorithm, comment.New("start of routing slip"), nil))
+			label.Set(slip)
+
+			lslip = Must(routingslip.NewRoutingSlip(THIRD, label))
+			MustBeSuccessful(lslip.Add(env.OCMContext(), THIRD, rsa.Algorithm, comment.New("linked comment"), []routingslip.Link{{Name: ORG, Digest: slip.Get(0).Digest}}))
+			label.Set(lslip)
+		})
+
+		It("verifies linked slips with dedicated keys", func() {
+			MustBeSuccessful(lslip.Verify(env.OCMContext(), THIRD, true))
+		})
+
+		It("uses preferred keys", func() {
+			_, pub := Must2(rsa.Handler{}.CreateKeyPair())
+			keys := signing.NewKeyRegistry()
+			keys.RegisterPublicKey(ORG, pub)
+			reg := signing.RegistryWithPreferredKeys(signingattr.Get(env.OCMContext()), keys)
+
+			MustBeSuccessful(slip.VerifyWith(env.OCMContext(), signingattr.Get(env.OCMContext()), nil, true))
+			Expect(slip.VerifyWith(env.OCMContext(), reg, nil, true)).To(MatchError(ContainSubstring("crypto/rsa: verification error")))
+		})
+
+		It("detects manipulated linked entry", func() {
+			label[ORG][0].Payload = Must(routingslip.ToGenericEntry(comment.New("manipulated")))
+			lslip = Must(label.Get(THIRD))
+			Expect(lslip.Verify(env.OCMContext(), THIRD, false)).To(MatchError(ContainSubstring("does not match")))
+		})
+	})
 })
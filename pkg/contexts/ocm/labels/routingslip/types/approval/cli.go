@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package approval
+
+import (
+	"github.com/open-component-model/ocm/pkg/cobrautils/flagsets"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/options"
+)
+
+func ConfigHandler() flagsets.ConfigOptionTypeSetHandler {
+	return flagsets.NewConfigOptionTypeSetHandler(
+		Type, AddConfig,
+		options.ApproverOption,
+		options.TicketOption,
+	)
+}
+
+func AddConfig(opts flagsets.ConfigOptions, config flagsets.Config) error {
+	flagsets.AddFieldByOptionP(opts, options.ApproverOption, config, "approver")
+	flagsets.AddFieldByOptionP(opts, options.TicketOption, config, "ticket")
+	return nil
+}
+
+var usage = `
+The approval of a component version, for example for a release.
+`
+
+var formatV1 = `
+The type specific specification fields are:
+
+- **<code>approver</code>**  *string*
+
+  The name of the approving person or instance.
+
+- **<code>ticket</code>** (optional) *string*
+
+  A reference to the ticket documenting the approval.
+`
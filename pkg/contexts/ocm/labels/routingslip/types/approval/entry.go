@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package approval
+
+import (
+	"fmt"
+
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/labels/routingslip/spi"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+// Type is the entry type for an approval.
+const (
+	Type   = "approval"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	spi.Register(spi.NewEntryType[*Entry](Type, spi.WithDescription(usage)))
+	spi.Register(spi.NewEntryType[*Entry](TypeV1, spi.WithFormatSpec(formatV1), spi.WithConfigHandler(ConfigHandler())))
+}
+
+// New creates a new approval entry.
+func New(approver string, ticket string) *Entry {
+	return &Entry{
+		ObjectVersionedType: runtime.NewVersionedTypedObject(Type),
+		Approver:            approver,
+		Ticket:              ticket,
+	}
+}
+
+// Entry describes the approval of a component version.
+type Entry struct {
+	runtime.ObjectVersionedType `json:",inline"`
+
+	// Approver is the name of the approving person or instance.
+	Approver string `json:"approver"`
+	// Ticket is an optional reference to the ticket documenting the approval.
+	Ticket string `json:"ticket,omitempty"`
+}
+
+var _ spi.Entry = (*Entry)(nil)
+
+func (a *Entry) Describe(ctx spi.Context) string {
+	if a.Ticket == "" {
+		return fmt.Sprintf("Approved by %s", a.Approver)
+	}
+	return fmt.Sprintf("Approved by %s (ticket %s)", a.Approver, a.Ticket)
+}
+
+func (a *Entry) Validate(spi.Context) error {
+	if a.Approver == "" {
+		return errors.ErrRequired("approver")
+	}
+	return nil
+}
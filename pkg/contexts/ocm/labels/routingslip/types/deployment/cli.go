@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deployment
+
+import (
+	"github.com/open-component-model/ocm/pkg/cobrautils/flagsets"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/options"
+)
+
+func ConfigHandler() flagsets.ConfigOptionTypeSetHandler {
+	return flagsets.NewConfigOptionTypeSetHandler(
+		Type, AddConfig,
+		options.TargetOption,
+		options.TimestampOption,
+	)
+}
+
+func AddConfig(opts flagsets.ConfigOptions, config flagsets.Config) error {
+	flagsets.AddFieldByOptionP(opts, options.TargetOption, config, "target")
+	flagsets.AddFieldByOptionP(opts, options.TimestampOption, config, "timestamp")
+	return nil
+}
+
+var usage = `
+The deployment of a component version to some target environment.
+`
+
+var formatV1 = `
+The type specific specification fields are:
+
+- **<code>target</code>**  *string*
+
+  The deployment target, for example a landscape or cluster.
+
+- **<code>timestamp</code>** (optional) *string*
+
+  The time of the deployment in RFC3339 format.
+`
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package deployment
+
+import (
+	"fmt"
+
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/labels/routingslip/spi"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+// Type is the entry type for a deployment.
+const (
+	Type   = "deployment"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	spi.Register(spi.NewEntryType[*Entry](Type, spi.WithDescription(usage)))
+	spi.Register(spi.NewEntryType[*Entry](TypeV1, spi.WithFormatSpec(formatV1), spi.WithConfigHandler(ConfigHandler())))
+}
+
+// New creates a new deployment entry.
+func New(target string, ts *metav1.Timestamp) *Entry {
+	return &Entry{
+		ObjectVersionedType: runtime.NewVersionedTypedObject(Type),
+		Target:              target,
+		Timestamp:           ts,
+	}
+}
+
+// Entry describes the deployment of a component version.
+type Entry struct {
+	runtime.ObjectVersionedType `json:",inline"`
+
+	// Target describes the deployment target, for example a landscape or cluster.
+	Target string `json:"target"`
+	// Timestamp is the optional time of the deployment.
+	Timestamp *metav1.Timestamp `json:"timestamp,omitempty"`
+}
+
+var _ spi.Entry = (*Entry)(nil)
+
+func (a *Entry) Describe(ctx spi.Context) string {
+	if a.Timestamp == nil {
+		return fmt.Sprintf("Deployed to %s", a.Target)
+	}
+	return fmt.Sprintf("Deployed to %s at %s", a.Target, a.Timestamp)
+}
+
+func (a *Entry) Validate(spi.Context) error {
+	if a.Target == "" {
+		return errors.ErrRequired("target")
+	}
+	return nil
+}
@@ -5,5 +5,8 @@
 package types
 
 import (
+	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/labels/routingslip/types/approval"
 	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/labels/routingslip/types/comment"
+	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/labels/routingslip/types/deployment"
+	_ "github.com/open-component-model/ocm/pkg/contexts/ocm/labels/routingslip/types/scan"
 )
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scan
+
+import (
+	"github.com/open-component-model/ocm/pkg/cobrautils/flagsets"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/options"
+)
+
+func ConfigHandler() flagsets.ConfigOptionTypeSetHandler {
+	return flagsets.NewConfigOptionTypeSetHandler(
+		Type, AddConfig,
+		options.ScanToolOption,
+		options.ScanResultOption,
+		options.ScanVerdictOption,
+	)
+}
+
+func AddConfig(opts flagsets.ConfigOptions, config flagsets.Config) error {
+	flagsets.AddFieldByOptionP(opts, options.ScanToolOption, config, "tool")
+	flagsets.AddFieldByOptionP(opts, options.ScanResultOption, config, "resultDigest")
+	flagsets.AddFieldByOptionP(opts, options.ScanVerdictOption, config, "verdict")
+	return nil
+}
+
+var usage = `
+The result of a scan (for example a vulnerability or license scan) of
+a component version.
+`
+
+var formatV1 = `
+The type specific specification fields are:
+
+- **<code>tool</code>**  *string*
+
+  The name of the scan tool.
+
+- **<code>resultDigest</code>** (optional) *string*
+
+  The digest of the scan result document.
+
+- **<code>verdict</code>**  *string*
+
+  The overall verdict of the scan, for example <code>passed</code> or
+  <code>failed</code>.
+`
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scan
+
+import (
+	"fmt"
+
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/labels/routingslip/spi"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+// Type is the entry type for the result of a scan.
+const (
+	Type   = "scan"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	spi.Register(spi.NewEntryType[*Entry](Type, spi.WithDescription(usage)))
+	spi.Register(spi.NewEntryType[*Entry](TypeV1, spi.WithFormatSpec(formatV1), spi.WithConfigHandler(ConfigHandler())))
+}
+
+// New creates a new scan entry.
+func New(tool string, verdict string, result digest.Digest) *Entry {
+	return &Entry{
+		ObjectVersionedType: runtime.NewVersionedTypedObject(Type),
+		Tool:                tool,
+		Verdict:             verdict,
+		ResultDigest:        result,
+	}
+}
+
+// Entry describes the result of a scan of a component version.
+type Entry struct {
+	runtime.ObjectVersionedType `json:",inline"`
+
+	// Tool is the name of the used scan tool.
+	Tool string `json:"tool"`
+	// ResultDigest is the digest of the scan result document.
+	ResultDigest digest.Digest `json:"resultDigest,omitempty"`
+	// Verdict is the overall verdict of the scan.
+	Verdict string `json:"verdict"`
+}
+
+var _ spi.Entry = (*Entry)(nil)
+
+func (a *Entry) Describe(ctx spi.Context) string {
+	s := fmt.Sprintf("Scan by %s: %s", a.Tool, a.Verdict)
+	if a.ResultDigest != "" {
+		s += fmt.Sprintf(" (result %s)", a.ResultDigest)
+	}
+	return s
+}
+
+func (a *Entry) Validate(spi.Context) error {
+	if a.Tool == "" {
+		return errors.ErrRequired("tool")
+	}
+	if a.Verdict == "" {
+		return errors.ErrRequired("verdict")
+	}
+	if a.ResultDigest != "" {
+		if err := a.ResultDigest.Validate(); err != nil {
+			return errors.ErrInvalidWrap(err, "result digest", a.ResultDigest.String())
+		}
+	}
+	return nil
+}
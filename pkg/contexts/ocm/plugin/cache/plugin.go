@@ -97,6 +97,19 @@ func (p *pluginImpl) GetValueMergeHandlerDescriptor(name string) *descriptor.Val
 	return nil
 }
 
+func (p *pluginImpl) GetSigningHandlerDescriptor(name string) *descriptor.SigningHandlerDescriptor {
+	if !p.IsValid() {
+		return nil
+	}
+
+	for _, h := range p.descriptor.SigningHandlers {
+		if h.Name == name {
+			return &h
+		}
+	}
+	return nil
+}
+
 func (p *pluginImpl) GetValueMappingDescriptor(name string) *descriptor.ValueMergeHandlerDescriptor {
 	if !p.IsValid() {
 		return nil
@@ -153,6 +166,30 @@ func (p *pluginImpl) GetAccessMethodDescriptor(name, version string) *descriptor
 	return nil
 }
 
+func (p *pluginImpl) GetInputTypeDescriptor(name, version string) *descriptor.InputTypeDescriptor {
+	if !p.IsValid() {
+		return nil
+	}
+
+	var fallback descriptor.InputTypeDescriptor
+	fallbackFound := false
+	for _, t := range p.descriptor.InputTypes {
+		if t.Name == name {
+			if t.Version == version {
+				return &t
+			}
+			if t.Version == "" || t.Version == "v1" {
+				fallback = t
+				fallbackFound = true
+			}
+		}
+	}
+	if fallbackFound && (version == "" || version == "v1") {
+		return &fallback
+	}
+	return nil
+}
+
 func (p *pluginImpl) GetValueSetDescriptor(purpose, name, version string) *descriptor.ValueSetDescriptor {
 	if !p.IsValid() {
 		return nil
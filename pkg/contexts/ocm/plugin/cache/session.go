@@ -0,0 +1,292 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/serve"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+// SESSION_SHUTDOWN_TIMEOUT is the time a plugin process is given to
+// terminate after closing its session, before it is killed.
+const SESSION_SHUTDOWN_TIMEOUT = 5 * time.Second
+
+// Session is a long-lived connection to a plugin executing
+// requests with the serve protocol. Requests are executed
+// sequentially, concurrent requests are serialized. Use a
+// SessionPool to execute requests in parallel. If the connection
+// breaks, the session is closed and all further requests fail.
+type Session struct {
+	lock   sync.Mutex
+	in     io.WriteCloser
+	out    io.Reader
+	closer func() error
+	err    error
+}
+
+// StartSession starts the serve command of a plugin executable
+// and provides a session for it.
+func StartSession(execpath string) (*Session, error) {
+	cmd := exec.Command(execpath, serve.Name)
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	err = cmd.Start()
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot start plugin session for %s", execpath)
+	}
+
+	closer := func() error {
+		done := make(chan error, 1)
+		go func() { done <- cmd.Wait() }()
+		select {
+		case err := <-done:
+			return err
+		case <-time.After(SESSION_SHUTDOWN_TIMEOUT):
+			cmd.Process.Kill()
+			return <-done
+		}
+	}
+	return NewSession(in, out, closer), nil
+}
+
+// NewSession provides a session for the serve protocol using the given
+// request and response streams. The optional closer is called after
+// closing the request stream when the session is closed.
+func NewSession(in io.WriteCloser, out io.Reader, closer func() error) *Session {
+	return &Session{
+		in:     in,
+		out:    bufio.NewReader(out),
+		closer: closer,
+	}
+}
+
+// IsClosed reports whether the session is closed, either explicitly
+// or because of a broken connection.
+func (s *Session) IsClosed() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.err != nil
+}
+
+func (s *Session) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.err != nil {
+		return nil
+	}
+	s.err = fmt.Errorf("plugin session closed")
+	return s.close()
+}
+
+func (s *Session) close() error {
+	err := s.in.Close()
+	if s.closer != nil {
+		err = errors.Join(err, s.closer())
+	}
+	return err
+}
+
+// Exec executes a plugin command with the same semantics as
+// the one-shot function Exec.
+func (s *Session) Exec(config json.RawMessage, r io.Reader, w io.Writer, args ...string) ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	if len(config) > 0 {
+		args = append([]string{"-c", string(config)}, args...)
+	}
+	stdout := w
+	if w == nil {
+		stdout = accessio.LimitBuffer(accessio.DESCRIPTOR_LIMIT)
+	}
+
+	result, err := s.request(r, stdout, args)
+	if err != nil {
+		s.err = errors.Wrapf(err, "plugin session broken")
+		s.close()
+		return nil, s.err
+	}
+	if result.Error != "" {
+		return nil, fmt.Errorf("%s", result.Error)
+	}
+	if l, ok := stdout.(*accessio.LimitedBuffer); ok {
+		if l.Exceeded() {
+			return nil, fmt.Errorf("stdout limit exceeded")
+		}
+		return l.Bytes(), nil
+	}
+	return nil, nil
+}
+
+// request sends a request and handles the response. The input is sent
+// in parallel to reading the response. A returned error indicates a
+// broken connection. Errors writing the output are reported
+// in the result.
+func (s *Session) request(r io.Reader, w io.Writer, args []string) (*serve.Result, error) {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+	if err := serve.WriteFrame(s.in, serve.REQUEST, data); err != nil {
+		return nil, err
+	}
+
+	// errors reading the input just fail the request,
+	// only errors completing the request break the session.
+	var ierr error
+	sent := make(chan error, 1)
+	go func() {
+		if r != nil {
+			_, ierr = io.Copy(serve.NewFrameWriter(s.in, serve.INPUT), r)
+		}
+		// the request must always be completed to keep the stream in sync
+		sent <- serve.WriteFrame(s.in, serve.EOF, nil)
+	}()
+
+	var werr error
+	for {
+		t, data, err := serve.ReadFrame(s.out)
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		switch t {
+		case serve.OUTPUT:
+			if werr == nil {
+				_, werr = w.Write(data)
+			}
+		case serve.RESULT:
+			var result serve.Result
+			if err := json.Unmarshal(data, &result); err != nil {
+				return nil, errors.Wrapf(err, "invalid result")
+			}
+			if err := <-sent; err != nil {
+				return nil, err
+			}
+			if result.Error == "" {
+				if ierr != nil {
+					result.Error = "cannot read input: " + ierr.Error()
+				} else if werr != nil {
+					result.Error = werr.Error()
+				}
+			}
+			return &result, nil
+		default:
+			return nil, fmt.Errorf("unexpected frame type %q", t)
+		}
+	}
+}
+
+// SESSION_POOL_SIZE is the default maximum number of sessions
+// (plugin processes) used to execute requests for a plugin concurrently.
+const SESSION_POOL_SIZE = 4
+
+// SessionPool manages the sessions for a plugin executable.
+// Because a session executes its requests sequentially, up to
+// size sessions are started on demand to handle concurrent requests.
+// Further requests wait for a session to become idle.
+type SessionPool struct {
+	lock   sync.Mutex
+	start  func() (*Session, error)
+	slots  chan struct{}
+	idle   []*Session
+	all    map[*Session]struct{}
+	closed bool
+}
+
+// NewSessionPool provides a session pool using the given function
+// to start new sessions. A non-positive size selects the
+// default SESSION_POOL_SIZE.
+func NewSessionPool(size int, start func() (*Session, error)) *SessionPool {
+	if size <= 0 {
+		size = SESSION_POOL_SIZE
+	}
+	return &SessionPool{
+		start: start,
+		slots: make(chan struct{}, size),
+		all:   map[*Session]struct{}{},
+	}
+}
+
+// Acquire provides a session for exclusive use by the caller,
+// which must be returned with Release. It waits until
+// an idle session is available or a new one can be started.
+func (p *SessionPool) Acquire() (*Session, error) {
+	p.slots <- struct{}{}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.closed {
+		<-p.slots
+		return nil, fmt.Errorf("plugin session closed")
+	}
+	for len(p.idle) > 0 {
+		s := p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+		if !s.IsClosed() {
+			return s, nil
+		}
+		delete(p.all, s)
+	}
+	s, err := p.start()
+	if err != nil {
+		<-p.slots
+		return nil, err
+	}
+	p.all[s] = struct{}{}
+	return s, nil
+}
+
+// Release returns a session acquired from the pool.
+// Broken sessions are discarded.
+func (p *SessionPool) Release(s *Session) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if s.IsClosed() {
+		delete(p.all, s)
+	} else if !p.closed {
+		p.idle = append(p.idle, s)
+	}
+	<-p.slots
+}
+
+// Close closes all sessions of the pool. Sessions currently
+// in use are closed, also, which breaks the running requests.
+func (p *SessionPool) Close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+	p.idle = nil
+	list := errors.ErrListf("closing plugin sessions")
+	for s := range p.all {
+		list.Add(s.Close())
+	}
+	p.all = map[*Session]struct{}{}
+	return list.Result()
+}
@@ -44,6 +44,11 @@ func DescribePluginDescriptorCapabilities(reg api.ActionTypeRegistry, d *descrip
 		out.Printf("Access Methods:\n")
 		DescribeAccessMethods(d, out)
 	}
+	if len(d.InputTypes) > 0 {
+		out.Printf("\n")
+		out.Printf("Input Types:\n")
+		DescribeInputTypes(d, out)
+	}
 	if len(d.Uploaders) > 0 {
 		out.Printf("\n")
 		// a working type inference would be really great
@@ -64,6 +69,11 @@ func DescribePluginDescriptorCapabilities(reg api.ActionTypeRegistry, d *descrip
 		DescribeValueSets(d, out)
 	}
 
+	if len(d.SigningHandlers) > 0 {
+		out.Printf("\n")
+		out.Printf("Signing Handlers:\n")
+		DescribeSigningHandlers(d, out)
+	}
 	if len(d.ValueMergeHandlers) > 0 {
 		out.Printf("\n")
 		out.Printf("Value Merge Handlers:\n")
@@ -89,8 +99,24 @@ type MethodVersion struct {
 }
 
 func GetAccessMethodInfo(methods []descriptor.AccessMethodDescriptor) map[string]*MethodInfo {
+	defs := make([]descriptor.ValueSetDefinition, len(methods))
+	for i, m := range methods {
+		defs[i] = m.ValueSetDefinition
+	}
+	return getMethodInfo(defs)
+}
+
+func GetInputTypeInfo(types []descriptor.InputTypeDescriptor) map[string]*MethodInfo {
+	defs := make([]descriptor.ValueSetDefinition, len(types))
+	for i, t := range types {
+		defs[i] = t.ValueSetDefinition
+	}
+	return getMethodInfo(defs)
+}
+
+func getMethodInfo(defs []descriptor.ValueSetDefinition) map[string]*MethodInfo {
 	found := map[string]*MethodInfo{}
-	for _, m := range methods {
+	for _, m := range defs {
 		i := found[m.Name]
 		if i == nil {
 			i = &MethodInfo{
@@ -139,8 +165,14 @@ func GetAccessMethodInfo(methods []descriptor.AccessMethodDescriptor) map[string
 }
 
 func DescribeAccessMethods(d *descriptor.Descriptor, out common.Printer) {
-	methods := GetAccessMethodInfo(d.AccessMethods)
+	describeMethods(GetAccessMethodInfo(d.AccessMethods), out)
+}
 
+func DescribeInputTypes(d *descriptor.Descriptor, out common.Printer) {
+	describeMethods(GetInputTypeInfo(d.InputTypes), out)
+}
+
+func describeMethods(methods map[string]*MethodInfo, out common.Printer) {
 	for _, n := range utils2.StringMapKeys(methods) {
 		out.Printf("- Name: %s\n", n)
 		m := methods[n]
@@ -279,6 +311,21 @@ func DescribeValueMergeHandlers(d *descriptor.Descriptor, out common.Printer) {
 	}
 }
 
+func DescribeSigningHandlers(d *descriptor.Descriptor, out common.Printer) {
+	handlers := map[string]descriptor.SigningHandlerDescriptor{}
+	for _, h := range d.SigningHandlers {
+		handlers[h.GetName()] = h
+	}
+
+	for _, n := range utils2.StringMapKeys(handlers) {
+		h := handlers[n]
+		out.Printf("- Name: %s\n", n)
+		if h.Description != "" {
+			out.Printf("%s\n", utils2.IndentLines(h.Description, "    "))
+		}
+	}
+}
+
 func DescribeLabelMergeSpecifications(d *descriptor.Descriptor, out common.Printer) {
 	handlers := map[string]descriptor.LabelMergeSpecification{}
 	for _, h := range d.LabelMergeSpecifications {
@@ -19,6 +19,12 @@ type Descriptor struct {
 	Short         string `json:"shortDescription"`
 	Long          string `json:"description"`
 
+	// Persistent indicates that the plugin supports the serve command.
+	// Such a plugin is started once per context (up to a small number
+	// of processes for concurrent requests) and handles all requests
+	// over a framed stdin/stdout protocol.
+	Persistent bool `json:"persistent,omitempty"`
+
 	Actions                  []ActionDescriptor                `json:"actions,omitempty"`
 	AccessMethods            []AccessMethodDescriptor          `json:"accessMethods,omitempty"`
 	Uploaders                List[UploaderDescriptor]          `json:"uploaders,omitempty"`
@@ -26,6 +32,8 @@ type Descriptor struct {
 	ValueMergeHandlers       List[ValueMergeHandlerDescriptor] `json:"valueMergeHandlers,omitempty"`
 	LabelMergeSpecifications List[LabelMergeSpecification]     `json:"labelMergeSpecifications,omitempty"`
 	ValueSets                List[ValueSetDescriptor]          `json:"valuesets,omitempty"`
+	InputTypes               List[InputTypeDescriptor]         `json:"inputTypes,omitempty"`
+	SigningHandlers          List[SigningHandlerDescriptor]    `json:"signingHandlers,omitempty"`
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -47,12 +55,21 @@ func (d *Descriptor) Capabilities() []string {
 	if len(d.ValueSets) > 0 {
 		caps = append(caps, "Value Sets")
 	}
+	if len(d.InputTypes) > 0 {
+		caps = append(caps, "Input Types")
+	}
 	if len(d.ValueMergeHandlers) > 0 {
 		caps = append(caps, "Value Merge Handlers")
 	}
 	if len(d.LabelMergeSpecifications) > 0 {
 		caps = append(caps, "Label Merge Specs")
 	}
+	if len(d.SigningHandlers) > 0 {
+		caps = append(caps, "Signing Handlers")
+	}
+	if d.Persistent {
+		caps = append(caps, "Persistent Sessions")
+	}
 	return caps
 }
 
@@ -117,6 +134,10 @@ type AccessMethodDescriptor struct {
 	SupportContentIdentity bool `json:"supportContentIdentity,omitempty"`
 }
 
+type InputTypeDescriptor struct {
+	ValueSetDefinition `json:",inline"`
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 type ValueSetDescriptor struct {
@@ -159,6 +180,21 @@ func (a ValueMergeHandlerDescriptor) GetDescription() string {
 
 ////////////////////////////////////////////////////////////////////////////////
 
+type SigningHandlerDescriptor struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+func (a SigningHandlerDescriptor) GetName() string {
+	return a.Name
+}
+
+func (a SigningHandlerDescriptor) GetDescription() string {
+	return a.Description
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
 type LabelMergeSpecification struct {
 	Name                               string `json:"name"`
 	Version                            string `json:"version,omitempty"`
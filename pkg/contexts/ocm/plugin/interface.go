@@ -10,10 +10,12 @@ import (
 )
 
 const (
-	KIND_PLUGIN       = descriptor.KIND_PLUGIN
-	KIND_UPLOADER     = descriptor.KIND_UPLOADER
-	KIND_ACCESSMETHOD = descriptor.KIND_ACCESSMETHOD
-	KIND_ACTION       = descriptor.KIND_ACTION
+	KIND_PLUGIN         = descriptor.KIND_PLUGIN
+	KIND_UPLOADER       = descriptor.KIND_UPLOADER
+	KIND_ACCESSMETHOD   = descriptor.KIND_ACCESSMETHOD
+	KIND_ACTION         = descriptor.KIND_ACTION
+	KIND_INPUTTYPE      = descriptor.KIND_INPUTTYPE
+	KIND_SIGNINGHANDLER = descriptor.KIND_SIGNINGHANDLER
 )
 
 var TAG = descriptor.REALM
@@ -30,7 +32,14 @@ type (
 	UploaderKeySet              = descriptor.UploaderKeySet
 	ValueSetDefinition          = descriptor.ValueSetDefinition
 	ValueSetDescriptor          = descriptor.ValueSetDescriptor
+	InputTypeDescriptor         = descriptor.InputTypeDescriptor
+	SigningHandlerDescriptor    = descriptor.SigningHandlerDescriptor
 
 	AccessSpecInfo       = internal.AccessSpecInfo
 	UploadTargetSpecInfo = internal.UploadTargetSpecInfo
+	InputSpecInfo        = internal.InputSpecInfo
+	InputResourceInfo    = internal.InputResourceInfo
+	SigningRequest       = internal.SigningRequest
+	VerificationRequest  = internal.VerificationRequest
+	Signature            = internal.Signature
 )
@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+)
+
+type InputSpecInfo struct {
+	Short      string                       `json:"short"`
+	MediaType  string                       `json:"mediaType"`
+	Hint       string                       `json:"hint"`
+	Digest     digest.Digest                `json:"digest,omitempty"`
+	ConsumerId credentials.ConsumerIdentity `json:"consumerId"`
+}
+
+// InputResourceInfo describes the element a blob is generated for.
+type InputResourceInfo struct {
+	// ComponentVersion is the name of the component version to generate.
+	ComponentVersion common.NameVersion
+	// ElementName is the name of the element to create.
+	ElementName string
+	// InputFilePath is the path of the file the input description has been taken from.
+	InputFilePath string
+}
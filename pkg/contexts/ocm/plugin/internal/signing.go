@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+// SigningRequest describes the digest to be signed by a signing handler.
+type SigningRequest struct {
+	// Digest is the hex encoded digest to sign.
+	Digest string `json:"digest"`
+	// HashAlgorithm is the name of the hash algorithm used to calculate the digest.
+	HashAlgorithm string `json:"hashAlgorithm"`
+	// Issuer is the distinguished name of the intended issuer, if configured.
+	Issuer string `json:"issuer,omitempty"`
+	// PrivateKey is the private key or key reference configured
+	// for the signature, if any.
+	PrivateKey []byte `json:"privateKey,omitempty"`
+}
+
+// VerificationRequest describes a signature to be verified by a signing handler.
+type VerificationRequest struct {
+	// Digest is the hex encoded digest the signature has been created for.
+	Digest string `json:"digest"`
+	// HashAlgorithm is the name of the hash algorithm used to calculate the digest.
+	HashAlgorithm string `json:"hashAlgorithm"`
+	// Issuer is the distinguished name of the expected issuer, if configured.
+	Issuer string `json:"issuer,omitempty"`
+	// PublicKey is the public key or key reference configured
+	// for the signature, if any.
+	PublicKey []byte `json:"publicKey,omitempty"`
+	// Signature is the signature to verify.
+	Signature Signature `json:"signature"`
+}
+
+// Signature is the signature created by a signing handler.
+type Signature struct {
+	Value     string `json:"value"`
+	MediaType string `json:"mediaType"`
+	Algorithm string `json:"algorithm,omitempty"`
+	Issuer    string `json:"issuer,omitempty"`
+}
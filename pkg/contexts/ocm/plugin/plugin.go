@@ -27,8 +27,15 @@ import (
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/action"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/action/execute"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/download"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/input"
+	inpcompose "github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/input/compose"
+	inpget "github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/input/get"
+	inpval "github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/input/validate"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/mergehandler"
 	merge "github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/mergehandler/execute"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/signing"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/signing/sign"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/signing/verify"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/upload"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/upload/put"
 	uplval "github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/upload/validate"
@@ -51,6 +58,10 @@ type pluginImpl struct {
 	impl
 	config                   json.RawMessage
 	disableAutoConfiguration bool
+
+	// sessions is the pool of long-lived sessions used for plugins
+	// supporting the persistent mode.
+	sessions *cache.SessionPool
 }
 
 func NewPlugin(ctx ocm.Context, impl cache.Plugin, config json.RawMessage) Plugin {
@@ -302,9 +313,51 @@ func (p *pluginImpl) Exec(r io.Reader, w io.Writer, args ...string) ([]byte, err
 	} else {
 		p.ctx.Logger(TAG).Debug("execute plugin action", "path", p.Path(), "args", args, "config", p.config)
 	}
+	if pool := p.getSessions(); pool != nil {
+		s, err := pool.Acquire()
+		if err == nil {
+			defer pool.Release(s)
+			return s.Exec(p.config, r, w, args...)
+		}
+		p.ctx.Logger(TAG).Warn("cannot start plugin session, using one-shot execution", "path", p.Path(), "error", err.Error())
+	}
 	return cache.Exec(p.Path(), p.config, r, w, args...)
 }
 
+// getSessions provides the session pool for plugins supporting the
+// persistent mode. Plugin processes are started on demand, up to
+// cache.SESSION_POOL_SIZE to handle concurrent requests, and terminated
+// when the context is finalized. For plugins not supporting the
+// persistent mode, nil is returned and the one-shot execution is used.
+func (p *pluginImpl) getSessions() *cache.SessionPool {
+	if !p.IsValid() || !p.GetDescriptor().Persistent {
+		return nil
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.sessions == nil {
+		path := p.Path()
+		p.sessions = cache.NewSessionPool(0, func() (*cache.Session, error) { return cache.StartSession(path) })
+		p.ctx.Finalizer().Close(p.sessions)
+	}
+	return p.sessions
+}
+
+// Close terminates the running plugin sessions.
+func (p *pluginImpl) Close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.sessions == nil {
+		return nil
+	}
+	err := p.sessions.Close()
+	p.sessions = nil
+	return err
+}
+
 func (p *pluginImpl) ValidateValueSet(purpose string, spec []byte) (*ppi.ValueSetInfo, error) {
 	result, err := p.Exec(nil, nil, valueset.Name, vsval.Name, purpose, string(spec))
 	if err != nil {
@@ -350,3 +403,113 @@ func (p *pluginImpl) ComposeValueSet(purpose, name string, opts flagsets.ConfigO
 	}
 	return nil
 }
+
+func (p *pluginImpl) ValidateInputSpec(inputFilePath string, spec []byte) (*ppi.InputSpecInfo, error) {
+	args := []string{input.Name, inpval.Name, string(spec)}
+	if inputFilePath != "" {
+		args = append(args, "--"+inpval.OptPath, inputFilePath)
+	}
+	result, err := p.Exec(nil, nil, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "plugin %s", p.Name())
+	}
+
+	var info ppi.InputSpecInfo
+	err = json.Unmarshal(result, &info)
+	if err != nil {
+		return nil, errors.Wrapf(err, "plugin %s: cannot unmarshal input spec info", p.Name())
+	}
+	return &info, nil
+}
+
+func (p *pluginImpl) ComposeInputSpec(name string, opts flagsets.ConfigOptions, base flagsets.Config) error {
+	cfg := flagsets.Config{}
+	for _, o := range opts.Options() {
+		cfg[o.GetName()] = o.Value()
+	}
+	optsdata, err := json.Marshal(cfg)
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal option values")
+	}
+	basedata, err := json.Marshal(base)
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal input specification base value")
+	}
+	result, err := p.Exec(nil, nil, input.Name, inpcompose.Name, name, string(optsdata), string(basedata))
+	if err != nil {
+		return err
+	}
+	var r flagsets.Config
+	err = json.Unmarshal(result, &r)
+	if err != nil {
+		return errors.Wrapf(err, "cannot unmarshal composition result")
+	}
+
+	for k := range base {
+		delete(base, k)
+	}
+	for k, v := range r {
+		base[k] = v
+	}
+	return nil
+}
+
+func (p *pluginImpl) GetInputBlob(w io.Writer, info ppi.InputResourceInfo, creds, spec json.RawMessage) error {
+	args := []string{input.Name, inpget.Name, string(spec)}
+	if creds != nil {
+		args = append(args, "--"+inpget.OptCreds, string(creds))
+	}
+	if info.InputFilePath != "" {
+		args = append(args, "--"+inpget.OptPath, info.InputFilePath)
+	}
+	if info.ComponentVersion.GetName() != "" && info.ComponentVersion.GetVersion() != "" {
+		args = append(args, "--"+inpget.OptComponent, info.ComponentVersion.String())
+	}
+	if info.ElementName != "" {
+		args = append(args, "--"+inpget.OptElement, info.ElementName)
+	}
+	_, err := p.Exec(nil, w, args...)
+	return err
+}
+
+func (p *pluginImpl) Sign(name string, req *ppi.SigningRequest, creds json.RawMessage) (*ppi.Signature, error) {
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{signing.Name, sign.Name, name}
+	if creds != nil {
+		args = append(args, "--"+sign.OptCreds, string(creds))
+	}
+	var buf bytes.Buffer
+	_, err = p.Exec(bytes.NewReader(input), &buf, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "plugin %s", p.Name())
+	}
+
+	var sig ppi.Signature
+	err = json.Unmarshal(buf.Bytes(), &sig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "plugin %s: invalid signature", p.Name())
+	}
+	return &sig, nil
+}
+
+func (p *pluginImpl) Verify(name string, req *ppi.VerificationRequest, creds json.RawMessage) error {
+	input, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	args := []string{signing.Name, verify.Name, name}
+	if creds != nil {
+		args = append(args, "--"+verify.OptCreds, string(creds))
+	}
+	var buf bytes.Buffer
+	_, err = p.Exec(bytes.NewReader(input), &buf, args...)
+	if err != nil {
+		return errors.Wrapf(err, "plugin %s", p.Name())
+	}
+	return nil
+}
@@ -85,7 +85,7 @@ func Command(p ppi.Plugin, cmd *cobra.Command, opts *Options) error {
 	if err != nil {
 		return err
 	}
-	_, err = io.Copy(os.Stdout, r)
+	_, err = io.Copy(cmd.OutOrStdout(), r)
 	r.Close()
 	return err
 }
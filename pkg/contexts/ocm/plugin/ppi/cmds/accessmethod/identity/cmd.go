@@ -6,7 +6,6 @@ package identity
 
 import (
 	"encoding/json"
-	"fmt"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -81,7 +80,7 @@ func Command(p ppi.Plugin, cmd *cobra.Command, opts *Options) error {
 
 	idp, ok := m.(ppi.ContentVersionIdentityProvider)
 	if !ok {
-		fmt.Println("")
+		cmd.Printf("\n")
 		return nil
 	}
 
@@ -89,6 +88,6 @@ func Command(p ppi.Plugin, cmd *cobra.Command, opts *Options) error {
 	if err != nil {
 		return err
 	}
-	fmt.Println(id)
-	return err
+	cmd.Printf("%s\n", id)
+	return nil
 }
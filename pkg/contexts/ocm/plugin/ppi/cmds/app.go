@@ -19,7 +19,10 @@ import (
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/describe"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/download"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/info"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/input"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/mergehandler"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/serve"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/signing"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/topics/descriptor"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/upload"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/valueset"
@@ -67,6 +70,9 @@ func NewPluginCommand(p ppi.Plugin) *PluginCommand {
 	cmd.AddCommand(upload.New(p))
 	cmd.AddCommand(download.New(p))
 	cmd.AddCommand(valueset.New(p))
+	cmd.AddCommand(input.New(p))
+	cmd.AddCommand(signing.New(p))
+	cmd.AddCommand(serve.New(func() *cobra.Command { return NewPluginCommand(p).Command() }))
 
 	cmd.InitDefaultHelpCmd()
 	var help *cobra.Command
@@ -10,4 +10,5 @@ const (
 	OptMedia  = "mediaType"
 	OptArt    = "artifactType"
 	OptConfig = "config"
+	OptPath   = "inputFilePath"
 )
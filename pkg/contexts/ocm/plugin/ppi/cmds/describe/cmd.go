@@ -5,8 +5,6 @@
 package describe
 
 import (
-	"os"
-
 	"github.com/spf13/cobra"
 
 	common2 "github.com/open-component-model/ocm/pkg/common"
@@ -25,7 +23,7 @@ func New(p ppi.Plugin) *cobra.Command {
 		Args:  cobra.MaximumNArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			d := p.Descriptor()
-			common.DescribePluginDescriptor(action.DefaultRegistry(), &d, common2.NewPrinter(os.Stdout))
+			common.DescribePluginDescriptor(action.DefaultRegistry(), &d, common2.NewPrinter(cmd.OutOrStdout()))
 			return nil
 		},
 	}
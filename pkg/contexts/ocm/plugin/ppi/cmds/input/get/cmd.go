@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/open-component-model/ocm/pkg/cobrautils/flag"
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/descriptor"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi"
+	commonppi "github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/common"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+const (
+	Name         = "get"
+	OptCreds     = commonppi.OptCreds
+	OptPath      = commonppi.OptPath
+	OptComponent = "componentVersion"
+	OptElement   = "elementName"
+)
+
+func New(p ppi.Plugin) *cobra.Command {
+	opts := Options{}
+
+	cmd := &cobra.Command{
+		Use:   Name + " [<flags>] <input spec>",
+		Short: "get blob",
+		Long: `
+Evaluate the given input specification and return the described blob on
+*stdout*.
+
+The blob is generated for the element with the name given by option
+<code>--` + OptElement + `</code> of the component version given by option
+<code>--` + OptComponent + `</code> (<code>&lt;name>:&lt;version></code>).
+Relative file paths used in the specification are interpreted relative to
+the directory of the file the specification has been taken from
+(option <code>--` + OptPath + `</code>).`,
+		Args: cobra.ExactArgs(1),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Complete(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Command(p, cmd, &opts)
+		},
+	}
+	opts.AddFlags(cmd.Flags())
+	return cmd
+}
+
+type Options struct {
+	Credentials   credentials.DirectCredentials
+	Specification json.RawMessage
+
+	ComponentVersion string
+	Info             ppi.InputResourceInfo
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	flag.YAMLVarP(fs, &o.Credentials, OptCreds, "c", nil, "credentials")
+	flag.StringToStringVarPFA(fs, &o.Credentials, "credential", "C", nil, "dedicated credential value")
+	fs.StringVarP(&o.Info.InputFilePath, OptPath, "p", "", "path of the file containing the input specification")
+	fs.StringVarP(&o.ComponentVersion, OptComponent, "V", "", "component version to generate the blob for")
+	fs.StringVarP(&o.Info.ElementName, OptElement, "e", "", "name of the element to generate the blob for")
+}
+
+func (o *Options) Complete(args []string) error {
+	if err := runtime.DefaultYAMLEncoding.Unmarshal([]byte(args[0]), &o.Specification); err != nil {
+		return errors.Wrapf(err, "invalid input specification")
+	}
+	if o.ComponentVersion != "" {
+		nv, err := common.ParseNameVersion(o.ComponentVersion)
+		if err != nil {
+			return errors.Wrapf(err, "invalid component version")
+		}
+		o.Info.ComponentVersion = nv
+	}
+	return nil
+}
+
+func Command(p ppi.Plugin, cmd *cobra.Command, opts *Options) error {
+	spec, err := p.DecodeInputSpecification(opts.Specification)
+	if err != nil {
+		return errors.Wrapf(err, "input specification")
+	}
+
+	t := p.GetInputType(runtime.KindVersion(spec.GetType()))
+	if t == nil {
+		return errors.ErrUnknown(descriptor.KIND_INPUTTYPE, spec.GetType())
+	}
+	_, err = t.ValidateSpecification(p, opts.Info.InputFilePath, spec)
+	if err != nil {
+		return err
+	}
+	r, err := t.Reader(p, opts.Info, spec, opts.Credentials)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(cmd.OutOrStdout(), r)
+	return errors.Join(err, r.Close())
+}
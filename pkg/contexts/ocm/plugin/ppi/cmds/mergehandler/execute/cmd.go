@@ -8,7 +8,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"os"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -96,7 +95,7 @@ func Command(p ppi.Plugin, cmd *cobra.Command, opts *Options) error {
 		return errors.ErrUnknown(hpi.KIND_VALUE_MERGE_ALGORITHM, opts.Name)
 	}
 
-	data, err := io.ReadAll(os.Stdin)
+	data, err := io.ReadAll(cmd.InOrStdin())
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package serve
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+const Name = "serve"
+
+// CommandFactory provides a new plugin command tree used to execute
+// a single request.
+type CommandFactory func() *cobra.Command
+
+func New(factory CommandFactory) *cobra.Command {
+	return &cobra.Command{
+		Use:   Name,
+		Short: "serve plugin requests",
+		Long: `
+Serve plugin requests read from *stdin* until the input stream is closed.
+
+Every request describes a plugin command (arguments and standard input),
+whose standard output and final error status is returned on *stdout*.
+Requests and responses are transferred as frames consisting of the frame type
+(one byte), the payload length (4 byte big endian integer) and the payload.
+
+A request is sent as frame of type <code>R</code> with the JSON encoded list
+of command arguments, followed by frames of type <code>I</code> providing the
+standard input of the command and a final frame of type <code>E</code>.
+The response consists of frames of type <code>O</code> providing the
+standard output of the command and a final frame of type <code>X</code>
+containing a JSON object with the optional field <code>error</code>.
+
+This command is used by the OCM library for plugins declaring
+the <code>persistent</code> capability in their descriptor.
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// the original stdout is reserved for the protocol,
+			// any output of the plugin code written directly
+			// to os.Stdout is redirected to stderr.
+			out := os.Stdout
+			os.Stdout = os.Stderr
+			defer func() { os.Stdout = out }()
+			return Serve(factory, bufio.NewReader(cmd.InOrStdin()), out)
+		},
+	}
+}
+
+// Serve handles requests read from the given reader until
+// the stream is closed.
+func Serve(factory CommandFactory, r io.Reader, w io.Writer) error {
+	for {
+		t, data, err := ReadFrame(r)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if t != REQUEST {
+			return fmt.Errorf("unexpected frame type %q", t)
+		}
+		var args []string
+		if err := json.Unmarshal(data, &args); err != nil {
+			return errors.Wrapf(err, "invalid request")
+		}
+		if err := handle(factory, args, r, w); err != nil {
+			return err
+		}
+	}
+}
+
+// handle executes a single request. The standard input is read from
+// the input frames in parallel to the command execution. Input not
+// consumed by the command is skipped to keep the stream in sync.
+func handle(factory CommandFactory, args []string, r io.Reader, w io.Writer) error {
+	in, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		done <- readInput(r, pw)
+	}()
+
+	if args == nil {
+		// cobra would use the process arguments, otherwise
+		args = []string{}
+	}
+	cmd := factory()
+	cmd.SetArgs(args)
+	cmd.SetIn(in)
+	cmd.SetOut(NewFrameWriter(w, OUTPUT))
+	cmd.SetErr(io.Discard)
+	cerr := cmd.Execute()
+
+	in.CloseWithError(io.ErrClosedPipe)
+	if err := <-done; err != nil {
+		return err
+	}
+
+	var result Result
+	if cerr != nil {
+		result.Error = cerr.Error()
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return WriteFrame(w, RESULT, data)
+}
+
+func readInput(r io.Reader, w *io.PipeWriter) error {
+	for {
+		t, data, err := ReadFrame(r)
+		if err != nil {
+			w.CloseWithError(err)
+			return err
+		}
+		switch t {
+		case INPUT:
+			// write errors just indicate that the command
+			// does not read its input anymore.
+			_, _ = w.Write(data)
+		case EOF:
+			w.Close()
+			return nil
+		default:
+			err = fmt.Errorf("unexpected frame type %q", t)
+			w.CloseWithError(err)
+			return err
+		}
+	}
+}
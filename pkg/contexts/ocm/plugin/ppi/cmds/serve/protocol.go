@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package serve
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// FrameType describes the kind of a protocol frame.
+// A frame consists of the frame type byte, the payload length
+// as 4 byte big endian integer and the payload.
+//
+// A request is sent as REQUEST frame with the JSON encoded command
+// arguments, followed by an arbitrary number of INPUT frames providing
+// the standard input of the command and a final EOF frame. The response
+// consists of an arbitrary number of OUTPUT frames providing the standard
+// output of the command and a final RESULT frame with the JSON encoded
+// Result.
+type FrameType byte
+
+const (
+	REQUEST FrameType = 'R'
+	INPUT   FrameType = 'I'
+	EOF     FrameType = 'E'
+	OUTPUT  FrameType = 'O'
+	RESULT  FrameType = 'X'
+)
+
+const (
+	// MAX_FRAME_SIZE limits the payload size of a frame accepted
+	// by a reader.
+	MAX_FRAME_SIZE = 16 * 1024 * 1024
+	// CHUNK_SIZE is the payload size used to send stream content.
+	CHUNK_SIZE = 64 * 1024
+)
+
+// Result is the payload of the RESULT frame of a request.
+// An empty error indicates a successful command execution.
+type Result struct {
+	Error string `json:"error,omitempty"`
+}
+
+// WriteFrame writes a single frame.
+func WriteFrame(w io.Writer, t FrameType, data []byte) error {
+	var header [5]byte
+
+	header[0] = byte(t)
+	binary.BigEndian.PutUint32(header[1:], uint32(len(data)))
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+	if len(data) > 0 {
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadFrame reads a single frame.
+// It returns io.EOF, if the stream ends before a new frame.
+func ReadFrame(r io.Reader) (FrameType, []byte, error) {
+	var header [5]byte
+
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return 0, nil, fmt.Errorf("incomplete frame header")
+		}
+		return 0, nil, err
+	}
+	n := binary.BigEndian.Uint32(header[1:])
+	if n > MAX_FRAME_SIZE {
+		return 0, nil, fmt.Errorf("frame size %d exceeds limit %d", n, MAX_FRAME_SIZE)
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return 0, nil, fmt.Errorf("incomplete frame: %w", err)
+	}
+	return FrameType(header[0]), data, nil
+}
+
+// FrameWriter is an io.Writer sending all written data as frames
+// of a dedicated type.
+type FrameWriter struct {
+	writer io.Writer
+	ftype  FrameType
+}
+
+var _ io.Writer = (*FrameWriter)(nil)
+
+func NewFrameWriter(w io.Writer, t FrameType) *FrameWriter {
+	return &FrameWriter{writer: w, ftype: t}
+}
+
+func (f *FrameWriter) Write(data []byte) (int, error) {
+	for written := 0; written < len(data); {
+		n := len(data) - written
+		if n > CHUNK_SIZE {
+			n = CHUNK_SIZE
+		}
+		if err := WriteFrame(f.writer, f.ftype, data[written:written+n]); err != nil {
+			return written, err
+		}
+		written += n
+	}
+	return len(data), nil
+}
@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sign
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/open-component-model/ocm/pkg/cobrautils/flag"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/descriptor"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi"
+	commonppi "github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/common"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+const (
+	Name     = "sign"
+	OptCreds = commonppi.OptCreds
+)
+
+func New(p ppi.Plugin) *cobra.Command {
+	opts := Options{}
+
+	cmd := &cobra.Command{
+		Use:   Name + " [<flags>] <name>",
+		Short: "sign a digest",
+		Long: `
+This command signs a digest with the signing handler given by name. The signing
+request is taken from *stdin* as JSON string. It has the following fields:
+
+- **<code>digest</code>** *string*
+
+  The hex encoded digest to sign.
+
+- **<code>hashAlgorithm</code>** *string*
+
+  The name of the hash algorithm used to calculate the digest.
+
+- **<code>issuer</code>** *string* (optional)
+
+  The distinguished name of the intended issuer.
+
+- **<code>privateKey</code>** *[]byte* (optional)
+
+  The private key or key reference configured for the signature.
+
+The credentials for the consumer id of the signing handler are passed
+with option <code>--` + OptCreds + `</code>.
+
+This action has to provide the signature as JSON string on *stdout*. It has the
+following fields:
+
+- **<code>value</code>** *string*
+
+  The signature value.
+
+- **<code>mediaType</code>** *string*
+
+  The media type of the signature value.
+
+- **<code>algorithm</code>** *string* (optional)
+
+  The name of the signature algorithm. If not given, the name of the
+  signing handler is used. A signature is verified by the handler
+  registered for this algorithm.
+
+- **<code>issuer</code>** *string* (optional)
+
+  The distinguished name of the issuer of the signature.
+`,
+		Args: cobra.ExactArgs(1),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Complete(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Command(p, cmd, &opts)
+		},
+	}
+	opts.AddFlags(cmd.Flags())
+	return cmd
+}
+
+type Options struct {
+	Name        string
+	Credentials credentials.DirectCredentials
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	flag.YAMLVarP(fs, &o.Credentials, OptCreds, "c", nil, "credentials")
+	flag.StringToStringVarPFA(fs, &o.Credentials, "credential", "C", nil, "dedicated credential value")
+}
+
+func (o *Options) Complete(args []string) error {
+	o.Name = args[0]
+	return nil
+}
+
+func Command(p ppi.Plugin, cmd *cobra.Command, opts *Options) error {
+	h := p.GetSigningHandler(opts.Name)
+	if h == nil {
+		return errors.ErrUnknown(descriptor.KIND_SIGNINGHANDLER, opts.Name)
+	}
+
+	data, err := io.ReadAll(cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+
+	var req ppi.SigningRequest
+	err = json.Unmarshal(data, &req)
+	if err != nil {
+		return errors.Wrapf(err, "invalid signing request")
+	}
+
+	sig, err := h.Sign(p, &req, opts.Credentials)
+	if err != nil {
+		return err
+	}
+	if sig.Algorithm == "" {
+		sig.Algorithm = h.Name()
+	}
+	data, err = json.Marshal(sig)
+	if err != nil {
+		return err
+	}
+	cmd.Printf("%s\n", string(data))
+	return nil
+}
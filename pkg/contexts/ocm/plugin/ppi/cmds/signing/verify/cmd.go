@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package verify
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/open-component-model/ocm/pkg/cobrautils/flag"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/descriptor"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi"
+	commonppi "github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/common"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+const (
+	Name     = "verify"
+	OptCreds = commonppi.OptCreds
+)
+
+func New(p ppi.Plugin) *cobra.Command {
+	opts := Options{}
+
+	cmd := &cobra.Command{
+		Use:   Name + " [<flags>] <name>",
+		Short: "verify a signature",
+		Long: `
+This command verifies a signature with the signing handler given by name.
+The verification request is taken from *stdin* as JSON string. It has the
+following fields:
+
+- **<code>digest</code>** *string*
+
+  The hex encoded digest the signature has been created for.
+
+- **<code>hashAlgorithm</code>** *string*
+
+  The name of the hash algorithm used to calculate the digest.
+
+- **<code>issuer</code>** *string* (optional)
+
+  The distinguished name of the expected issuer.
+
+- **<code>publicKey</code>** *[]byte* (optional)
+
+  The public key or key reference configured for the signature.
+
+- **<code>signature</code>** *object*
+
+  The signature to verify with the fields <code>value</code>,
+  <code>mediaType</code>, <code>algorithm</code> and <code>issuer</code>
+  (see <CMD>plugin signing sign</CMD>).
+
+The credentials for the consumer id of the signing handler are passed
+with option <code>--` + OptCreds + `</code>.
+
+If the verification fails, the command must exit with a non-zero exit code
+and an appropriate error message.
+`,
+		Args: cobra.ExactArgs(1),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Complete(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Command(p, cmd, &opts)
+		},
+	}
+	opts.AddFlags(cmd.Flags())
+	return cmd
+}
+
+type Options struct {
+	Name        string
+	Credentials credentials.DirectCredentials
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	flag.YAMLVarP(fs, &o.Credentials, OptCreds, "c", nil, "credentials")
+	flag.StringToStringVarPFA(fs, &o.Credentials, "credential", "C", nil, "dedicated credential value")
+}
+
+func (o *Options) Complete(args []string) error {
+	o.Name = args[0]
+	return nil
+}
+
+func Command(p ppi.Plugin, cmd *cobra.Command, opts *Options) error {
+	h := p.GetSigningHandler(opts.Name)
+	if h == nil {
+		return errors.ErrUnknown(descriptor.KIND_SIGNINGHANDLER, opts.Name)
+	}
+
+	data, err := io.ReadAll(cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+
+	var req ppi.VerificationRequest
+	err = json.Unmarshal(data, &req)
+	if err != nil {
+		return errors.Wrapf(err, "invalid verification request")
+	}
+	return h.Verify(p, &req, opts.Credentials)
+}
@@ -73,12 +73,28 @@ following fields:
 
   A description explaining the capabilities of the plugin
 
+- **<code>persistent</code>** *bool*
+
+  If set to true, the plugin supports the command <code>serve</code>.
+  Such a plugin is kept running per OCM context and executes all
+  requests over a framed protocol on *stdin* and *stdout*, instead of
+  starting the plugin executable for every single request. A plugin
+  process executes its requests sequentially, therefore up to 4 processes
+  are started on demand to execute concurrent requests.
+
 - **<code>accessMethods</code>** *[]AccessMethodDescriptor*
 
   The list of access methods versions provided by this plugin.
   This feature is already used to establish new access types, if
   the plugins are registered at an OCM context.
 
+- **<code>inputTypes</code>** *[]InputTypeDescriptor*
+
+  The list of input types versions provided by this plugin.
+  Input types are used by the OCM CLI to generate the blob content for
+  resources and sources added to a component version
+  (for example with <code>ocm add resources</code>).
+
 - **<code>uploaders</code>** *[]UploaderDescriptor*
 
   The list of supported uploaders. Uploaders will be used in a future
@@ -111,6 +127,14 @@ following fields:
 
   The list of assignments of label merge specification to labels.
 
+- **<code>signingHandlers</code>** *[]SigningHandlerDescriptor*
+
+  The list of supported signing handlers. Signing handlers are
+  registered as signers and verifiers for their name at the signing
+  registry of an OCM context. They can be used to sign and verify
+  component versions with keys managed by the plugin, for example
+  with a remote key management service (see <CMD>plugin signing</CMD>).
+
 #### Access Method Descriptor
 
 An access method descriptor describes a dedicated supported access method.
@@ -162,6 +186,12 @@ It uses the following fields:
 
 ` + options.DefaultRegistry.Usage() + `
 
+#### Input Type Descriptor
+
+An input type descriptor describes a dedicated supported input type.
+It uses the same fields as an access method descriptor. If options are given,
+the plugin must support the command <CMD>plugin input compose</CMD>.
+
 #### Uploader Descriptor
 
 The descriptor for an uploader has the following fields:
@@ -269,6 +299,28 @@ The descriptor for a value merge handler has the following fields:
 
   The description of the algorithm.
 
+### Signing Handler Descriptor
+
+The descriptor for a signing handler has the following fields:
+
+- **<code>name</code>** *string*
+
+  The name of the signing handler. It is used as signature algorithm
+  name for the registration (option <code>--algorithm</code>
+  of the commands <code>ocm sign</code> and <code>ocm verify</code>).
+  Handlers with a name already used by another signing handler,
+  for example a built-in algorithm, are ignored.
+
+- **<code>description</code>** *string*
+
+  The description of the signing handler.
+
+The credentials passed to the signing handler are taken from the
+consumer type <code>Signinghandler.ocm.software</code> with the
+identity attributes <code>algorithm</code> (the name of the
+signing handler) and <code>issuer</code> (the optional issuer of the
+signature).
+
 ### Label Merge Specification
 
 The descriptor for a label merge specification has the following fields:
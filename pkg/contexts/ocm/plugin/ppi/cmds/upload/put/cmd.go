@@ -8,7 +8,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"os"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -96,7 +95,7 @@ func Command(p ppi.Plugin, cmd *cobra.Command, opts *Options) error {
 	if err != nil {
 		return err
 	}
-	_, err = io.Copy(w, os.Stdin)
+	_, err = io.Copy(w, cmd.InOrStdin())
 	if err != nil {
 		w.Close()
 		return err
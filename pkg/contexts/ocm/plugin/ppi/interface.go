@@ -23,12 +23,18 @@ type (
 	DownloaderKey          = descriptor.DownloaderKey
 	DownloaderDescriptor   = descriptor.DownloaderDescriptor
 	AccessMethodDescriptor = descriptor.AccessMethodDescriptor
+	InputTypeDescriptor    = descriptor.InputTypeDescriptor
 	CLIOption              = descriptor.CLIOption
 
 	ActionSpecInfo       = internal.ActionSpecInfo
 	AccessSpecInfo       = internal.AccessSpecInfo
 	ValueSetInfo         = internal.ValueSetInfo
+	InputSpecInfo        = internal.InputSpecInfo
+	InputResourceInfo    = internal.InputResourceInfo
 	UploadTargetSpecInfo = internal.UploadTargetSpecInfo
+	SigningRequest       = internal.SigningRequest
+	VerificationRequest  = internal.VerificationRequest
+	Signature            = internal.Signature
 )
 
 var REALM = descriptor.REALM
@@ -42,6 +48,7 @@ type Plugin interface {
 
 	SetShort(s string)
 	SetLong(s string)
+	SetPersistent(b bool)
 	SetConfigParser(config func(raw json.RawMessage) (interface{}, error))
 
 	RegisterDownloader(arttype, mediatype string, u Downloader) error
@@ -57,6 +64,10 @@ type Plugin interface {
 	DecodeAccessSpecification(data []byte) (AccessSpec, error)
 	GetAccessMethod(name string, version string) AccessMethod
 
+	RegisterInputType(t InputType) error
+	DecodeInputSpecification(data []byte) (InputSpec, error)
+	GetInputType(name string, version string) InputType
+
 	RegisterAction(a Action) error
 	DecodeAction(data []byte) (ActionSpec, error)
 	GetAction(name string) Action
@@ -64,6 +75,9 @@ type Plugin interface {
 	RegisterValueMergeHandler(h ValueMergeHandler) error
 	GetValueMergeHandler(name string) ValueMergeHandler
 
+	RegisterSigningHandler(h SigningHandler) error
+	GetSigningHandler(name string) SigningHandler
+
 	RegisterValueSet(h ValueSet) error
 	DecodeValueSet(purpose string, data []byte) (runtime.TypedObject, error)
 	GetValueSet(purpose, name, version string) ValueSet
@@ -103,6 +117,34 @@ type AccessSpec = runtime.TypedObject
 
 type AccessSpecProvider func() AccessSpec
 
+// InputType describes a blob input type usable to provide the content of
+// resources and sources added with the OCM CLI.
+type InputType interface {
+	runtime.TypedObjectDecoder[InputSpec]
+
+	Name() string
+	Version() string
+
+	// Options provides the list of CLI options supported to compose the input
+	// specification.
+	Options() []options.OptionType
+
+	// Description provides a general description for the input type.
+	Description() string
+	// Format describes the attributes of the dedicated version.
+	Format() string
+
+	// ValidateSpecification validates the input specification. Relative file paths
+	// used by the specification are interpreted relative to the directory of the
+	// given input file path.
+	ValidateSpecification(p Plugin, inputFilePath string, spec InputSpec) (info *InputSpecInfo, err error)
+	// Reader provides the blob described by the input specification.
+	Reader(p Plugin, info InputResourceInfo, spec InputSpec, creds credentials.Credentials) (io.ReadCloser, error)
+	ComposeSpecification(p Plugin, opts Config, config Config) error
+}
+
+type InputSpec = runtime.TypedObject
+
 type UploadFormats runtime.KnownTypes[runtime.TypedObject, runtime.TypedObjectDecoder[runtime.TypedObject]]
 
 type Uploader interface {
@@ -160,6 +202,19 @@ type ValueMergeHandler interface {
 	Execute(p Plugin, local Value, inbound Value, config json.RawMessage) (result ValueMergeResult, err error)
 }
 
+// SigningHandler signs and verifies digests for a dedicated
+// signing algorithm, for example by using a key management service.
+type SigningHandler interface {
+	Name() string
+	Description() string
+
+	// Sign creates a signature for the digest described by the request.
+	Sign(p Plugin, req *SigningRequest, creds credentials.Credentials) (*Signature, error)
+	// Verify checks the signature described by the request. It returns
+	// an error, if the verification fails.
+	Verify(p Plugin, req *VerificationRequest, creds credentials.Credentials) error
+}
+
 type ValueSet interface {
 	runtime.TypedObjectDecoder[AccessSpec]
 
@@ -36,10 +36,15 @@ type plugin struct {
 	methods      map[string]AccessMethod
 	accessScheme runtime.Scheme[runtime.TypedObject, runtime.TypedObjectDecoder[runtime.TypedObject]]
 
+	inputTypes  map[string]InputType
+	inputScheme runtime.Scheme[runtime.TypedObject, runtime.TypedObjectDecoder[runtime.TypedObject]]
+
 	actions       map[string]Action
 	mergehandlers map[string]ValueMergeHandler
 	mergespecs    map[string]*descriptor.LabelMergeSpecification
 
+	signinghandlers map[string]SigningHandler
+
 	valuesets map[string]map[string]ValueSet
 	setScheme map[string]runtime.Scheme[runtime.TypedObject, runtime.TypedObjectDecoder[runtime.TypedObject]]
 
@@ -61,10 +66,15 @@ func NewPlugin(name string, version string) Plugin {
 		accessScheme:   runtime.MustNewDefaultScheme[runtime.TypedObject, runtime.TypedObjectDecoder[runtime.TypedObject]](&runtime.UnstructuredVersionedTypedObject{}, false, nil),
 		uploaderScheme: runtime.MustNewDefaultScheme[runtime.TypedObject, runtime.TypedObjectDecoder[runtime.TypedObject]](&runtime.UnstructuredVersionedTypedObject{}, false, nil),
 
+		inputTypes:  map[string]InputType{},
+		inputScheme: runtime.MustNewDefaultScheme[runtime.TypedObject, runtime.TypedObjectDecoder[runtime.TypedObject]](&runtime.UnstructuredVersionedTypedObject{}, false, nil),
+
 		actions:       map[string]Action{},
 		mergehandlers: map[string]ValueMergeHandler{},
 		mergespecs:    map[string]*descriptor.LabelMergeSpecification{},
 
+		signinghandlers: map[string]SigningHandler{},
+
 		valuesets: map[string]map[string]ValueSet{},
 		setScheme: map[string]runtime.Scheme[runtime.TypedObject, runtime.TypedObjectDecoder[runtime.TypedObject]]{},
 
@@ -103,6 +113,12 @@ func (p *plugin) SetShort(s string) {
 	p.descriptor.Short = s
 }
 
+// SetPersistent declares the plugin to support long-lived sessions
+// served by the serve command.
+func (p *plugin) SetPersistent(b bool) {
+	p.descriptor.Persistent = b
+}
+
 func (p *plugin) SetDescriptorTweaker(t func(descriptor descriptor.Descriptor) descriptor.Descriptor) {
 	p.tweaker = t
 }
@@ -256,23 +272,9 @@ func (p *plugin) RegisterAccessMethod(m AccessMethod) error {
 		return errors.ErrAlreadyExists(errors.KIND_ACCESSMETHOD, n)
 	}
 
-	var optlist []CLIOption
-	for _, o := range m.Options() {
-		known := options.DefaultRegistry.GetOptionType(o.GetName())
-		if known != nil {
-			if o.ValueType() != known.ValueType() {
-				return fmt.Errorf("option type %s[%s] conflicts with standard option type using value type %s", o.GetName(), o.ValueType(), known.ValueType())
-			}
-			optlist = append(optlist, CLIOption{
-				Name: o.GetName(),
-			})
-		} else {
-			optlist = append(optlist, CLIOption{
-				Name:        o.GetName(),
-				Type:        o.ValueType(),
-				Description: o.GetDescriptionText(),
-			})
-		}
+	optlist, err := cliOptions(m.Options())
+	if err != nil {
+		return err
 	}
 	_, idp := m.(ContentVersionIdentityProvider)
 	vers := m.Version()
@@ -318,6 +320,61 @@ func (p *plugin) GetAccessMethod(name string, version string) AccessMethod {
 	return p.methods[n]
 }
 
+func (p *plugin) RegisterInputType(t InputType) error {
+	if p.GetInputType(t.Name(), t.Version()) != nil {
+		n := t.Name()
+		if t.Version() != "" {
+			n += runtime.VersionSeparator + t.Version()
+		}
+		return errors.ErrAlreadyExists(descriptor.KIND_INPUTTYPE, n)
+	}
+
+	optlist, err := cliOptions(t.Options())
+	if err != nil {
+		return err
+	}
+	vers := t.Version()
+	if vers == "" {
+		typ := descriptor.InputTypeDescriptor{
+			ValueSetDefinition: descriptor.ValueSetDefinition{
+				Name:        t.Name(),
+				Description: t.Description(),
+				Format:      t.Format(),
+				CLIOptions:  optlist,
+			},
+		}
+		p.descriptor.InputTypes = append(p.descriptor.InputTypes, typ)
+		p.inputScheme.RegisterByDecoder(t.Name(), t)
+		p.inputTypes[t.Name()] = t
+		vers = "v1"
+	}
+	typ := descriptor.InputTypeDescriptor{
+		ValueSetDefinition: descriptor.ValueSetDefinition{
+			Name:        t.Name(),
+			Version:     vers,
+			Description: t.Description(),
+			Format:      t.Format(),
+			CLIOptions:  optlist,
+		},
+	}
+	p.descriptor.InputTypes = append(p.descriptor.InputTypes, typ)
+	p.inputScheme.RegisterByDecoder(t.Name()+"/"+vers, t)
+	p.inputTypes[t.Name()+"/"+vers] = t
+	return nil
+}
+
+func (p *plugin) DecodeInputSpecification(data []byte) (InputSpec, error) {
+	return p.inputScheme.Decode(data, nil)
+}
+
+func (p *plugin) GetInputType(name string, version string) InputType {
+	n := name
+	if version != "" {
+		n += "/" + version
+	}
+	return p.inputTypes[n]
+}
+
 func (p *plugin) RegisterAction(a Action) error {
 	if p.GetAction(a.Name()) != nil {
 		return errors.ErrAlreadyExists("action", a.Name())
@@ -365,6 +422,24 @@ func (p *plugin) GetValueMergeHandler(name string) ValueMergeHandler {
 	return p.mergehandlers[name]
 }
 
+func (p *plugin) RegisterSigningHandler(h SigningHandler) error {
+	if p.GetSigningHandler(h.Name()) != nil {
+		return errors.ErrAlreadyExists(descriptor.KIND_SIGNINGHANDLER, h.Name())
+	}
+
+	hd := descriptor.SigningHandlerDescriptor{
+		Name:        h.Name(),
+		Description: h.Description(),
+	}
+	p.descriptor.SigningHandlers = append(p.descriptor.SigningHandlers, hd)
+	p.signinghandlers[h.Name()] = h
+	return nil
+}
+
+func (p *plugin) GetSigningHandler(name string) SigningHandler {
+	return p.signinghandlers[name]
+}
+
 func (p *plugin) RegisterLabelMergeSpecification(name, version string, spec *metav1.MergeAlgorithmSpecification, desc string) error {
 	e := descriptor.LabelMergeSpecification{
 		Name:                        name,
@@ -431,23 +506,9 @@ func (p *plugin) RegisterValueSet(s ValueSet) error {
 		}
 	}
 
-	var optlist []CLIOption
-	for _, o := range s.Options() {
-		known := options.DefaultRegistry.GetOptionType(o.GetName())
-		if known != nil {
-			if o.ValueType() != known.ValueType() {
-				return fmt.Errorf("option type %s[%s] conflicts with standard option type using value type %s", o.GetName(), o.ValueType(), known.ValueType())
-			}
-			optlist = append(optlist, CLIOption{
-				Name: o.GetName(),
-			})
-		} else {
-			optlist = append(optlist, CLIOption{
-				Name:        o.GetName(),
-				Type:        o.ValueType(),
-				Description: o.GetDescriptionText(),
-			})
-		}
+	optlist, err := cliOptions(s.Options())
+	if err != nil {
+		return err
 	}
 	vers := s.Version()
 	if vers == "" {
@@ -503,3 +564,27 @@ func (p *plugin) RegisterValueSet(s ValueSet) error {
 	}
 	return nil
 }
+
+// cliOptions provides the CLI option descriptions for a list of option types.
+// Standard options are described by their name, only.
+func cliOptions(list []options.OptionType) ([]CLIOption, error) {
+	var optlist []CLIOption
+	for _, o := range list {
+		known := options.DefaultRegistry.GetOptionType(o.GetName())
+		if known != nil {
+			if o.ValueType() != known.ValueType() {
+				return nil, fmt.Errorf("option type %s[%s] conflicts with standard option type using value type %s", o.GetName(), o.ValueType(), known.ValueType())
+			}
+			optlist = append(optlist, CLIOption{
+				Name: o.GetName(),
+			})
+		} else {
+			optlist = append(optlist, CLIOption{
+				Name:        o.GetName(),
+				Type:        o.ValueType(),
+				Description: o.GetDescriptionText(),
+			})
+		}
+	}
+	return optlist, nil
+}
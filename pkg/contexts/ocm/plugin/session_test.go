@@ -0,0 +1,228 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/options"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/cache"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/descriptor"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/accessmethod"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/accessmethod/identity"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/ppi/cmds/serve"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+type pathSpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+	Path                        string `json:"path"`
+}
+
+// pathMethod is an access method providing the path
+// as content version identity.
+type pathMethod struct {
+	ppi.AccessMethodBase
+}
+
+var (
+	_ ppi.AccessMethod                   = (*pathMethod)(nil)
+	_ ppi.ContentVersionIdentityProvider = (*pathMethod)(nil)
+)
+
+func (m *pathMethod) Options() []options.OptionType {
+	return nil
+}
+
+func (m *pathMethod) ValidateSpecification(p ppi.Plugin, spec ppi.AccessSpec) (*ppi.AccessSpecInfo, error) {
+	return &ppi.AccessSpecInfo{Short: spec.(*pathSpec).Path}, nil
+}
+
+func (m *pathMethod) Reader(p ppi.Plugin, spec ppi.AccessSpec, creds credentials.Credentials) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(spec.(*pathSpec).Path)), nil
+}
+
+func (m *pathMethod) ComposeAccessSpecification(p ppi.Plugin, opts ppi.Config, config ppi.Config) error {
+	return nil
+}
+
+func (m *pathMethod) GetInexpensiveContentVersionIdentity(p ppi.Plugin, spec ppi.AccessSpec, creds credentials.Credentials) (string, error) {
+	return "path:" + spec.(*pathSpec).Path, nil
+}
+
+// echo provides a command tree echoing its config, arguments and
+// standard input. The argument fail lets the command fail
+// without reading the input.
+func echo() *cobra.Command {
+	var config string
+	cmd := &cobra.Command{
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 && args[0] == "fail" {
+				return fmt.Errorf("failed on request")
+			}
+			data, err := io.ReadAll(cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			cmd.Printf("%s%s:%s", config, strings.Join(args, ","), string(data))
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&config, "config", "c", "", "config")
+	return cmd
+}
+
+func startSession(factory serve.CommandFactory) (*cache.Session, chan error) {
+	sin, cin := io.Pipe()
+	cout, sout := io.Pipe()
+	served := make(chan error, 1)
+	go func() {
+		err := serve.Serve(factory, sin, sout)
+		sout.Close()
+		served <- err
+	}()
+	return cache.NewSession(cin, cout, func() error { return <-served }), served
+}
+
+var _ = Describe("plugin sessions", func() {
+	It("executes requests", func() {
+		s, _ := startSession(echo)
+		defer s.Close()
+
+		Expect(Must(s.Exec(nil, strings.NewReader("first"), nil, "a", "b"))).To(Equal([]byte("a,b:first")))
+		Expect(Must(s.Exec(json.RawMessage(`{}`), nil, nil, "c"))).To(Equal([]byte("{}c:")))
+
+		var buf bytes.Buffer
+		Expect(Must(s.Exec(nil, strings.NewReader("third"), &buf, "d"))).To(BeNil())
+		Expect(buf.String()).To(Equal("d:third"))
+	})
+
+	It("transfers large streams", func() {
+		s, _ := startSession(echo)
+		defer s.Close()
+
+		input := strings.Repeat("0123456789", 100000)
+		var buf bytes.Buffer
+		Expect(Must(s.Exec(nil, strings.NewReader(input), &buf, "large"))).To(BeNil())
+		Expect(buf.String()).To(Equal("large:" + input))
+	})
+
+	It("keeps session after failed request", func() {
+		s, _ := startSession(echo)
+		defer s.Close()
+
+		_, err := s.Exec(nil, strings.NewReader(strings.Repeat("x", 200000)), nil, "fail")
+		Expect(err).To(MatchError("failed on request"))
+		Expect(s.IsClosed()).To(BeFalse())
+		Expect(Must(s.Exec(nil, strings.NewReader("next"), nil, "ok"))).To(Equal([]byte("ok:next")))
+	})
+
+	It("terminates server on close", func() {
+		s, _ := startSession(echo)
+		Expect(Must(s.Exec(nil, nil, nil, "a"))).To(Equal([]byte("a:")))
+		MustBeSuccessful(s.Close())
+		Expect(s.IsClosed()).To(BeTrue())
+		_, err := s.Exec(nil, nil, nil, "a")
+		Expect(err).To(MatchError("plugin session closed"))
+	})
+
+	It("serves plugin commands", func() {
+		p := ppi.NewPlugin("test", "v1")
+		p.SetPersistent(true)
+		s, _ := startSession(func() *cobra.Command { return cmds.NewPluginCommand(p).Command() })
+		defer s.Close()
+
+		var d descriptor.Descriptor
+		for i := 0; i < 2; i++ {
+			MustBeSuccessful(json.Unmarshal(Must(s.Exec(nil, nil, nil, "info")), &d))
+			Expect(d.PluginName).To(Equal("test"))
+			Expect(d.Persistent).To(BeTrue())
+		}
+		_, err := s.Exec(nil, nil, nil, "unknown")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("serves access method identity", func() {
+		p := ppi.NewPlugin("test", "v1")
+		p.SetPersistent(true)
+		MustBeSuccessful(p.RegisterAccessMethod(&pathMethod{ppi.MustNewAccessMethodBase("path", "", &pathSpec{}, "path access", "")}))
+		s, _ := startSession(func() *cobra.Command { return cmds.NewPluginCommand(p).Command() })
+		defer s.Close()
+
+		Expect(string(Must(s.Exec(nil, nil, nil, accessmethod.Name, identity.Name, `{"type":"path","path":"a/b"}`)))).To(Equal("path:a/b\n"))
+	})
+
+	It("executes concurrent requests with pooled sessions", func() {
+		var lock sync.Mutex
+		var started []*cache.Session
+		start := func() (*cache.Session, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			s, _ := startSession(echo)
+			started = append(started, s)
+			return s, nil
+		}
+		pool := cache.NewSessionPool(2, start)
+
+		// the input of the first requests is blocked until both
+		// requests are running, which requires two sessions.
+		r1, w1 := io.Pipe()
+		r2, w2 := io.Pipe()
+		var wg sync.WaitGroup
+		results := make([][]byte, 2)
+		for i, r := range []io.Reader{r1, r2} {
+			i, r := i, r
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				s := Must(pool.Acquire())
+				defer pool.Release(s)
+				results[i] = Must(s.Exec(nil, r, nil, fmt.Sprintf("r%d", i)))
+			}()
+		}
+		Eventually(func() int {
+			lock.Lock()
+			defer lock.Unlock()
+			return len(started)
+		}, 5*time.Second).Should(Equal(2))
+		w1.Write([]byte("one"))
+		w1.Close()
+		w2.Write([]byte("two"))
+		w2.Close()
+		wg.Wait()
+		Expect(results).To(Equal([][]byte{[]byte("r0:one"), []byte("r1:two")}))
+
+		// idle sessions are reused
+		s := Must(pool.Acquire())
+		Expect(Must(s.Exec(nil, nil, nil, "a"))).To(Equal([]byte("a:")))
+		pool.Release(s)
+		Expect(started).To(HaveLen(2))
+
+		MustBeSuccessful(pool.Close())
+		for _, s := range started {
+			Expect(s.IsClosed()).To(BeTrue())
+		}
+		_, err := pool.Acquire()
+		Expect(err).To(MatchError("plugin session closed"))
+	})
+})
@@ -10,6 +10,7 @@ import (
 	"github.com/opencontainers/go-digest"
 
 	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/errors"
 	"github.com/open-component-model/ocm/pkg/iotools"
 )
 
@@ -31,3 +32,38 @@ func (d *AccessDataWriter) WriteTo(w accessio.Writer) (int64, digest.Digest, err
 	}
 	return dw.Size(), dw.Digest(), nil
 }
+
+type InputDataWriter struct {
+	plugin    Plugin
+	info      InputResourceInfo
+	creds     json.RawMessage
+	inputspec json.RawMessage
+	digest    digest.Digest
+}
+
+// NewInputDataWriter provides a writer for the blob of a plugin input.
+// If a digest is declared by the plugin, the written content is
+// verified against it.
+func NewInputDataWriter(p Plugin, info InputResourceInfo, creds, inputspec json.RawMessage, dig digest.Digest) *InputDataWriter {
+	return &InputDataWriter{p, info, creds, inputspec, dig}
+}
+
+func (d *InputDataWriter) WriteTo(w accessio.Writer) (int64, digest.Digest, error) {
+	var dw *iotools.DigestWriter
+	if d.digest != "" {
+		if err := d.digest.Validate(); err != nil {
+			return accessio.BLOB_UNKNOWN_SIZE, accessio.BLOB_UNKNOWN_DIGEST, errors.Wrapf(err, "invalid digest %q declared by plugin", d.digest)
+		}
+		dw = iotools.NewDigestWriterWith(d.digest.Algorithm(), accessio.NopWriteCloser(w))
+	} else {
+		dw = iotools.NewDefaultDigestWriter(accessio.NopWriteCloser(w))
+	}
+	err := d.plugin.GetInputBlob(dw, d.info, d.creds, d.inputspec)
+	if err != nil {
+		return accessio.BLOB_UNKNOWN_SIZE, accessio.BLOB_UNKNOWN_DIGEST, err
+	}
+	if d.digest != "" && dw.Digest() != d.digest {
+		return accessio.BLOB_UNKNOWN_SIZE, accessio.BLOB_UNKNOWN_DIGEST, errors.Newf("digest mismatch for plugin input: declared %s, but found %s", d.digest, dw.Digest())
+	}
+	return dw.Size(), dw.Digest(), nil
+}
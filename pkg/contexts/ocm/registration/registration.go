@@ -13,6 +13,7 @@ import (
 	pluginaccess "github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/plugin"
 	pluginaction "github.com/open-component-model/ocm/pkg/contexts/ocm/actionhandler/plugin"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/plugincacheattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/signingattr"
 	pluginupload "github.com/open-component-model/ocm/pkg/contexts/ocm/blobhandler/handlers/generic/plugin"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/download"
@@ -24,6 +25,8 @@ import (
 	pluginmerge "github.com/open-component-model/ocm/pkg/contexts/ocm/valuemergehandler/handlers/plugin"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/valuemergehandler/hpi"
 	"github.com/open-component-model/ocm/pkg/runtime"
+	"github.com/open-component-model/ocm/pkg/signing"
+	pluginsigning "github.com/open-component-model/ocm/pkg/signing/handlers/plugin"
 )
 
 // RegisterExtensions registers all the extension provided by the found plugin.
@@ -32,6 +35,7 @@ func RegisterExtensions(ctx ocm.Context) error {
 
 	logger := Logger(ctx)
 	vmreg := valuemergehandler.For(ctx)
+	var signreg signing.HandlerRegistry
 	for _, n := range pi.PluginNames() {
 		p := pi.Get(n)
 		if !p.IsValid() {
@@ -72,6 +76,25 @@ func RegisterExtensions(ctx ocm.Context) error {
 			pi.GetContext().AccessMethods().Register(pluginaccess.NewType(name, p, &m))
 		}
 
+		for _, s := range p.GetDescriptor().SigningHandlers {
+			if signingHandlerExists(ctx, signreg, s.Name) {
+				logger.Error("signing handler for plugin conflicts with existing handler", "plugin", p.Name(), "handler", s.Name)
+				continue
+			}
+			h, err := pluginsigning.New(p, s.Name)
+			if err != nil {
+				logger.Error("cannot create signing handler for plugin", "plugin", p.Name(), "handler", s.Name)
+			} else {
+				if signreg == nil {
+					signreg = signing.NewHandlerRegistry(signingattr.Get(ctx).HandlerRegistry())
+				}
+				logger.Info("registering signing handler",
+					"plugin", p.Name(),
+					"handler", s.Name)
+				signreg.RegisterSignatureHandler(h)
+			}
+		}
+
 		for _, m := range p.GetDescriptor().ValueSets {
 			if !slices.Contains(m.Purposes, descriptor.PURPOSE_ROUTINGSLIP) {
 				continue
@@ -139,5 +162,20 @@ func RegisterExtensions(ctx ocm.Context) error {
 			}
 		}
 	}
+	if signreg != nil {
+		return signingattr.SetHandlerRegistry(ctx, signreg)
+	}
 	return nil
 }
+
+// signingHandlerExists checks whether a signer or verifier is already
+// known for the given name. Plugins must not replace existing handlers,
+// because a plugin handler manages its keys on its own and would
+// otherwise decide about all signatures of the replaced algorithm.
+func signingHandlerExists(ctx ocm.Context, reg signing.HandlerRegistry, name string) bool {
+	if reg != nil {
+		return reg.GetSigner(name) != nil || reg.GetVerifier(name) != nil
+	}
+	attr := signingattr.Get(ctx)
+	return attr.GetSigner(name) != nil || attr.GetVerifier(name) != nil
+}
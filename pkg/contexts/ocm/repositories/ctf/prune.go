@@ -0,0 +1,222 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctf
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi/repocpi"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/genericocireg"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+type (
+	GCResult = ctf.GCResult
+	BlobInfo = ctf.BlobInfo
+)
+
+// RetentionRules describe the component versions kept when
+// pruning a CTF. A version is kept if it is selected by any of
+// the rules. Without any rule, all versions are kept.
+type RetentionRules struct {
+	// KeepLast keeps the latest n versions of every component.
+	KeepLast int
+	// KeepMajorLatest keeps the latest version of every major release
+	// of a component.
+	KeepMajorLatest bool
+}
+
+// IsEmpty reports whether no retention rule is set.
+func (r *RetentionRules) IsEmpty() bool {
+	return r == nil || (r.KeepLast <= 0 && !r.KeepMajorLatest)
+}
+
+// Discarded provides the versions of a version list not selected by
+// the retention rules. Versions not conforming to semver are never
+// pruned.
+func (r *RetentionRules) Discarded(versions []string) []string {
+	if r.IsEmpty() {
+		return nil
+	}
+	var list semver.Collection
+	for _, v := range versions {
+		if sv, err := semver.NewVersion(v); err == nil {
+			list = append(list, sv)
+		}
+	}
+	sort.Sort(sort.Reverse(list))
+
+	var pruned []string
+	majors := map[uint64]bool{}
+	for i, v := range list {
+		keep := i < r.KeepLast
+		if r.KeepMajorLatest && !majors[v.Major()] {
+			majors[v.Major()] = true
+			keep = true
+		}
+		if !keep {
+			pruned = append(pruned, v.Original())
+		}
+	}
+	return pruned
+}
+
+// PruneResult describes the outcome of pruning a CTF.
+type PruneResult struct {
+	// Pruned lists the removed component versions.
+	Pruned []common.NameVersion
+	// Referenced lists the component versions not selected by the
+	// retention rules, which are kept, because they are (directly or
+	// indirectly) referenced by retained component versions.
+	Referenced []common.NameVersion
+	*GCResult
+}
+
+// GC removes all blobs of a CTF based OCM repository not reachable
+// from its artifact index. In dry-run mode, the orphaned blobs are
+// just reported.
+func GC(repo cpi.Repository, dryRun bool) (*GCResult, error) {
+	r, err := getCTF(repo)
+	if err != nil {
+		return nil, err
+	}
+	return r.GC(dryRun)
+}
+
+// Prune removes all component versions of a CTF based OCM repository
+// not selected by the given retention rules. Versions referenced by
+// retained component versions are kept, also. Afterwards, all
+// blobs not reachable anymore are deleted. In dry-run mode,
+// the pruned versions and blobs are just reported.
+func Prune(repo cpi.Repository, rules RetentionRules, dryRun bool) (result *PruneResult, err error) {
+	r, err := getCTF(repo)
+	if err != nil {
+		return nil, err
+	}
+	impl, err := repocpi.GetRepositoryImplementation(repo)
+	if err != nil {
+		return nil, err
+	}
+	mapper, ok := impl.(*genericocireg.RepositoryImpl)
+	if !ok {
+		return nil, errors.ErrNotSupported("repository implementation type", fmt.Sprintf("%T", impl))
+	}
+	lister := repo.ComponentLister()
+	if lister == nil {
+		return nil, errors.ErrNotSupported("component lister")
+	}
+	comps, err := lister.GetComponents("", true)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(comps)
+
+	var candidates []common.NameVersion
+	var retained []common.NameVersion
+	discard := map[common.NameVersion]bool{}
+	for _, name := range comps {
+		versions, err := listVersions(repo, name)
+		if err != nil {
+			return nil, err
+		}
+		discarded := rules.Discarded(versions)
+		for _, v := range discarded {
+			nv := common.NewNameVersion(name, v)
+			candidates = append(candidates, nv)
+			discard[nv] = true
+		}
+		for _, v := range versions {
+			if nv := common.NewNameVersion(name, v); !discard[nv] {
+				retained = append(retained, nv)
+			}
+		}
+	}
+
+	// keep all versions still referenced by retained versions.
+	var referenced []common.NameVersion
+	for len(retained) > 0 {
+		nv := retained[0]
+		retained = retained[1:]
+		refs, err := listReferences(repo, nv)
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range refs {
+			if discard[ref] {
+				delete(discard, ref)
+				referenced = append(referenced, ref)
+				retained = append(retained, ref)
+			}
+		}
+	}
+
+	var pruned []common.NameVersion
+	var drop []ctf.ArtifactRef
+	for _, nv := range candidates {
+		if !discard[nv] {
+			continue
+		}
+		ns, err := mapper.MapComponentNameToNamespace(nv.GetName())
+		if err != nil {
+			return nil, err
+		}
+		art, err := r.LookupArtifact(ns, strings.ReplaceAll(nv.GetVersion(), "+", genericocireg.META_SEPARATOR))
+		if err != nil {
+			return nil, errors.Wrapf(err, "component version %s", nv)
+		}
+		drop = append(drop, ctf.ArtifactRef{Namespace: ns, Digest: art.Digest()})
+		pruned = append(pruned, nv)
+		err = art.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	gc, err := r.GC(dryRun, drop...)
+	if err != nil {
+		return nil, err
+	}
+	return &PruneResult{Pruned: pruned, Referenced: referenced, GCResult: gc}, nil
+}
+
+func listVersions(repo cpi.Repository, name string) (_ []string, rerr error) {
+	c, err := repo.LookupComponent(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "component %s", name)
+	}
+	defer errors.PropagateError(&rerr, c.Close)
+	versions, err := c.ListVersions()
+	if err != nil {
+		return nil, errors.Wrapf(err, "component %s", name)
+	}
+	return versions, nil
+}
+
+func listReferences(repo cpi.Repository, nv common.NameVersion) (_ []common.NameVersion, rerr error) {
+	cv, err := repo.LookupComponentVersion(nv.GetName(), nv.GetVersion())
+	if err != nil {
+		return nil, errors.Wrapf(err, "component version %s", nv)
+	}
+	defer errors.PropagateError(&rerr, cv.Close)
+	var refs []common.NameVersion
+	for _, r := range cv.GetDescriptor().References {
+		refs = append(refs, common.NewNameVersion(r.ComponentName, r.Version))
+	}
+	return refs, nil
+}
+
+func getCTF(repo cpi.Repository) (*ctf.Repository, error) {
+	o := genericocireg.GetOCIRepository(repo)
+	if r, ok := o.(*ctf.Repository); ok {
+		return r, nil
+	}
+	return nil, errors.ErrNotSupported("garbage collection", repo.GetSpecification().GetType())
+}
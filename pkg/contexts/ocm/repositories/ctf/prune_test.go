@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ctf_test
+
+import (
+	"path"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/env/builder"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const (
+	ARCH       = "/tmp/ctf"
+	COMPONENT2 = "github.com/mandelsoft/other"
+	APP        = "github.com/mandelsoft/app"
+	LIB        = "github.com/mandelsoft/lib"
+)
+
+var _ = Describe("pruning", func() {
+	var env *Builder
+
+	versions := []string{"1.0.0", "1.1.0", "1.2.0", "2.0.0", "2.1.0+build.1"}
+
+	BeforeEach(func() {
+		env = NewBuilder()
+
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			for _, v := range versions {
+				env.Component(COMPONENT, func() {
+					env.Version(v, func() {
+						env.Provider("mandelsoft")
+						env.Resource("testdata", "", "PlainText", metav1.LocalRelation, func() {
+							env.BlobStringData(mime.MIME_TEXT, "testdata "+v)
+						})
+					})
+				})
+			}
+			env.Component(COMPONENT2, func() {
+				env.Version("0.1.0", func() {
+					env.Provider("mandelsoft")
+				})
+			})
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("selects versions to discard", func() {
+		rules := &ctf.RetentionRules{}
+		Expect(rules.IsEmpty()).To(BeTrue())
+		Expect(rules.Discarded(versions)).To(BeEmpty())
+
+		rules.KeepLast = 2
+		Expect(rules.Discarded(versions)).To(Equal([]string{"1.2.0", "1.1.0", "1.0.0"}))
+
+		rules.KeepLast = 0
+		rules.KeepMajorLatest = true
+		Expect(rules.Discarded(append(versions, "invalid"))).To(Equal([]string{"2.0.0", "1.1.0", "1.0.0"}))
+	})
+
+	It("reports pruned versions in dry-run mode", func() {
+		repo := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(repo)
+
+		result := Must(ctf.Prune(repo, ctf.RetentionRules{KeepLast: 1, KeepMajorLatest: true}, true))
+		Expect(result.Pruned).To(Equal([]common.NameVersion{
+			common.NewNameVersion(COMPONENT, "2.0.0"),
+			common.NewNameVersion(COMPONENT, "1.1.0"),
+			common.NewNameVersion(COMPONENT, "1.0.0"),
+		}))
+		Expect(result.Orphaned).To(HaveLen(12))
+		c := Must(repo.LookupComponent(COMPONENT))
+		defer Close(c)
+		Expect(c.ListVersions()).To(ConsistOf(versions))
+	})
+
+	It("prunes versions and collects their blobs", func() {
+		repo := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+		result := Must(ctf.Prune(repo, ctf.RetentionRules{KeepLast: 1, KeepMajorLatest: true}, false))
+		MustBeSuccessful(repo.Close())
+		Expect(result.Pruned).To(HaveLen(3))
+		Expect(result.Orphaned).To(HaveLen(12))
+
+		repo = Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(repo)
+		c := Must(repo.LookupComponent(COMPONENT))
+		defer Close(c)
+		Expect(c.ListVersions()).To(ConsistOf("1.2.0", "2.1.0+build.1"))
+		cv := Must(c.LookupVersion("1.2.0"))
+		defer Close(cv)
+		r := Must(cv.GetResourceByIndex(0))
+		m := Must(r.AccessMethod())
+		defer Close(m)
+		Expect(m.Get()).To(Equal([]byte("testdata 1.2.0")))
+		Expect(Must(ctf.GC(repo, true)).Orphaned).To(BeEmpty())
+		Expect(repo.ExistsComponentVersion(COMPONENT2, "0.1.0")).To(BeTrue())
+	})
+
+	It("keeps versions referenced by retained versions", func() {
+		add := func(repo ocm.Repository, name, version string, refs ...common.NameVersion) {
+			c := Must(repo.LookupComponent(name))
+			defer Close(c)
+			cv := Must(c.NewVersion(version))
+			defer Close(cv)
+			cv.GetDescriptor().Provider.Name = "mandelsoft"
+			for _, r := range refs {
+				MustBeSuccessful(cv.SetReference(compdesc.NewComponentReference(path.Base(r.GetName()), r.GetName(), r.GetVersion(), nil)))
+			}
+			MustBeSuccessful(c.AddVersion(cv))
+		}
+
+		repo := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+		add(repo, LIB, "1.0.0", common.NewNameVersion(COMPONENT, "1.1.0"))
+		add(repo, LIB, "1.1.0")
+		add(repo, APP, "1.0.0", common.NewNameVersion(LIB, "1.0.0"))
+
+		result := Must(ctf.Prune(repo, ctf.RetentionRules{KeepLast: 1}, false))
+		MustBeSuccessful(repo.Close())
+		Expect(result.Referenced).To(Equal([]common.NameVersion{
+			common.NewNameVersion(LIB, "1.0.0"),
+			common.NewNameVersion(COMPONENT, "1.1.0"),
+		}))
+		Expect(result.Pruned).To(Equal([]common.NameVersion{
+			common.NewNameVersion(COMPONENT, "2.0.0"),
+			common.NewNameVersion(COMPONENT, "1.2.0"),
+			common.NewNameVersion(COMPONENT, "1.0.0"),
+		}))
+
+		repo = Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(repo)
+		c := Must(repo.LookupComponent(COMPONENT))
+		defer Close(c)
+		Expect(c.ListVersions()).To(ConsistOf("1.1.0", "2.1.0+build.1"))
+		Expect(repo.ExistsComponentVersion(LIB, "1.0.0")).To(BeTrue())
+		Expect(Must(ctf.GC(repo, true)).Orphaned).To(BeEmpty())
+	})
+})
@@ -28,7 +28,10 @@ type localBlobAccessMethod struct {
 	artifact  oci.ArtifactAccess
 }
 
-var _ accspeccpi.AccessMethodImpl = (*localBlobAccessMethod)(nil)
+var (
+	_ accspeccpi.AccessMethodImpl = (*localBlobAccessMethod)(nil)
+	_ accspeccpi.SizeSource       = (*localBlobAccessMethod)(nil)
+)
 
 func newLocalBlobAccessMethod(a *localblob.AccessSpec, ns oci.NamespaceAccess, art oci.ArtifactAccess, ref refmgmt.ExtendedAllocatable) (accspeccpi.AccessMethod, error) {
 	return accspeccpi.AccessMethodForImplementation(newLocalBlobAccessMethodImpl(a, ns, art, ref))
@@ -112,6 +115,20 @@ func (m *localBlobAccessMethod) Get() ([]byte, error) {
 	return blobaccess.BlobData(m.getBlob())
 }
 
+// GetSize provides the size of the blob according to the layer
+// descriptor of the component version artifact.
+func (m *localBlobAccessMethod) GetSize() (int64, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.artifact != nil {
+		if d := m.artifact.GetDescriptor().GetBlobDescriptor(digest.Digest(m.spec.LocalReference)); d != nil {
+			return d.Size, nil
+		}
+	}
+	return blobaccess.BLOB_UNKNOWN_SIZE, nil
+}
+
 func (m *localBlobAccessMethod) MimeType() string {
 	return m.spec.MediaType
 }
@@ -20,6 +20,8 @@ const (
 	HELM_CHART = "helmChart"
 	// NPM_PACKAGE describes an NPM package.
 	NPM_PACKAGE = "npmPackage"
+	// MAVEN_ARTIFACT describes a file of a Maven artifact.
+	MAVEN_ARTIFACT = "mavenArtifact"
 	// BLUEPRINT describes a Gardener Landscaper blueprint which is an artifact used in its installations describing
 	// how to deploy a software component.
 	BLUEPRINT        = "landscaper.gardener.cloud/blueprint"
@@ -36,6 +38,8 @@ const (
 	PLAIN_TEXT = "plainText"
 	// OCM_PLUGIN describes an OS executable OCM plugin.
 	OCM_PLUGIN = "ocmPlugin"
+	// SBOM describes a software bill of materials (CycloneDX or SPDX JSON).
+	SBOM = "sbom"
 
 	// OCM_FILE describes a generic file or unspecified byte stream.
 	OCM_FILE = "file"
@@ -236,6 +236,11 @@ func (dc *DigestContext) determineSignatureInfo(state WalkingState, cv ocm.Compo
 					}
 					signatures = append(signatures, sig.Name)
 					dc.DigestType = DigesterType(&sig.Digest)
+				} else if v, err := opts.VerifierFor(s.Signature.Algorithm); err != nil && opts.SignatureConfigured(sig.Name) {
+					return nil, errors.Wrapf(err, "signature %q", sig.Name)
+				} else if signing.ManagesKeys(v) {
+					signatures = append(signatures, sig.Name)
+					dc.DigestType = DigesterType(&sig.Digest)
 				} else {
 					if opts.SignatureName() != "" {
 						return nil, errors.ErrNotFound(compdesc.KIND_PUBLIC_KEY, sig.Name)
@@ -117,6 +117,11 @@ func Apply(printer common.Printer, state *WalkingState, cv ocm.ComponentVersionA
 	if err != nil {
 		return nil, err
 	}
+	if opts.DoPolicy() {
+		if err := opts.PolicyReport.Error(common.VersionedElementKey(cv)); err != nil {
+			return nil, err
+		}
+	}
 
 	return dc.Digest, nil
 }
@@ -269,6 +274,11 @@ func _apply(state WalkingState, nv common.NameVersion, cv ocm.ComponentVersionAc
 			spec = dig
 		}
 	}
+	if opts.DoPolicy() && opts.PolicyReport.Get(nv) == nil {
+		if err := evaluatePolicy(digests, nv, opts); err != nil {
+			return nil, err
+		}
+	}
 	err := ctx.Propagate(spec)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed propagating digest context")
@@ -422,10 +432,22 @@ func doVerify(digests *compdesc.CompDescDigests, state WalkingState, signatureNa
 		}
 		sig := &digests.Descriptor().Signatures[f]
 
+		verifier, err := opts.VerifierFor(sig.Signature.Algorithm)
+		if err != nil {
+			return nil, errors.Wrapf(err, "signature %q", n)
+		}
+		if verifier == nil {
+			if opts.SignatureConfigured(n) {
+				return nil, errors.ErrUnknown(compdesc.KIND_VERIFY_ALGORITHM, n)
+			}
+			opts.Printer.Printf("Warning: no verifier (%s) found for signature %q in %s\n", sig.Signature.Algorithm, n, state.History)
+			continue
+		}
+
 		sctx.Issuer = opts.IssuerFor(n)
 		if !opts.Keyless {
 			sctx.PublicKey = opts.PublicKey(n)
-			if sctx.PublicKey == nil {
+			if sctx.PublicKey == nil && !signing.ManagesKeys(verifier) {
 				var err error
 
 				opts.Printer.Printf("no public key found for signature %q -> extract key from signature\n", n)
@@ -435,29 +457,12 @@ func doVerify(digests *compdesc.CompDescDigests, state WalkingState, signatureNa
 				}
 			}
 		}
-		verifier := opts.Registry.GetVerifier(sig.Signature.Algorithm)
-		if verifier == nil {
-			if opts.SignatureConfigured(n) {
-				return nil, errors.ErrUnknown(compdesc.KIND_VERIFY_ALGORITHM, n)
-			}
-			opts.Printer.Printf("Warning: no verifier (%s) found for signature %q in %s\n", sig.Signature.Algorithm, n, state.History)
-			continue
-		}
-
-		hasher := opts.Registry.GetHasher(sig.Digest.HashAlgorithm)
-		if hasher == nil {
-			return nil, errors.ErrUnknown(compdesc.KIND_HASH_ALGORITHM, sig.Digest.HashAlgorithm)
-		}
 
-		_, digest, err := digests.Get(sig.Digest.NormalisationAlgorithm, hasher)
+		hash, err := checkSignatureDigest(digests, sig, opts)
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed hashing component descriptor")
-		}
-		if sig.Digest.Value != digest {
-			return nil, errors.Newf("signature digest (%s) does not match found digest (%s)", sig.Digest.Value, digest)
+			return nil, err
 		}
-
-		sctx.Hash = hasher.Crypto()
+		sctx.Hash = hash
 		err = verifier.Verify(sig.Digest.Value, sig.ConvertToSigning(), sctx)
 		if err != nil {
 			return nil, errors.Wrapf(err, "signature %q", n)
@@ -478,6 +483,24 @@ func doVerify(digests *compdesc.CompDescDigests, state WalkingState, signatureNa
 	return spec, nil
 }
 
+// checkSignatureDigest checks the digest of a signature against the
+// digest of the component descriptor and provides the used hash function.
+func checkSignatureDigest(digests *compdesc.CompDescDigests, sig *compdesc.Signature, opts *Options) (crypto.Hash, error) {
+	hasher := opts.Registry.GetHasher(sig.Digest.HashAlgorithm)
+	if hasher == nil {
+		return 0, errors.ErrUnknown(compdesc.KIND_HASH_ALGORITHM, sig.Digest.HashAlgorithm)
+	}
+
+	_, digest, err := digests.Get(sig.Digest.NormalisationAlgorithm, hasher)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed hashing component descriptor")
+	}
+	if sig.Digest.Value != digest {
+		return 0, errors.Newf("signature digest (%s) does not match found digest (%s)", sig.Digest.Value, digest)
+	}
+	return hasher.Crypto(), nil
+}
+
 func GetPublicKeyFromSignature(sig *compdesc.Signature, sctx signing.SigningContext, opts *Options) (signutils.GenericPublicKey, error) {
 	if sig.Signature.MediaType != signutils.MediaTypePEM {
 		return nil, errors.ErrNotFound(compdesc.KIND_PUBLIC_KEY)
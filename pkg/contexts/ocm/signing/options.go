@@ -13,7 +13,9 @@ import (
 	"github.com/open-component-model/ocm/pkg/contexts/datacontext/attrs/rootcertsattr"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/signingattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/trustpolicyattr"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/signing/trustpolicy"
 	"github.com/open-component-model/ocm/pkg/errors"
 	"github.com/open-component-model/ocm/pkg/generics"
 	"github.com/open-component-model/ocm/pkg/signing"
@@ -166,6 +168,33 @@ func (o *signer) ApplySigningOption(opts *Options) {
 
 ////////////////////////////////////////////////////////////////////////////////
 
+type verifierHandler struct {
+	algo     string
+	verifier signing.Verifier
+}
+
+// Verifier provides an option requesting to use a dedicated verifier for
+// the signatures verified by a verification operation, instead of the
+// verifier registered for the algorithm of a signature. Signatures
+// with another algorithm than the one of the verifier are rejected.
+func Verifier(h signing.Verifier) Option {
+	return &verifierHandler{"", h}
+}
+
+// VerifierByAlgo provides an option requesting to use a dedicated verifier
+// by algorithm for a verification operation. The effective verifier is taken
+// from the signer registry provided by the OCM context.
+func VerifierByAlgo(algo string) Option {
+	return &verifierHandler{algo, nil}
+}
+
+func (o *verifierHandler) ApplySigningOption(opts *Options) {
+	opts.VerifyAlgo = o.algo
+	opts.Verifier = o.verifier
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
 type hasher struct {
 	algo   string
 	hasher signing.Hasher
@@ -440,6 +469,38 @@ func (o *tsaOpt) ApplySigningOption(opts *Options) {
 
 ////////////////////////////////////////////////////////////////////////////////
 
+type policy struct {
+	policy *trustpolicy.Policy
+	report *trustpolicy.Report
+}
+
+// Policy provides an option requesting to use a dedicated trust policy
+// for a verification operation. By default, the policy configured for
+// the OCM context is used. An empty policy disables the policy evaluation.
+func Policy(p *trustpolicy.Policy) Option {
+	if p == nil {
+		p = trustpolicy.New()
+	}
+	return &policy{policy: p}
+}
+
+// PolicyReport provides an option requesting to record the trust policy
+// evaluation results of a verification operation in the given report.
+func PolicyReport(r *trustpolicy.Report) Option {
+	return &policy{report: r}
+}
+
+func (o *policy) ApplySigningOption(opts *Options) {
+	if o.policy != nil {
+		opts.Policy = o.policy
+	}
+	if o.report != nil {
+		opts.PolicyReport = o.report
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
 type Options struct {
 	Printer           common.Printer
 	Update            bool
@@ -448,6 +509,8 @@ type Options struct {
 	Verify            bool
 	SignAlgo          string
 	Signer            signing.Signer
+	VerifyAlgo        string
+	Verifier          signing.Verifier
 	Issuer            *pkix.Name
 	VerifySignature   bool
 	RootCerts         signutils.GenericCertificatePool
@@ -462,8 +525,11 @@ type Options struct {
 	Keyless           bool
 	TSAUrl            string
 	UseTSA            bool
+	Policy            *trustpolicy.Policy
+	PolicyReport      *trustpolicy.Report
 
 	effectiveRegistry signing.Registry
+	doPolicy          bool
 }
 
 var _ Option = (*Options)(nil)
@@ -489,6 +555,12 @@ func (o *Options) ApplySigningOption(opts *Options) {
 	if o.Signer != nil {
 		opts.Signer = o.Signer
 	}
+	if o.VerifyAlgo != "" {
+		opts.VerifyAlgo = o.VerifyAlgo
+	}
+	if o.Verifier != nil {
+		opts.Verifier = o.Verifier
+	}
 	if o.DigestMode != "" {
 		opts.DigestMode = o.DigestMode
 	}
@@ -531,6 +603,12 @@ func (o *Options) ApplySigningOption(opts *Options) {
 	if o.UseTSA {
 		opts.UseTSA = o.UseTSA
 	}
+	if o.Policy != nil {
+		opts.Policy = o.Policy
+	}
+	if o.PolicyReport != nil {
+		opts.PolicyReport = o.PolicyReport
+	}
 }
 
 // Complete takes either nil, an ocm.ContextProvider or a signing.Registry.
@@ -591,6 +669,12 @@ func (o *Options) Complete(ctx interface{}) error {
 			return errors.ErrUnknown(compdesc.KIND_SIGN_ALGORITHM, o.SignAlgo)
 		}
 	}
+	if o.Verifier == nil && o.VerifyAlgo != "" {
+		o.Verifier = o.Registry.GetVerifier(o.VerifyAlgo)
+		if o.Verifier == nil {
+			return errors.ErrUnknown(compdesc.KIND_VERIFY_ALGORITHM, o.VerifyAlgo)
+		}
+	}
 	if o.Signer != nil {
 		if len(o.SignatureNames) == 0 {
 			return errors.Newf("signature name required for signing")
@@ -599,7 +683,7 @@ func (o *Options) Complete(ctx interface{}) error {
 		if err != nil {
 			return err
 		}
-		if priv == nil && !o.Keyless {
+		if priv == nil && !o.Keyless && !signing.ManagesKeys(o.Signer) {
 			return errors.ErrNotFound(compdesc.KIND_PRIVATE_KEY, o.SignatureNames[0])
 		}
 		if o.DigestMode == "" {
@@ -641,9 +725,23 @@ func (o *Options) Complete(ctx interface{}) error {
 	if o.Hasher == nil {
 		o.Hasher = o.Registry.GetHasher(sha256.Algorithm)
 	}
+
+	if o.Policy == nil {
+		o.Policy = trustpolicyattr.Get(ocmctx)
+	}
+	// the trust policy is only evaluated for pure verification
+	o.doPolicy = !o.Policy.IsEmpty() && o.DoVerify() && !o.DoSign()
+	if o.doPolicy && o.PolicyReport == nil {
+		o.PolicyReport = trustpolicy.NewReport()
+	}
 	return nil
 }
 
+// DoPolicy checks whether a trust policy has to be evaluated.
+func (o *Options) DoPolicy() bool {
+	return o.doPolicy
+}
+
 func (o *Options) checkCert(data interface{}, name *pkix.Name) error {
 	cert, pool, err := signutils.GetCertificate(data, false)
 	if err != nil {
@@ -702,6 +800,20 @@ func (o *Options) IssuerFor(name string) *pkix.Name {
 	return nil
 }
 
+// VerifierFor provides the verifier to use for a signature
+// with the given algorithm. A dedicated verifier is only used for
+// signatures with a matching algorithm, signatures with any other
+// algorithm are rejected.
+func (o *Options) VerifierFor(algo string) (signing.Verifier, error) {
+	if o.Verifier != nil {
+		if o.Verifier.Algorithm() != algo {
+			return nil, errors.Newf("signature algorithm %q does not match requested verification algorithm %q", algo, o.Verifier.Algorithm())
+		}
+		return o.Verifier, nil
+	}
+	return o.Registry.GetVerifier(algo), nil
+}
+
 func (o *Options) SignatureConfigured(name string) bool {
 	for _, n := range o.SignatureNames {
 		if n == name {
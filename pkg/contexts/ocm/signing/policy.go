@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package signing
+
+import (
+	"crypto/x509"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/signing/trustpolicy"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/signing"
+	"github.com/open-component-model/ocm/pkg/signing/signutils"
+)
+
+// evaluatePolicy evaluates the trust policy for a component version.
+// All signatures considered by the matching rule are verified and the
+// result is recorded in the policy report of the options.
+func evaluatePolicy(digests *compdesc.CompDescDigests, nv common.NameVersion, opts *Options) error {
+	cd := digests.Descriptor()
+	rule := opts.Policy.RuleFor(nv.GetName())
+
+	var roots signutils.GenericCertificatePool = opts.RootCerts
+	if rule != nil {
+		pool, err := rule.RootCertPool()
+		if err != nil {
+			return errors.Wrapf(err, "trust policy rule %q", rule.GetName())
+		}
+		if pool != nil {
+			roots = pool
+		}
+	}
+
+	var verified []*trustpolicy.Signature
+	failed := map[string]error{}
+	for i := range cd.Signatures {
+		sig := &cd.Signatures[i]
+		if rule != nil && !rule.Considers(sig.Name) {
+			continue
+		}
+		s, err := verifyPolicySignature(digests, sig, roots, opts)
+		if err != nil {
+			failed[sig.Name] = err
+		} else {
+			verified = append(verified, s)
+		}
+	}
+
+	var refs []common.NameVersion
+	for _, r := range cd.References {
+		refs = append(refs, common.NewNameVersion(r.ComponentName, r.Version))
+	}
+	res := opts.PolicyReport.Evaluate(opts.Policy, nv, verified, failed, refs)
+	opts.Printer.Printf("%s\n", res)
+	return nil
+}
+
+func verifyPolicySignature(digests *compdesc.CompDescDigests, sig *compdesc.Signature, roots signutils.GenericCertificatePool, opts *Options) (*trustpolicy.Signature, error) {
+	verifier, err := opts.VerifierFor(sig.Signature.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if verifier == nil {
+		return nil, errors.ErrUnknown(compdesc.KIND_VERIFY_ALGORITHM, sig.Signature.Algorithm)
+	}
+
+	sctx := &signing.DefaultSigningContext{
+		RootCerts: roots,
+		Issuer:    opts.IssuerFor(sig.Name),
+	}
+	result := &trustpolicy.Signature{Name: sig.Name}
+
+	// certificates stored along with the signature are only used,
+	// if they are verified (by the keyless verifier or as source
+	// of the public key), otherwise the certificate chain of the
+	// configured public key is used, if available.
+	var certs []*x509.Certificate
+	if sig.Signature.MediaType == signutils.MediaTypePEM {
+		_, _, certs, err = signutils.GetSignatureFromPem([]byte(sig.Signature.Value))
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot decode signature PEM")
+		}
+	}
+	if opts.Keyless {
+		result.Certificates = certs
+	} else {
+		sctx.PublicKey = opts.PublicKey(sig.Name)
+		if sctx.PublicKey == nil && !signing.ManagesKeys(verifier) {
+			var err error
+			sctx.PublicKey, err = GetPublicKeyFromSignature(sig, sctx, opts)
+			if err != nil {
+				return nil, errors.Wrapf(err, "public key from signature")
+			}
+			result.Certificates = certs
+		} else if sctx.PublicKey != nil {
+			if chain, err := signutils.GetCertificateChain(sctx.PublicKey, false); err == nil {
+				result.Certificates = chain
+			}
+		}
+	}
+
+	hash, err := checkSignatureDigest(digests, sig, opts)
+	if err != nil {
+		return nil, err
+	}
+	sctx.Hash = hash
+	err = verifier.Verify(sig.Digest.Value, sig.ConvertToSigning(), sctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the issuer stored along with the signature is not bound to the
+	// verifying key, therefore, only the subject of a certificate is used.
+	if len(result.Certificates) > 0 {
+		result.Issuer = &result.Certificates[0].Subject
+	}
+	return result, nil
+}
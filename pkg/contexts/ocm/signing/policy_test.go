@@ -0,0 +1,201 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package signing_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/contexts/ocm/signing"
+	. "github.com/open-component-model/ocm/pkg/contexts/ocm/testhelper"
+	. "github.com/open-component-model/ocm/pkg/env/builder"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/signingattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/trustpolicyattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/signing/trustpolicy"
+	tenv "github.com/open-component-model/ocm/pkg/env"
+)
+
+var _ = Describe("trust policy", func() {
+	var env *Builder
+
+	BeforeEach(func() {
+		env = NewBuilder(tenv.NewEnvironment())
+		env.RSAKeyPair(SIGNATURE, SIGNATURE2)
+
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.Component(COMPONENTA, func() {
+				env.Version(VERSION, func() {
+					env.Provider(PROVIDER)
+					TestDataResource(env)
+				})
+			})
+			env.Component(COMPONENTB, func() {
+				env.Version(VERSION, func() {
+					env.Provider(PROVIDER)
+					OtherDataResource(env)
+					env.Reference("ref", COMPONENTA, VERSION)
+				})
+			})
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	sign := func(name string, comp string, recursive bool) {
+		src := Must(ctf.Open(env, accessobj.ACC_WRITABLE, ARCH, 0, env))
+		defer Close(src, "ctf")
+		cv := Must(src.LookupComponentVersion(comp, VERSION))
+		defer Close(cv, "cv")
+		opts := NewOptions(
+			Sign(signingattr.Get(env.OCMContext()).GetSigner(SIGN_ALGO), name),
+			Resolver(src), Update(), VerifyDigests(), Recursive(recursive),
+		)
+		MustBeSuccessful(opts.Complete(env))
+		Must(Apply(nil, nil, cv, opts))
+	}
+
+	verify := func(policy *trustpolicy.Policy, name string) (*trustpolicy.Report, string, error) {
+		src := Must(ctf.Open(env, accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(src, "ctf")
+		cv := Must(src.LookupComponentVersion(COMPONENTB, VERSION))
+		defer Close(cv, "cv")
+
+		report := trustpolicy.NewReport()
+		opts := NewOptions(VerifySignature(name), Resolver(src), VerifyDigests(), PolicyReport(report))
+		if policy != nil {
+			opts.Eval(Policy(policy))
+		}
+		MustBeSuccessful(opts.Complete(env))
+		pr, buf := common.NewBufferedPrinter()
+		_, err := Apply(pr, nil, cv, opts)
+		return report, buf.String(), err
+	}
+
+	It("accepts closure signed with required signature", func() {
+		sign(SIGNATURE, COMPONENTB, true)
+
+		rule := &trustpolicy.Rule{Name: "mandelsoft", Components: []string{"github.com/mandelsoft/*"}, Signatures: []string{SIGNATURE}}
+		report, out, err := verify(trustpolicy.New(rule), SIGNATURE)
+		MustBeSuccessful(err)
+		Expect(out).To(ContainSubstring(`  trust policy rule "mandelsoft" passed for github.com/mandelsoft/test:v1 (signatures test)`))
+		Expect(out).To(ContainSubstring(`trust policy rule "mandelsoft" passed for github.com/mandelsoft/ref:v1 (signatures test)`))
+
+		res := report.Results(common.NewNameVersion(COMPONENTB, VERSION))
+		Expect(len(res)).To(Equal(2))
+		Expect(res[0].ComponentVersion).To(Equal(common.NewNameVersion(COMPONENTB, VERSION)))
+		Expect(res[0].Failed()).To(BeFalse())
+		Expect(res[1].ComponentVersion).To(Equal(common.NewNameVersion(COMPONENTA, VERSION)))
+		Expect(res[1].Failed()).To(BeFalse())
+	})
+
+	It("rejects nested component version without required signature", func() {
+		sign(SIGNATURE, COMPONENTB, false)
+
+		rule := &trustpolicy.Rule{Name: "all", Components: []string{"*"}, Signatures: []string{SIGNATURE}}
+		report, out, err := verify(trustpolicy.New(rule), SIGNATURE)
+		Expect(err).To(MatchError(`trust policy violated: github.com/mandelsoft/test:v1 (rule "all"): 0 of 1 required trusted signatures (signature "test" not found)`))
+		Expect(out).To(ContainSubstring(`trust policy rule "all" failed for github.com/mandelsoft/test:v1`))
+		Expect(report.Get(common.NewNameVersion(COMPONENTB, VERSION)).Failed()).To(BeFalse())
+	})
+
+	It("evaluates m-of-n signatures", func() {
+		sign(SIGNATURE, COMPONENTB, true)
+
+		rule := &trustpolicy.Rule{Name: "two", Components: []string{"github.com/mandelsoft/**"}, Signatures: []string{SIGNATURE, SIGNATURE2}}
+		_, _, err := verify(trustpolicy.New(rule), SIGNATURE)
+		Expect(err).To(MatchError(ContainSubstring(`1 of 2 required trusted signatures (signature "second" not found)`)))
+
+		rule.MinSignatures = 1
+		_, _, err = verify(trustpolicy.New(rule), SIGNATURE)
+		MustBeSuccessful(err)
+
+		sign(SIGNATURE2, COMPONENTB, true)
+		rule.MinSignatures = 0
+		_, _, err = verify(trustpolicy.New(rule), SIGNATURE)
+		MustBeSuccessful(err)
+	})
+
+	It("checks same authority for references", func() {
+		sign(SIGNATURE2, COMPONENTA, false)
+		sign(SIGNATURE, COMPONENTB, false)
+
+		policy := trustpolicy.New(
+			&trustpolicy.Rule{Name: "top", Components: []string{COMPONENTB}, SameAuthority: true},
+			&trustpolicy.Rule{Name: "nested", Components: []string{"*"}},
+		)
+		_, _, err := verify(policy, SIGNATURE)
+		Expect(err).To(MatchError(`trust policy violated: github.com/mandelsoft/ref:v1 (rule "top"): referenced component version github.com/mandelsoft/test:v1 not signed by same authority`))
+
+		sign(SIGNATURE, COMPONENTA, false)
+		_, _, err = verify(policy, SIGNATURE)
+		MustBeSuccessful(err)
+	})
+
+	It("rejects component versions without matching rule", func() {
+		sign(SIGNATURE, COMPONENTB, true)
+
+		policy := trustpolicy.New(&trustpolicy.Rule{Name: "top", Components: []string{COMPONENTB}, Signatures: []string{SIGNATURE}})
+		report, out, err := verify(policy, SIGNATURE)
+		Expect(err).To(MatchError(`trust policy violated: github.com/mandelsoft/test:v1: no matching trust policy rule`))
+		Expect(out).To(ContainSubstring(`trust policy failed for github.com/mandelsoft/test:v1: no matching trust policy rule`))
+		Expect(report.Get(common.NewNameVersion(COMPONENTA, VERSION)).Rule).To(BeEmpty())
+
+		policy.AcceptUnmatched = true
+		_, out, err = verify(policy, SIGNATURE)
+		MustBeSuccessful(err)
+		Expect(out).To(ContainSubstring(`no trust policy rule for github.com/mandelsoft/test:v1`))
+	})
+
+	It("rejects key based signature for issuer rule", func() {
+		sign(SIGNATURE, COMPONENTB, true)
+
+		rule := &trustpolicy.Rule{Name: "issuer", Components: []string{"*"}, Issuers: []string{"CN=acme.org"}}
+		_, _, err := verify(trustpolicy.New(rule), SIGNATURE)
+		Expect(err).To(MatchError(ContainSubstring(`0 of 1 required trusted signatures (signature "test": no issuer (certificate required))`)))
+	})
+
+	It("rejects key based signature claiming issuer for issuer rule", func() {
+		sign(SIGNATURE, COMPONENTB, true)
+
+		// the issuer is not covered by the signature and can be set freely
+		src := Must(ctf.Open(env, accessobj.ACC_WRITABLE, ARCH, 0, env))
+		for _, comp := range []string{COMPONENTA, COMPONENTB} {
+			cv := Must(src.LookupComponentVersion(comp, VERSION))
+			cv.GetDescriptor().Signatures[0].Signature.Issuer = "CN=acme.org"
+			MustBeSuccessful(cv.Close())
+		}
+		MustBeSuccessful(src.Close())
+
+		rule := &trustpolicy.Rule{Name: "issuer", Components: []string{"*"}, Issuers: []string{"CN=acme.org"}}
+		_, _, err := verify(trustpolicy.New(rule), SIGNATURE)
+		Expect(err).To(MatchError(ContainSubstring(`0 of 1 required trusted signatures (signature "test": no issuer (certificate required))`)))
+	})
+
+	It("uses policy from config context", func() {
+		sign(SIGNATURE, COMPONENTB, false)
+
+		cfg := trustpolicyattr.New(&trustpolicy.Rule{Name: "all", Components: []string{"*"}, Signatures: []string{SIGNATURE}})
+		MustBeSuccessful(env.ConfigContext().ApplyConfig(cfg, "test"))
+		Expect(trustpolicyattr.Get(env)).NotTo(BeNil())
+
+		_, _, err := verify(nil, SIGNATURE)
+		Expect(err).To(MatchError(ContainSubstring(`github.com/mandelsoft/test:v1 (rule "all")`)))
+
+		_, _, err = verify(trustpolicy.New(), SIGNATURE)
+		MustBeSuccessful(err)
+	})
+
+	It("is not evaluated for signing", func() {
+		MustBeSuccessful(trustpolicyattr.Set(env, trustpolicy.New(&trustpolicy.Rule{Components: []string{"*"}, Signatures: []string{SIGNATURE2}})))
+		sign(SIGNATURE, COMPONENTB, false)
+	})
+})
@@ -0,0 +1,284 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trustpolicy
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	cfgcpi "github.com/open-component-model/ocm/pkg/contexts/config/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/signing/signutils"
+)
+
+const KIND_RULE = "trust policy rule"
+
+// Policy describes a declarative trust policy used to verify
+// the component versions of a component version closure.
+// For every component version the first rule matching the component
+// name is evaluated. Component versions without a matching rule are
+// rejected, unless AcceptUnmatched is set.
+type Policy struct {
+	Rules []*Rule `json:"rules,omitempty"`
+	// AcceptUnmatched accepts component versions without matching rule.
+	AcceptUnmatched bool `json:"acceptUnmatched,omitempty"`
+}
+
+func New(rules ...*Rule) *Policy {
+	return &Policy{Rules: rules}
+}
+
+func (p *Policy) IsEmpty() bool {
+	return p == nil || len(p.Rules) == 0
+}
+
+// AddRules adds rules to the policy. Rules with the same name
+// replace an already existing rule.
+func (p *Policy) AddRules(rules ...*Rule) {
+outer:
+	for _, r := range rules {
+		if r.Name != "" {
+			for i, o := range p.Rules {
+				if o.Name == r.Name {
+					p.Rules[i] = r
+					continue outer
+				}
+			}
+		}
+		p.Rules = append(p.Rules, r)
+	}
+}
+
+// RuleFor provides the first rule matching the given component name.
+func (p *Policy) RuleFor(name string) *Rule {
+	if p == nil {
+		return nil
+	}
+	for _, r := range p.Rules {
+		if r.Matches(name) {
+			return r
+		}
+	}
+	return nil
+}
+
+// AcceptsUnmatched checks whether component versions without
+// matching rule are accepted.
+func (p *Policy) AcceptsUnmatched() bool {
+	return p != nil && p.AcceptUnmatched
+}
+
+func (p *Policy) Validate() error {
+	list := errors.ErrListf("invalid trust policy")
+	for i, r := range p.Rules {
+		list.Add(errors.Wrapf(r.Validate(), "rule %d", i))
+	}
+	return list.Result()
+}
+
+func (p *Policy) Copy() *Policy {
+	if p == nil {
+		return nil
+	}
+	return &Policy{Rules: append([]*Rule(nil), p.Rules...), AcceptUnmatched: p.AcceptUnmatched}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Rule describes the trust requirements for a set of components.
+type Rule struct {
+	// Name is the name of the rule used for reporting.
+	Name string `json:"name,omitempty"`
+	// Components is a list of component name patterns the rule applies to.
+	// A pattern may use the wildcards of path.Match. The pattern '*'
+	// matches all components and a trailing '/**' matches all components
+	// below the given prefix.
+	Components []string `json:"components"`
+	// Signatures is the list of signature names considered by the rule.
+	// If empty, all signatures found in a component version are considered.
+	Signatures []string `json:"signatures,omitempty"`
+	// MinSignatures is the number of considered signatures which must be
+	// valid and trusted (m-of-n). It defaults to the number of configured
+	// signature names, or one, if no names are configured.
+	MinSignatures int `json:"minSignatures,omitempty"`
+	// Issuers is a list of trusted issuers (distinguished names) given
+	// as string. If set, a signature must be based on a certificate
+	// issued by one of them.
+	Issuers []string `json:"issuers,omitempty"`
+	// RootCertificates are the certificate authorities trusted for the
+	// matching components. If set, a signature must provide a certificate
+	// chain anchored in one of them.
+	RootCertificates []cfgcpi.ContentSpec `json:"rootCertificates,omitempty"`
+	// SameAuthority requires the referenced component versions to
+	// be signed by the same authority as the referencing one.
+	SameAuthority bool `json:"sameAuthority,omitempty"`
+
+	lock   sync.Mutex
+	pool   *x509.CertPool
+	poolok bool
+}
+
+func (r *Rule) GetName() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return strings.Join(r.Components, ",")
+}
+
+func (r *Rule) Validate() error {
+	if len(r.Components) == 0 {
+		return errors.ErrRequired("component pattern")
+	}
+	for _, c := range r.Components {
+		if _, err := path.Match(c, ""); err != nil {
+			return errors.ErrInvalidWrap(err, "component pattern", c)
+		}
+	}
+	if r.MinSignatures < 0 || (len(r.Signatures) > 0 && r.MinSignatures > len(r.Signatures)) {
+		return errors.ErrInvalid("minimum number of signatures", fmt.Sprintf("%d", r.MinSignatures))
+	}
+	for _, i := range r.Issuers {
+		if _, err := signutils.ParseDN(i); err != nil {
+			return err
+		}
+	}
+	_, err := r.RootCertPool()
+	return err
+}
+
+// Matches checks whether the rule applies to the given component name.
+func (r *Rule) Matches(name string) bool {
+	for _, c := range r.Components {
+		switch {
+		case c == "*":
+			return true
+		case strings.HasSuffix(c, "/**"):
+			if strings.HasPrefix(name, c[:len(c)-2]) {
+				return true
+			}
+		default:
+			if ok, _ := path.Match(c, name); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Considers checks whether a signature name is considered by the rule.
+func (r *Rule) Considers(name string) bool {
+	if len(r.Signatures) == 0 {
+		return true
+	}
+	for _, n := range r.Signatures {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Required provides the number of trusted signatures required by the rule.
+func (r *Rule) Required() int {
+	if r.MinSignatures > 0 {
+		return r.MinSignatures
+	}
+	if len(r.Signatures) > 0 {
+		return len(r.Signatures)
+	}
+	return 1
+}
+
+// RootCertPool provides the certificate pool for the certificate authorities
+// trusted by this rule. If no authorities are configured, nil is returned.
+func (r *Rule) RootCertPool() (*x509.CertPool, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.poolok || len(r.RootCertificates) == 0 {
+		return r.pool, nil
+	}
+	pool := x509.NewCertPool()
+	for i, spec := range r.RootCertificates {
+		data, err := spec.Get()
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot get root certificate %d", i)
+		}
+		certs, err := signutils.GetCertificateChain(data, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid root certificate %d", i)
+		}
+		for _, c := range certs {
+			pool.AddCert(c)
+		}
+	}
+	r.pool, r.poolok = pool, true
+	return pool, nil
+}
+
+// Accepts checks whether a verified signature is trusted by the rule.
+func (r *Rule) Accepts(sig *Signature) error {
+	if len(r.Issuers) > 0 {
+		if sig.Issuer == nil || len(sig.Certificates) == 0 {
+			return fmt.Errorf("no issuer (certificate required)")
+		}
+		found := false
+		for _, i := range r.Issuers {
+			dn, err := signutils.ParseDN(i)
+			if err != nil {
+				return err
+			}
+			if signutils.MatchDN(*sig.Issuer, *dn) == nil {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("untrusted issuer %q", signutils.DNAsString(*sig.Issuer))
+		}
+	}
+	pool, err := r.RootCertPool()
+	if err != nil {
+		return err
+	}
+	if pool != nil {
+		if len(sig.Certificates) == 0 {
+			return fmt.Errorf("no certificate")
+		}
+		err := signutils.VerifyCertificate(sig.Certificates[0], sig.Certificates[1:], pool, nil)
+		if err != nil {
+			return errors.Wrapf(err, "untrusted certificate authority")
+		}
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Signature describes a successfully verified signature of a component version.
+type Signature struct {
+	Name string
+	// Issuer is the subject of the verified public key certificate,
+	// if available. The issuer stored along with a signature is not
+	// used, because it is not bound to the verifying key.
+	Issuer *pkix.Name
+	// Certificates is the certificate chain of the public key, if available.
+	Certificates []*x509.Certificate
+}
+
+// Authority describes the authority responsible for a signature.
+// For certificate based signatures it is the issuer of the public key
+// certificate, otherwise it is the name of the signature, which
+// determines the used public key.
+func (s *Signature) Authority() string {
+	if len(s.Certificates) > 0 {
+		return signutils.NormalizeDN(s.Certificates[0].Issuer)
+	}
+	return "signature " + s.Name
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trustpolicy_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	cfgcpi "github.com/open-component-model/ocm/pkg/contexts/config/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/signing/trustpolicy"
+	"github.com/open-component-model/ocm/pkg/signing/handlers/rsa"
+	"github.com/open-component-model/ocm/pkg/signing/signutils"
+)
+
+var _ = Describe("trust policy", func() {
+	DescribeTable("matches components", func(pattern, name string, match bool) {
+		r := &trustpolicy.Rule{Components: []string{pattern}}
+		Expect(r.Matches(name)).To(Equal(match))
+	},
+		Entry("all", "*", "acme.org/a/b", true),
+		Entry("prefix", "acme.org/**", "acme.org/a/b", true),
+		Entry("other prefix", "acme.org/**", "other.org/a", false),
+		Entry("glob", "acme.org/*", "acme.org/a", true),
+		Entry("glob depth", "acme.org/*", "acme.org/a/b", false),
+		Entry("exact", "acme.org/a", "acme.org/a", true),
+	)
+
+	Context("certificates", func() {
+		ca, capriv := Must2(rsa.CreateRootCertificate(signutils.CommonName("ca-authority"), 10*time.Hour))
+		other, _ := Must2(rsa.CreateRootCertificate(signutils.CommonName("other-authority"), 10*time.Hour))
+		_, pemBytes, _ := Must3(rsa.CreateSigningCertificate(signutils.CommonName("acme.org"), ca, ca, capriv, time.Hour))
+		certs := Must(signutils.GetCertificateChain(pemBytes, false))
+		sig := &trustpolicy.Signature{Name: "acme", Issuer: &certs[0].Subject, Certificates: certs}
+
+		It("accepts trusted issuer and authority", func() {
+			r := &trustpolicy.Rule{
+				Components:       []string{"*"},
+				Issuers:          []string{"CN=acme.org"},
+				RootCertificates: []cfgcpi.ContentSpec{{Data: signutils.CertificateToPem(ca)}},
+			}
+			MustBeSuccessful(r.Validate())
+			MustBeSuccessful(r.Accepts(sig))
+			Expect(sig.Authority()).To(Equal("ca-authority"))
+		})
+
+		It("rejects untrusted issuer", func() {
+			r := &trustpolicy.Rule{Components: []string{"*"}, Issuers: []string{"CN=other.org"}}
+			Expect(r.Accepts(sig)).To(MatchError(`untrusted issuer "acme.org"`))
+		})
+
+		It("rejects untrusted authority", func() {
+			r := &trustpolicy.Rule{
+				Components:       []string{"*"},
+				RootCertificates: []cfgcpi.ContentSpec{{Data: signutils.CertificateToPem(other)}},
+			}
+			Expect(r.Accepts(sig)).To(MatchError(ContainSubstring("untrusted certificate authority")))
+		})
+	})
+})
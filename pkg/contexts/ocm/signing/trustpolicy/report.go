@@ -0,0 +1,209 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trustpolicy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/utils"
+)
+
+// Result describes the evaluation of a policy for a component version.
+type Result struct {
+	ComponentVersion common.NameVersion
+	// Rule is the name of the evaluated rule, it is empty, if no rule matches.
+	// Such component versions are rejected, unless the policy accepts them.
+	Rule string
+	// Accepted lists the names of the valid and trusted signatures.
+	Accepted []string
+	// Error describes the policy violation, if the rule failed.
+	Error error
+
+	authorities []string
+	references  []common.NameVersion
+}
+
+func (r *Result) Failed() bool {
+	return r.Error != nil
+}
+
+func (r *Result) String() string {
+	switch {
+	case r.Rule == "" && r.Failed():
+		return fmt.Sprintf("trust policy failed for %s: %s", r.ComponentVersion, r.Error)
+	case r.Rule == "":
+		return fmt.Sprintf("no trust policy rule for %s", r.ComponentVersion)
+	case r.Failed():
+		return fmt.Sprintf("trust policy rule %q failed for %s: %s", r.Rule, r.ComponentVersion, r.Error)
+	default:
+		return fmt.Sprintf("trust policy rule %q passed for %s (signatures %s)", r.Rule, r.ComponentVersion, strings.Join(r.Accepted, ", "))
+	}
+}
+
+// Report keeps track of the policy evaluation results for the
+// component versions of a verification run.
+type Report struct {
+	lock    sync.Mutex
+	results map[common.NameVersion]*Result
+}
+
+func NewReport() *Report {
+	return &Report{results: map[common.NameVersion]*Result{}}
+}
+
+func (r *Report) Get(nv common.NameVersion) *Result {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.results[nv]
+}
+
+func (r *Report) set(res *Result) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.results[res.ComponentVersion] = res
+}
+
+// Results provides the results for the closure of the given component
+// version, or all results, if no component version is given.
+func (r *Report) Results(nvs ...common.NameVersion) []*Result {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var list []*Result
+	if len(nvs) == 0 {
+		for _, res := range r.results {
+			list = append(list, res)
+		}
+	} else {
+		found := map[common.NameVersion]bool{}
+		for len(nvs) > 0 {
+			nv := nvs[0]
+			nvs = nvs[1:]
+			res := r.results[nv]
+			if res == nil || found[nv] {
+				continue
+			}
+			found[nv] = true
+			list = append(list, res)
+			nvs = append(nvs, res.references...)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ComponentVersion.Compare(list[j].ComponentVersion) < 0 })
+	return list
+}
+
+// Error provides an error describing the policy violations
+// in the closure of the given component version.
+func (r *Report) Error(nv common.NameVersion) error {
+	list := errors.ErrListf("trust policy violated")
+	for _, res := range r.Results(nv) {
+		switch {
+		case !res.Failed():
+		case res.Rule == "":
+			list.Add(errors.Wrapf(res.Error, "%s", res.ComponentVersion))
+		default:
+			list.Add(errors.Wrapf(res.Error, "%s (rule %q)", res.ComponentVersion, res.Rule))
+		}
+	}
+	return list.Result()
+}
+
+// Evaluate evaluates the rule of the given policy matching a component
+// version with the given set of verified signatures and signatures failing
+// verification. The result is recorded in the report. Referenced component
+// versions must have been evaluated before.
+func (r *Report) Evaluate(policy *Policy, nv common.NameVersion, verified []*Signature, failed map[string]error, refs []common.NameVersion) *Result {
+	res := &Result{
+		ComponentVersion: nv,
+		references:       refs,
+	}
+	defer r.set(res)
+
+	rule := policy.RuleFor(nv.GetName())
+	if rule == nil {
+		if !policy.AcceptsUnmatched() {
+			res.Error = errors.New("no matching trust policy rule")
+			return res
+		}
+		for _, s := range verified {
+			res.authorities = append(res.authorities, s.Authority())
+		}
+		return res
+	}
+	res.Rule = rule.GetName()
+
+	var reasons []string
+	for _, s := range verified {
+		if !rule.Considers(s.Name) {
+			continue
+		}
+		if err := rule.Accepts(s); err != nil {
+			reasons = append(reasons, fmt.Sprintf("signature %q: %s", s.Name, err))
+			continue
+		}
+		res.Accepted = append(res.Accepted, s.Name)
+		res.authorities = append(res.authorities, s.Authority())
+	}
+	for _, n := range utils.StringMapKeys(failed) {
+		if rule.Considers(n) {
+			reasons = append(reasons, fmt.Sprintf("signature %q: %s", n, failed[n]))
+		}
+	}
+	for _, n := range rule.Signatures {
+		if _, ok := failed[n]; ok {
+			continue
+		}
+		found := false
+		for _, s := range verified {
+			if s.Name == n {
+				found = true
+				break
+			}
+		}
+		if !found {
+			reasons = append(reasons, fmt.Sprintf("signature %q not found", n))
+		}
+	}
+
+	if len(res.Accepted) < rule.Required() {
+		msg := fmt.Sprintf("%d of %d required trusted signatures", len(res.Accepted), rule.Required())
+		if len(reasons) > 0 {
+			msg += " (" + strings.Join(reasons, ", ") + ")"
+		}
+		res.Error = errors.New(msg)
+		return res
+	}
+
+	if rule.SameAuthority {
+		for _, ref := range refs {
+			nested := r.Get(ref)
+			if nested == nil {
+				res.Error = errors.Newf("referenced component version %s not evaluated", ref)
+				return res
+			}
+			if !intersects(res.authorities, nested.authorities) {
+				res.Error = errors.Newf("referenced component version %s not signed by same authority", ref)
+				return res
+			}
+		}
+	}
+	return res
+}
+
+func intersects(a, b []string) bool {
+	for _, e := range a {
+		for _, o := range b {
+			if e == o {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transfer
+
+import (
+	"reflect"
+
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/journal"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+// ResumeVersion checks whether a component version has already been
+// transferred successfully according to the journal and is still present
+// in the target repository. The journal entry is only used, if the source
+// component descriptor is unchanged and the transfer handler does not
+// enforce the transport of the version. The references recorded for its
+// transport closure must still be handled (see JournaledReferences),
+// because the referenced component versions might have been changed
+// independently.
+func (c *control) ResumeVersion(src ocm.ComponentVersionAccess, tgt ocm.Repository, handler TransferHandler) (bool, error) {
+	if c.journal == nil {
+		return false, nil
+	}
+	nv := common.VersionedElementKey(src)
+	dig, err := descriptorDigest(src.GetDescriptor())
+	if err != nil {
+		return false, err
+	}
+	if !c.journal.IsVersionDone(nv, dig) {
+		return false, nil
+	}
+	t, err := tgt.LookupComponentVersion(nv.GetName(), nv.GetVersion())
+	if err != nil {
+		if errors.IsErrNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer t.Close()
+	if handler != nil {
+		if ok, err := handler.EnforceTransport(src, t); ok || err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// JournaledReferences provides the references of a resumed component
+// version, which have been transferred as part of its transport closure
+// according to the journal. Every such reference is resumed on its own,
+// if its source component descriptor is unchanged, or transferred again.
+func (c *control) JournaledReferences(nv common.NameVersion, refs compdesc.References) compdesc.References {
+	var result compdesc.References
+	if c.journal == nil {
+		return result
+	}
+	journaled := c.journal.GetVersion(nv).GetReferences()
+	for _, r := range refs {
+		for _, j := range journaled {
+			if j.GetName() == r.ComponentName && j.GetVersion() == r.Version {
+				result = append(result, r)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// descriptorDigest provides the digest of the complete serialized component
+// descriptor. In contrast to the normalized form used for signing, it
+// covers all changes, for example, of access specifications.
+func descriptorDigest(cd *compdesc.ComponentDescriptor) (string, error) {
+	data, err := compdesc.Encode(cd)
+	if err != nil {
+		return "", errors.Wrapf(err, "journal")
+	}
+	return digest.FromBytes(data).String(), nil
+}
+
+func (c *control) StartVersion(nv common.NameVersion) error {
+	if c.journal == nil {
+		return nil
+	}
+	return c.journal.StartVersion(nv)
+}
+
+// VersionDone records the result of a component version transfer
+// together with the digest of the source component descriptor and
+// the references transferred as part of its closure.
+func (c *control) VersionDone(state *WalkingState, nv common.NameVersion, d *compdesc.ComponentDescriptor, err error) error {
+	if c.journal == nil {
+		return nil
+	}
+	dig, derr := descriptorDigest(d)
+	if derr != nil {
+		return derr
+	}
+
+	var list []common.NameVersion
+	c.lock.Lock()
+	for _, r := range d.References {
+		rnv := common.NewNameVersion(r.ComponentName, r.Version)
+		if state.Closure.Contains(rnv) {
+			list = append(list, rnv)
+		}
+	}
+	c.lock.Unlock()
+	return c.journal.VersionDone(nv, dig, list, err)
+}
+
+// ResumeResource checks whether a resource has already been transferred
+// by-value according to the journal. The journal entry is only used, if the
+// source access and digest are unchanged and the recorded target access is
+// a still readable global access (for example, provided by an uploader).
+// In this case the recorded access is set for the resource in the target
+// component version. Local blobs are always added again, because they
+// are part of the target component version. Storage backends typically
+// skip the upload of already existing blobs.
+func (c *control) ResumeResource(nv common.NameVersion, index int, r ocm.ResourceAccess, a ocm.AccessSpec, t ocm.ComponentVersionAccess) bool {
+	if c.journal == nil {
+		return false
+	}
+	e := c.journal.GetResource(nv, index)
+	if e == nil || e.Status != journal.STATUS_DONE || e.Access == nil || e.SourceAccess == nil {
+		return false
+	}
+	if d := r.Meta().Digest; d != nil && !d.Equal(e.SourceDigest) {
+		return false
+	}
+	if !sameSpec(e.SourceAccess, a) {
+		return false
+	}
+	data, err := e.Access.GetRaw()
+	if err != nil {
+		return false
+	}
+	spec, err := t.GetContext().AccessSpecForConfig(data, runtime.DefaultJSONEncoding)
+	if err != nil {
+		return false
+	}
+	if spec.IsLocal(t.GetContext()) {
+		return false
+	}
+	m, err := spec.AccessMethod(t)
+	if err != nil {
+		return false
+	}
+	defer m.Close()
+	reader, err := m.Reader()
+	if err != nil {
+		return false
+	}
+	reader.Close()
+	return t.SetResource(r.Meta(), spec, ocm.ModifyResource(), ocm.SkipVerify()) == nil
+}
+
+func sameSpec(u *runtime.UnstructuredTypedObject, spec ocm.AccessSpec) bool {
+	cur, err := runtime.ToUnstructuredTypedObject(spec)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(u.Object, cur.Object)
+}
+
+// ResourceDone records the result of a by-value transfer of a resource.
+// The source digest must be the one valid before the transfer, because
+// the transfer may update the resource meta data.
+func (c *control) ResourceDone(nv common.NameVersion, index int, r ocm.ResourceAccess, a ocm.AccessSpec, srcdigest *metav1.DigestSpec, t ocm.ComponentVersionAccess, err error) error {
+	if c.journal == nil {
+		return nil
+	}
+	if err != nil {
+		return c.journal.ResourceDone(nv, index, r.Meta().GetName(), a, srcdigest, nil, nil, err)
+	}
+	tr, err := t.GetResourceByIndex(index)
+	if err != nil {
+		return errors.Wrapf(err, "journal")
+	}
+	acc, err := tr.Access()
+	if err != nil {
+		return errors.Wrapf(err, "journal")
+	}
+	return c.journal.ResourceDone(nv, index, r.Meta().GetName(), a, srcdigest, tr.Meta().Digest, acc, nil)
+}
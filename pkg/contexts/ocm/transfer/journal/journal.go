@@ -0,0 +1,312 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package journal
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"golang.org/x/exp/slices"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/runtime"
+)
+
+const KIND_JOURNAL = "transfer journal"
+
+const (
+	// STATUS_PENDING describes a transfer step, which has been started, but
+	// not finished, yet.
+	STATUS_PENDING = "pending"
+	// STATUS_DONE describes a successfully finished transfer step.
+	STATUS_DONE = "done"
+	// STATUS_FAILED describes a failed transfer step.
+	STATUS_FAILED = "failed"
+)
+
+// State is the persisted state of a transfer.
+type State struct {
+	// Target is the specification of the target repository.
+	Target string `json:"target,omitempty"`
+	// Versions describes the state of the transferred component versions.
+	Versions []*Version `json:"componentVersions,omitempty"`
+}
+
+// Version describes the transfer state of a component version.
+type Version struct {
+	Component string `json:"component"`
+	Version   string `json:"version"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	// Digest is the digest of the source component descriptor.
+	Digest     string      `json:"digest,omitempty"`
+	References []Reference `json:"references,omitempty"`
+	Resources  []*Resource `json:"resources,omitempty"`
+}
+
+// Reference describes a component version transferred as part of the
+// transport closure of a component version.
+type Reference struct {
+	Component string `json:"component"`
+	Version   string `json:"version"`
+}
+
+// Resource describes the transfer state of a resource transferred by-value.
+type Resource struct {
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+	// SourceAccess is the access specification in the source component version.
+	SourceAccess *runtime.UnstructuredTypedObject `json:"sourceAccess,omitempty"`
+	// SourceDigest is the digest of the resource in the source component version.
+	SourceDigest *metav1.DigestSpec `json:"sourceDigest,omitempty"`
+	// Digest is the digest of the resource in the target component version.
+	Digest *metav1.DigestSpec `json:"digest,omitempty"`
+	// Access is the access specification in the target component version.
+	Access *runtime.UnstructuredTypedObject `json:"access,omitempty"`
+	Status string                           `json:"status"`
+	Error  string                           `json:"error,omitempty"`
+}
+
+func (v *Version) GetName() string {
+	return v.Component
+}
+
+func (v *Version) GetVersion() string {
+	return v.Version
+}
+
+func (v *Version) GetReferences() []common.NameVersion {
+	var list []common.NameVersion
+	for _, r := range v.References {
+		list = append(list, common.NewNameVersion(r.Component, r.Version))
+	}
+	return list
+}
+
+func (v *Version) GetResource(index int) *Resource {
+	for _, r := range v.Resources {
+		if r.Index == index {
+			return r
+		}
+	}
+	return nil
+}
+
+func (v *Version) copy() *Version {
+	n := *v
+	n.References = slices.Clone(v.References)
+	n.Resources = nil
+	for _, r := range v.Resources {
+		c := *r
+		n.Resources = append(n.Resources, &c)
+	}
+	return &n
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Journal records the progress of a component version transfer in a state file.
+// It is used to resume a failed transfer. Component versions and resources
+// already successfully transferred by a previous run can be skipped.
+// A Journal can be used concurrently. Every state change is directly
+// persisted.
+type Journal struct {
+	lock  sync.Mutex
+	fs    vfs.FileSystem
+	path  string
+	state State
+}
+
+// New provides a journal using the given state file.
+// If the file already exists, the state is loaded from the file.
+func New(fs vfs.FileSystem, path string) (*Journal, error) {
+	j := &Journal{
+		fs:   fs,
+		path: path,
+	}
+	if ok, err := vfs.FileExists(fs, path); ok || err != nil {
+		if err != nil {
+			return nil, err
+		}
+		data, err := vfs.ReadFile(fs, path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot read %s %q", KIND_JOURNAL, path)
+		}
+		err = json.Unmarshal(data, &j.state)
+		if err != nil {
+			return nil, errors.ErrInvalidWrap(err, KIND_JOURNAL, path)
+		}
+	}
+	return j, nil
+}
+
+func (j *Journal) GetPath() string {
+	return j.path
+}
+
+// SetTarget sets the target repository for the transfer. If the journal
+// already describes a transfer to another target, an error is returned.
+func (j *Journal) SetTarget(spec runtime.TypedObject) error {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.state.Target != "" && j.state.Target != string(data) {
+		return errors.Newf("%s %q describes a transfer to target %s", KIND_JOURNAL, j.path, j.state.Target)
+	}
+	j.state.Target = string(data)
+	return j.save()
+}
+
+// GetVersion returns a copy of the state of a component version, or nil,
+// if the journal does not contain information about this version.
+func (j *Journal) GetVersion(nv common.NameVersion) *Version {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if v := j.lookup(nv); v != nil {
+		return v.copy()
+	}
+	return nil
+}
+
+// IsVersionDone checks whether the component version has been
+// transferred successfully from a source component descriptor with
+// the given digest.
+func (j *Journal) IsVersionDone(nv common.NameVersion, digest string) bool {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	v := j.lookup(nv)
+	return v != nil && v.Status == STATUS_DONE && v.Digest == digest
+}
+
+// StartVersion marks the transfer of a component version as pending.
+// Resource information recorded by a previous run is kept.
+func (j *Journal) StartVersion(nv common.NameVersion) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	v := j.assure(nv)
+	v.Status = STATUS_PENDING
+	v.Error = ""
+	return j.save()
+}
+
+// VersionDone records the result of a component version transfer, together
+// with the digest of the source component descriptor and the component
+// versions transferred as part of its transport closure.
+func (j *Journal) VersionDone(nv common.NameVersion, digest string, refs []common.NameVersion, err error) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	v := j.assure(nv)
+	v.Status, v.Error = status(err)
+	v.Digest = digest
+	v.References = nil
+	for _, r := range refs {
+		v.References = append(v.References, Reference{Component: r.GetName(), Version: r.GetVersion()})
+	}
+	return j.save()
+}
+
+// GetResource returns a copy of the state of a resource, or nil,
+// if the journal does not contain information about this resource.
+func (j *Journal) GetResource(nv common.NameVersion, index int) *Resource {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if v := j.lookup(nv); v != nil {
+		if r := v.GetResource(index); r != nil {
+			c := *r
+			return &c
+		}
+	}
+	return nil
+}
+
+// ResourceDone records the result of the transfer of a resource.
+func (j *Journal) ResourceDone(nv common.NameVersion, index int, name string, srcacc runtime.TypedObject, srcdigest, digest *metav1.DigestSpec, acc runtime.TypedObject, err error) error {
+	var srcunstr, unstr *runtime.UnstructuredTypedObject
+	var uerr error
+	if srcacc != nil {
+		srcunstr, uerr = runtime.ToUnstructuredTypedObject(srcacc)
+		if uerr != nil {
+			return errors.Wrapf(uerr, "cannot record source access of resource %d", index)
+		}
+	}
+	if err == nil && acc != nil {
+		unstr, uerr = runtime.ToUnstructuredTypedObject(acc)
+		if uerr != nil {
+			return errors.Wrapf(uerr, "cannot record access of resource %d", index)
+		}
+	}
+
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	v := j.assure(nv)
+	r := v.GetResource(index)
+	if r == nil {
+		r = &Resource{Index: index}
+		v.Resources = append(v.Resources, r)
+		slices.SortFunc(v.Resources, func(a, b *Resource) int { return a.Index - b.Index })
+	}
+	r.Name = name
+	r.SourceAccess = srcunstr
+	r.SourceDigest = srcdigest.Copy()
+	r.Digest = digest.Copy()
+	r.Access = unstr
+	r.Status, r.Error = status(err)
+	return j.save()
+}
+
+func status(err error) (string, string) {
+	if err != nil {
+		return STATUS_FAILED, err.Error()
+	}
+	return STATUS_DONE, ""
+}
+
+func (j *Journal) lookup(nv common.NameVersion) *Version {
+	for _, v := range j.state.Versions {
+		if v.Component == nv.GetName() && v.Version == nv.GetVersion() {
+			return v
+		}
+	}
+	return nil
+}
+
+func (j *Journal) assure(nv common.NameVersion) *Version {
+	v := j.lookup(nv)
+	if v == nil {
+		v = &Version{
+			Component: nv.GetName(),
+			Version:   nv.GetVersion(),
+		}
+		j.state.Versions = append(j.state.Versions, v)
+	}
+	return v
+}
+
+func (j *Journal) save() error {
+	data, err := json.MarshalIndent(&j.state, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal %s", KIND_JOURNAL)
+	}
+	// write a temporary file first to avoid corrupted state files.
+	tmp := j.path + ".tmp"
+	err = vfs.WriteFile(j.fs, tmp, data, 0o600)
+	if err != nil {
+		return errors.Wrapf(err, "cannot write %s %q", KIND_JOURNAL, j.path)
+	}
+	return errors.Wrapf(j.fs.Rename(tmp, j.path), "cannot write %s %q", KIND_JOURNAL, j.path)
+}
@@ -0,0 +1,225 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transfer_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/contexts/oci/testhelper"
+	. "github.com/open-component-model/ocm/pkg/env/builder"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/oci"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/grammar"
+	ctfoci "github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/ociartifact"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/ociuploadattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/journal"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler/standard"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const (
+	STATEFILE = "/tmp/state.json"
+	OCIPATH   = "/tmp/oci"
+	OCITARGET = "/tmp/ocitarget"
+	OCIHOST   = "alias"
+)
+
+type failingHandler struct {
+	*standard.Handler
+	fail string
+}
+
+func (h *failingHandler) TransferVersion(repo ocm.Repository, src ocm.ComponentVersionAccess, meta *compdesc.ComponentReference, tgt ocm.Repository) (ocm.ComponentVersionAccess, transferhandler.TransferHandler, error) {
+	cv, _, err := h.Handler.TransferVersion(repo, src, meta, tgt)
+	return cv, h, err
+}
+
+func (h *failingHandler) HandleTransferResource(r ocm.ResourceAccess, m cpi.AccessMethod, hint string, t ocm.ComponentVersionAccess) error {
+	if r.Meta().GetName() == h.fail {
+		return fmt.Errorf("simulated failure")
+	}
+	return h.Handler.HandleTransferResource(r, m, hint, t)
+}
+
+var _ = Describe("journaled transfer", func() {
+	var env *Builder
+
+	BeforeEach(func() {
+		env = NewBuilder()
+
+		FakeOCIRepo(env, OCIPATH, OCIHOST)
+		env.OCICommonTransport(OCIPATH, accessio.FormatDirectory, func() {
+			OCIManifest1(env)
+		})
+		env.OCICommonTransport(OCITARGET, accessio.FormatDirectory)
+		spec := Must(ctfoci.NewRepositorySpec(accessobj.ACC_WRITABLE, OCITARGET, accessio.PathFileSystem(env.FileSystem())))
+		env.OCIContext().SetAlias("target", spec)
+		ociuploadattr.Set(env.OCMContext(), ociuploadattr.New("target.alias"+grammar.RepositorySeparator+"copy"))
+
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.ComponentVersion(COMMON, VERSION, func() {
+				env.Provider(PROVIDER)
+				env.Resource("image", "", resourcetypes.OCI_IMAGE, metav1.LocalRelation, func() {
+					env.Access(
+						ociartifact.New(oci.StandardOCIRef(OCIHOST+".alias", OCINAMESPACE, OCIVERSION)),
+					)
+				})
+				for i := 0; i < 2; i++ {
+					env.Resource(fmt.Sprintf("data%d", i), "", resourcetypes.PLAIN_TEXT, metav1.LocalRelation, func() {
+						env.BlobStringData(mime.MIME_TEXT, fmt.Sprintf("data %d", i))
+					})
+				}
+			})
+			env.ComponentVersion(TOP, VERSION, func() {
+				env.Provider(PROVIDER)
+				env.Reference("common", COMMON, VERSION)
+			})
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	transfer := func(fail string, topts ...transferhandler.TransferOption) (error, string) {
+		src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(src, "source")
+		cv := Must(src.LookupComponentVersion(TOP, VERSION))
+		defer Close(cv, "source cv")
+		tgt := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+		defer Close(tgt, "target")
+
+		j := Must(journal.New(env, STATEFILE))
+		opts := &standard.Options{}
+		MustBeSuccessful(opts.Apply(append([]transferhandler.TransferOption{standard.Recursive(), standard.ResourcesByValue(), standard.Journal(j)}, topts...)...))
+		handler := &failingHandler{standard.NewDefaultHandler(opts), fail}
+
+		// the upload target is kept open by the attribute until the
+		// context is finalized, simulate a new process for every run.
+		defer Close(ociuploadattr.Get(env.OCMContext()), "upload target")
+
+		p, buf := common.NewBufferedPrinter()
+		err := transfer.TransferVersion(p, nil, cv, tgt, handler)
+		return err, buf.String()
+	}
+
+	It("resumes a failed transfer", func() {
+		err, _ := transfer("data0")
+		Expect(err).To(HaveOccurred())
+
+		j := Must(journal.New(env, STATEFILE))
+		v := j.GetVersion(common.NewNameVersion(COMMON, VERSION))
+		Expect(v).NotTo(BeNil())
+		Expect(v.Status).To(Equal(journal.STATUS_FAILED))
+		Expect(v.Resources).To(HaveLen(2))
+		Expect(v.Resources[0].Status).To(Equal(journal.STATUS_DONE))
+		Expect(v.Resources[1].Status).To(Equal(journal.STATUS_FAILED))
+		Expect(j.GetVersion(common.NewNameVersion(TOP, VERSION)).Status).To(Equal(journal.STATUS_FAILED))
+
+		err, out := transfer("")
+		MustBeSuccessful(err)
+		Expect(out).To(StringEqualTrimmedWithContext(`
+transferring version "acme.org/top:v1"...
+  version "acme.org/top:v1" already present -> skip transport
+  transferring version "acme.org/common:v1"...
+  ...resource 0 image[ociImage](ocm/value:v2.0) (already transferred according to journal)
+  ...resource 1 data0[plainText]...
+  ...resource 2 data1[plainText]...
+  ...adding component version...
+`))
+		j = Must(journal.New(env, STATEFILE))
+		Expect(j.GetVersion(common.NewNameVersion(COMMON, VERSION)).Status).To(Equal(journal.STATUS_DONE))
+		Expect(j.GetVersion(common.NewNameVersion(TOP, VERSION)).Status).To(Equal(journal.STATUS_DONE))
+		Expect(j.GetVersion(common.NewNameVersion(TOP, VERSION)).Digest).NotTo(BeEmpty())
+
+		tgt := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, OUT, 0, env))
+		defer Close(tgt, "target")
+		tcv := Must(tgt.LookupComponentVersion(COMMON, VERSION))
+		defer Close(tcv, "target cv")
+		for i, r := range tcv.GetResources() {
+			if i == 0 {
+				Expect(Must(r.Access()).GetKind()).To(Equal(ociartifact.Type))
+				continue
+			}
+			m := Must(r.AccessMethod())
+			Expect(string(Must(m.Get()))).To(Equal(fmt.Sprintf("data %d", i-1)))
+			MustBeSuccessful(m.Close())
+		}
+
+		err, out = transfer("")
+		MustBeSuccessful(err)
+		Expect(out).To(StringEqualTrimmedWithContext(`
+version "acme.org/top:v1" already transferred according to journal -> skip transport
+  version "acme.org/common:v1" already transferred according to journal -> skip transport
+`))
+	})
+
+	It("does not resume changed versions", func() {
+		err, _ := transfer("")
+		MustBeSuccessful(err)
+
+		src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+		cv := Must(src.LookupComponentVersion(TOP, VERSION))
+		MustBeSuccessful(cv.GetDescriptor().Labels.Set("changed", "true"))
+		MustBeSuccessful(cv.Close())
+		MustBeSuccessful(src.Close())
+
+		err, out := transfer("")
+		MustBeSuccessful(err)
+		Expect(out).NotTo(ContainSubstring(`version "acme.org/top:v1" already transferred according to journal`))
+		Expect(out).To(ContainSubstring(`version "acme.org/common:v1" already transferred according to journal`))
+
+		// a changed reference of an unchanged version is transferred again.
+		src = Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+		cv = Must(src.LookupComponentVersion(COMMON, VERSION))
+		MustBeSuccessful(cv.GetDescriptor().Labels.Set("changed", "true"))
+		MustBeSuccessful(cv.Close())
+		MustBeSuccessful(src.Close())
+
+		err, out = transfer("")
+		MustBeSuccessful(err)
+		Expect(out).To(ContainSubstring(`version "acme.org/top:v1" already transferred according to journal`))
+		Expect(out).NotTo(ContainSubstring(`version "acme.org/common:v1" already transferred according to journal`))
+		Expect(out).To(ContainSubstring(`transferring version "acme.org/common:v1"...`))
+
+		tgt := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, OUT, 0, env))
+		defer Close(tgt, "target")
+		tcv := Must(tgt.LookupComponentVersion(COMMON, VERSION))
+		defer Close(tcv, "target cv")
+		Expect(tcv.GetDescriptor().Labels.GetIndex("changed")).To(BeNumerically(">=", 0))
+	})
+
+	It("does not resume enforced transports", func() {
+		err, _ := transfer("")
+		MustBeSuccessful(err)
+
+		err, out := transfer("", standard.EnforceTransport())
+		MustBeSuccessful(err)
+		Expect(out).NotTo(ContainSubstring("already transferred according to journal"))
+		Expect(out).To(ContainSubstring(`transferring version "acme.org/common:v1"...`))
+	})
+
+	It("rejects journal for other target", func() {
+		j := Must(journal.New(env, STATEFILE))
+		MustBeSuccessful(j.SetTarget(Must(ctf.NewRepositorySpec(accessobj.ACC_READONLY, "other"))))
+		err, _ := transfer("")
+		Expect(err).To(MatchError(ContainSubstring("describes a transfer to target")))
+	})
+})
@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transfer
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/journal"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/plan"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler"
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+// control coordinates the potentially parallel transfer steps of
+// a single transfer run and records them in an optional journal
+// or plan.
+// The number of concurrently executed steps is limited by a
+// fixed number of worker slots. If no slot is available, a step
+// is executed synchronously by the requesting go routine, this
+// way nested steps can never be blocked by waiting for a free slot.
+type control struct {
+	lock  sync.Mutex
+	slots chan struct{}
+
+	// pending holds the component versions currently in transfer.
+	pending map[common.NameVersion]chan struct{}
+	// deps describes which pending component versions wait
+	// for the completion of other ones. It is used to detect
+	// cycles between parallel transfer paths.
+	deps map[common.NameVersion]map[common.NameVersion]struct{}
+
+	journal *journal.Journal
+	plan    *plan.Plan
+}
+
+func newControl(handler TransferHandler) *control {
+	c := &control{
+		pending: map[common.NameVersion]chan struct{}{},
+		deps:    map[common.NameVersion]map[common.NameVersion]struct{}{},
+	}
+	if p, ok := handler.(transferhandler.ConcurrencyProvider); ok {
+		if n := p.GetConcurrency(); n > 1 {
+			// the requesting go routine always acts as additional worker.
+			c.slots = make(chan struct{}, n-1)
+		}
+	}
+	if p, ok := handler.(transferhandler.PlanProvider); ok {
+		c.plan = p.GetPlan()
+	}
+	// in plan mode nothing is written, this includes the journal.
+	if p, ok := handler.(transferhandler.JournalProvider); ok && c.plan == nil {
+		c.journal = p.GetJournal()
+	}
+	return c
+}
+
+func (c *control) IsParallel() bool {
+	return c.slots != nil
+}
+
+// Go executes the given function in a separate go routine, if a free
+// worker slot is available. Otherwise, it is executed synchronously.
+func (c *control) Go(wg *sync.WaitGroup, f func()) {
+	if c.slots != nil {
+		select {
+		case c.slots <- struct{}{}:
+			wg.Add(1)
+			go func() {
+				defer func() {
+					<-c.slots
+					wg.Done()
+				}()
+				f()
+			}()
+			return
+		default:
+		}
+	}
+	f()
+}
+
+// Run executes the given function for the indices 0 to n-1. After the first
+// failure no further executions are started. The result is the error of the
+// first failed execution in index order.
+func (c *control) Run(n int, f func(i int) error) error {
+	var wg sync.WaitGroup
+	var failed atomic.Bool
+
+	errs := make([]error, n)
+	for i := 0; i < n && !failed.Load(); i++ {
+		i := i
+		c.Go(&wg, func() {
+			if errs[i] = f(i); errs[i] != nil {
+				failed.Store(true)
+			}
+		})
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Begin registers a component version in the walking state.
+// It returns true if the transfer of the version has to be done by the caller.
+// In this case Done must be called after the transfer has been finished.
+// If the version is currently transferred by a parallel transfer path,
+// Begin waits for its completion and returns false.
+func (c *control) Begin(state *WalkingState, nv common.NameVersion) (bool, error) {
+	c.lock.Lock()
+
+	ok, err := state.Add(ocm.KIND_COMPONENTVERSION, nv)
+	if err != nil {
+		c.lock.Unlock()
+		return false, err
+	}
+
+	var parent *common.NameVersion
+	if len(state.History) > 1 {
+		parent = &state.History[len(state.History)-2]
+	}
+
+	if ok {
+		c.pending[nv] = make(chan struct{})
+		c.addDep(parent, nv)
+		c.lock.Unlock()
+		return true, nil
+	}
+
+	done := c.pending[nv]
+	if done == nil || parent == nil {
+		c.lock.Unlock()
+		return false, nil
+	}
+	if c.reaches(nv, *parent) {
+		c.lock.Unlock()
+		return false, errors.ErrRecusion(ocm.KIND_COMPONENTVERSION, nv, state.History)
+	}
+	c.addDep(parent, nv)
+	c.lock.Unlock()
+
+	<-done
+	return false, nil
+}
+
+// Done marks the transfer of a component version as finished.
+func (c *control) Done(nv common.NameVersion) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if done := c.pending[nv]; done != nil {
+		close(done)
+	}
+	delete(c.pending, nv)
+	delete(c.deps, nv)
+}
+
+func (c *control) addDep(parent *common.NameVersion, nv common.NameVersion) {
+	if parent == nil {
+		return
+	}
+	deps := c.deps[*parent]
+	if deps == nil {
+		deps = map[common.NameVersion]struct{}{}
+		c.deps[*parent] = deps
+	}
+	deps[nv] = struct{}{}
+}
+
+// reaches checks whether the completion of component version from
+// (transitively) depends on the completion of component version to.
+func (c *control) reaches(from, to common.NameVersion) bool {
+	visited := map[common.NameVersion]struct{}{}
+	list := []common.NameVersion{from}
+	for len(list) > 0 {
+		cur := list[0]
+		list = list[1:]
+		if cur == to {
+			return true
+		}
+		if _, ok := visited[cur]; ok {
+			continue
+		}
+		visited[cur] = struct{}{}
+		for d := range c.deps[cur] {
+			list = append(list, d)
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transfer_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/env/builder"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/localblob"
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler/standard"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const (
+	ARCH     = "/tmp/ctf"
+	OUT      = "/tmp/res"
+	PROVIDER = "mandelsoft"
+	VERSION  = "v1"
+	TOP      = "acme.org/top"
+	LEFT     = "acme.org/left"
+	RIGHT    = "acme.org/right"
+	COMMON   = "acme.org/common"
+)
+
+var _ = Describe("parallel transfer", func() {
+	var env *Builder
+
+	resources := func(name string) {
+		for i := 0; i < 5; i++ {
+			env.Resource(fmt.Sprintf("data%d", i), "", resourcetypes.PLAIN_TEXT, metav1.LocalRelation, func() {
+				env.BlobStringData(mime.MIME_TEXT, fmt.Sprintf("%s data %d", name, i))
+			})
+		}
+	}
+
+	BeforeEach(func() {
+		env = NewBuilder()
+
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.ComponentVersion(COMMON, VERSION, func() {
+				env.Provider(PROVIDER)
+				resources(COMMON)
+			})
+			env.ComponentVersion(LEFT, VERSION, func() {
+				env.Provider(PROVIDER)
+				env.Reference("common", COMMON, VERSION)
+				resources(LEFT)
+			})
+			env.ComponentVersion(RIGHT, VERSION, func() {
+				env.Provider(PROVIDER)
+				env.Reference("common", COMMON, VERSION)
+				resources(RIGHT)
+			})
+			env.ComponentVersion(TOP, VERSION, func() {
+				env.Provider(PROVIDER)
+				env.Reference("left", LEFT, VERSION)
+				env.Reference("right", RIGHT, VERSION)
+				resources(TOP)
+			})
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	DescribeTable("transfers closure", func(n int) {
+		src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(src, "source")
+		cv := Must(src.LookupComponentVersion(TOP, VERSION))
+		defer Close(cv, "source cv")
+		tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+		defer Close(tgt, "target")
+
+		handler := Must(standard.New(standard.Recursive(), standard.Concurrency(n)))
+		closure := transfer.TransportClosure{}
+		MustBeSuccessful(transfer.TransferVersion(nil, closure, cv, tgt, handler))
+
+		Expect(closure).To(HaveLen(4))
+		for _, c := range []string{TOP, LEFT, RIGHT, COMMON} {
+			Expect(closure.Contains(common.NewNameVersion(c, VERSION))).To(BeTrue())
+			tcv := Must(tgt.LookupComponentVersion(c, VERSION))
+			Expect(tcv.GetDescriptor().Resources).To(HaveLen(5))
+			for i, r := range tcv.GetResources() {
+				Expect(r.Meta().Name).To(Equal(fmt.Sprintf("data%d", i)))
+				acc := Must(r.Access())
+				Expect(acc.GetKind()).To(Equal(localblob.Type))
+				m := Must(r.AccessMethod())
+				Expect(string(Must(m.Get()))).To(Equal(fmt.Sprintf("%s data %d", c, i)))
+				MustBeSuccessful(m.Close())
+			}
+			MustBeSuccessful(tcv.Close())
+		}
+	},
+		Entry("sequential", 1),
+		Entry("parallel", 4),
+		Entry("high parallelism", 20),
+	)
+})
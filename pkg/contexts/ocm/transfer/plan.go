@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transfer
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/oci"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ocireg"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/mapocirepoattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/ociuploadattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
+	ocmcpi "github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi/accspeccpi"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/genericocireg"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/plan"
+)
+
+// IsPlanning returns true, if the transfer is executed in plan (dry-run) mode.
+// In this mode nothing must be written to the target repository.
+func (c *control) IsPlanning() bool {
+	return c.plan != nil
+}
+
+func (c *control) PlanVersion(nv common.NameVersion, hist common.History) {
+	if c.plan != nil {
+		c.plan.AddVersion(nv, hist)
+	}
+}
+
+func (c *control) PlanAction(nv common.NameVersion, action string, msg string, args ...interface{}) {
+	if c.plan != nil {
+		c.plan.SetAction(nv, action, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (c *control) PlanReferences(nv common.NameVersion, refs compdesc.References) {
+	if c.plan != nil {
+		for _, r := range refs {
+			c.plan.AddReference(nv, r.Name, common.NewNameVersion(r.ComponentName, r.Version))
+		}
+	}
+}
+
+// PlanResource records the planned transfer of a resource. For artifacts
+// copied by-value the size and the expected upload target are determined
+// without reading the blob.
+func (c *control) PlanResource(nv common.NameVersion, index int, meta compdesc.ArtifactMetaAccess, a ocm.AccessSpec, m ocmcpi.AccessMethod, mode, action, hint string, t ocm.ComponentVersionAccess) {
+	if c.plan != nil {
+		c.plan.AddResource(nv, planArtifact(index, meta, a, m, mode, action, hint, t))
+	}
+}
+
+// PlanSource records the planned transfer of a source.
+func (c *control) PlanSource(nv common.NameVersion, index int, meta compdesc.ArtifactMetaAccess, a ocm.AccessSpec, m ocmcpi.AccessMethod, mode, action, hint string, t ocm.ComponentVersionAccess) {
+	if c.plan != nil {
+		c.plan.AddSource(nv, planArtifact(index, meta, a, m, mode, action, hint, t))
+	}
+}
+
+func planArtifact(index int, meta compdesc.ArtifactMetaAccess, a ocm.AccessSpec, m ocmcpi.AccessMethod, mode, action, hint string, t ocm.ComponentVersionAccess) *plan.Artifact {
+	e := &plan.Artifact{
+		Index:  index,
+		Name:   meta.GetName(),
+		Type:   meta.GetType(),
+		Mode:   mode,
+		Action: action,
+		Hint:   hint,
+		Size:   blobaccess.BLOB_UNKNOWN_SIZE,
+	}
+	if a != nil {
+		e.AccessType = a.GetType()
+	}
+	if action == plan.ACTION_COPY && m != nil {
+		e.Size = estimateSize(m)
+		e.Target = expectedOCITarget(t.GetContext(), t.Repository().GetSpecification(), m.MimeType(), hint)
+	}
+	return e
+}
+
+// estimateSize determines the size of the blob described by an access method,
+// if this is possible without reading the blob.
+func estimateSize(m ocmcpi.AccessMethod) int64 {
+	if s, ok := accspeccpi.GetAccessMethodImplementation(m).(accspeccpi.SizeSource); ok {
+		if size, err := s.GetSize(); err == nil {
+			return size
+		}
+	}
+	return blobaccess.BLOB_UNKNOWN_SIZE
+}
+
+// expectedOCITarget determines the OCI artifact reference an OCI artifact blob
+// is expected to be uploaded to by the standard OCI upload handlers.
+// It follows the naming rules of the generic upload handler configured
+// by the ociuploadattr attribute and of the upload handler for OCI registry
+// based OCM repositories (including the mapping configured by the mapocirepoattr
+// attribute). If no upload is expected, an empty string is returned.
+func expectedOCITarget(ctx ocm.Context, spec ocm.RepositorySpec, mime string, hint string) string {
+	if !artdesc.IsOCIMediaType(mime) || (!strings.HasSuffix(mime, "+tar") && !strings.HasSuffix(mime, "+tar+gzip")) {
+		return ""
+	}
+	if hint == "" {
+		return ""
+	}
+
+	if i := strings.LastIndex(hint, "@"); i >= 0 {
+		hint = hint[:i] // remove digest
+	}
+	name, version := hint, ""
+	if i := strings.LastIndex(hint, ":"); i > 0 {
+		name, version = hint[:i], hint[i:]
+	}
+
+	if attr := ociuploadattr.Get(ctx); attr != nil {
+		var base *oci.UniformRepositorySpec
+		var prefix string
+		switch {
+		case attr.Ref != "":
+			ref, err := oci.ParseRef(attr.Ref)
+			if err != nil {
+				return ""
+			}
+			base, prefix = &ref.UniformRepositorySpec, ref.Repository
+		case attr.Repository != nil:
+			s, err := attr.Repository.Evaluate(ctx.OCIContext())
+			if err != nil {
+				return ""
+			}
+			base, prefix = s.UniformRepositorySpec(), attr.NamespacePrefix
+		default:
+			return ""
+		}
+		return base.ComposeRef(path.Join(prefix, name) + version)
+	}
+
+	if s, ok := spec.(*genericocireg.RepositorySpec); ok {
+		if r, ok := s.RepositorySpec.(*ocireg.RepositorySpec); ok {
+			prefix := ocmcpi.RepositoryPrefix(spec)
+			mapping := mapocirepoattr.Get(ctx)
+			if mapping.Prefix != nil {
+				prefix = *mapping.Prefix
+			}
+			return path.Join(r.BaseURL, prefix, mapping.Map(name)) + version
+		}
+	}
+	return ""
+}
@@ -0,0 +1,266 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plan
+
+import (
+	"sync"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/common"
+)
+
+// Actions planned for a component version.
+const (
+	// ACTION_NEW describes a component version not yet present in the target.
+	ACTION_NEW = "new"
+	// ACTION_TRANSPORT describes a transport of a component version
+	// already present in the target, because the transport is enforced
+	// or some resources require a value transport.
+	ACTION_TRANSPORT = "transport"
+	// ACTION_OVERWRITE describes a component version present in the target,
+	// which is overwritten.
+	ACTION_OVERWRITE = "overwrite"
+	// ACTION_UPDATE describes an update of volatile (non-signature relevant)
+	// properties of a component version present in the target.
+	ACTION_UPDATE = "update"
+	// ACTION_SKIP describes a component version already present in the
+	// target or whose update is skipped.
+	ACTION_SKIP = "skip"
+	// ACTION_ABORT describes a conflicting component version aborting
+	// the transfer.
+	ACTION_ABORT = "abort"
+)
+
+// Transfer modes planned for an artifact (resource or source).
+const (
+	// MODE_VALUE describes an artifact transferred by-value.
+	MODE_VALUE = "value"
+	// MODE_REFERENCE describes an artifact kept by reference.
+	MODE_REFERENCE = "reference"
+)
+
+// Actions planned for an artifact transferred by-value.
+const (
+	// ACTION_COPY describes an artifact blob copied to the target.
+	ACTION_COPY = "copy"
+	// ACTION_KEEP describes an artifact already present in the target,
+	// whose current access is kept.
+	ACTION_KEEP = "keep"
+)
+
+// Version describes the planned transfer of a component version.
+type Version struct {
+	Component string `json:"component"`
+	Version   string `json:"version"`
+	Action    string `json:"action"`
+	Message   string `json:"message,omitempty"`
+	// History is the reference path leading to this component version.
+	History    common.History `json:"history,omitempty"`
+	References []Reference    `json:"references,omitempty"`
+	Resources  []*Artifact    `json:"resources,omitempty"`
+	Sources    []*Artifact    `json:"sources,omitempty"`
+}
+
+// Reference describes a component version referenced by a planned
+// component version.
+type Reference struct {
+	Name      string `json:"name"`
+	Component string `json:"component"`
+	Version   string `json:"version"`
+}
+
+// Artifact describes the planned transfer of a resource or source.
+type Artifact struct {
+	Index      int    `json:"index"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	AccessType string `json:"accessType,omitempty"`
+	Mode       string `json:"mode"`
+	Action     string `json:"action,omitempty"`
+	// Hint is the artifact name hint passed to the upload handlers.
+	Hint string `json:"hint,omitempty"`
+	// Target is the expected OCI artifact reference used to upload the blob,
+	// if it will be uploaded by one of the standard OCI upload handlers.
+	Target string `json:"target,omitempty"`
+	// Size is the estimated size of the blob to copy in bytes, or -1,
+	// if it cannot be determined without reading the blob.
+	Size int64 `json:"size"`
+}
+
+func (v *Version) GetName() string {
+	return v.Component
+}
+
+func (v *Version) GetVersion() string {
+	return v.Version
+}
+
+// GetSize provides the accumulated size of the artifacts copied for
+// the component version. The second result indicates whether the size
+// of all those artifacts is known.
+func (v *Version) GetSize() (int64, bool) {
+	var size int64
+	complete := true
+	for _, list := range [][]*Artifact{v.Resources, v.Sources} {
+		for _, a := range list {
+			if a.Action != ACTION_COPY {
+				continue
+			}
+			if a.Size == blobaccess.BLOB_UNKNOWN_SIZE {
+				complete = false
+			} else {
+				size += a.Size
+			}
+		}
+	}
+	return size, complete
+}
+
+func (v *Version) copy() *Version {
+	n := *v
+	n.History = v.History.Copy()
+	n.References = slices.Clone(v.References)
+	n.Resources = copyArtifacts(v.Resources)
+	n.Sources = copyArtifacts(v.Sources)
+	return &n
+}
+
+func copyArtifacts(list []*Artifact) []*Artifact {
+	var n []*Artifact
+	for _, a := range list {
+		c := *a
+		n = append(n, &c)
+	}
+	return n
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Plan records the decisions taken by a component version transfer
+// executed in plan (dry-run) mode. In this mode nothing is written
+// to the target repository.
+// A Plan can be used concurrently.
+type Plan struct {
+	lock     sync.Mutex
+	versions []*Version
+}
+
+func New() *Plan {
+	return &Plan{}
+}
+
+// GetVersions returns a copy of the planned component versions
+// in the order of their processing.
+func (p *Plan) GetVersions() []*Version {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	list := make([]*Version, len(p.versions))
+	for i, v := range p.versions {
+		list[i] = v.copy()
+	}
+	return list
+}
+
+// GetVersion returns a copy of the plan for a component version, or nil,
+// if the plan does not contain this version.
+func (p *Plan) GetVersion(nv common.NameVersion) *Version {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if v := p.lookup(nv); v != nil {
+		return v.copy()
+	}
+	return nil
+}
+
+// GetSize provides the accumulated size of all artifacts planned to be
+// copied. The second result indicates whether the size of all those
+// artifacts is known.
+func (p *Plan) GetSize() (int64, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var size int64
+	complete := true
+	for _, v := range p.versions {
+		s, c := v.GetSize()
+		size += s
+		complete = complete && c
+	}
+	return size, complete
+}
+
+// AddVersion records a component version visited by the transfer.
+func (p *Plan) AddVersion(nv common.NameVersion, hist common.History) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	v := p.assure(nv)
+	v.History = hist.Copy()
+}
+
+// SetAction records the action planned for a component version.
+func (p *Plan) SetAction(nv common.NameVersion, action string, msg string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	v := p.assure(nv)
+	v.Action = action
+	v.Message = msg
+}
+
+// AddReference records a component reference of a component version.
+func (p *Plan) AddReference(nv common.NameVersion, name string, ref common.NameVersion) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	v := p.assure(nv)
+	v.References = append(v.References, Reference{Name: name, Component: ref.GetName(), Version: ref.GetVersion()})
+}
+
+// AddResource records the planned transfer of a resource.
+func (p *Plan) AddResource(nv common.NameVersion, a *Artifact) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	v := p.assure(nv)
+	v.Resources = addArtifact(v.Resources, a)
+}
+
+// AddSource records the planned transfer of a source.
+func (p *Plan) AddSource(nv common.NameVersion, a *Artifact) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	v := p.assure(nv)
+	v.Sources = addArtifact(v.Sources, a)
+}
+
+func addArtifact(list []*Artifact, a *Artifact) []*Artifact {
+	list = append(list, a)
+	slices.SortFunc(list, func(a, b *Artifact) int { return a.Index - b.Index })
+	return list
+}
+
+func (p *Plan) lookup(nv common.NameVersion) *Version {
+	for _, v := range p.versions {
+		if v.Component == nv.GetName() && v.Version == nv.GetVersion() {
+			return v
+		}
+	}
+	return nil
+}
+
+func (p *Plan) assure(nv common.NameVersion) *Version {
+	v := p.lookup(nv)
+	if v == nil {
+		v = &Version{Component: nv.GetName(), Version: nv.GetVersion()}
+		p.versions = append(p.versions, v)
+	}
+	return v
+}
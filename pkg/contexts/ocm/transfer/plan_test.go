@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transfer_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/contexts/oci/testhelper"
+	. "github.com/open-component-model/ocm/pkg/env/builder"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/oci"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/ociartifact"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/ociuploadattr"
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/plan"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler/standard"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+var _ = Describe("transfer plan", func() {
+	var env *Builder
+
+	BeforeEach(func() {
+		env = NewBuilder()
+
+		FakeOCIRepo(env, OCIPATH, OCIHOST)
+		env.OCICommonTransport(OCIPATH, accessio.FormatDirectory, func() {
+			OCIManifest1(env)
+		})
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.ComponentVersion(COMMON, VERSION, func() {
+				env.Provider(PROVIDER)
+				env.Resource("image", VERSION, resourcetypes.OCI_IMAGE, metav1.ExternalRelation, func() {
+					env.Access(
+						ociartifact.New(oci.StandardOCIRef(OCIHOST+".alias", OCINAMESPACE, OCIVERSION)),
+					)
+				})
+				env.Resource("data", "", resourcetypes.PLAIN_TEXT, metav1.LocalRelation, func() {
+					env.BlobStringData(mime.MIME_TEXT, "some data")
+				})
+			})
+			env.ComponentVersion(TOP, VERSION, func() {
+				env.Provider(PROVIDER)
+				env.Reference("common", COMMON, VERSION)
+			})
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	run := func(p *plan.Plan) {
+		src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(src, "source")
+		cv := Must(src.LookupComponentVersion(TOP, VERSION))
+		defer Close(cv, "source cv")
+		tgt := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+		defer Close(tgt, "target")
+
+		opts := []transferhandler.TransferOption{standard.Recursive()}
+		if p != nil {
+			opts = append(opts, standard.Plan(p))
+		}
+		handler := Must(standard.New(append(opts, standard.ResourcesByValue())...))
+		MustBeSuccessful(transfer.TransferVersion(nil, nil, cv, tgt, handler))
+	}
+
+	It("plans a transfer without writing", func() {
+		ociuploadattr.Set(env.OCMContext(), ociuploadattr.New("ghcr.io/acme"))
+		p := plan.New()
+		run(p)
+
+		tgt := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, OUT, 0, env))
+		defer Close(tgt, "target")
+		Expect(tgt.ComponentLister().GetComponents("", true)).To(BeEmpty())
+
+		list := p.GetVersions()
+		Expect(list).To(HaveLen(2))
+		Expect(list[0].Component).To(Equal(TOP))
+		Expect(list[0].Action).To(Equal(plan.ACTION_NEW))
+		Expect(list[0].References).To(Equal([]plan.Reference{{Name: "common", Component: COMMON, Version: VERSION}}))
+
+		v := list[1]
+		Expect(v.Component).To(Equal(COMMON))
+		Expect(v.Action).To(Equal(plan.ACTION_NEW))
+		Expect(v.History).To(Equal(common.History{common.NewNameVersion(TOP, VERSION), common.NewNameVersion(COMMON, VERSION)}))
+		Expect(v.Resources).To(HaveLen(2))
+
+		img := v.Resources[0]
+		Expect(img.Mode).To(Equal(plan.MODE_VALUE))
+		Expect(img.Action).To(Equal(plan.ACTION_COPY))
+		Expect(img.AccessType).To(Equal(ociartifact.Type))
+		Expect(img.Target).To(Equal("ghcr.io/acme/" + OCINAMESPACE + ":" + OCIVERSION))
+		Expect(img.Size).To(BeNumerically(">", 0))
+
+		data := v.Resources[1]
+		Expect(data.Mode).To(Equal(plan.MODE_VALUE))
+		Expect(data.Action).To(Equal(plan.ACTION_COPY))
+		Expect(data.Target).To(Equal(""))
+		Expect(data.Size).To(Equal(int64(len("some data"))))
+
+		size, complete := p.GetSize()
+		Expect(complete).To(BeTrue())
+		Expect(size).To(Equal(img.Size + data.Size))
+	})
+
+	It("plans skipping already transferred versions", func() {
+		run(nil)
+
+		p := plan.New()
+		run(p)
+		list := p.GetVersions()
+		Expect(list).To(HaveLen(2))
+		Expect(list[0].Action).To(Equal(plan.ACTION_SKIP))
+		Expect(list[1].Action).To(Equal(plan.ACTION_SKIP))
+		size, _ := p.GetSize()
+		Expect(size).To(Equal(int64(0)))
+	})
+})
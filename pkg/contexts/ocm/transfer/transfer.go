@@ -6,6 +6,8 @@ package transfer
 
 import (
 	"fmt"
+	"strings"
+	"sync"
 
 	"github.com/mandelsoft/logging"
 
@@ -15,6 +17,8 @@ import (
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
 	ocmcpi "github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/internal"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/plan"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler/standard"
 	"github.com/open-component-model/ocm/pkg/errors"
 	"github.com/open-component-model/ocm/pkg/finalizer"
@@ -30,17 +34,22 @@ func TransferVersion(printer common.Printer, closure TransportClosure, src ocmcp
 		closure = TransportClosure{}
 	}
 	state := WalkingState{Closure: closure}
-	return transferVersion(common.AssurePrinter(printer), Logger(src), state, src, tgt, handler)
+	ctl := newControl(handler)
+	if ctl.journal != nil {
+		if err := ctl.journal.SetTarget(tgt.GetSpecification()); err != nil {
+			return err
+		}
+	}
+	return transferVersion(common.AssurePrinter(printer), Logger(src), ctl, state, src, tgt, handler)
 }
 
-func transferVersion(printer common.Printer, log logging.Logger, state WalkingState, src ocmcpi.ComponentVersionAccess, tgt ocmcpi.Repository, handler TransferHandler) (rerr error) {
+func transferVersion(printer common.Printer, log logging.Logger, ctl *control, state WalkingState, src ocmcpi.ComponentVersionAccess, tgt ocmcpi.Repository, handler TransferHandler) (rerr error) {
 	nv := common.VersionedElementKey(src)
 	log = log.WithValues("history", state.History.String(), "version", nv)
-	if ok, err := state.Add(ocm.KIND_COMPONENTVERSION, nv); !ok {
+	if ok, err := ctl.Begin(&state, nv); !ok {
 		return err
 	}
-	log.Info("transferring version")
-	printer.Printf("transferring version %q...\n", nv)
+	defer ctl.Done(nv)
 	if handler == nil {
 		var err error
 		handler, err = standard.New(standard.Overwrite())
@@ -48,12 +57,32 @@ func transferVersion(printer common.Printer, log logging.Logger, state WalkingSt
 			return err
 		}
 	}
+	if ok, err := ctl.ResumeVersion(src, tgt, handler); ok || err != nil {
+		if err != nil {
+			return err
+		}
+		printer.Printf("version %q already transferred according to journal -> skip transport\n", nv)
+		list := errors.ErrListf("component references for %s", nv)
+		refs := ctl.JournaledReferences(nv, src.GetDescriptor().References)
+		return list.Add(transferReferences(printer.AddGap("  "), log, ctl, state, src, refs, tgt, handler, list)).Result()
+	}
+	log.Info("transferring version")
+	printer.Printf("transferring version %q...\n", nv)
+	ctl.PlanVersion(nv, state.History)
+
+	d := src.GetDescriptor()
+	ctl.PlanReferences(nv, d.References)
+
+	if err := ctl.StartVersion(nv); err != nil {
+		return err
+	}
+	defer func() {
+		rerr = errors.Join(rerr, ctl.VersionDone(&state, nv, d, rerr))
+	}()
 
 	var finalize finalizer.Finalizer
 	defer finalize.FinalizeWithErrorPropagation(&rerr)
 
-	d := src.GetDescriptor()
-
 	comp, err := tgt.LookupComponent(src.GetName())
 	if err != nil {
 		return errors.Wrapf(err, "%s: lookup target component", state.History)
@@ -81,6 +110,7 @@ func transferVersion(printer common.Printer, log logging.Logger, state WalkingSt
 
 	if err != nil {
 		if errors.IsErrNotFound(err) {
+			ctl.PlanAction(nv, plan.ACTION_NEW, "")
 			t, err = comp.NewVersion(src.GetVersion())
 			finalize.Close(t, "new target version")
 		}
@@ -92,6 +122,7 @@ func transferVersion(printer common.Printer, log logging.Logger, state WalkingSt
 		if ok {
 			//  execute transport as if the component version were not present
 			// 	on the target side.
+			ctl.PlanAction(nv, plan.ACTION_TRANSPORT, "transport enforced")
 		} else {
 			// determine transport mode for component version present
 			// on the target side.
@@ -99,9 +130,11 @@ func transferVersion(printer common.Printer, log logging.Logger, state WalkingSt
 				if eq.IsEquivalent() {
 					if !needsResourceTransport(src, d, t.GetDescriptor(), handler) {
 						printer.Printf("  version %q already present -> skip transport\n", nv)
+						ctl.PlanAction(nv, plan.ACTION_SKIP, "already present")
 						doTransport = false
 					} else {
 						printer.Printf("  version %q already present -> but requires resource transport\n", nv)
+						ctl.PlanAction(nv, plan.ACTION_TRANSPORT, "already present, but requires resource transport")
 					}
 				} else {
 					ok, err = handler.UpdateVersion(src, t)
@@ -110,15 +143,18 @@ func transferVersion(printer common.Printer, log logging.Logger, state WalkingSt
 					}
 					if !ok {
 						printer.Printf("  version %q requires update of volatile data, but skipped\n", nv)
+						ctl.PlanAction(nv, plan.ACTION_SKIP, "requires update of volatile data, but skipped")
 						return nil
 					}
 					ok, err = handler.OverwriteVersion(src, t)
 					if ok {
 						printer.Printf("  warning: version %q already present, but transport enforced by overwrite option)\n", nv)
+						ctl.PlanAction(nv, plan.ACTION_OVERWRITE, "transport enforced by overwrite option")
 						doMerge = false
 						doCopy = true
 					} else {
 						printer.Printf("  updating volatile properties of %q\n", nv)
+						ctl.PlanAction(nv, plan.ACTION_UPDATE, "updating volatile properties")
 						doMerge = true
 						doCopy = false
 					}
@@ -147,8 +183,10 @@ func transferVersion(printer common.Printer, log logging.Logger, state WalkingSt
 				if ok {
 					doMerge = false
 					printer.Printf("warning: "+msg+" (transport enforced by overwrite option)\n", nv)
+					ctl.PlanAction(nv, plan.ACTION_OVERWRITE, strings.TrimSpace(msg)+" (transport enforced by overwrite option)", nv)
 				} else {
 					printer.Printf(msg+" -> transport aborted (use option overwrite option to enforce transport)\n", nv)
+					ctl.PlanAction(nv, plan.ACTION_ABORT, strings.TrimSpace(msg), nv)
 					return errors.ErrAlreadyExists(ocm.KIND_COMPONENTVERSION, nv.String())
 				}
 			}
@@ -161,15 +199,9 @@ func transferVersion(printer common.Printer, log logging.Logger, state WalkingSt
 	subp := printer.AddGap("  ")
 	list := errors.ErrListf("component references for %s", nv)
 	log.Info("  transferring references")
-	for _, r := range d.References {
-		cv, shdlr, err := handler.TransferVersion(src.Repository(), src, &r, tgt)
-		if err != nil {
-			return errors.Wrapf(err, "%s: nested component %s[%s:%s]", state.History, r.GetName(), r.ComponentName, r.GetVersion())
-		}
-		if cv != nil {
-			list.Add(transferVersion(subp, log.WithValues("ref", r.Name), state, cv, tgt, shdlr))
-			list.Addf(nil, cv.Close(), "closing reference %s", r.Name)
-		}
+	err = transferReferences(subp, log, ctl, state, src, d.References, tgt, handler, list)
+	if err != nil {
+		return err
 	}
 
 	if doTransport {
@@ -199,133 +231,210 @@ func transferVersion(printer common.Printer, log logging.Logger, state WalkingSt
 		// corrupted content in target.
 		// If no copy is done, merge must keep the access methods in target!!!
 		if !doMerge || doCopy {
-			err = copyVersion(printer, log, state.History, src, t, n, handler)
+			err = copyVersion(printer, log, ctl, state.History, src, t, n, handler)
 			if err != nil {
 				return err
 			}
-		} else {
+		} else if !ctl.IsPlanning() {
 			*t.GetDescriptor() = *n
 		}
 
-		printer.Printf("...adding component version...\n")
-		log.Info("  adding component version")
-		list.Add(comp.AddVersion(t))
+		if !ctl.IsPlanning() {
+			if f, ok := handler.(transferhandler.VersionFinalizer); ok {
+				if err := f.FinalizeVersion(src, t); err != nil {
+					return err
+				}
+			}
+			printer.Printf("...adding component version...\n")
+			log.Info("  adding component version")
+			list.Add(comp.AddVersion(t))
+		}
 	}
 	return list.Result()
 }
 
+// transferReferences transfers the given component references, potentially in parallel.
+// Errors of the nested transfers are collected in the given error list in the order
+// of the references.
+func transferReferences(printer common.Printer, log logging.Logger, ctl *control, state WalkingState, src ocmcpi.ComponentVersionAccess, refs compdesc.References, tgt ocmcpi.Repository, handler TransferHandler, list *errors.ErrorList) error {
+	var wg sync.WaitGroup
+
+	errs := make([]error, len(refs))
+	defer func() {
+		wg.Wait()
+		for _, err := range errs {
+			list.Add(err)
+		}
+	}()
+	for i := range refs {
+		r := &refs[i]
+		cv, shdlr, err := handler.TransferVersion(src.Repository(), src, r, tgt)
+		if err != nil {
+			return errors.Wrapf(err, "%s: nested component %s[%s:%s]", state.History, r.GetName(), r.ComponentName, r.GetVersion())
+		}
+		if cv != nil {
+			// parallel transfer paths must not share the history.
+			sub := state
+			sub.History = state.History.Copy()
+			idx := i
+			ctl.Go(&wg, func() {
+				errs[idx] = errors.Join(
+					transferVersion(printer, log.WithValues("ref", r.Name), ctl, sub, cv, tgt, shdlr),
+					errors.Wrapf(cv.Close(), "closing reference %s", r.Name),
+				)
+			})
+		}
+	}
+	return nil
+}
+
 func CopyVersion(printer common.Printer, log logging.Logger, hist common.History, src ocm.ComponentVersionAccess, t ocm.ComponentVersionAccess, handler TransferHandler) (rerr error) {
-	return copyVersion(printer, log, hist, src, t, src.GetDescriptor().Copy(), handler)
+	return copyVersion(printer, log, newControl(handler), hist, src, t, src.GetDescriptor().Copy(), handler)
 }
 
 // copyVersion (purely internal) expects an already prepared target comp desc for t given as prep.
-func copyVersion(printer common.Printer, log logging.Logger, hist common.History, src ocm.ComponentVersionAccess, t ocm.ComponentVersionAccess, prep *compdesc.ComponentDescriptor, handler TransferHandler) (rerr error) {
-	var finalize finalizer.Finalizer
-
-	defer errors.PropagateError(&rerr, finalize.Finalize)
-
+// Resources and sources are transferred in parallel, if enabled by the control.
+func copyVersion(printer common.Printer, log logging.Logger, ctl *control, hist common.History, src ocm.ComponentVersionAccess, t ocm.ComponentVersionAccess, prep *compdesc.ComponentDescriptor, handler TransferHandler) error {
 	if handler == nil {
 		handler = standard.NewDefaultHandler(nil)
 	}
 
 	srccd := src.GetDescriptor()
 	cur := *t.GetDescriptor()
-	*t.GetDescriptor() = *prep
+	if !ctl.IsPlanning() {
+		*t.GetDescriptor() = *prep
+	}
 	log.Info("  transferring resources")
-	for i, r := range src.GetResources() {
-		var m ocmcpi.AccessMethod
+	resources := src.GetResources()
+	err := ctl.Run(len(resources), func(i int) error {
+		return copyResource(printer, log, ctl, hist, src, srccd, i, resources[i], t, &cur, handler)
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Info("  transferring sources")
+	sources := src.GetSources()
+	return ctl.Run(len(sources), func(i int) error {
+		return copySource(printer, log, ctl, hist, src, i, sources[i], t, handler)
+	})
+}
 
-		nested := finalize.Nested()
+func copyResource(printer common.Printer, log logging.Logger, ctl *control, hist common.History, src ocm.ComponentVersionAccess, srccd *compdesc.ComponentDescriptor, i int, r ocm.ResourceAccess, t ocm.ComponentVersionAccess, cur *compdesc.ComponentDescriptor, handler TransferHandler) (rerr error) {
+	var m ocmcpi.AccessMethod
+	var finalize finalizer.Finalizer
 
-		a, err := r.Access()
-		if err == nil {
-			m, err = a.AccessMethod(src)
-			nested.Close(m, fmt.Sprintf("%s: transferring resource %d: closing access method", hist, i))
-		}
-		if err == nil {
-			ok := a.IsLocal(src.GetContext())
-			if !ok {
-				if !none.IsNone(a.GetKind()) {
-					ok, err = handler.TransferResource(src, a, r)
-					if err == nil && !ok {
-						log.Info("transport omitted", "resource", r.Meta().Name, "index", i, "access", a.GetType())
-					}
+	defer errors.PropagateError(&rerr, finalize.Finalize)
+
+	a, err := r.Access()
+	if err == nil {
+		m, err = a.AccessMethod(src)
+		finalize.Close(m, fmt.Sprintf("%s: transferring resource %d: closing access method", hist, i))
+	}
+	if err == nil {
+		nv := common.VersionedElementKey(src)
+		ok := a.IsLocal(src.GetContext())
+		if !ok {
+			if !none.IsNone(a.GetKind()) {
+				ok, err = handler.TransferResource(src, a, r)
+				if err == nil && !ok {
+					log.Info("transport omitted", "resource", r.Meta().Name, "index", i, "access", a.GetType())
 				}
 			}
-			if ok {
-				var old compdesc.Resource
-
-				hint := ocmcpi.ArtifactNameHint(a, src)
-				old, err = cur.GetResourceByIdentity(r.Meta().GetIdentity(srccd.Resources))
-
-				changed := err != nil || old.Digest == nil || !old.Digest.Equal(r.Meta().Digest)
-				valueNeeded := err == nil && needsTransport(src.GetContext(), r, &old)
-				if changed || valueNeeded {
-					var msgs []interface{}
-					if !errors.IsErrNotFound(err) {
-						if err != nil {
-							return err
-						}
-						if !changed && valueNeeded {
-							msgs = []interface{}{"copy"}
-						} else {
-							msgs = []interface{}{"overwrite"}
-						}
+			if err == nil && !ok {
+				ctl.PlanResource(nv, i, r.Meta(), a, m, plan.MODE_REFERENCE, "", "", t)
+			}
+		}
+		if ok {
+			var old compdesc.Resource
+
+			hint := ocmcpi.ArtifactNameHint(a, src)
+			old, err = cur.GetResourceByIdentity(r.Meta().GetIdentity(srccd.Resources))
+
+			changed := err != nil || old.Digest == nil || !old.Digest.Equal(r.Meta().Digest)
+			valueNeeded := err == nil && needsTransport(src.GetContext(), r, &old)
+			if changed || valueNeeded {
+				var msgs []interface{}
+				if !errors.IsErrNotFound(err) {
+					if err != nil {
+						return err
 					}
+					if !changed && valueNeeded {
+						msgs = []interface{}{"copy"}
+					} else {
+						msgs = []interface{}{"overwrite"}
+					}
+				}
+				if ctl.IsPlanning() {
 					notifyArtifactInfo(printer, log, "resource", i, r.Meta(), hint, msgs...)
-					err = handler.HandleTransferResource(r, m, hint, t)
+					ctl.PlanResource(nv, i, r.Meta(), a, m, plan.MODE_VALUE, plan.ACTION_COPY, hint, t)
+					err = nil
+				} else if ctl.ResumeResource(nv, i, r, a, t) {
+					notifyArtifactInfo(printer, log, "resource", i, r.Meta(), hint, "already transferred according to journal")
+					err = nil
 				} else {
-					if err == nil { // old resource found -> keep current access method
-						t.SetResource(r.Meta(), old.Access, ocm.ModifyResource(), ocm.SkipVerify())
-					}
-					notifyArtifactInfo(printer, log, "resource", i, r.Meta(), hint, "already present")
+					notifyArtifactInfo(printer, log, "resource", i, r.Meta(), hint, msgs...)
+					srcdigest := r.Meta().Digest.Copy()
+					err = handler.HandleTransferResource(r, m, hint, t)
+					err = errors.Join(err, ctl.ResourceDone(nv, i, r, a, srcdigest, t, err))
 				}
+			} else {
+				if ctl.IsPlanning() {
+					ctl.PlanResource(nv, i, r.Meta(), a, m, plan.MODE_VALUE, plan.ACTION_KEEP, hint, t)
+				} else if err == nil { // old resource found -> keep current access method
+					t.SetResource(r.Meta(), old.Access, ocm.ModifyResource(), ocm.SkipVerify())
+				}
+				notifyArtifactInfo(printer, log, "resource", i, r.Meta(), hint, "already present")
 			}
 		}
-		if err != nil {
-			if !errors.IsErrUnknownKind(err, errors.KIND_ACCESSMETHOD) {
-				return errors.Wrapf(err, "%s: transferring resource %d", hist, i)
-			}
-			printer.Printf("WARN: %s: transferring resource %d: %s (enforce transport by reference)\n", hist, i, err)
-		}
-		err = nested.Finalize()
-		if err != nil {
-			return err
+	}
+	if err != nil {
+		if !errors.IsErrUnknownKind(err, errors.KIND_ACCESSMETHOD) {
+			return errors.Wrapf(err, "%s: transferring resource %d", hist, i)
 		}
+		printer.Printf("WARN: %s: transferring resource %d: %s (enforce transport by reference)\n", hist, i, err)
 	}
+	return nil
+}
 
-	log.Info("  transferring sources")
-	for i, r := range src.GetSources() {
-		var m ocmcpi.AccessMethod
+func copySource(printer common.Printer, log logging.Logger, ctl *control, hist common.History, src ocm.ComponentVersionAccess, i int, r ocm.SourceAccess, t ocm.ComponentVersionAccess, handler TransferHandler) error {
+	var m ocmcpi.AccessMethod
 
-		a, err := r.Access()
-		if err == nil {
-			m, err = a.AccessMethod(src)
-		}
-		if err == nil {
-			ok := a.IsLocal(src.GetContext())
-			if !ok {
-				if !none.IsNone(a.GetKind()) {
-					ok, err = handler.TransferSource(src, a, r)
-					if err == nil && !ok {
-						log.Info("transport omitted", "source", r.Meta().Name, "index", i, "access", a.GetType())
-					}
+	a, err := r.Access()
+	if err == nil {
+		m, err = a.AccessMethod(src)
+	}
+	if err == nil {
+		nv := common.VersionedElementKey(src)
+		ok := a.IsLocal(src.GetContext())
+		if !ok {
+			if !none.IsNone(a.GetKind()) {
+				ok, err = handler.TransferSource(src, a, r)
+				if err == nil && !ok {
+					log.Info("transport omitted", "source", r.Meta().Name, "index", i, "access", a.GetType())
 				}
 			}
-			if ok {
-				// sources do not have digests fo far, so they have to copied, always.
-				hint := ocmcpi.ArtifactNameHint(a, src)
-				notifyArtifactInfo(printer, log, "source", i, r.Meta(), hint)
-				err = errors.Join(err, handler.HandleTransferSource(r, m, hint, t))
+			if err == nil && !ok {
+				ctl.PlanSource(nv, i, r.Meta(), a, m, plan.MODE_REFERENCE, "", "", t)
 			}
-			err = errors.Join(err, m.Close())
 		}
-		if err != nil {
-			if !errors.IsErrUnknownKind(err, errors.KIND_ACCESSMETHOD) {
-				return errors.Wrapf(err, "%s: transferring source %d", hist, i)
+		if ok {
+			// sources do not have digests fo far, so they have to copied, always.
+			hint := ocmcpi.ArtifactNameHint(a, src)
+			notifyArtifactInfo(printer, log, "source", i, r.Meta(), hint)
+			if ctl.IsPlanning() {
+				ctl.PlanSource(nv, i, r.Meta(), a, m, plan.MODE_VALUE, plan.ACTION_COPY, hint, t)
+			} else {
+				err = errors.Join(err, handler.HandleTransferSource(r, m, hint, t))
 			}
-			printer.Printf("WARN: %s: transferring source %d: %s (enforce transport by reference)\n", hist, i, err)
 		}
+		err = errors.Join(err, m.Close())
+	}
+	if err != nil {
+		if !errors.IsErrUnknownKind(err, errors.KIND_ACCESSMETHOD) {
+			return errors.Wrapf(err, "%s: transferring source %d", hist, i)
+		}
+		printer.Printf("WARN: %s: transferring source %d: %s (enforce transport by reference)\n", hist, i, err)
 	}
 	return nil
 }
@@ -31,6 +31,8 @@ type Config struct {
 	StopOnExisting              *bool    `json:"stopOnExistingVersion,omitempty"`
 	Overwrite                   *bool    `json:"overwrite,omitempty"`
 	OmitAccessTypes             []string `json:"omitAccessTypes,omitempty"`
+	Platforms                   []string `json:"platforms,omitempty"`
+	DropSignatures              *bool    `json:"dropSignatures,omitempty"`
 }
 
 // NewConfig creates a new memory ConfigSpec.
@@ -85,6 +87,16 @@ func (c *Config) ApplyTo(ctx cfgcpi.Context, target interface{}) error {
 			opts.SetOmittedAccessTypes(c.OmitAccessTypes...)
 		}
 	}
+	if c.Platforms != nil {
+		if opts, ok := target.(standard.PlatformsOption); ok {
+			opts.SetPlatforms(c.Platforms...)
+		}
+	}
+	if c.DropSignatures != nil {
+		if opts, ok := target.(standard.DropSignaturesOption); ok {
+			opts.SetDropSignatures(*c.DropSignatures)
+		}
+	}
 	return nil
 }
 
@@ -102,5 +114,15 @@ The config type <code>` + ConfigType + `</code> can be used to define transfer s
     stopOnExistingVersion: false
     omitAccessTypes:
     - s3
+    platforms:
+    - linux/arm64
+    dropSignatures: false
 </pre>
+
+The field <code>platforms</code> restricts the by-value transfer of
+multi-arch OCI artifacts to the given platforms (<code>&lt;os>/&lt;arch></code>).
+Filtering signed component versions (or component versions referenced by
+signed component versions) is rejected, unless the field
+<code>dropSignatures</code> is set to remove the invalidated signatures
+along the reference chain.
 `
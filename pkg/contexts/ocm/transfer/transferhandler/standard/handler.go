@@ -5,27 +5,47 @@
 package standard
 
 import (
+	"fmt"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/open-component-model/ocm/pkg/common"
 	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/artifactset"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/transfer/filters"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/signingattr"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
 	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi/accspeccpi"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/journal"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/plan"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler"
 	"github.com/open-component-model/ocm/pkg/errors"
+	ocmlog "github.com/open-component-model/ocm/pkg/logging"
 )
 
+var REALM = ocmlog.DefineSubRealm("standard transfer handler", "transfer", "standard")
+
 type Handler struct {
+	lock *sync.Mutex
 	opts *Options
 }
 
+var (
+	_ transferhandler.ConcurrencyProvider = (*Handler)(nil)
+	_ transferhandler.JournalProvider     = (*Handler)(nil)
+	_ transferhandler.PlanProvider        = (*Handler)(nil)
+	_ transferhandler.VersionFinalizer    = (*Handler)(nil)
+)
+
 func NewDefaultHandler(opts *Options) *Handler {
 	if opts == nil {
 		opts = &Options{}
 	}
-	return &Handler{opts: opts}
+	return &Handler{lock: &sync.Mutex{}, opts: opts}
 }
 
 func New(opts ...transferhandler.TransferOption) (transferhandler.TransferHandler, error) {
@@ -34,9 +54,25 @@ func New(opts ...transferhandler.TransferOption) (transferhandler.TransferHandle
 	if err != nil {
 		return nil, err
 	}
+	_, err = filters.Platforms(defaultOpts.GetPlatforms()...)
+	if err != nil {
+		return nil, err
+	}
 	return NewDefaultHandler(defaultOpts), nil
 }
 
+func (h *Handler) GetConcurrency() int {
+	return h.opts.GetConcurrency()
+}
+
+func (h *Handler) GetJournal() *journal.Journal {
+	return h.opts.GetJournal()
+}
+
+func (h *Handler) GetPlan() *plan.Plan {
+	return h.opts.GetPlan()
+}
+
 func (h *Handler) UpdateVersion(src ocm.ComponentVersionAccess, tgt ocm.ComponentVersionAccess) (bool, error) {
 	return !h.opts.IsSkipUpdate(), nil
 }
@@ -88,11 +124,135 @@ func (h *Handler) HandleTransferResource(r ocm.ResourceAccess, m cpi.AccessMetho
 		return err
 	}
 	defer blob.Close()
+
+	var data cpi.BlobAccess = blob
+	meta := r.Meta()
+	global := h.GlobalAccess(t.GetContext(), m)
+	filter, err := filters.Platforms(h.opts.GetPlatforms()...)
+	if err != nil {
+		return err
+	}
+	if filter != nil {
+		fblob, filtered, err := artifactset.FilterArtifactBlob(blob, filter)
+		if err != nil {
+			return errors.Wrapf(err, "filtering platforms of resource %s", meta.GetName())
+		}
+		defer fblob.Close()
+		if filtered {
+			err = h.modify(t, fmt.Sprintf("filtering platforms of resource %s", meta.GetName()))
+			if err != nil {
+				return err
+			}
+			// the filtered artifact has a new digest, which has to be
+			// recalculated, and the original global access does not
+			// describe it anymore.
+			data = fblob
+			global = nil
+			meta = meta.Copy()
+			if meta.Digest != nil {
+				meta.Digest = &metav1.DigestSpec{
+					HashAlgorithm:          meta.Digest.HashAlgorithm,
+					NormalisationAlgorithm: meta.Digest.NormalisationAlgorithm,
+				}
+			}
+		}
+	}
 	return accessio.Retry(h.opts.GetRetries(), time.Second, func() error {
-		return t.SetResourceBlob(r.Meta(), blob, hint, h.GlobalAccess(t.GetContext(), m), ocm.SkipVerify())
+		return t.SetResourceBlob(meta, data, hint, global, ocm.SkipVerify())
 	})
 }
 
+// modify handles the modification of the content of the target component
+// version and removes its signatures, which are invalidated this way.
+// This is only done if explicitly requested, otherwise the modification
+// is rejected.
+func (h *Handler) modify(t ocm.ComponentVersionAccess, reason string) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	nv := common.VersionedElementKey(t)
+	cd := t.GetDescriptor()
+	if len(cd.Signatures) > 0 {
+		if !h.opts.IsDropSignatures() {
+			return errors.Newf("%s invalidates the signatures of %s (use drop signatures option to enforce)", reason, nv)
+		}
+		names := make([]string, len(cd.Signatures))
+		for i, s := range cd.Signatures {
+			names[i] = s.Name
+		}
+		t.GetContext().Logger(REALM).Warn("dropping invalidated signatures", "version", nv.String(), "reason", reason, "signatures", names)
+		cd.Signatures = nil
+	}
+	return nil
+}
+
+// FinalizeVersion updates the digests of references to component versions
+// modified by the transfer. The digest is taken from the referenced version
+// found in the target repository, this covers versions modified by earlier
+// runs, also, which are skipped or resumed by this run. This modifies the
+// referencing component version, too, so that the modification is propagated
+// along the reference chain.
+func (h *Handler) FinalizeVersion(src ocm.ComponentVersionAccess, t ocm.ComponentVersionAccess) error {
+	cd := t.GetDescriptor()
+	var refs []int
+	var digests []*metav1.DigestSpec
+	var names []string
+	for i, r := range cd.References {
+		if r.Digest == nil {
+			continue
+		}
+		d, err := referenceDigest(t.Repository(), &cd.References[i])
+		if err != nil {
+			return errors.Wrapf(err, "reference %s", r.GetName())
+		}
+		if d != nil && !d.Equal(r.Digest) {
+			refs = append(refs, i)
+			digests = append(digests, d)
+			names = append(names, r.GetName())
+		}
+	}
+
+	if len(refs) == 0 {
+		return nil
+	}
+	err := h.modify(t, fmt.Sprintf("modifying referenced component versions (%s)", strings.Join(names, ", ")))
+	if err != nil {
+		return err
+	}
+	for i, r := range refs {
+		cd.References[r].Digest = digests[i]
+	}
+	return nil
+}
+
+// referenceDigest calculates the digest of a referenced component version
+// found in the given repository using the algorithms of the actual
+// reference digest. If the version is not present, nil is returned.
+func referenceDigest(repo ocm.Repository, r *compdesc.ComponentReference) (*metav1.DigestSpec, error) {
+	cv, err := repo.LookupComponentVersion(r.ComponentName, r.Version)
+	if err != nil {
+		if errors.IsErrNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer cv.Close()
+
+	hasher := signingattr.Get(repo.GetContext()).GetHasher(r.Digest.HashAlgorithm)
+	if hasher == nil {
+		return nil, errors.ErrUnknown(compdesc.KIND_HASH_ALGORITHM, r.Digest.HashAlgorithm)
+	}
+	value, err := compdesc.Hash(cv.GetDescriptor(), r.Digest.NormalisationAlgorithm, hasher.Create())
+	if err != nil {
+		return nil, err
+	}
+	return &metav1.DigestSpec{
+		HashAlgorithm:          r.Digest.HashAlgorithm,
+		NormalisationAlgorithm: r.Digest.NormalisationAlgorithm,
+		Value:                  value,
+	}, nil
+}
+
 func (h *Handler) HandleTransferSource(r ocm.SourceAccess, m cpi.AccessMethod, hint string, t ocm.ComponentVersionAccess) error {
 	blob, err := accspeccpi.BlobAccessForAccessMethod(m)
 	if err != nil {
@@ -8,6 +8,8 @@ import (
 	"golang.org/x/exp/slices"
 
 	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/journal"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/plan"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler"
 	"github.com/open-component-model/ocm/pkg/errors"
 	"github.com/open-component-model/ocm/pkg/generics"
@@ -21,6 +23,9 @@ func init() {
 
 type Options struct {
 	retries           *int
+	concurrency       *int
+	journal           *journal.Journal
+	plan              *plan.Plan
 	recursive         *bool
 	resourcesByValue  *bool
 	localByValue      *bool
@@ -32,6 +37,8 @@ type Options struct {
 	skipUpdate        *bool
 	omitAccessTypes   utils.StringSet
 	omitArtifactTypes utils.StringSet
+	platforms         []string
+	dropSignatures    *bool
 	resolver          ocm.ComponentVersionResolver
 }
 
@@ -39,6 +46,9 @@ var (
 	_ transferhandler.TransferOption = (*Options)(nil)
 
 	_ RetryOption                 = (*Options)(nil)
+	_ ConcurrencyOption           = (*Options)(nil)
+	_ JournalOption               = (*Options)(nil)
+	_ PlanOption                  = (*Options)(nil)
 	_ ResourcesByValueOption      = (*Options)(nil)
 	_ LocalResourcesByValueOption = (*Options)(nil)
 	_ EnforceTransportOption      = (*Options)(nil)
@@ -50,6 +60,8 @@ var (
 	_ KeepGlobalAccessOption      = (*Options)(nil)
 	_ OmitAccessTypesOption       = (*Options)(nil)
 	_ OmitArtifactTypesOption     = (*Options)(nil)
+	_ PlatformsOption             = (*Options)(nil)
+	_ DropSignaturesOption        = (*Options)(nil)
 )
 
 type TransferOptionsCreator = transferhandler.SpecializedOptionsCreator[*Options, Options]
@@ -68,6 +80,21 @@ func (o *Options) ApplyTransferOption(target transferhandler.TransferOptions) er
 			opts.SetRetries(*o.retries)
 		}
 	}
+	if o.concurrency != nil {
+		if opts, ok := target.(ConcurrencyOption); ok {
+			opts.SetConcurrency(*o.concurrency)
+		}
+	}
+	if o.journal != nil {
+		if opts, ok := target.(JournalOption); ok {
+			opts.SetJournal(o.journal)
+		}
+	}
+	if o.plan != nil {
+		if opts, ok := target.(PlanOption); ok {
+			opts.SetPlan(o.plan)
+		}
+	}
 	if o.recursive != nil {
 		if opts, ok := target.(RecursiveOption); ok {
 			opts.SetRecursive(*o.recursive)
@@ -123,6 +150,16 @@ func (o *Options) ApplyTransferOption(target transferhandler.TransferOptions) er
 			opts.SetOmittedArtifactTypes(utils.StringMapKeys(o.omitAccessTypes)...)
 		}
 	}
+	if o.platforms != nil {
+		if opts, ok := target.(PlatformsOption); ok {
+			opts.SetPlatforms(o.platforms...)
+		}
+	}
+	if o.dropSignatures != nil {
+		if opts, ok := target.(DropSignaturesOption); ok {
+			opts.SetDropSignatures(*o.dropSignatures)
+		}
+	}
 	if o.resolver != nil {
 		if opts, ok := target.(ResolverOption); ok {
 			opts.SetResolver(o.resolver)
@@ -210,6 +247,33 @@ func (o *Options) GetRetries() int {
 	return *o.retries
 }
 
+func (o *Options) SetConcurrency(n int) {
+	o.concurrency = &n
+}
+
+func (o *Options) GetConcurrency() int {
+	if o.concurrency == nil {
+		return 0
+	}
+	return *o.concurrency
+}
+
+func (o *Options) SetJournal(j *journal.Journal) {
+	o.journal = j
+}
+
+func (o *Options) GetJournal() *journal.Journal {
+	return o.journal
+}
+
+func (o *Options) SetPlan(p *plan.Plan) {
+	o.plan = p
+}
+
+func (o *Options) GetPlan() *plan.Plan {
+	return o.plan
+}
+
 func (o *Options) SetResolver(resolver ocm.ComponentVersionResolver) {
 	o.resolver = resolver
 }
@@ -293,6 +357,22 @@ func (o *Options) IsArtifactTypeOmitted(t string) bool {
 	return o.omitArtifactTypes.Contains(t)
 }
 
+func (o *Options) SetPlatforms(list ...string) {
+	o.platforms = slices.Clone(list)
+}
+
+func (o *Options) GetPlatforms() []string {
+	return o.platforms
+}
+
+func (o *Options) SetDropSignatures(drop bool) {
+	o.dropSignatures = &drop
+}
+
+func (o *Options) IsDropSignatures() bool {
+	return utils.AsBool(o.dropSignatures)
+}
+
 //////////////////////////////////////////////////////////////////////////////
 
 type EnforceTransportOption interface {
@@ -402,6 +482,91 @@ func Retries(retries int) transferhandler.TransferOption {
 
 ///////////////////////////////////////////////////////////////////////////////
 
+type ConcurrencyOption interface {
+	SetConcurrency(n int)
+	GetConcurrency() int
+}
+
+type concurrencyOption struct {
+	TransferOptionsCreator
+	concurrency int
+}
+
+func (o *concurrencyOption) ApplyTransferOption(to transferhandler.TransferOptions) error {
+	if eff, ok := to.(ConcurrencyOption); ok {
+		eff.SetConcurrency(o.concurrency)
+		return nil
+	} else {
+		return errors.ErrNotSupported(transferhandler.KIND_TRANSFEROPTION, "concurrency")
+	}
+}
+
+// Concurrency sets the maximum number of parallel transfer
+// operations. Independent component version references
+// and artifacts are transferred in parallel. A value less than 2
+// results in a sequential transfer.
+func Concurrency(n int) transferhandler.TransferOption {
+	return &concurrencyOption{concurrency: n}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type JournalOption interface {
+	SetJournal(j *journal.Journal)
+	GetJournal() *journal.Journal
+}
+
+type journalOption struct {
+	TransferOptionsCreator
+	journal *journal.Journal
+}
+
+func (o *journalOption) ApplyTransferOption(to transferhandler.TransferOptions) error {
+	if eff, ok := to.(JournalOption); ok {
+		eff.SetJournal(o.journal)
+		return nil
+	} else {
+		return errors.ErrNotSupported(transferhandler.KIND_TRANSFEROPTION, "journal")
+	}
+}
+
+// Journal sets a journal used to record the transfer progress.
+// Component versions and resources already successfully transferred
+// according to the journal are skipped.
+func Journal(j *journal.Journal) transferhandler.TransferOption {
+	return &journalOption{journal: j}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type PlanOption interface {
+	SetPlan(p *plan.Plan)
+	GetPlan() *plan.Plan
+}
+
+type planOption struct {
+	TransferOptionsCreator
+	plan *plan.Plan
+}
+
+func (o *planOption) ApplyTransferOption(to transferhandler.TransferOptions) error {
+	if eff, ok := to.(PlanOption); ok {
+		eff.SetPlan(o.plan)
+		return nil
+	} else {
+		return errors.ErrNotSupported(transferhandler.KIND_TRANSFEROPTION, "plan")
+	}
+}
+
+// Plan enables the plan (dry-run) mode. The decisions taken by the
+// transfer are recorded in the given plan instead of writing anything
+// to the target repository.
+func Plan(p *plan.Plan) transferhandler.TransferOption {
+	return &planOption{plan: p}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
 type RecursiveOption interface {
 	SetRecursive(bool)
 	IsRecursive() bool
@@ -662,3 +827,65 @@ func AddOmittedArtifactTypes(list ...string) transferhandler.TransferOption {
 		list: slices.Clone(list),
 	}
 }
+
+///////////////////////////////////////////////////////////////////////////////
+
+type PlatformsOption interface {
+	SetPlatforms(...string)
+	GetPlatforms() []string
+}
+
+type platformsOption struct {
+	TransferOptionsCreator
+	list []string
+}
+
+func (o *platformsOption) ApplyTransferOption(to transferhandler.TransferOptions) error {
+	if eff, ok := to.(PlatformsOption); ok {
+		eff.SetPlatforms(o.list...)
+		return nil
+	} else {
+		return errors.ErrNotSupported(transferhandler.KIND_TRANSFEROPTION, "platforms")
+	}
+}
+
+// Platforms restricts the by-value transfer of multi-arch OCI artifacts
+// (image indices) to the given platforms (<os>/<architecture>).
+// The transferred index only lists the retained manifests and the
+// resource digest is recalculated.
+func Platforms(list ...string) transferhandler.TransferOption {
+	return &platformsOption{
+		list: slices.Clone(list),
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type DropSignaturesOption interface {
+	SetDropSignatures(bool)
+	IsDropSignatures() bool
+}
+
+type dropSignaturesOption struct {
+	TransferOptionsCreator
+	flag bool
+}
+
+func (o *dropSignaturesOption) ApplyTransferOption(to transferhandler.TransferOptions) error {
+	if eff, ok := to.(DropSignaturesOption); ok {
+		eff.SetDropSignatures(o.flag)
+		return nil
+	} else {
+		return errors.ErrNotSupported(transferhandler.KIND_TRANSFEROPTION, "drop-signatures")
+	}
+}
+
+// DropSignatures allows to modify the content of signed component
+// versions by platform filtering. The signatures invalidated
+// by the modification are removed from the transferred component version
+// and all transferred component versions referencing it.
+// Without this option, filtering the platforms of a resource of a signed
+// component version or of a component version referenced by a signed one fails.
+func DropSignatures(args ...bool) transferhandler.TransferOption {
+	return &dropSignaturesOption{flag: utils.GetOptionFlag(args...)}
+}
@@ -0,0 +1,303 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package standard_test
+
+import (
+	"crypto/sha256"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/contexts/oci/testhelper"
+	. "github.com/open-component-model/ocm/pkg/env/builder"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/oci"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/artdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/oci/repositories/artifactset"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/accessmethods/ociartifact"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/signingattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	ocmsign "github.com/open-component-model/ocm/pkg/contexts/ocm/signing"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/journal"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler/standard"
+	"github.com/open-component-model/ocm/pkg/finalizer"
+)
+
+var _ = Describe("platform filter", func() {
+	var env *Builder
+	var idesc *artdesc.Descriptor
+
+	BeforeEach(func() {
+		env = NewBuilder()
+
+		env.OCICommonTransport(OCIPATH, accessio.FormatDirectory, func() {
+			idesc = OCIIndex1(env)
+		})
+
+		FakeOCIRepo(env, OCIPATH, OCIHOST)
+
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.Component(COMPONENT, func() {
+				env.Version(VERSION, func() {
+					env.Provider(PROVIDER)
+					env.Resource("image", VERSION, resourcetypes.OCI_IMAGE, metav1.ExternalRelation, func() {
+						env.Access(
+							ociartifact.New(oci.StandardOCIRef(OCIHOST+".alias", OCINAMESPACE3, OCIINDEXVERSION)),
+						)
+					})
+				})
+			})
+			env.Component(COMPONENT2, func() {
+				env.Version(VERSION, func() {
+					env.Provider(PROVIDER)
+					env.Reference("ref", COMPONENT, VERSION)
+				})
+			})
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	mainArtifact := func(tgt ocm.Repository) (*metav1.DigestSpec, []artdesc.Descriptor) {
+		var finalize finalizer.Finalizer
+		defer Defer(finalize.Finalize)
+
+		tcv := Must(tgt.LookupComponentVersion(COMPONENT, VERSION))
+		finalize.Close(tcv)
+		r := Must(tcv.GetResourceByIndex(0))
+		meth := Must(r.AccessMethod())
+		finalize.Close(meth)
+		reader := Must(meth.Reader())
+		finalize.Close(reader)
+		set := Must(artifactset.Open(accessobj.ACC_READONLY, "", 0, accessio.Reader(reader)))
+		finalize.Close(set)
+		art := Must(set.GetArtifact(set.GetMain().String()))
+		finalize.Close(art)
+		Expect(art.IsIndex()).To(BeTrue())
+		Expect(r.Meta().Digest.Value).To(Equal(art.Digest().Encoded()))
+		return r.Meta().Digest, art.IndexAccess().GetDescriptor().Manifests
+	}
+
+	It("transfers complete index", func() {
+		src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(src, "source")
+		cv := Must(src.LookupComponentVersion(COMPONENT, VERSION))
+		defer Close(cv, "source cv")
+		tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+		defer Close(tgt, "target")
+
+		MustBeSuccessful(transfer.Transfer(cv, tgt, standard.ResourcesByValue()))
+
+		digest, manifests := mainArtifact(tgt)
+		Expect(digest.Value).To(Equal(idesc.Digest.Encoded()))
+		Expect(manifests).To(HaveLen(3))
+	})
+
+	It("transfers filtered index", func() {
+		src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(src, "source")
+		cv := Must(src.LookupComponentVersion(COMPONENT, VERSION))
+		defer Close(cv, "source cv")
+		tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+		defer Close(tgt, "target")
+
+		MustBeSuccessful(transfer.Transfer(cv, tgt, standard.ResourcesByValue(), standard.Platforms("linux/arm64", "darwin/arm64")))
+
+		digest, manifests := mainArtifact(tgt)
+		Expect(digest.Value).NotTo(Equal(idesc.Digest.Encoded()))
+		Expect(digest.NormalisationAlgorithm).To(Equal(cv.GetDescriptor().Resources[0].Digest.NormalisationAlgorithm))
+		Expect(manifests).To(HaveLen(2))
+		Expect(manifests[0].Platform).To(Equal(&artdesc.Platform{OS: "linux", Architecture: "arm64"}))
+		Expect(manifests[1].Platform).To(Equal(&artdesc.Platform{OS: "darwin", Architecture: "arm64"}))
+	})
+
+	Context("signed", func() {
+		sign := func(src ocm.Repository) ocm.ComponentVersionAccess {
+			env.RSAKeyPair(SIGNATURE)
+			cv := Must(src.LookupComponentVersion(COMPONENT, VERSION))
+			opts := ocmsign.NewOptions(
+				ocmsign.Sign(signingattr.Get(env.OCMContext()).GetSigner(SIGN_ALGO), SIGNATURE),
+				ocmsign.Resolver(src),
+				ocmsign.Update(), ocmsign.VerifyDigests(),
+			)
+			MustBeSuccessful(opts.Complete(env.OCMContext()))
+			Must(ocmsign.Apply(nil, nil, cv, opts))
+			Expect(cv.GetDescriptor().Signatures).To(HaveLen(1))
+			return cv
+		}
+
+		It("rejects filtering signed versions", func() {
+			src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+			defer Close(src, "source")
+			cv := sign(src)
+			defer Close(cv, "source cv")
+			tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+			defer Close(tgt, "target")
+
+			err := transfer.Transfer(cv, tgt, standard.ResourcesByValue(), standard.Platforms("linux/arm64"))
+			Expect(err).To(MatchError(ContainSubstring("filtering platforms of resource image invalidates the signatures of " + COMPONENT + ":" + VERSION)))
+		})
+
+		It("transfers signed versions with unfiltered index", func() {
+			src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+			defer Close(src, "source")
+			cv := sign(src)
+			defer Close(cv, "source cv")
+			tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+			defer Close(tgt, "target")
+
+			MustBeSuccessful(transfer.Transfer(cv, tgt, standard.ResourcesByValue(), standard.Platforms("linux/amd64", "linux/arm64", "darwin/arm64")))
+
+			tcv := Must(tgt.LookupComponentVersion(COMPONENT, VERSION))
+			defer Close(tcv, "target cv")
+			Expect(tcv.GetDescriptor().Signatures).To(HaveLen(1))
+		})
+
+		It("drops signatures on request", func() {
+			src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+			defer Close(src, "source")
+			cv := sign(src)
+			defer Close(cv, "source cv")
+			tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+			defer Close(tgt, "target")
+
+			MustBeSuccessful(transfer.Transfer(cv, tgt, standard.ResourcesByValue(), standard.Platforms("linux/arm64", "darwin/arm64"), standard.DropSignatures()))
+
+			_, manifests := mainArtifact(tgt)
+			Expect(manifests).To(HaveLen(2))
+			tcv := Must(tgt.LookupComponentVersion(COMPONENT, VERSION))
+			defer Close(tcv, "target cv")
+			Expect(tcv.GetDescriptor().Signatures).To(BeEmpty())
+			Expect(cv.GetDescriptor().Signatures).To(HaveLen(1))
+		})
+	})
+
+	Context("signed references", func() {
+		const STATEFILE = "/tmp/state.json"
+
+		targetDigest := func(tgt ocm.Repository, d *metav1.DigestSpec) *metav1.DigestSpec {
+			tcv := Must(tgt.LookupComponentVersion(COMPONENT, VERSION))
+			defer Close(tcv, "target ref")
+			return &metav1.DigestSpec{
+				HashAlgorithm:          d.HashAlgorithm,
+				NormalisationAlgorithm: d.NormalisationAlgorithm,
+				Value:                  Must(compdesc.Hash(tcv.GetDescriptor(), d.NormalisationAlgorithm, sha256.New())),
+			}
+		}
+
+		sign := func(src ocm.Repository) ocm.ComponentVersionAccess {
+			env.RSAKeyPair(SIGNATURE)
+			cv := Must(src.LookupComponentVersion(COMPONENT2, VERSION))
+			opts := ocmsign.NewOptions(
+				ocmsign.Sign(signingattr.Get(env.OCMContext()).GetSigner(SIGN_ALGO), SIGNATURE),
+				ocmsign.Resolver(src),
+				ocmsign.Update(), ocmsign.VerifyDigests(), ocmsign.Recursive(),
+			)
+			MustBeSuccessful(opts.Complete(env.OCMContext()))
+			Must(ocmsign.Apply(nil, nil, cv, opts))
+			Expect(cv.GetDescriptor().Signatures).To(HaveLen(1))
+			Expect(cv.GetDescriptor().References[0].Digest).NotTo(BeNil())
+			return cv
+		}
+
+		It("rejects filtering unsigned versions referenced by signed versions", func() {
+			src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+			defer Close(src, "source")
+			cv := sign(src)
+			defer Close(cv, "source cv")
+			// only the referencing version is signed
+			ref := Must(src.LookupComponentVersion(COMPONENT, VERSION))
+			ref.GetDescriptor().Signatures = nil
+			MustBeSuccessful(ref.Close())
+			tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+			defer Close(tgt, "target")
+
+			err := transfer.Transfer(cv, tgt, standard.Recursive(), standard.ResourcesByValue(), standard.Platforms("linux/arm64"))
+			Expect(err).To(MatchError(ContainSubstring("modifying referenced component versions (ref) invalidates the signatures of " + COMPONENT2 + ":" + VERSION)))
+			ExpectError(tgt.LookupComponentVersion(COMPONENT2, VERSION)).To(MatchError(ContainSubstring("not found")))
+		})
+
+		It("drops signatures along the reference chain on request", func() {
+			src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+			defer Close(src, "source")
+			cv := sign(src)
+			defer Close(cv, "source cv")
+			tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+			defer Close(tgt, "target")
+
+			MustBeSuccessful(transfer.Transfer(cv, tgt, standard.Recursive(), standard.ResourcesByValue(), standard.Platforms("linux/arm64", "darwin/arm64"), standard.DropSignatures()))
+
+			_, manifests := mainArtifact(tgt)
+			Expect(manifests).To(HaveLen(2))
+			tcv := Must(tgt.LookupComponentVersion(COMPONENT2, VERSION))
+			defer Close(tcv, "target cv")
+			Expect(tcv.GetDescriptor().Signatures).To(BeEmpty())
+			Expect(tcv.GetDescriptor().References[0].Digest).To(Equal(targetDigest(tgt, cv.GetDescriptor().References[0].Digest)))
+			Expect(tcv.GetDescriptor().References[0].Digest).NotTo(Equal(cv.GetDescriptor().References[0].Digest))
+			Expect(cv.GetDescriptor().Signatures).To(HaveLen(1))
+		})
+
+		It("updates reference digests of versions resumed by the journal", func() {
+			src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+			defer Close(src, "source")
+			cv := sign(src)
+			defer Close(cv, "source cv")
+			ref := Must(src.LookupComponentVersion(COMPONENT, VERSION))
+			ref.GetDescriptor().Signatures = nil
+			MustBeSuccessful(ref.Close())
+			tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+			defer Close(tgt, "target")
+
+			// the first run transfers the filtered version, but fails for the
+			// signed referencing version.
+			j := Must(journal.New(env, STATEFILE))
+			ExpectError(transfer.Transfer(cv, tgt, standard.Recursive(), standard.ResourcesByValue(), standard.Platforms("linux/arm64"), standard.Journal(j))).
+				To(MatchError(ContainSubstring("invalidates the signatures of " + COMPONENT2 + ":" + VERSION)))
+
+			// the second run resumes the filtered version from the journal,
+			// so it is not modified by this run.
+			j = Must(journal.New(env, STATEFILE))
+			p, buf := common.NewBufferedPrinter()
+			MustBeSuccessful(transfer.TransferWithHandler(p, cv, tgt, Must(standard.New(standard.Recursive(), standard.ResourcesByValue(), standard.Platforms("linux/arm64"), standard.DropSignatures(), standard.Journal(j)))))
+			Expect(buf.String()).To(ContainSubstring("already transferred according to journal"))
+
+			tcv := Must(tgt.LookupComponentVersion(COMPONENT2, VERSION))
+			defer Close(tcv, "target cv")
+			Expect(tcv.GetDescriptor().Signatures).To(BeEmpty())
+			Expect(tcv.GetDescriptor().References[0].Digest).To(Equal(targetDigest(tgt, cv.GetDescriptor().References[0].Digest)))
+			Expect(tcv.GetDescriptor().References[0].Digest).NotTo(Equal(cv.GetDescriptor().References[0].Digest))
+		})
+
+		It("keeps signatures of versions referencing unmodified versions", func() {
+			src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+			defer Close(src, "source")
+			cv := sign(src)
+			defer Close(cv, "source cv")
+			tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+			defer Close(tgt, "target")
+
+			MustBeSuccessful(transfer.Transfer(cv, tgt, standard.Recursive(), standard.ResourcesByValue(), standard.Platforms("linux/amd64", "linux/arm64", "darwin/arm64")))
+
+			tcv := Must(tgt.LookupComponentVersion(COMPONENT2, VERSION))
+			defer Close(tcv, "target cv")
+			Expect(tcv.GetDescriptor().Signatures).To(HaveLen(1))
+			Expect(tcv.GetDescriptor().References[0].Digest).To(Equal(cv.GetDescriptor().References[0].Digest))
+		})
+	})
+
+	It("rejects invalid platforms", func() {
+		ExpectError(standard.New(standard.Platforms("linux"))).To(MatchError("platform \"linux\" is invalid"))
+	})
+})
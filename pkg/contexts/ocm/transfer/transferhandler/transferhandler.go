@@ -9,6 +9,8 @@ import (
 	"github.com/open-component-model/ocm/pkg/contexts/ocm"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/journal"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/plan"
 	"github.com/open-component-model/ocm/pkg/errors"
 )
 
@@ -108,6 +110,39 @@ type TransferHandler interface {
 	HandleTransferSource(r ocm.SourceAccess, m cpi.AccessMethod, hint string, t ocm.ComponentVersionAccess) error
 }
 
+// ConcurrencyProvider is an optional interface for a TransferHandler.
+// It provides the maximum number of parallel transfer operations
+// used to transfer independent component version references and
+// artifacts. A value less than 2 results in a sequential transfer.
+type ConcurrencyProvider interface {
+	GetConcurrency() int
+}
+
+// VersionFinalizer is an optional interface for a TransferHandler.
+// It is called for a transferred component version after its references,
+// resources and sources have been transferred, just before it is added
+// to the target repository. It may adjust the target component descriptor.
+type VersionFinalizer interface {
+	FinalizeVersion(src ocm.ComponentVersionAccess, tgt ocm.ComponentVersionAccess) error
+}
+
+// JournalProvider is an optional interface for a TransferHandler.
+// It provides a journal used to record the progress of a transfer.
+// Component versions and resources already transferred according to
+// the journal are skipped, this way a failed transfer can be resumed.
+type JournalProvider interface {
+	GetJournal() *journal.Journal
+}
+
+// PlanProvider is an optional interface for a TransferHandler.
+// If it provides a plan, the transfer is executed in plan (dry-run) mode:
+// the decisions taken for component versions, resources and sources
+// are recorded in the plan, but nothing is written to the target
+// repository.
+type PlanProvider interface {
+	GetPlan() *plan.Plan
+}
+
 func ApplyOptions(set TransferOptions, opts ...TransferOption) error {
 	list := errors.ErrListf("transfer options")
 	for _, o := range opts {
@@ -0,0 +1,401 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	ocmutils "github.com/open-component-model/ocm/pkg/contexts/ocm/utils"
+	"github.com/open-component-model/ocm/pkg/optionutils"
+	"github.com/open-component-model/ocm/pkg/utils"
+)
+
+type ChangeKind string
+
+const (
+	ADDED    ChangeKind = "added"
+	REMOVED  ChangeKind = "removed"
+	MODIFIED ChangeKind = "modified"
+)
+
+// Change describes a single change of a field of a component version.
+// The path describes the field using the JSON field names of the
+// component descriptor. Elements of lists (resources, sources, references
+// and labels) are addressed by their identity.
+type Change struct {
+	Component string      `json:"component"`
+	Kind      ChangeKind  `json:"kind"`
+	Path      string      `json:"path"`
+	Old       interface{} `json:"old,omitempty"`
+	New       interface{} `json:"new,omitempty"`
+}
+
+func (c *Change) String() string {
+	switch c.Kind {
+	case ADDED:
+		return fmt.Sprintf("%s: + %s: %s", c.Component, c.Path, FormatValue(c.New))
+	case REMOVED:
+		return fmt.Sprintf("%s: - %s: %s", c.Component, c.Path, FormatValue(c.Old))
+	default:
+		return fmt.Sprintf("%s: ~ %s: %s -> %s", c.Component, c.Path, FormatValue(c.Old), FormatValue(c.New))
+	}
+}
+
+type Changes []*Change
+
+// FormatValue provides a compact string representation of a change value.
+// Strings are provided as they are, all other values as JSON.
+func FormatValue(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Diff provides a diff object for comparing component versions.
+// By default, only the given component versions are compared.
+// Optionally, the reference closures can be compared, also.
+func Diff(opts ...Option) *Options {
+	return optionutils.EvalOptions(opts...)
+}
+
+// For compares two component versions. With option Recursive
+// the component versions of both reference closures are compared by
+// component name, also.
+func (o *Options) For(old, new ocm.ComponentVersionAccess) (Changes, error) {
+	changes, err := o.Descriptors(old.GetDescriptor(), new.GetDescriptor())
+	if err != nil || !optionutils.AsBool(o.Recursive) {
+		return changes, err
+	}
+
+	oldClosure, err := o.closure(old)
+	if err != nil {
+		return nil, err
+	}
+	newClosure, err := o.closure(new)
+	if err != nil {
+		return nil, err
+	}
+	delete(oldClosure, common.VersionedElementKey(old))
+	delete(newClosure, common.VersionedElementKey(new))
+
+	oldVersions := versionsByName(oldClosure)
+	newVersions := versionsByName(newClosure)
+	names := utils.StringMapKeys(oldVersions)
+	for _, n := range utils.StringMapKeys(newVersions) {
+		if _, ok := oldVersions[n]; !ok {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+
+	for _, n := range names {
+		ov, nv := oldVersions[n], newVersions[n]
+		if len(ov) == 1 && len(nv) == 1 {
+			c, err := o.Descriptors(oldClosure[common.NewNameVersion(n, ov[0])], newClosure[common.NewNameVersion(n, nv[0])])
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, c...)
+			continue
+		}
+		for _, v := range ov {
+			if !slices.Contains(nv, v) {
+				changes = append(changes, &Change{Component: n, Kind: REMOVED, Path: "version", Old: v})
+			}
+		}
+		for _, v := range nv {
+			if !slices.Contains(ov, v) {
+				changes = append(changes, &Change{Component: n, Kind: ADDED, Path: "version", New: v})
+				continue
+			}
+			c, err := o.Descriptors(oldClosure[common.NewNameVersion(n, v)], newClosure[common.NewNameVersion(n, v)])
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, c...)
+		}
+	}
+	return changes, nil
+}
+
+func (o *Options) closure(cv ocm.ComponentVersionAccess) (common.NameVersionInfo[*compdesc.ComponentDescriptor], error) {
+	resolver := ocm.NewCompoundResolver(cv.Repository(), o.Resolver)
+	return ocmutils.Walk[*compdesc.ComponentDescriptor](nil, cv, resolver,
+		func(state common.WalkingState[*compdesc.ComponentDescriptor, ocm.ComponentVersionAccess]) (bool, error) {
+			state.Closure[common.VersionedElementKey(state.Context)] = state.Context.GetDescriptor().Copy()
+			return true, nil
+		})
+}
+
+func versionsByName(closure common.NameVersionInfo[*compdesc.ComponentDescriptor]) map[string][]string {
+	result := map[string][]string{}
+	for nv := range closure {
+		result[nv.GetName()] = append(result[nv.GetName()], nv.GetVersion())
+	}
+	for _, v := range result {
+		sort.Strings(v)
+	}
+	return result
+}
+
+// Descriptors compares two component descriptors.
+func (o *Options) Descriptors(old, new *compdesc.ComponentDescriptor) (Changes, error) {
+	d := &differ{component: new.GetName()}
+
+	meta := func(cd *compdesc.ComponentDescriptor) interface{} {
+		return map[string]interface{}{
+			"name":     cd.GetName(),
+			"version":  cd.GetVersion(),
+			"provider": cd.Provider,
+			"labels":   cd.Labels,
+		}
+	}
+	if err := d.compare("", meta(old), meta(new)); err != nil {
+		return nil, err
+	}
+
+	ignore := optionutils.AsBool(o.IgnoreAccess)
+	if err := d.elements("sources", old.Sources, new.Sources, ignore); err != nil {
+		return nil, err
+	}
+	if err := d.elements("resources", old.Resources, new.Resources, ignore); err != nil {
+		return nil, err
+	}
+	if err := d.elements("componentReferences", old.References, new.References, false); err != nil {
+		return nil, err
+	}
+	return d.changes, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type differ struct {
+	component string
+	changes   Changes
+}
+
+func (d *differ) add(kind ChangeKind, path string, old, new interface{}) {
+	d.changes = append(d.changes, &Change{Component: d.component, Kind: kind, Path: path, Old: old, New: new})
+}
+
+func (d *differ) compare(path string, old, new interface{}) error {
+	o, err := generic(old)
+	if err != nil {
+		return err
+	}
+	n, err := generic(new)
+	if err != nil {
+		return err
+	}
+	d.walk(path, o, n)
+	return nil
+}
+
+// elements compares the elements of two element lists matched by their identity.
+func (d *differ) elements(field string, old, new compdesc.ElementAccessor, ignoreAccess bool) error {
+	oldElems, oldKeys, err := elementMap(old, ignoreAccess)
+	if err != nil {
+		return err
+	}
+	newElems, newKeys, err := elementMap(new, ignoreAccess)
+	if err != nil {
+		return err
+	}
+	for _, k := range oldKeys {
+		if _, ok := newElems[k]; !ok {
+			d.add(REMOVED, field+k, oldElems[k], nil)
+		}
+	}
+	for _, k := range newKeys {
+		if o, ok := oldElems[k]; ok {
+			d.walk(field+k, o, newElems[k])
+		} else {
+			d.add(ADDED, field+k, nil, newElems[k])
+		}
+	}
+	return nil
+}
+
+func elementMap(acc compdesc.ElementAccessor, ignoreAccess bool) (map[string]interface{}, []string, error) {
+	elems := map[string]interface{}{}
+	var keys []string
+	for i := 0; i < acc.Len(); i++ {
+		e := acc.Get(i)
+		g, err := generic(e)
+		if err != nil {
+			return nil, nil, err
+		}
+		if m, ok := g.(map[string]interface{}); ok && ignoreAccess {
+			delete(m, "access")
+		}
+		k := identityKey(e.GetMeta().GetIdentity(acc))
+		elems[k] = g
+		keys = append(keys, k)
+	}
+	return elems, keys, nil
+}
+
+// identityKey provides the path element for an element identity
+// with the name attribute first.
+func identityKey(id metav1.Identity) string {
+	attrs := []string{fmt.Sprintf("%s=%s", compdesc.SystemIdentityName, id[compdesc.SystemIdentityName])}
+	for _, k := range utils.StringMapKeys(id) {
+		if k != compdesc.SystemIdentityName {
+			attrs = append(attrs, fmt.Sprintf("%s=%s", k, id[k]))
+		}
+	}
+	return "[" + strings.Join(attrs, ",") + "]"
+}
+
+func (d *differ) walk(path string, old, new interface{}) {
+	if strings.HasSuffix(path, "labels") {
+		// labels are compared by name, a missing label list
+		// is handled like an empty one.
+		if old == nil {
+			old = map[string]interface{}{}
+		}
+		if new == nil {
+			new = map[string]interface{}{}
+		}
+	}
+	switch o := old.(type) {
+	case map[string]interface{}:
+		if n, ok := new.(map[string]interface{}); ok {
+			for _, k := range utils.StringMapKeys(o) {
+				if _, ok := n[k]; !ok && k != "labels" {
+					d.add(REMOVED, join(path, k), o[k], nil)
+				} else {
+					d.walk(join(path, k), o[k], n[k])
+				}
+			}
+			for _, k := range utils.StringMapKeys(n) {
+				if _, ok := o[k]; !ok {
+					if k == "labels" {
+						d.walk(join(path, k), nil, n[k])
+					} else {
+						d.add(ADDED, join(path, k), nil, n[k])
+					}
+				}
+			}
+			return
+		}
+	case []interface{}:
+		if n, ok := new.([]interface{}); ok {
+			for i := 0; i < len(o) || i < len(n); i++ {
+				p := fmt.Sprintf("%s[%d]", path, i)
+				switch {
+				case i >= len(n):
+					d.add(REMOVED, p, o[i], nil)
+				case i >= len(o):
+					d.add(ADDED, p, nil, n[i])
+				default:
+					d.walk(p, o[i], n[i])
+				}
+			}
+			return
+		}
+	}
+	if !reflect.DeepEqual(old, new) {
+		switch {
+		case old == nil:
+			d.add(ADDED, path, nil, new)
+		case new == nil:
+			d.add(REMOVED, path, old, nil)
+		default:
+			d.add(MODIFIED, path, old, new)
+		}
+	}
+}
+
+func join(path, key string) string {
+	if path == "" || strings.HasPrefix(key, "[") {
+		return path + key
+	}
+	return path + "." + key
+}
+
+// generic provides the generic JSON representation of a value.
+// Label lists are mapped to maps using the label name as key
+// to match labels by name.
+func generic(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var g interface{}
+	err = json.Unmarshal(data, &g)
+	if err != nil {
+		return nil, err
+	}
+	return normalizeLabels(g), nil
+}
+
+func normalizeLabels(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, e := range t {
+			if k == "labels" {
+				if m := labelMap(e); m != nil {
+					t[k] = m
+					continue
+				}
+			}
+			t[k] = normalizeLabels(e)
+		}
+	case []interface{}:
+		for i, e := range t {
+			t[i] = normalizeLabels(e)
+		}
+	}
+	return v
+}
+
+// labelMap maps a label list to a map using the label name
+// as path element. If the list is no valid label list, nil is returned.
+func labelMap(v interface{}) map[string]interface{} {
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil
+	}
+	labels := map[string]interface{}{}
+	for _, e := range list {
+		m, ok := e.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		n, ok := m["name"].(string)
+		if !ok {
+			return nil
+		}
+		l := map[string]interface{}{}
+		for k, f := range m {
+			if k != "name" {
+				l[k] = normalizeLabels(f)
+			}
+		}
+		labels["["+n+"]"] = l
+	}
+	return labels
+}
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package diff_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/env/builder"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/utils/diff"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const (
+	ARCH     = "/tmp/ctf"
+	PROVIDER = "acme.org"
+	COMP     = "acme.org/comp"
+	SUB      = "acme.org/sub"
+	V1       = "1.0.0"
+	V2       = "2.0.0"
+)
+
+var _ = Describe("diff", func() {
+	var env *Builder
+	var repo ocm.Repository
+	var cv1, cv2 ocm.ComponentVersionAccess
+
+	BeforeEach(func() {
+		env = NewBuilder()
+
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.ComponentVersion(SUB, V1, func() {
+				env.Provider(PROVIDER)
+			})
+			env.ComponentVersion(SUB, V2, func() {
+				env.Provider(PROVIDER)
+				env.Label("stage", "test")
+			})
+			env.ComponentVersion(COMP, V1, func() {
+				env.Provider(PROVIDER)
+				env.Label("stage", "dev")
+				env.Resource("text", V1, resourcetypes.PLAIN_TEXT, metav1.LocalRelation, func() {
+					env.BlobStringData(mime.MIME_TEXT, "old")
+				})
+				env.Resource("removed", V1, resourcetypes.PLAIN_TEXT, metav1.LocalRelation, func() {
+					env.ExtraIdentity("platform", "linux")
+					env.BlobStringData(mime.MIME_TEXT, "removed")
+				})
+				env.Reference("sub", SUB, V1)
+			})
+			env.ComponentVersion(COMP, V2, func() {
+				env.Provider(PROVIDER)
+				env.Label("stage", "prod")
+				env.Label("owner", "team")
+				env.Resource("text", V2, resourcetypes.PLAIN_TEXT, metav1.LocalRelation, func() {
+					env.BlobStringData(mime.MIME_TEXT, "new")
+				})
+				env.Reference("sub", SUB, V2)
+			})
+		})
+
+		repo = Must(ctf.Open(env, accessobj.ACC_READONLY, ARCH, 0, env))
+		cv1 = Must(repo.LookupComponentVersion(COMP, V1))
+		cv2 = Must(repo.LookupComponentVersion(COMP, V2))
+	})
+
+	AfterEach(func() {
+		MustBeSuccessful(cv1.Close())
+		MustBeSuccessful(cv2.Close())
+		MustBeSuccessful(repo.Close())
+		env.Cleanup()
+	})
+
+	It("finds no changes for identical versions", func() {
+		Expect(Must(diff.Diff(diff.Recursive()).For(cv1, cv1))).To(BeEmpty())
+	})
+
+	It("compares component versions", func() {
+		oldDigest := digest.FromString("old").Encoded()
+		newDigest := digest.FromString("new").Encoded()
+		changes := Must(diff.Diff().For(cv1, cv2))
+		Expect(changes).To(HaveLen(8))
+		Expect(changes[:3]).To(Equal(diff.Changes{
+			{Component: COMP, Kind: diff.MODIFIED, Path: "labels[stage].value", Old: "dev", New: "prod"},
+			{Component: COMP, Kind: diff.ADDED, Path: "labels[owner]", New: map[string]interface{}{"value": "team"}},
+			{Component: COMP, Kind: diff.MODIFIED, Path: "version", Old: V1, New: V2},
+		}))
+		Expect(changes[3].Kind).To(Equal(diff.REMOVED))
+		Expect(changes[3].Path).To(Equal("resources[name=removed,platform=linux]"))
+		Expect(changes[4:]).To(Equal(diff.Changes{
+			{Component: COMP, Kind: diff.MODIFIED, Path: "resources[name=text].access.localReference", Old: "sha256:" + oldDigest, New: "sha256:" + newDigest},
+			{Component: COMP, Kind: diff.MODIFIED, Path: "resources[name=text].digest.value", Old: oldDigest, New: newDigest},
+			{Component: COMP, Kind: diff.MODIFIED, Path: "resources[name=text].version", Old: V1, New: V2},
+			{Component: COMP, Kind: diff.MODIFIED, Path: "componentReferences[name=sub].version", Old: V1, New: V2},
+		}))
+	})
+
+	It("ignores access changes", func() {
+		changes := Must(diff.Diff(diff.IgnoreAccess()).For(cv1, cv2))
+		Expect(changes).To(HaveLen(7))
+		for _, c := range changes {
+			Expect(c.Path).NotTo(ContainSubstring(".access"))
+		}
+	})
+
+	It("compares reference closures", func() {
+		changes := Must(diff.Diff(diff.Recursive(), diff.IgnoreAccess()).For(cv1, cv2))
+		Expect(changes).To(HaveLen(9))
+		Expect(changes[7:]).To(Equal(diff.Changes{
+			{Component: SUB, Kind: diff.ADDED, Path: "labels[stage]", New: map[string]interface{}{"value": "test"}},
+			{Component: SUB, Kind: diff.MODIFIED, Path: "version", Old: V1, New: V2},
+		}))
+	})
+})
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package diff
+
+import (
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/optionutils"
+	"github.com/open-component-model/ocm/pkg/utils"
+)
+
+type Option = optionutils.Option[*Options]
+
+type Options struct {
+	IgnoreAccess *bool
+	Recursive    *bool
+	Resolver     ocm.ComponentVersionResolver
+}
+
+var _ Option = (*Options)(nil)
+
+func (o *Options) ApplyTo(opts *Options) {
+	optionutils.ApplyOption(o.IgnoreAccess, &opts.IgnoreAccess)
+	optionutils.ApplyOption(o.Recursive, &opts.Recursive)
+	if o.Resolver != nil {
+		opts.Resolver = o.Resolver
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type ignoreAccess bool
+
+// IgnoreAccess ignores changes of the access specifications of
+// resources and sources, like they are caused by a transfer.
+func IgnoreAccess(b ...bool) Option {
+	return ignoreAccess(utils.OptionalDefaultedBool(true, b...))
+}
+
+func (i ignoreAccess) ApplyTo(t *Options) {
+	t.IgnoreAccess = optionutils.PointerTo(bool(i))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type recursive bool
+
+// Recursive compares the component versions of the reference closures, also.
+func Recursive(b ...bool) Option {
+	return recursive(utils.OptionalDefaultedBool(true, b...))
+}
+
+func (r recursive) ApplyTo(t *Options) {
+	t.Recursive = optionutils.PointerTo(bool(r))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type resolver struct {
+	resolver ocm.ComponentVersionResolver
+}
+
+// Resolver provides an additional resolver used to look up
+// referenced component versions.
+func Resolver(r ocm.ComponentVersionResolver) Option {
+	return resolver{r}
+}
+
+func (r resolver) ApplyTo(t *Options) {
+	t.Resolver = r.resolver
+}
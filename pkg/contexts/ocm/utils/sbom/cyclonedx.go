@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom
+
+import (
+	"encoding/json"
+
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	"github.com/open-component-model/ocm/pkg/version"
+)
+
+const CYCLONEDX_SPEC_VERSION = "1.5"
+
+type cdxDocument struct {
+	BOMFormat   string          `json:"bomFormat"`
+	SpecVersion string          `json:"specVersion"`
+	Version     int             `json:"version"`
+	Metadata    cdxMetadata     `json:"metadata"`
+	Components  []*cdxComponent `json:"components,omitempty"`
+	// Dependencies describes the component references
+	// and the resources provided by a component version.
+	Dependencies []*cdxDependency `json:"dependencies,omitempty"`
+}
+
+type cdxMetadata struct {
+	Timestamp string        `json:"timestamp"`
+	Tools     cdxTools      `json:"tools"`
+	Component *cdxComponent `json:"component,omitempty"`
+}
+
+type cdxTools struct {
+	Components []*cdxComponent `json:"components"`
+}
+
+type cdxComponent struct {
+	BOMRef     string         `json:"bom-ref,omitempty"`
+	Type       string         `json:"type"`
+	Name       string         `json:"name"`
+	Version    string         `json:"version,omitempty"`
+	Supplier   *cdxSupplier   `json:"supplier,omitempty"`
+	Hashes     []*cdxHash     `json:"hashes,omitempty"`
+	Properties []*cdxProperty `json:"properties,omitempty"`
+	// Components contains the components of an embedded SBOM.
+	Components []json.RawMessage `json:"components,omitempty"`
+}
+
+type cdxSupplier struct {
+	Name string `json:"name"`
+}
+
+type cdxHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type cdxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type cdxDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
+// cdxEmbedded is used to extract the components of an
+// embedded CycloneDX document.
+type cdxEmbedded struct {
+	BOMFormat  string            `json:"bomFormat"`
+	Components []json.RawMessage `json:"components"`
+}
+
+func cycloneDX(c closure, o *Options) (*cdxDocument, error) {
+	doc := &cdxDocument{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: CYCLONEDX_SPEC_VERSION,
+		Version:     1,
+		Metadata: cdxMetadata{
+			Timestamp: o.timestamp(),
+			Tools: cdxTools{
+				Components: []*cdxComponent{{Type: "application", Name: "ocm", Version: version.Get().String()}},
+			},
+		},
+	}
+
+	for i, e := range c {
+		cd := e.descriptor
+		ref := componentRef(cd.GetName(), cd.GetVersion())
+		comp := &cdxComponent{
+			BOMRef:     ref,
+			Type:       "application",
+			Name:       cd.GetName(),
+			Version:    cd.GetVersion(),
+			Properties: cdxProperties(labelProperties(cd.Labels)),
+		}
+		if cd.Provider.Name != "" {
+			comp.Supplier = &cdxSupplier{Name: string(cd.Provider.Name)}
+		}
+		if i == 0 {
+			doc.Metadata.Component = comp
+		} else {
+			doc.Components = append(doc.Components, comp)
+		}
+
+		dep := &cdxDependency{Ref: ref}
+		for j := range cd.Resources {
+			r := &cd.Resources[j]
+			res, err := cdxResource(cd, r, e.embedded[j])
+			if err != nil {
+				return nil, err
+			}
+			doc.Components = append(doc.Components, res)
+			dep.DependsOn = append(dep.DependsOn, res.BOMRef)
+		}
+		for _, r := range cd.References {
+			dep.DependsOn = append(dep.DependsOn, componentRef(r.ComponentName, r.Version))
+		}
+		doc.Dependencies = append(doc.Dependencies, dep)
+	}
+	return doc, nil
+}
+
+func cdxResource(cd *compdesc.ComponentDescriptor, r *compdesc.Resource, embedded []byte) (*cdxComponent, error) {
+	props, err := resourceProperties(r)
+	if err != nil {
+		return nil, err
+	}
+	comp := &cdxComponent{
+		BOMRef:     resourceRef(cd, r.GetIdentity(cd.Resources)),
+		Type:       cdxType(r.GetType()),
+		Name:       r.GetName(),
+		Version:    r.GetVersion(),
+		Properties: cdxProperties(props),
+	}
+	if alg, value := digest(r.Digest, CYCLONEDX); alg != "" {
+		comp.Hashes = []*cdxHash{{Alg: alg, Content: value}}
+	}
+	if embedded != nil {
+		var bom cdxEmbedded
+		// SBOMs in other formats are ignored.
+		if json.Unmarshal(embedded, &bom) == nil && bom.BOMFormat == "CycloneDX" {
+			comp.Components = bom.Components
+		}
+	}
+	return comp, nil
+}
+
+// cdxType maps OCM resource types to CycloneDX component types.
+func cdxType(t string) string {
+	switch t {
+	case resourcetypes.OCI_IMAGE:
+		return "container"
+	case resourcetypes.EXECUTABLE, resourcetypes.OCM_PLUGIN:
+		return "application"
+	default:
+		return "file"
+	}
+}
+
+func cdxProperties(props []property) []*cdxProperty {
+	var result []*cdxProperty
+	for _, p := range props {
+		result = append(result, &cdxProperty{Name: p.name, Value: p.value})
+	}
+	return result
+}
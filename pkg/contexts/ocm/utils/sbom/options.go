@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom
+
+import (
+	"time"
+
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/optionutils"
+	"github.com/open-component-model/ocm/pkg/utils"
+)
+
+type Option = optionutils.Option[*Options]
+
+type Options struct {
+	Embed     *bool
+	Timestamp *time.Time
+	Resolver  ocm.ComponentVersionResolver
+}
+
+var _ Option = (*Options)(nil)
+
+func (o *Options) ApplyTo(opts *Options) {
+	optionutils.ApplyOption(o.Embed, &opts.Embed)
+	optionutils.ApplyOption(o.Timestamp, &opts.Timestamp)
+	if o.Resolver != nil {
+		opts.Resolver = o.Resolver
+	}
+}
+
+func (o *Options) timestamp() string {
+	t := time.Now()
+	if o.Timestamp != nil {
+		t = *o.Timestamp
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type embed bool
+
+// Embed embeds the content of SBOMs found as resources
+// of type sbom into the generated document.
+func Embed(b ...bool) Option {
+	return embed(utils.OptionalDefaultedBool(true, b...))
+}
+
+func (e embed) ApplyTo(t *Options) {
+	t.Embed = optionutils.PointerTo(bool(e))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type timestamp time.Time
+
+// Timestamp sets the creation time of the generated document.
+// By default, the actual time is used.
+func Timestamp(t time.Time) Option {
+	return timestamp(t)
+}
+
+func (s timestamp) ApplyTo(t *Options) {
+	t.Timestamp = optionutils.PointerTo(time.Time(s))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type resolver struct {
+	resolver ocm.ComponentVersionResolver
+}
+
+// Resolver provides an additional resolver used to look up
+// referenced component versions.
+func Resolver(r ocm.ComponentVersionResolver) Option {
+	return resolver{r}
+}
+
+func (r resolver) ApplyTo(t *Options) {
+	t.Resolver = r.resolver
+}
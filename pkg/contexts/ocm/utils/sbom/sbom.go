@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	ocmutils "github.com/open-component-model/ocm/pkg/contexts/ocm/utils"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/optionutils"
+	"github.com/open-component-model/ocm/pkg/utils"
+)
+
+const (
+	CYCLONEDX = "cyclonedx"
+	SPDX      = "spdx"
+)
+
+// Formats lists the supported SBOM formats.
+var Formats = []string{CYCLONEDX, SPDX}
+
+// Generate provides a software bill of materials for the reference closure
+// of a component version as JSON document in the given format.
+// Component versions and their resources are mapped to SBOM components
+// (or packages), component references to dependencies. With option Embed
+// the content of SBOMs found as resources of type sbom is embedded,
+// if it uses the requested format.
+func Generate(cv ocm.ComponentVersionAccess, format string, opts ...Option) ([]byte, error) {
+	var doc interface{}
+
+	o := optionutils.EvalOptions(opts...)
+	c, err := o.closure(cv)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(format) {
+	case CYCLONEDX:
+		doc, err = cycloneDX(c, o)
+	case SPDX:
+		doc, err = spdx(c, o)
+	default:
+		return nil, errors.ErrNotSupported("sbom format", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// element describes a component version of the closure together with
+// the content of the SBOM resources to embed, indexed by the resource index.
+type element struct {
+	descriptor *compdesc.ComponentDescriptor
+	embedded   map[int][]byte
+}
+
+// closure is the list of component versions of a reference closure
+// with the root component version first.
+type closure []*element
+
+func (o *Options) closure(cv ocm.ComponentVersionAccess) (closure, error) {
+	embed := optionutils.AsBool(o.Embed)
+	resolver := ocm.NewCompoundResolver(cv.Repository(), o.Resolver)
+	found, err := ocmutils.Walk[*element](nil, cv, resolver,
+		func(state common.WalkingState[*element, ocm.ComponentVersionAccess]) (bool, error) {
+			e := &element{descriptor: state.Context.GetDescriptor().Copy()}
+			if embed {
+				for i, r := range state.Context.GetResources() {
+					if r.Meta().GetType() != resourcetypes.SBOM {
+						continue
+					}
+					data, err := ocmutils.GetResourceData(r)
+					if err != nil {
+						return false, errors.Wrapf(err, "cannot read sbom resource %s", r.Meta().GetIdentity(e.descriptor.Resources))
+					}
+					if e.embedded == nil {
+						e.embedded = map[int][]byte{}
+					}
+					e.embedded[i] = data
+				}
+			}
+			state.Closure[common.VersionedElementKey(state.Context)] = e
+			return true, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	root := common.VersionedElementKey(cv)
+	keys := utils.MapKeys(found)
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Compare(keys[j]) < 0 })
+	result := closure{found[root]}
+	for _, k := range keys {
+		if k != root {
+			result = append(result, found[k])
+		}
+	}
+	return result, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// componentRef provides a unique reference name for a component version.
+func componentRef(name, version string) string {
+	return fmt.Sprintf("ocm:%s:%s", name, version)
+}
+
+// resourceRef provides a unique reference name for a resource
+// of a component version.
+func resourceRef(cd *compdesc.ComponentDescriptor, id metav1.Identity) string {
+	attrs := []string{id[compdesc.SystemIdentityName]}
+	for _, k := range utils.StringMapKeys(id) {
+		if k != compdesc.SystemIdentityName {
+			attrs = append(attrs, fmt.Sprintf("%s=%s", k, id[k]))
+		}
+	}
+	return componentRef(cd.GetName(), cd.GetVersion()) + "/" + strings.Join(attrs, ",")
+}
+
+// property describes an OCM specific attribute of an SBOM element.
+type property struct {
+	name  string
+	value string
+}
+
+// labelProperties maps labels to properties using the
+// JSON representation of the label value.
+func labelProperties(labels metav1.Labels) []property {
+	var props []property
+	for _, l := range labels {
+		props = append(props, property{"ocm:label:" + l.Name, string(l.Value)})
+	}
+	return props
+}
+
+func resourceProperties(r *compdesc.Resource) ([]property, error) {
+	props := []property{
+		{"ocm:type", r.GetType()},
+		{"ocm:relation", string(r.Relation)},
+	}
+	if r.Access != nil {
+		data, err := json.Marshal(r.Access)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot marshal access of resource %s", r.Name)
+		}
+		props = append(props, property{"ocm:access", string(data)})
+	}
+	return append(props, labelProperties(r.Labels)...), nil
+}
+
+// hashAlgorithms maps the digest algorithms used by OCM to
+// the algorithm names used by CycloneDX and SPDX.
+var hashAlgorithms = map[string][2]string{
+	"SHA-1":   {"SHA-1", "SHA1"},
+	"SHA-256": {"SHA-256", "SHA256"},
+	"SHA-384": {"SHA-384", "SHA384"},
+	"SHA-512": {"SHA-512", "SHA512"},
+}
+
+// digest provides the hash algorithm and value of a resource digest
+// for the given format. Digests without a known hash algorithm
+// are omitted.
+func digest(d *metav1.DigestSpec, format string) (string, string) {
+	if d == nil || d.HashAlgorithm == metav1.NoDigest || d.Value == "" {
+		return "", ""
+	}
+	names, ok := hashAlgorithms[strings.ToUpper(d.HashAlgorithm)]
+	if !ok {
+		return "", ""
+	}
+	if format == SPDX {
+		return names[1], d.Value
+	}
+	return names[0], d.Value
+}
@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom_test
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/env/builder"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/utils/sbom"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const (
+	ARCH     = "/tmp/ctf"
+	PROVIDER = "acme.org"
+	COMP     = "acme.org/comp"
+	SUB      = "acme.org/sub"
+	VERSION  = "1.0.0"
+)
+
+const CYCLONEDX_SBOM = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "components": [ { "type": "library", "name": "zlib", "version": "1.3" } ]
+}`
+
+const SPDX_SBOM = `{
+  "spdxVersion": "SPDX-2.3",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "packages": [ { "SPDXID": "SPDXRef-zlib", "name": "zlib", "versionInfo": "1.3", "licenseConcluded": "Zlib" } ],
+  "files": [ { "SPDXID": "SPDXRef-File-zlib.h", "fileName": "./zlib.h" } ],
+  "relationships": [
+    { "spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-zlib" },
+    { "spdxElementId": "SPDXRef-zlib", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "NOASSERTION" },
+    { "spdxElementId": "SPDXRef-zlib", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-File-zlib.h" },
+    { "spdxElementId": "SPDXRef-zlib", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "DocumentRef-libc:SPDXRef-libc" }
+  ]
+}`
+
+func generate(cv ocm.ComponentVersionAccess, format string, opts ...sbom.Option) map[string]interface{} {
+	var doc map[string]interface{}
+	data := Must(sbom.Generate(cv, format, append(opts, sbom.Timestamp(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))...))
+	MustBeSuccessful(json.Unmarshal(data, &doc))
+	return doc
+}
+
+var _ = Describe("sbom", func() {
+	var env *Builder
+	var repo ocm.Repository
+	var cv ocm.ComponentVersionAccess
+	textDigest := digest.FromString("text").Encoded()
+
+	BeforeEach(func() {
+		env = NewBuilder()
+
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.ComponentVersion(SUB, VERSION, func() {
+				env.Provider(PROVIDER)
+				env.Resource("cyclonedx", VERSION, resourcetypes.SBOM, metav1.LocalRelation, func() {
+					env.BlobStringData(mime.MIME_JSON, CYCLONEDX_SBOM)
+				})
+				env.Resource("spdx", VERSION, resourcetypes.SBOM, metav1.LocalRelation, func() {
+					env.BlobStringData(mime.MIME_JSON, SPDX_SBOM)
+				})
+			})
+			env.ComponentVersion(COMP, VERSION, func() {
+				env.Provider(PROVIDER)
+				env.Label("stage", "dev")
+				env.Resource("text", VERSION, resourcetypes.PLAIN_TEXT, metav1.LocalRelation, func() {
+					env.BlobStringData(mime.MIME_TEXT, "text")
+				})
+				env.Reference("sub", SUB, VERSION)
+			})
+		})
+
+		repo = Must(ctf.Open(env, accessobj.ACC_READONLY, ARCH, 0, env))
+		cv = Must(repo.LookupComponentVersion(COMP, VERSION))
+	})
+
+	AfterEach(func() {
+		MustBeSuccessful(cv.Close())
+		MustBeSuccessful(repo.Close())
+		env.Cleanup()
+	})
+
+	It("rejects unknown formats", func() {
+		_, err := sbom.Generate(cv, "unknown")
+		Expect(err).To(MatchError(ContainSubstring("unknown")))
+	})
+
+	Context("cyclonedx", func() {
+		It("maps the closure", func() {
+			doc := generate(cv, sbom.CYCLONEDX)
+			Expect(doc["bomFormat"]).To(Equal("CycloneDX"))
+
+			meta := doc["metadata"].(map[string]interface{})
+			Expect(meta["timestamp"]).To(Equal("2024-01-01T00:00:00Z"))
+			Expect(meta["component"]).To(Equal(map[string]interface{}{
+				"bom-ref":    "ocm:" + COMP + ":" + VERSION,
+				"type":       "application",
+				"name":       COMP,
+				"version":    VERSION,
+				"supplier":   map[string]interface{}{"name": PROVIDER},
+				"properties": []interface{}{map[string]interface{}{"name": "ocm:label:stage", "value": `"dev"`}},
+			}))
+
+			comps := doc["components"].([]interface{})
+			Expect(comps).To(HaveLen(4))
+			text := comps[0].(map[string]interface{})
+			Expect(text["bom-ref"]).To(Equal("ocm:" + COMP + ":" + VERSION + "/text"))
+			Expect(text["type"]).To(Equal("file"))
+			Expect(text["hashes"]).To(Equal([]interface{}{map[string]interface{}{"alg": "SHA-256", "content": textDigest}}))
+			Expect(text).NotTo(HaveKey("components"))
+			Expect(comps[1].(map[string]interface{})["bom-ref"]).To(Equal("ocm:" + SUB + ":" + VERSION))
+
+			Expect(doc["dependencies"]).To(Equal([]interface{}{
+				map[string]interface{}{
+					"ref":       "ocm:" + COMP + ":" + VERSION,
+					"dependsOn": []interface{}{"ocm:" + COMP + ":" + VERSION + "/text", "ocm:" + SUB + ":" + VERSION},
+				},
+				map[string]interface{}{
+					"ref":       "ocm:" + SUB + ":" + VERSION,
+					"dependsOn": []interface{}{"ocm:" + SUB + ":" + VERSION + "/cyclonedx", "ocm:" + SUB + ":" + VERSION + "/spdx"},
+				},
+			}))
+		})
+
+		It("embeds sboms", func() {
+			doc := generate(cv, sbom.CYCLONEDX, sbom.Embed())
+			comps := doc["components"].([]interface{})
+			Expect(comps[2].(map[string]interface{})["components"]).To(Equal([]interface{}{
+				map[string]interface{}{"type": "library", "name": "zlib", "version": "1.3"},
+			}))
+			Expect(comps[3]).NotTo(HaveKey("components"))
+		})
+	})
+
+	Context("spdx", func() {
+		It("maps the closure", func() {
+			doc := generate(cv, sbom.SPDX)
+			Expect(doc["spdxVersion"]).To(Equal("SPDX-2.3"))
+			Expect(doc["documentNamespace"]).To(Equal("https://ocm.software/spdx/" + COMP + "/" + VERSION))
+
+			pkgs := doc["packages"].([]interface{})
+			Expect(pkgs).To(HaveLen(5))
+			text := pkgs[1].(map[string]interface{})
+			Expect(text["SPDXID"]).To(Equal("SPDXRef-Component-0-Resource-0"))
+			Expect(text["checksums"]).To(Equal([]interface{}{map[string]interface{}{"algorithm": "SHA256", "checksumValue": textDigest}}))
+			Expect(pkgs[0].(map[string]interface{})["supplier"]).To(Equal("Organization: " + PROVIDER))
+
+			Expect(doc["relationships"]).To(ConsistOf(
+				map[string]interface{}{"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-Component-0"},
+				map[string]interface{}{"spdxElementId": "SPDXRef-Component-0", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-Component-0-Resource-0"},
+				map[string]interface{}{"spdxElementId": "SPDXRef-Component-0", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-Component-1"},
+				map[string]interface{}{"spdxElementId": "SPDXRef-Component-1", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-Component-1-Resource-0"},
+				map[string]interface{}{"spdxElementId": "SPDXRef-Component-1", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-Component-1-Resource-1"},
+			))
+		})
+
+		It("embeds sboms", func() {
+			doc := generate(cv, sbom.SPDX, sbom.Embed())
+			pkgs := doc["packages"].([]interface{})
+			Expect(pkgs).To(HaveLen(6))
+			Expect(pkgs[5]).To(Equal(map[string]interface{}{
+				"SPDXID":           "SPDXRef-Component-1-Resource-1-zlib",
+				"name":             "zlib",
+				"versionInfo":      "1.3",
+				"licenseConcluded": "Zlib",
+			}))
+			var embedded []interface{}
+			for _, r := range doc["relationships"].([]interface{}) {
+				if strings.HasPrefix(r.(map[string]interface{})["relatedSpdxElement"].(string), "SPDXRef-Component-1-Resource-1-") ||
+					strings.HasPrefix(r.(map[string]interface{})["spdxElementId"].(string), "SPDXRef-Component-1-Resource-1-") {
+					embedded = append(embedded, r)
+				}
+			}
+			Expect(embedded).To(ConsistOf(
+				map[string]interface{}{"spdxElementId": "SPDXRef-Component-1-Resource-1", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-Component-1-Resource-1-zlib"},
+				map[string]interface{}{"spdxElementId": "SPDXRef-Component-1-Resource-1-zlib", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "NOASSERTION"},
+			))
+		})
+	})
+})
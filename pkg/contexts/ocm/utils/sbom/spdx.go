@@ -0,0 +1,263 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/resourcetypes"
+	"github.com/open-component-model/ocm/pkg/version"
+)
+
+const (
+	SPDX_VERSION   = "SPDX-2.3"
+	SPDX_NAMESPACE = "https://ocm.software/spdx"
+	SPDX_REF       = "SPDXRef-"
+	SPDX_DOCUMENT  = SPDX_REF + "DOCUMENT"
+	NOASSERTION    = "NOASSERTION"
+	NONE           = "NONE"
+)
+
+type spdxDocument struct {
+	SPDXVersion       string           `json:"spdxVersion"`
+	DataLicense       string           `json:"dataLicense"`
+	SPDXID            string           `json:"SPDXID"`
+	Name              string           `json:"name"`
+	DocumentNamespace string           `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo `json:"creationInfo"`
+	// Packages contains *spdxPackage entries and the
+	// verbatim packages of embedded documents.
+	Packages      []interface{}       `json:"packages,omitempty"`
+	Relationships []*spdxRelationship `json:"relationships,omitempty"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	SPDXID                string            `json:"SPDXID"`
+	Name                  string            `json:"name"`
+	VersionInfo           string            `json:"versionInfo,omitempty"`
+	Supplier              string            `json:"supplier,omitempty"`
+	DownloadLocation      string            `json:"downloadLocation"`
+	FilesAnalyzed         bool              `json:"filesAnalyzed"`
+	PrimaryPackagePurpose string            `json:"primaryPackagePurpose,omitempty"`
+	Checksums             []*spdxChecksum   `json:"checksums,omitempty"`
+	Annotations           []*spdxAnnotation `json:"annotations,omitempty"`
+	ExternalRefs          []*spdxExternal   `json:"externalRefs,omitempty"`
+}
+
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+// spdxAnnotation is used to carry OCM specific properties,
+// because SPDX packages do not support generic properties.
+type spdxAnnotation struct {
+	Annotator      string `json:"annotator"`
+	AnnotationDate string `json:"annotationDate"`
+	AnnotationType string `json:"annotationType"`
+	Comment        string `json:"comment"`
+}
+
+type spdxExternal struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+// spdxEmbedded is used to extract the packages and relationships
+// of an embedded SPDX document.
+type spdxEmbedded struct {
+	SPDXVersion   string                       `json:"spdxVersion"`
+	Packages      []map[string]json.RawMessage `json:"packages"`
+	Relationships []*spdxRelationship          `json:"relationships"`
+}
+
+type spdxBuilder struct {
+	doc     *spdxDocument
+	creator string
+	created string
+}
+
+func spdx(c closure, o *Options) (*spdxDocument, error) {
+	root := c[0].descriptor
+	creator := "Tool: ocm-" + version.Get().String()
+	created := o.timestamp()
+	b := &spdxBuilder{
+		doc: &spdxDocument{
+			SPDXVersion:       SPDX_VERSION,
+			DataLicense:       "CC0-1.0",
+			SPDXID:            SPDX_DOCUMENT,
+			Name:              componentRef(root.GetName(), root.GetVersion()),
+			DocumentNamespace: fmt.Sprintf("%s/%s/%s", SPDX_NAMESPACE, root.GetName(), root.GetVersion()),
+			CreationInfo: spdxCreationInfo{
+				Created:  created,
+				Creators: []string{creator},
+			},
+		},
+		creator: creator,
+		created: created,
+	}
+
+	ids := map[string]string{}
+	for i, e := range c {
+		ids[componentRef(e.descriptor.GetName(), e.descriptor.GetVersion())] = fmt.Sprintf("SPDXRef-Component-%d", i)
+	}
+
+	for i, e := range c {
+		cd := e.descriptor
+		id := ids[componentRef(cd.GetName(), cd.GetVersion())]
+		pkg := &spdxPackage{
+			SPDXID:                id,
+			Name:                  cd.GetName(),
+			VersionInfo:           cd.GetVersion(),
+			DownloadLocation:      NOASSERTION,
+			PrimaryPackagePurpose: "APPLICATION",
+			Annotations:           b.annotations(labelProperties(cd.Labels)),
+			ExternalRefs:          []*spdxExternal{spdxOCMRef(componentRef(cd.GetName(), cd.GetVersion()))},
+		}
+		if cd.Provider.Name != "" {
+			pkg.Supplier = "Organization: " + string(cd.Provider.Name)
+		}
+		b.doc.Packages = append(b.doc.Packages, pkg)
+		if i == 0 {
+			b.relate(SPDX_DOCUMENT, "DESCRIBES", id)
+		}
+
+		for j := range cd.Resources {
+			rid := fmt.Sprintf("%s-Resource-%d", id, j)
+			if err := b.resource(cd, &cd.Resources[j], rid, e.embedded[j]); err != nil {
+				return nil, err
+			}
+			b.relate(id, "CONTAINS", rid)
+		}
+		for _, r := range cd.References {
+			if ref, ok := ids[componentRef(r.ComponentName, r.Version)]; ok {
+				b.relate(id, "DEPENDS_ON", ref)
+			}
+		}
+	}
+	return b.doc, nil
+}
+
+func (b *spdxBuilder) resource(cd *compdesc.ComponentDescriptor, r *compdesc.Resource, id string, embedded []byte) error {
+	props, err := resourceProperties(r)
+	if err != nil {
+		return err
+	}
+	pkg := &spdxPackage{
+		SPDXID:                id,
+		Name:                  r.GetName(),
+		VersionInfo:           r.GetVersion(),
+		DownloadLocation:      NOASSERTION,
+		PrimaryPackagePurpose: spdxPurpose(r.GetType()),
+		Annotations:           b.annotations(props),
+		ExternalRefs:          []*spdxExternal{spdxOCMRef(resourceRef(cd, r.GetIdentity(cd.Resources)))},
+	}
+	if alg, value := digest(r.Digest, SPDX); alg != "" {
+		pkg.Checksums = []*spdxChecksum{{Algorithm: alg, ChecksumValue: value}}
+	}
+	b.doc.Packages = append(b.doc.Packages, pkg)
+
+	if embedded != nil {
+		b.embed(id, embedded)
+	}
+	return nil
+}
+
+// embed adds the packages and relationships of an embedded SPDX document.
+// The SPDX ids are prefixed by the id of the resource package to keep them
+// unique. Packages described by the embedded document are contained in
+// the resource package. Relationships to other elements (for example files
+// or external documents) are dropped, because those elements are not
+// imported. SBOMs in other formats are ignored.
+func (b *spdxBuilder) embed(id string, data []byte) {
+	var doc spdxEmbedded
+
+	if json.Unmarshal(data, &doc) != nil || doc.SPDXVersion == "" {
+		return
+	}
+
+	ids := map[string]string{SPDX_DOCUMENT: id, NONE: NONE, NOASSERTION: NOASSERTION}
+	for _, p := range doc.Packages {
+		var pid string
+		if json.Unmarshal(p["SPDXID"], &pid) != nil || !strings.HasPrefix(pid, SPDX_REF) || len(pid) == len(SPDX_REF) {
+			continue
+		}
+		ids[pid] = id + "-" + strings.TrimPrefix(pid, SPDX_REF)
+		p["SPDXID"], _ = json.Marshal(ids[pid])
+		b.doc.Packages = append(b.doc.Packages, p)
+	}
+	for _, r := range doc.Relationships {
+		from, ok := ids[r.SPDXElementID]
+		if !ok {
+			continue
+		}
+		to, ok := ids[r.RelatedSPDXElement]
+		if !ok {
+			continue
+		}
+		if r.SPDXElementID == SPDX_DOCUMENT && r.RelationshipType == "DESCRIBES" {
+			b.relate(id, "CONTAINS", to)
+		} else {
+			b.relate(from, r.RelationshipType, to)
+		}
+	}
+}
+
+func (b *spdxBuilder) relate(from, rel, to string) {
+	b.doc.Relationships = append(b.doc.Relationships, &spdxRelationship{
+		SPDXElementID:      from,
+		RelationshipType:   rel,
+		RelatedSPDXElement: to,
+	})
+}
+
+func (b *spdxBuilder) annotations(props []property) []*spdxAnnotation {
+	var result []*spdxAnnotation
+	for _, p := range props {
+		result = append(result, &spdxAnnotation{
+			Annotator:      b.creator,
+			AnnotationDate: b.created,
+			AnnotationType: "OTHER",
+			Comment:        p.name + "=" + p.value,
+		})
+	}
+	return result
+}
+
+// spdxOCMRef provides an external reference to an OCM element.
+func spdxOCMRef(ref string) *spdxExternal {
+	return &spdxExternal{
+		ReferenceCategory: "OTHER",
+		ReferenceType:     "ocm",
+		ReferenceLocator:  ref,
+	}
+}
+
+// spdxPurpose maps OCM resource types to SPDX package purposes.
+func spdxPurpose(t string) string {
+	switch t {
+	case resourcetypes.OCI_IMAGE:
+		return "CONTAINER"
+	case resourcetypes.EXECUTABLE, resourcetypes.OCM_PLUGIN:
+		return "APPLICATION"
+	default:
+		return "FILE"
+	}
+}
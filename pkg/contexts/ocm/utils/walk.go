@@ -36,6 +36,7 @@ func walk[T any](state common.WalkingState[T, ocm.ComponentVersionAccess], cv oc
 	if ok, err := state.Add(ocm.KIND_COMPONENTVERSION, nv); !ok || err != nil {
 		return err
 	}
+	state.Context = cv
 	c, err := step(state)
 	if err != nil {
 		return errors.Wrapf(err, "%s", state.History)
@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/log"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+
+	"github.com/open-component-model/ocm/pkg/docker/resolve"
+)
+
+type dockerReferrersLister struct {
+	dockerBase *dockerBase
+}
+
+func (r *dockerResolver) Referrers(ctx context.Context, ref string) (resolve.ReferrersLister, error) {
+	base, err := r.resolveDockerBase(ref)
+	if err != nil {
+		return nil, err
+	}
+	if base.refspec.Object != "" {
+		return nil, ErrObjectNotRequired
+	}
+
+	return &dockerReferrersLister{
+		dockerBase: base,
+	}, nil
+}
+
+// ListReferrers uses the referrers API of the OCI distribution spec 1.1
+// to list the manifests referring to the given subject. A registry
+// without referrers support answers with 404, which is reported as
+// resolve.ErrReferrersNotSupported.
+func (r *dockerReferrersLister) ListReferrers(ctx context.Context, subject digest.Digest, artifactType string) ([]ocispec.Descriptor, error) {
+	base := r.dockerBase
+
+	hosts := base.filterHosts(HostCapabilityPull | HostCapabilityResolve)
+	if len(hosts) == 0 {
+		return nil, errors.Wrap(errdefs.ErrNotFound, "no referrers hosts")
+	}
+
+	ctx, err := ContextWithRepositoryScope(ctx, base.refspec, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var firstErr error
+	for _, host := range hosts {
+		ctxWithLogger := log.WithLogger(ctx, log.G(ctx).WithField("host", host.Host))
+
+		req := base.request(host, http.MethodGet, "referrers", subject.String())
+		if artifactType != "" {
+			req.path += "?" + url.Values{"artifactType": []string{artifactType}}.Encode()
+		}
+		if err := req.addNamespace(base.refspec.Hostname()); err != nil {
+			return nil, err
+		}
+		req.header["Accept"] = []string{ocispec.MediaTypeImageIndex, "application/json"}
+
+		var result []ocispec.Descriptor
+		for req != nil {
+			log.G(ctxWithLogger).Debug("listing referrers")
+			resp, err := req.doWithRetries(ctxWithLogger, nil)
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				break
+			}
+			if resp.StatusCode > 299 {
+				resp.Body.Close()
+				if resp.StatusCode == http.StatusNotFound {
+					return nil, resolve.ErrReferrersNotSupported
+				}
+				if firstErr == nil {
+					firstErr = errors.Errorf("listing referrers from host %s failed with status code %v", host.Host, resp.Status)
+				}
+				break
+			}
+			data, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			var index ocispec.Index
+			if err := json.Unmarshal(data, &index); err != nil {
+				return nil, errors.Wrapf(err, "invalid referrers response")
+			}
+			result = append(result, index.Manifests...)
+			req = nextRequest(req, resp)
+		}
+		if req == nil {
+			return filterArtifactType(result, artifactType), nil
+		}
+	}
+	if firstErr == nil {
+		firstErr = errors.Wrap(errdefs.ErrNotFound, base.refspec.Locator)
+	}
+	return nil, firstErr
+}
+
+// nextRequest provides the request for the next page
+// described by a Link header, or nil if there is none.
+func nextRequest(req *request, resp *http.Response) *request {
+	for _, link := range resp.Header.Values("Link") {
+		for _, l := range strings.Split(link, ",") {
+			parts := strings.Split(l, ";")
+			if len(parts) < 2 || !strings.Contains(parts[1], `rel="next"`) {
+				continue
+			}
+			u, err := url.Parse(strings.Trim(strings.TrimSpace(parts[0]), "<>"))
+			if err != nil {
+				return nil
+			}
+			next := *req
+			next.path = u.EscapedPath()
+			if u.RawQuery != "" {
+				next.path += "?" + u.RawQuery
+			}
+			return &next
+		}
+	}
+	return nil
+}
+
+// filterArtifactType filters the list, if the registry
+// ignores the artifactType query parameter.
+func filterArtifactType(list []ocispec.Descriptor, artifactType string) []ocispec.Descriptor {
+	if artifactType == "" {
+		return list
+	}
+	var result []ocispec.Descriptor
+	for _, d := range list {
+		if d.ArtifactType == artifactType {
+			result = append(result, d)
+		}
+	}
+	return result
+}
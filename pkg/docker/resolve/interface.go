@@ -6,6 +6,7 @@ package resolve
 
 import (
 	"context"
+	"errors"
 	"io"
 
 	"github.com/containerd/containerd/content"
@@ -45,6 +46,10 @@ type Resolver interface {
 	Pusher(ctx context.Context, ref string) (Pusher, error)
 
 	Lister(ctx context.Context, ref string) (Lister, error)
+
+	// Referrers returns a lister for the referrers API
+	// for the provided (namespace) reference.
+	Referrers(ctx context.Context, ref string) (ReferrersLister, error)
 }
 
 // Fetcher fetches content.
@@ -65,6 +70,16 @@ type Lister interface {
 	List(context.Context) ([]string, error)
 }
 
+// ErrReferrersNotSupported is returned by a ReferrersLister,
+// if the registry does not support the referrers API.
+var ErrReferrersNotSupported = errors.New("referrers API not supported")
+
+// ReferrersLister lists the manifests referring to a subject manifest
+// according to the OCI distribution spec 1.1.
+type ReferrersLister interface {
+	ListReferrers(ctx context.Context, subject digest.Digest, artifactType string) ([]ocispec.Descriptor, error)
+}
+
 // PushRequest handles the result of a push request
 // replaces containerd content.Writer.
 type PushRequest interface {
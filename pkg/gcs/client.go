@@ -0,0 +1,420 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gcs
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" //nolint:gosec // md5 is the content checksum used by the storage service
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/gcs/identity"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/logging"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+// DEFAULT_ENDPOINT is the public Google Cloud Storage endpoint.
+const DEFAULT_ENDPOINT = "https://storage.googleapis.com"
+
+// SCOPE is the OAuth2 scope requested for service account keys.
+const SCOPE = "https://www.googleapis.com/auth/devstorage.read_write"
+
+const (
+	// CHUNK_SIZE is the default size of the chunks used to upload larger
+	// objects with a resumable upload. Chunk sizes must be a multiple of 256 KiB.
+	CHUNK_SIZE = 8 * 1024 * 1024
+	// TIMEOUT is the timeout for a single request. Because larger objects are
+	// uploaded in chunks, it also limits the upload of a single chunk.
+	// For downloads only the wait time for the response is limited.
+	TIMEOUT = 5 * time.Minute
+)
+
+// Endpoint provides the effective storage endpoint.
+func Endpoint(endpoint string) string {
+	if endpoint == "" {
+		return DEFAULT_ENDPOINT
+	}
+	return strings.TrimSuffix(endpoint, "/")
+}
+
+// Object describes the metadata of a stored object as provided by
+// the JSON API.
+type Object struct {
+	Bucket      string `json:"bucket"`
+	Name        string `json:"name"`
+	ContentType string `json:"contentType,omitempty"`
+	Size        string `json:"size,omitempty"`
+	Generation  string `json:"generation,omitempty"`
+	// MD5Hash is the base64 encoded MD5 hash of the content. It is not
+	// provided for composite objects.
+	MD5Hash string `json:"md5Hash,omitempty"`
+}
+
+// GetSize provides the object size or blobaccess.BLOB_UNKNOWN_SIZE.
+func (o *Object) GetSize() int64 {
+	s, err := strconv.ParseInt(o.Size, 10, 64)
+	if err != nil {
+		return blobaccess.BLOB_UNKNOWN_SIZE
+	}
+	return s
+}
+
+// Client provides access to the objects of a bucket using the
+// JSON API of Google Cloud Storage. Any server implementing this API
+// (for example fake-gcs-server) can be used by specifying its endpoint.
+type Client struct {
+	endpoint  string
+	creds     common.Properties
+	tokens    oauth2.TokenSource
+	chunkSize int64
+	client    *http.Client
+	stream    *http.Client
+}
+
+// NewClient provides a client for the given endpoint. The credential
+// properties are described by the package
+// github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/gcs/identity.
+func NewClient(endpoint string, creds common.Properties) (*Client, error) {
+	e := Endpoint(endpoint)
+	if _, err := url.Parse(e); err != nil {
+		return nil, errors.ErrInvalidWrap(err, "storage endpoint", e)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = TIMEOUT
+	c := &Client{
+		endpoint:  e,
+		creds:     creds,
+		chunkSize: CHUNK_SIZE,
+		client:    &http.Client{Transport: transport, Timeout: TIMEOUT},
+		stream:    &http.Client{Transport: transport},
+	}
+	if key := creds[identity.ATTR_SERVICE_ACCOUNT_KEY]; key != "" {
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, c.client)
+		sa, err := google.CredentialsFromJSON(ctx, []byte(key), SCOPE)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid service account key")
+		}
+		c.tokens = sa.TokenSource
+	}
+	return c, nil
+}
+
+// WithChunkSize sets the size of the chunks used to upload objects.
+// Objects not larger than the chunk size are uploaded with a single request.
+// The size must be a multiple of 256 KiB, only tests may use other sizes
+// with servers not enforcing this restriction.
+func (c *Client) WithChunkSize(size int64) *Client {
+	if size > 0 {
+		c.chunkSize = size
+	}
+	return c
+}
+
+func (c *Client) Endpoint() string {
+	return c.endpoint
+}
+
+func (c *Client) objectURL(bucket, object, generation string) string {
+	u := c.endpoint + "/storage/v1/b/" + url.PathEscape(bucket) + "/o/" + url.PathEscape(object)
+	if generation != "" {
+		u += "?generation=" + url.QueryEscape(generation)
+	}
+	return u
+}
+
+// GetObject provides the metadata of an object. If no generation is given,
+// the latest one is used.
+func (c *Client) GetObject(bucket, object, generation string) (*Object, error) {
+	resp, err := c.request(c.client, http.MethodGet, c.objectURL(bucket, object, generation), nil, -1, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp, bucket, object); err != nil {
+		return nil, err
+	}
+	var o Object
+	if err := json.NewDecoder(resp.Body).Decode(&o); err != nil {
+		return nil, errors.Wrapf(err, "invalid object metadata for %s in bucket %s", object, bucket)
+	}
+	return &o, nil
+}
+
+// Download provides a blob access for the content of an object.
+// If the object provides an MD5 hash, the content is verified.
+// If no media type is given, the content type of the object is used.
+func (c *Client) Download(bucket, object, generation string, mediaType string) (_ blobaccess.BlobAccess, rerr error) {
+	log := logging.Context().Logger(identity.REALM).WithValues("bucket", bucket, "object", object)
+
+	meta, err := c.GetObject(bucket, object, generation)
+	if err != nil {
+		return nil, err
+	}
+	// pin the generation to match the metadata
+	u := c.objectURL(bucket, object, meta.Generation)
+	if meta.Generation == "" {
+		u += "?alt=media"
+	} else {
+		u += "&alt=media"
+	}
+	resp, err := c.request(c.stream, http.MethodGet, u, nil, -1, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer errors.PropagateError(&rerr, resp.Body.Close)
+	if err := checkStatus(resp, bucket, object); err != nil {
+		return nil, err
+	}
+
+	temp, err := blobaccess.NewTempFile("", "gcs*")
+	if err != nil {
+		return nil, err
+	}
+	defer errors.PropagateError(&rerr, temp.Close)
+
+	h := md5.New() //nolint:gosec // see above
+	if _, err := io.Copy(io.MultiWriter(temp.Writer(), h), resp.Body); err != nil {
+		return nil, errors.Wrapf(err, "cannot download %s from bucket %s", object, bucket)
+	}
+	if meta.MD5Hash != "" {
+		found := base64.StdEncoding.EncodeToString(h.Sum(nil))
+		if found != meta.MD5Hash {
+			return nil, errors.Newf("MD5 mismatch for object %s in bucket %s: expected %s, found %s", object, bucket, meta.MD5Hash, found)
+		}
+		log.Debug("MD5 checksum verified")
+	} else {
+		log.Debug("no checksum provided for {{object}}")
+	}
+	if mediaType == "" {
+		mediaType = meta.ContentType
+	}
+	if mediaType == "" {
+		mediaType = mime.MIME_OCTET
+	}
+	return temp.AsBlob(mediaType), nil
+}
+
+// Upload stores the given blob as object. If an object with the same
+// content already exists, nothing is done. It provides the metadata of
+// the stored object.
+func (c *Client) Upload(bucket, object string, data blobaccess.BlobAccess) (_ *Object, rerr error) {
+	log := logging.Context().Logger(identity.REALM).WithValues("bucket", bucket, "object", object)
+
+	size, sum, err := md5sum(data)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := c.GetObject(bucket, object, "")
+	if err != nil && !errors.IsErrNotFound(err) {
+		return nil, err
+	}
+	if meta != nil && meta.MD5Hash == sum {
+		log.Debug("object already exists, skipping upload")
+		return meta, nil
+	}
+
+	rd, err := data.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer errors.PropagateError(&rerr, rd.Close)
+
+	mediaType := data.MimeType()
+	if mediaType == "" {
+		mediaType = mime.MIME_OCTET
+	}
+	log.Debug("uploading")
+	var o *Object
+	if size > c.chunkSize {
+		o, err = c.uploadResumable(bucket, object, mediaType, size, sum, rd)
+	} else {
+		u := c.endpoint + "/upload/storage/v1/b/" + url.PathEscape(bucket) + "/o?uploadType=media&name=" + url.QueryEscape(object)
+		var resp *http.Response
+		resp, err = c.request(c.client, http.MethodPost, u, http.Header{"Content-Type": {mediaType}}, size, rd)
+		if err == nil {
+			o, err = objectFrom(resp, bucket, object)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if o.MD5Hash != "" && o.MD5Hash != sum {
+		return nil, errors.Newf("MD5 mismatch for uploaded object %s in bucket %s: expected %s, found %s", object, bucket, sum, o.MD5Hash)
+	}
+	log.Debug("successfully uploaded")
+	return o, nil
+}
+
+// uploadResumable uploads the content with a resumable upload session in
+// chunks. This is required for objects larger than the maximum size
+// of a single request. Chunks not completely persisted by the service
+// are sent again.
+func (c *Client) uploadResumable(bucket, object string, mediaType string, size int64, sum string, rd io.Reader) (*Object, error) {
+	log := logging.Context().Logger(identity.REALM).WithValues("bucket", bucket, "object", object)
+
+	meta, err := json.Marshal(&Object{Name: object, ContentType: mediaType, MD5Hash: sum})
+	if err != nil {
+		return nil, err
+	}
+	u := c.endpoint + "/upload/storage/v1/b/" + url.PathEscape(bucket) + "/o?uploadType=resumable"
+	header := http.Header{}
+	header.Set("Content-Type", mime.MIME_JSON_OFFICIAL)
+	header.Set("X-Upload-Content-Type", mediaType)
+	header.Set("X-Upload-Content-Length", strconv.FormatInt(size, 10))
+	resp, err := c.request(c.client, http.MethodPost, u, header, int64(len(meta)), bytes.NewReader(meta))
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	if err := checkStatus(resp, bucket, object); err != nil {
+		return nil, err
+	}
+	session := resp.Header.Get("Location")
+	if session == "" {
+		return nil, errors.Newf("no upload session provided for object %s in bucket %s", object, bucket)
+	}
+
+	buf := make([]byte, c.chunkSize)
+	for offset := int64(0); offset < size; {
+		n := int64(len(buf))
+		if size-offset < n {
+			n = size - offset
+		}
+		if _, err := io.ReadFull(rd, buf[:n]); err != nil {
+			return nil, errors.Wrapf(err, "cannot read object content")
+		}
+		for start := offset; start < offset+n; {
+			chunk := buf[start-offset : n]
+			header := http.Header{}
+			header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, start+int64(len(chunk))-1, size))
+			log.Trace("uploading chunk", "offset", start, "size", len(chunk))
+			resp, err := c.request(c.client, http.MethodPut, session, header, int64(len(chunk)), bytes.NewReader(chunk))
+			if err != nil {
+				return nil, err
+			}
+			if resp.StatusCode != http.StatusPermanentRedirect {
+				return objectFrom(resp, bucket, object)
+			}
+			resp.Body.Close()
+			// status 308 (resume incomplete) provides the persisted range
+			persisted, err := persistedBytes(resp.Header.Get("Range"))
+			if err != nil || persisted <= start || persisted > offset+n {
+				return nil, errors.Newf("unexpected range %q for upload of object %s in bucket %s", resp.Header.Get("Range"), object, bucket)
+			}
+			start = persisted
+		}
+		offset += n
+	}
+	return nil, errors.Newf("upload of object %s in bucket %s not finalized", object, bucket)
+}
+
+// objectFrom provides the object metadata returned by a successful upload.
+func objectFrom(resp *http.Response, bucket, object string) (*Object, error) {
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusCreated {
+		resp.StatusCode = http.StatusOK
+	}
+	if err := checkStatus(resp, bucket, object); err != nil {
+		return nil, err
+	}
+	var o Object
+	if err := json.NewDecoder(resp.Body).Decode(&o); err != nil {
+		return nil, errors.Wrapf(err, "invalid object metadata for %s in bucket %s", object, bucket)
+	}
+	return &o, nil
+}
+
+// persistedBytes provides the number of persisted bytes described by the
+// Range header (bytes=0-<last>) of a resumable upload response.
+func persistedBytes(r string) (int64, error) {
+	if r == "" {
+		return 0, nil
+	}
+	last, ok := strings.CutPrefix(r, "bytes=0-")
+	if !ok {
+		return 0, errors.ErrInvalid("range", r)
+	}
+	n, err := strconv.ParseInt(last, 10, 64)
+	if err != nil {
+		return 0, errors.ErrInvalidWrap(err, "range", r)
+	}
+	return n + 1, nil
+}
+
+func checkStatus(resp *http.Response, bucket, object string) error {
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return errors.ErrNotFound("object", object, bucket)
+	}
+	buf := &bytes.Buffer{}
+	_, _ = io.Copy(buf, io.LimitReader(resp.Body, 2000))
+	return fmt.Errorf("http (%d) - %s %s: %s", resp.StatusCode, resp.Request.Method, resp.Request.URL, buf.String())
+}
+
+func (c *Client) request(client *http.Client, method, location string, header http.Header, size int64, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(context.Background(), method, location, body)
+	if err != nil {
+		return nil, err
+	}
+	if size >= 0 {
+		req.ContentLength = size
+		if size == 0 {
+			req.Body = http.NoBody
+		}
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	token, err := c.token()
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return client.Do(req)
+}
+
+func (c *Client) token() (string, error) {
+	if c.tokens != nil {
+		t, err := c.tokens.Token()
+		if err != nil {
+			return "", errors.Wrapf(err, "cannot get access token for service account")
+		}
+		return t.AccessToken, nil
+	}
+	return c.creds[identity.ATTR_TOKEN], nil
+}
+
+func md5sum(data blobaccess.DataAccess) (_ int64, _ string, rerr error) {
+	rd, err := data.Reader()
+	if err != nil {
+		return 0, "", err
+	}
+	defer errors.PropagateError(&rerr, rd.Close)
+	h := md5.New() //nolint:gosec // see above
+	n, err := io.Copy(h, rd)
+	if err != nil {
+		return 0, "", err
+	}
+	return n, base64.StdEncoding.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gcs_test
+
+import (
+	"crypto/md5" //nolint:gosec // used by the storage service
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/gcs"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+const (
+	BUCKET  = "blobs"
+	OBJECT  = "dir/object"
+	CONTENT = "0123456789"
+)
+
+type object struct {
+	meta gcs.Object
+	data []byte
+}
+
+// fakeServer is a minimal stand-in for the JSON API (like fake-gcs-server)
+// supporting resumable uploads. The first chunk of an upload session is
+// only persisted partially.
+type fakeServer struct {
+	lock     sync.Mutex
+	url      string
+	objects  map[string]*object
+	sessions map[string]*object
+	requests []string
+}
+
+func (s *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	switch {
+	case r.Method == http.MethodPost && r.URL.Query().Get("uploadType") == "resumable":
+		var meta gcs.Object
+		if json.NewDecoder(r.Body).Decode(&meta) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		meta.Bucket = BUCKET
+		id := strconv.Itoa(len(s.sessions))
+		s.sessions[id] = &object{meta: meta}
+		s.requests = append(s.requests, "session")
+		w.Header().Set("Location", s.url+"/session/"+id)
+	case r.Method == http.MethodPost:
+		data, _ := io.ReadAll(r.Body)
+		s.requests = append(s.requests, "media")
+		s.store(&object{meta: gcs.Object{Bucket: BUCKET, Name: r.URL.Query().Get("name"), ContentType: r.Header.Get("Content-Type")}, data: data}, w)
+	case r.Method == http.MethodPut:
+		o := s.sessions[strings.TrimPrefix(r.URL.Path, "/session/")]
+		data, _ := io.ReadAll(r.Body)
+		var start, end, size int
+		_, err := fmt.Sscanf(r.Header.Get("Content-Range"), "bytes %d-%d/%d", &start, &end, &size)
+		if o == nil || err != nil || start != len(o.data) || end != start+len(data)-1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.requests = append(s.requests, r.Header.Get("Content-Range"))
+		if len(o.data) == 0 && len(data) > 1 {
+			data = data[:len(data)/2]
+		}
+		o.data = append(o.data, data...)
+		if len(o.data) < size {
+			w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", len(o.data)-1))
+			w.WriteHeader(http.StatusPermanentRedirect)
+			return
+		}
+		sum := md5.Sum(o.data) //nolint:gosec // see above
+		if o.meta.MD5Hash != base64.StdEncoding.EncodeToString(sum[:]) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.store(o, w)
+	default:
+		o := s.objects[strings.TrimPrefix(r.URL.Path, "/storage/v1/b/"+BUCKET+"/o/")]
+		if o == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("alt") == "media" {
+			w.Write(o.data)
+			return
+		}
+		json.NewEncoder(w).Encode(&o.meta)
+	}
+}
+
+func (s *fakeServer) store(o *object, w http.ResponseWriter) {
+	sum := md5.Sum(o.data) //nolint:gosec // see above
+	o.meta.Size = strconv.Itoa(len(o.data))
+	o.meta.Generation = strconv.Itoa(len(s.objects) + 1)
+	o.meta.MD5Hash = base64.StdEncoding.EncodeToString(sum[:])
+	s.objects[o.meta.Name] = o
+	json.NewEncoder(w).Encode(&o.meta)
+}
+
+var _ = Describe("client", func() {
+	var (
+		fake   *fakeServer
+		server *httptest.Server
+		client *gcs.Client
+	)
+
+	BeforeEach(func() {
+		fake = &fakeServer{objects: map[string]*object{}, sessions: map[string]*object{}}
+		server = httptest.NewServer(fake)
+		fake.url = server.URL
+		client = Must(gcs.NewClient(server.URL, common.Properties{}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("uploads small object with single request", func() {
+		Must(client.Upload(BUCKET, OBJECT, blobaccess.ForString(mime.MIME_TEXT, CONTENT)))
+		Expect(fake.requests).To(Equal([]string{"media"}))
+
+		b := Must(client.Download(BUCKET, OBJECT, "", ""))
+		defer Close(b)
+		Expect(b.MimeType()).To(Equal(mime.MIME_TEXT))
+		Expect(string(Must(b.Get()))).To(Equal(CONTENT))
+	})
+
+	It("uploads object in chunks", func() {
+		client.WithChunkSize(4)
+		o := Must(client.Upload(BUCKET, OBJECT, blobaccess.ForString(mime.MIME_TEXT, CONTENT)))
+		Expect(o.GetSize()).To(Equal(int64(len(CONTENT))))
+		Expect(fake.requests).To(Equal([]string{
+			"session",
+			"bytes 0-3/10",
+			"bytes 2-3/10", // resend part not persisted
+			"bytes 4-7/10",
+			"bytes 8-9/10",
+		}))
+
+		b := Must(client.Download(BUCKET, OBJECT, "", ""))
+		defer Close(b)
+		Expect(b.MimeType()).To(Equal(mime.MIME_TEXT))
+		Expect(string(Must(b.Get()))).To(Equal(CONTENT))
+
+		Must(client.Upload(BUCKET, OBJECT, blobaccess.ForString(mime.MIME_TEXT, CONTENT)))
+		Expect(len(fake.requests)).To(Equal(5))
+	})
+})
@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package maven
+
+import (
+	"path"
+	"strings"
+
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/mime"
+)
+
+// DEFAULT_EXTENSION is the file extension used if no extension is specified.
+const DEFAULT_EXTENSION = "jar"
+
+const (
+	MIME_JAR = "application/java-archive"
+	MIME_POM = "application/xml"
+	MIME_ZIP = "application/zip"
+)
+
+// Coordinates describes a dedicated file of a Maven artifact.
+type Coordinates struct {
+	// GroupId of the Maven artifact.
+	GroupId string `json:"groupId"`
+	// ArtifactId of the Maven artifact.
+	ArtifactId string `json:"artifactId"`
+	// Version of the Maven artifact.
+	Version string `json:"version"`
+	// Classifier of the Maven artifact file.
+	Classifier string `json:"classifier,omitempty"`
+	// Extension of the Maven artifact file (default jar).
+	Extension string `json:"extension,omitempty"`
+}
+
+func NewCoordinates(groupId, artifactId, version string, classifier ...string) *Coordinates {
+	c := &Coordinates{
+		GroupId:    groupId,
+		ArtifactId: artifactId,
+		Version:    version,
+	}
+	if len(classifier) > 0 {
+		c.Classifier = classifier[0]
+	}
+	if len(classifier) > 1 {
+		c.Extension = classifier[1]
+	}
+	return c
+}
+
+// Parse parses coordinates of the form
+// <groupId>:<artifactId>:<version>[:<classifier>[:<extension>]].
+func Parse(s string) (*Coordinates, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 3 || len(parts) > 5 {
+		return nil, errors.ErrInvalid("maven coordinates", s)
+	}
+	c := NewCoordinates(parts[0], parts[1], parts[2], parts[3:]...)
+	if err := c.Validate(); err != nil {
+		return nil, errors.ErrInvalidWrap(err, "maven coordinates", s)
+	}
+	return c, nil
+}
+
+// String provides the string representation understood by Parse.
+func (c *Coordinates) String() string {
+	s := c.GroupId + ":" + c.ArtifactId + ":" + c.Version
+	if c.Classifier != "" || c.Extension != "" {
+		s += ":" + c.Classifier
+	}
+	if c.Extension != "" {
+		s += ":" + c.Extension
+	}
+	return s
+}
+
+// Validate checks the coordinates. Because the coordinates are mapped
+// to a file path in the repository, the fields must not contain path
+// separators or denote relative path segments.
+func (c *Coordinates) Validate() error {
+	if c.GroupId == "" {
+		return errors.ErrRequired("groupId")
+	}
+	if c.ArtifactId == "" {
+		return errors.ErrRequired("artifactId")
+	}
+	if c.Version == "" {
+		return errors.ErrRequired("version")
+	}
+	for _, p := range strings.Split(c.GroupId, ".") {
+		if err := validateSegment("groupId", c.GroupId, p); err != nil {
+			return err
+		}
+	}
+	if err := validateSegment("artifactId", c.ArtifactId, c.ArtifactId); err != nil {
+		return err
+	}
+	if err := validateSegment("version", c.Version, c.Version); err != nil {
+		return err
+	}
+	if c.Classifier != "" {
+		if err := validateSegment("classifier", c.Classifier, c.Classifier); err != nil {
+			return err
+		}
+	}
+	if c.Extension != "" {
+		if err := validateSegment("extension", c.Extension, c.Extension); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateSegment(field, value, segment string) error {
+	if segment == "" || segment == "." || segment == ".." || strings.ContainsAny(segment, "/\\") {
+		return errors.ErrInvalid(field, value)
+	}
+	return nil
+}
+
+// GetExtension provides the effective file extension.
+func (c *Coordinates) GetExtension() string {
+	if c.Extension == "" {
+		return DEFAULT_EXTENSION
+	}
+	return c.Extension
+}
+
+// GroupPath provides the repository path for the group id.
+func (c *Coordinates) GroupPath() string {
+	return strings.ReplaceAll(c.GroupId, ".", "/")
+}
+
+// FileName provides the name of the artifact file.
+func (c *Coordinates) FileName() string {
+	n := c.ArtifactId + "-" + c.Version
+	if c.Classifier != "" {
+		n += "-" + c.Classifier
+	}
+	return n + "." + c.GetExtension()
+}
+
+// FilePath provides the path of the artifact file according to the
+// Maven repository layout.
+func (c *Coordinates) FilePath() string {
+	return path.Join(c.GroupPath(), c.ArtifactId, c.Version, c.FileName())
+}
+
+// MimeType provides the media type according to the file extension.
+func (c *Coordinates) MimeType() string {
+	switch c.GetExtension() {
+	case "jar", "war", "ear":
+		return MIME_JAR
+	case "pom", "xml":
+		return MIME_POM
+	case "zip":
+		return MIME_ZIP
+	case "tar.gz", "tgz":
+		return mime.MIME_TGZ
+	case "tar":
+		return mime.MIME_TAR
+	default:
+		return mime.MIME_OCTET
+	}
+}
@@ -0,0 +1,348 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package maven
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	//nolint:gosec // sha1 checksums are part of the maven repository layout
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/builtin/maven/identity"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/logging"
+	"github.com/open-component-model/ocm/pkg/utils"
+)
+
+// ChecksumType describes a checksum sidecar file of a Maven repository.
+type ChecksumType struct {
+	Extension string
+	Hash      crypto.Hash
+}
+
+// ChecksumTypes lists the supported checksum sidecar files in the order
+// of preference.
+var ChecksumTypes = []ChecksumType{
+	{"sha256", crypto.SHA256},
+	{"sha1", crypto.SHA1},
+}
+
+// Repository describes a Maven repository. It is either given by
+// an http(s) URL or by a directory in a filesystem (for example
+// a local ~/.m2/repository).
+type Repository struct {
+	url  string
+	path string
+	fs   vfs.FileSystem
+
+	unverified bool
+}
+
+// NewUrlRepository provides a repository for a repository URL.
+// Besides http(s) URLs, file URLs and plain directory paths are supported.
+func NewUrlRepository(repoUrl string, fss ...vfs.FileSystem) (*Repository, error) {
+	if strings.HasPrefix(repoUrl, "file://") {
+		return NewFileRepository(repoUrl[7:], fss...), nil
+	}
+	if !strings.HasPrefix(repoUrl, "http://") && !strings.HasPrefix(repoUrl, "https://") {
+		if strings.Contains(repoUrl, "://") {
+			return nil, errors.ErrInvalid("maven repository url", repoUrl)
+		}
+		return NewFileRepository(repoUrl, fss...), nil
+	}
+	if _, err := url.Parse(repoUrl); err != nil {
+		return nil, errors.ErrInvalidWrap(err, "maven repository url", repoUrl)
+	}
+	return &Repository{url: strings.TrimSuffix(repoUrl, "/")}, nil
+}
+
+// NewFileRepository provides a repository for a directory.
+func NewFileRepository(path string, fss ...vfs.FileSystem) *Repository {
+	return &Repository{path: path, fs: utils.FileSystem(fss...)}
+}
+
+func (r *Repository) String() string {
+	if r.IsFileSystem() {
+		return "file://" + r.path
+	}
+	return r.url
+}
+
+// AllowUnverified allows to download files without checksum sidecar file.
+// By default, such downloads are rejected.
+func (r *Repository) AllowUnverified(b bool) *Repository {
+	r.unverified = b
+	return r
+}
+
+// IsFileSystem returns whether the repository is a filesystem based one.
+func (r *Repository) IsFileSystem() bool {
+	return r.url == ""
+}
+
+// Location provides the URL or filesystem path of the file described
+// by the given coordinates.
+func (r *Repository) Location(c *Coordinates) string {
+	if r.IsFileSystem() {
+		return vfs.Join(r.fs, r.path, c.FilePath())
+	}
+	return r.url + "/" + c.FilePath()
+}
+
+// Exists checks whether the file described by the given coordinates
+// exists in the repository.
+func (r *Repository) Exists(c *Coordinates, creds common.Properties) (bool, error) {
+	if err := c.Validate(); err != nil {
+		return false, err
+	}
+	if r.IsFileSystem() {
+		return vfs.FileExists(r.fs, r.Location(c))
+	}
+	resp, err := r.request(http.MethodHead, r.Location(c), nil, creds)
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, errors.Newf("cannot check %s: %s", r.Location(c), resp.Status)
+	}
+}
+
+// Checksum provides the checksum for the file described by the given
+// coordinates according to the first found checksum sidecar file.
+// If no sidecar file is found, an empty checksum is returned.
+func (r *Repository) Checksum(c *Coordinates, creds common.Properties) (crypto.Hash, string, error) {
+	if err := c.Validate(); err != nil {
+		return 0, "", err
+	}
+	for _, t := range ChecksumTypes {
+		rd, err := r.reader(r.Location(c)+"."+t.Extension, creds)
+		if err != nil {
+			if errors.IsErrNotFound(err) {
+				continue
+			}
+			return 0, "", err
+		}
+		data, err := io.ReadAll(io.LimitReader(rd, 1000))
+		rd.Close()
+		if err != nil {
+			return 0, "", errors.Wrapf(err, "cannot read %s checksum for %s", t.Extension, c)
+		}
+		// sidecar files may contain the file name after the checksum.
+		fields := strings.Fields(string(data))
+		if len(fields) == 0 {
+			return 0, "", errors.Newf("empty %s checksum for %s", t.Extension, c)
+		}
+		return t.Hash, strings.ToLower(fields[0]), nil
+	}
+	return 0, "", nil
+}
+
+// Download provides a blob for the file described by the given coordinates.
+// The content is verified with the checksum sidecar file. If no sidecar
+// file is available, the download fails, unless unverified downloads
+// are explicitly allowed for the repository.
+func (r *Repository) Download(c *Coordinates, creds common.Properties) (_ blobaccess.BlobAccess, rerr error) {
+	log := logging.Context().Logger(identity.REALM).WithValues("repository", r.String(), "artifact", c.String())
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	algo, sum, err := r.Checksum(c, creds)
+	if err != nil {
+		return nil, err
+	}
+	if sum == "" && !r.unverified {
+		return nil, errors.Newf("no checksum sidecar file found for %s in %s", c, r)
+	}
+
+	rd, err := r.reader(r.Location(c), creds)
+	if err != nil {
+		return nil, err
+	}
+	defer errors.PropagateError(&rerr, rd.Close)
+
+	temp, err := blobaccess.NewTempFile("", "maven*")
+	if err != nil {
+		return nil, err
+	}
+	defer errors.PropagateError(&rerr, temp.Close)
+
+	w := temp.Writer()
+	var h hash.Hash
+	if sum != "" {
+		h = algo.New()
+		w = io.MultiWriter(w, h)
+	} else {
+		log.Warn("downloading {{artifact}} without checksum verification")
+	}
+	if _, err := io.Copy(w, rd); err != nil {
+		return nil, errors.Wrapf(err, "cannot download %s", r.Location(c))
+	}
+	if h != nil {
+		found := hex.EncodeToString(h.Sum(nil))
+		if found != sum {
+			return nil, errors.Newf("%s checksum mismatch for %s: expected %s, found %s", algo, c, sum, found)
+		}
+		log.Debug("{{hash}} checksum verified", "hash", algo.String())
+	}
+	return temp.AsBlob(c.MimeType()), nil
+}
+
+// Upload uploads the given blob as file described by the given coordinates
+// together with the checksum sidecar files. If the file already exists
+// with the same content, nothing is done.
+func (r *Repository) Upload(c *Coordinates, blob blobaccess.DataAccess, creds common.Properties) (rerr error) {
+	log := logging.Context().Logger(identity.REALM).WithValues("repository", r.String(), "artifact", c.String())
+
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	sums, err := checksums(blob)
+	if err != nil {
+		return err
+	}
+
+	exists, err := r.Exists(c, creds)
+	if err != nil {
+		return err
+	}
+	if exists {
+		algo, sum, err := r.Checksum(c, creds)
+		if err != nil {
+			return err
+		}
+		if sum == "" {
+			return errors.Newf("%s already exists in %s without checksum", c, r)
+		}
+		if sums[algo] == sum {
+			log.Debug("artifact already exists, skipping upload")
+			return nil
+		}
+		return errors.Newf("%s already exists in %s with different content", c, r)
+	}
+
+	rd, err := blob.Reader()
+	if err != nil {
+		return err
+	}
+	defer errors.PropagateError(&rerr, rd.Close)
+	log.Debug("uploading")
+	if err := r.write(r.Location(c), rd, creds); err != nil {
+		return err
+	}
+	for _, t := range ChecksumTypes {
+		if err := r.write(r.Location(c)+"."+t.Extension, strings.NewReader(sums[t.Hash]), creds); err != nil {
+			return err
+		}
+	}
+	log.Debug("successfully uploaded")
+	return nil
+}
+
+func checksums(blob blobaccess.DataAccess) (_ map[crypto.Hash]string, rerr error) {
+	rd, err := blob.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer errors.PropagateError(&rerr, rd.Close)
+
+	hashes := map[crypto.Hash]hash.Hash{}
+	var writers []io.Writer
+	for _, t := range ChecksumTypes {
+		h := t.Hash.New()
+		hashes[t.Hash] = h
+		writers = append(writers, h)
+	}
+	if _, err := io.Copy(io.MultiWriter(writers...), rd); err != nil {
+		return nil, err
+	}
+	result := map[crypto.Hash]string{}
+	for k, h := range hashes {
+		result[k] = hex.EncodeToString(h.Sum(nil))
+	}
+	return result, nil
+}
+
+func (r *Repository) reader(location string, creds common.Properties) (io.ReadCloser, error) {
+	if r.IsFileSystem() {
+		f, err := r.fs.Open(location)
+		if err != nil {
+			if vfs.IsErrNotExist(err) {
+				return nil, errors.ErrNotFound("file", location)
+			}
+			return nil, err
+		}
+		return f, nil
+	}
+	resp, err := r.request(http.MethodGet, location, nil, creds)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, errors.ErrNotFound("file", location)
+		}
+		return nil, errors.Newf("request %s provides %s", location, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+func (r *Repository) write(location string, data io.Reader, creds common.Properties) error {
+	if r.IsFileSystem() {
+		if err := r.fs.MkdirAll(vfs.Dir(r.fs, location), 0o755); err != nil {
+			return err
+		}
+		f, err := r.fs.OpenFile(location, vfs.O_CREATE|vfs.O_TRUNC|vfs.O_WRONLY, 0o644)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(f, data)
+		return errors.Join(err, f.Close())
+	}
+	resp, err := r.request(http.MethodPut, location, data, creds)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		buf := &bytes.Buffer{}
+		_, _ = io.Copy(buf, io.LimitReader(resp.Body, 2000))
+		return fmt.Errorf("http (%d) - failed to upload %s: %s", resp.StatusCode, location, buf.String())
+	}
+	return nil
+}
+
+func (r *Repository) request(method, location string, body io.Reader, creds common.Properties) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(context.Background(), method, location, body)
+	if err != nil {
+		return nil, err
+	}
+	if creds != nil {
+		if user := creds[identity.ATTR_USERNAME]; user != "" {
+			req.SetBasicAuth(user, creds[identity.ATTR_PASSWORD])
+		}
+	}
+	return http.DefaultClient.Do(req)
+}
@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ecdsa
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/signing"
+	"github.com/open-component-model/ocm/pkg/signing/signutils"
+)
+
+// Algorithm defines the type for the ECDSA signature algorithm.
+// The used curve is determined by the key, supported are
+// P-256, P-384 and P-521.
+const Algorithm = "ECDSA"
+
+// MediaType defines the media type for a plain ECDSA signature
+// (ASN.1 DER encoded).
+const MediaType = "application/vnd.ocm.signature.ecdsa"
+
+// MediaTypePEM is used if the signature contains the public key certificate chain.
+const MediaTypePEM = signutils.MediaTypePEM
+
+func init() {
+	signing.DefaultHandlerRegistry().RegisterSigner(Algorithm, NewHandler())
+}
+
+type (
+	PrivateKey = ecdsa.PrivateKey
+	PublicKey  = ecdsa.PublicKey
+)
+
+// Handler is a signatures.Signer compatible struct to sign with ECDSA.
+// and a signatures.Verifier compatible struct to verify ECDSA signatures.
+type Handler struct{}
+
+func NewHandler() signing.SignatureHandler {
+	return &Handler{}
+}
+
+func (h Handler) Algorithm() string {
+	return Algorithm
+}
+
+func (h Handler) Sign(cctx credentials.Context, digest string, sctx signing.SigningContext) (signature *signing.Signature, err error) {
+	privateKey, err := GetPrivateKey(sctx.GetPrivateKey())
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid ecdsa private key")
+	}
+	decodedHash, err := hex.DecodeString(digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding hash to bytes")
+	}
+	sig, err := ecdsa.SignASN1(rand.Reader, privateKey, decodedHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed signing hash, %w", err)
+	}
+
+	media := MediaType
+	value := hex.EncodeToString(sig)
+
+	var iss string
+	pub := sctx.GetPublicKey()
+	if pub != nil {
+		var pubKey *PublicKey
+		certs, err := signutils.GetCertificateChain(pub, false)
+		if err == nil && len(certs) > 0 {
+			pubKey, _, err = GetPublicKey(certs[0].PublicKey)
+			if err != nil {
+				return nil, errors.ErrInvalidWrap(err, "public key")
+			}
+			err = signutils.VerifyCertificate(certs[0], certs[1:], sctx.GetRootCerts(), sctx.GetIssuer())
+			if err != nil {
+				return nil, errors.Wrapf(err, "public key certificate")
+			}
+			media = MediaTypePEM
+			value = string(signutils.SignatureBytesToPem(Algorithm, sig, certs...))
+			iss = certs[0].Subject.String()
+		} else {
+			pubKey, _, err = GetPublicKey(pub)
+			if err != nil {
+				return nil, errors.ErrInvalidWrap(err, "public key")
+			}
+		}
+		if !privateKey.PublicKey.Equal(pubKey) {
+			return nil, fmt.Errorf("invalid public key for private key")
+		}
+	}
+
+	return &signing.Signature{
+		Value:     value,
+		MediaType: media,
+		Algorithm: Algorithm,
+		Issuer:    iss,
+	}, nil
+}
+
+// Verify checks the signature, returns an error on verification failure.
+func (h Handler) Verify(digest string, signature *signing.Signature, sctx signing.SigningContext) (err error) {
+	var signatureBytes []byte
+
+	publicKey, name, err := GetPublicKey(sctx.GetPublicKey())
+	if err != nil {
+		return fmt.Errorf("failed to get public key: %w", err)
+	}
+
+	switch signature.MediaType {
+	case MediaType:
+		signatureBytes, err = hex.DecodeString(signature.Value)
+		if err != nil {
+			return fmt.Errorf("unable to get signature value: failed decoding hash %s: %w", digest, err)
+		}
+	case signutils.MediaTypePEM:
+		sig, algo, _, err := signutils.GetSignatureFromPem([]byte(signature.Value))
+		if err != nil {
+			return fmt.Errorf("unable to get signature from pem: %w", err)
+		}
+		if algo != "" && algo != Algorithm {
+			return errors.ErrInvalid(signutils.KIND_SIGN_ALGORITHM, algo)
+		}
+		signatureBytes = sig
+	default:
+		return fmt.Errorf("invalid signature mediaType %s", signature.MediaType)
+	}
+
+	decodedHash, err := hex.DecodeString(digest)
+	if err != nil {
+		return fmt.Errorf("failed decoding hash %s: %w", digest, err)
+	}
+
+	if name != nil {
+		if signature.Issuer != "" {
+			iss, err := signutils.ParseDN(signature.Issuer)
+			if err != nil {
+				return errors.Wrapf(err, "signature issuer")
+			}
+			if signutils.MatchDN(*iss, *name) != nil {
+				return fmt.Errorf("issuer %s does not match %s", signature.Issuer, name)
+			}
+		}
+	}
+	if !ecdsa.VerifyASN1(publicKey, decodedHash, signatureBytes) {
+		return fmt.Errorf("signature verification failed, invalid signature")
+	}
+	return nil
+}
+
+func (_ Handler) CreateKeyPair() (priv signutils.GenericPrivateKey, pub signutils.GenericPublicKey, err error) {
+	return CreateKeyPair()
+}
+
+// CreateKeyPair creates a key pair for the curve P-256.
+func CreateKeyPair() (priv signutils.GenericPrivateKey, pub signutils.GenericPublicKey, err error) {
+	return CreateKeyPairFor(elliptic.P256())
+}
+
+// CreateKeyPairFor creates a key pair for the given curve.
+func CreateKeyPairFor(curve elliptic.Curve) (priv signutils.GenericPrivateKey, pub signutils.GenericPublicKey, err error) {
+	if err := checkCurve(curve); err != nil {
+		return nil, nil, err
+	}
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, &key.PublicKey, nil
+}
@@ -5,6 +5,9 @@
 package handlers
 
 import (
+	_ "github.com/open-component-model/ocm/pkg/signing/handlers/ecdsa"
+	_ "github.com/open-component-model/ocm/pkg/signing/handlers/ed25519"
+	_ "github.com/open-component-model/ocm/pkg/signing/handlers/pkcs11"
 	_ "github.com/open-component-model/ocm/pkg/signing/handlers/rsa"
 	_ "github.com/open-component-model/ocm/pkg/signing/handlers/rsa-pss"
 	_ "github.com/open-component-model/ocm/pkg/signing/handlers/rsa-pss-signingservice"
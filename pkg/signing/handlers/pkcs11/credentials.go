@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pkcs11
+
+import (
+	"strconv"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/listformat"
+)
+
+const (
+	CONSUMER_TYPE = "PKCS11"
+
+	ID_TOKEN_LABEL = "tokenLabel"
+	ID_SLOT        = "slot"
+
+	ATTR_PIN = "pin"
+)
+
+func init() {
+	attrs := listformat.FormatListElements("", listformat.StringElementDescriptionList{
+		ATTR_PIN, "user PIN used to log in to the token",
+	})
+	ids := listformat.FormatListElements("", listformat.StringElementDescriptionList{
+		ID_TOKEN_LABEL, "label of the token",
+		ID_SLOT, "(optional) slot number of the token",
+	})
+	cpi.RegisterStandardIdentity(CONSUMER_TYPE, cpi.PartialMatch,
+		`PKCS#11 token credential matcher
+
+This matcher matches credentials for a PKCS#11 token used for signing.
+All attributes of a configured identity must match the requested one.
+It uses the following identity attributes:
+`+ids,
+		attrs)
+}
+
+// GetConsumerId provides the consumer identity used to look up the
+// credentials for the token hosting the given key.
+func GetConsumerId(key *Key) credentials.ConsumerIdentity {
+	id := credentials.ConsumerIdentity{
+		cpi.ID_TYPE: CONSUMER_TYPE,
+	}
+	if key.TokenLabel != "" {
+		id[ID_TOKEN_LABEL] = key.TokenLabel
+	}
+	if key.Slot != nil {
+		id[ID_SLOT] = strconv.Itoa(*key.Slot)
+	}
+	return id
+}
+
+// GetPIN provides the PIN for the token hosting the given key
+// from the credentials context.
+func GetPIN(cctx credentials.Context, key *Key) (string, error) {
+	id := GetConsumerId(key)
+	creds, err := credentials.CredentialsForConsumer(cctx, id, cpi.PartialMatch)
+	if err != nil {
+		return "", err
+	}
+	if creds == nil || !creds.ExistsProperty(ATTR_PIN) {
+		return "", errors.ErrNotFound(credentials.KIND_CREDENTIALS, id.String())
+	}
+	return creds.GetProperty(ATTR_PIN), nil
+}
@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pkcs11
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/runtime"
+	"github.com/open-component-model/ocm/pkg/signing"
+	ecdsahandler "github.com/open-component-model/ocm/pkg/signing/handlers/ecdsa"
+	rsahandler "github.com/open-component-model/ocm/pkg/signing/handlers/rsa"
+	"github.com/open-component-model/ocm/pkg/signing/signutils"
+)
+
+// Name is the name of the signer using a key stored on a PKCS#11 token.
+// The resulting signature uses the algorithm of the key found on the
+// token (RSA or ECDSA) and can therefore be verified by the standard
+// verifiers for these algorithms.
+const Name = "pkcs11"
+
+// Key describes the key to use on a PKCS#11 token.
+// It is passed as private key to the signer.
+type Key struct {
+	// Library is the path of the PKCS#11 module to load.
+	Library string `json:"library"`
+	// TokenLabel selects the token by its label.
+	TokenLabel string `json:"tokenLabel,omitempty"`
+	// Slot selects the token by its slot number.
+	Slot *int `json:"slot,omitempty"`
+	// KeyId is the hex encoded object id (CKA_ID) of the key.
+	KeyId string `json:"id,omitempty"`
+	// KeyLabel is the object label (CKA_LABEL) of the key.
+	KeyLabel string `json:"label,omitempty"`
+}
+
+func (k *Key) Validate() error {
+	if k.Library == "" {
+		return errors.ErrRequired("library")
+	}
+	if k.TokenLabel == "" && k.Slot == nil {
+		return errors.ErrRequired("token label or slot")
+	}
+	if k.TokenLabel != "" && k.Slot != nil {
+		return errors.Newf("only one of token label or slot possible")
+	}
+	if k.KeyId == "" && k.KeyLabel == "" {
+		return errors.ErrRequired("key id or label")
+	}
+	if k.KeyId != "" {
+		if _, err := hex.DecodeString(k.KeyId); err != nil {
+			return errors.ErrInvalidWrap(err, "key id", k.KeyId)
+		}
+	}
+	return nil
+}
+
+func init() {
+	signing.DefaultHandlerRegistry().RegisterSigner(Name, NewHandler())
+}
+
+// Handler is a signatures.Signer compatible struct to sign
+// with a key stored on a PKCS#11 token.
+type Handler struct{}
+
+func NewHandler() signing.Signer {
+	return &Handler{}
+}
+
+func (h *Handler) Algorithm() string {
+	return Name
+}
+
+func (h *Handler) Sign(cctx credentials.Context, digest string, sctx signing.SigningContext) (signature *signing.Signature, err error) {
+	key, err := PrivateKey(sctx.GetPrivateKey())
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid pkcs11 key specification")
+	}
+	if err := key.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid pkcs11 key specification")
+	}
+	pin, err := GetPIN(cctx, key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "no PIN for token")
+	}
+	decodedHash, err := hex.DecodeString(digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding hash to bytes")
+	}
+
+	signer, closer, err := openSigner(key, pin)
+	if err != nil {
+		return nil, err
+	}
+	defer errors.PropagateError(&err, closer.Close)
+
+	var (
+		algo  string
+		media string
+		opts  crypto.SignerOpts
+	)
+	switch signer.Public().(type) {
+	case *rsa.PublicKey:
+		algo, media, opts = rsahandler.Algorithm, rsahandler.MediaType, sctx.GetHash()
+	case *ecdsa.PublicKey:
+		// the token returns ASN.1 DER encoded signatures
+		algo, media, opts = ecdsahandler.Algorithm, ecdsahandler.MediaType, crypto.Hash(0)
+	default:
+		return nil, errors.ErrNotSupported("key type", fmt.Sprintf("%T", signer.Public()))
+	}
+	sig, err := signer.Sign(rand.Reader, decodedHash, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed signing hash, %w", err)
+	}
+
+	value := hex.EncodeToString(sig)
+	var iss string
+	pub := sctx.GetPublicKey()
+	if pub != nil {
+		var pubKey interface{}
+		certs, err := signutils.GetCertificateChain(pub, false)
+		if err == nil && len(certs) > 0 {
+			err = signutils.VerifyCertificate(certs[0], certs[1:], sctx.GetRootCerts(), sctx.GetIssuer())
+			if err != nil {
+				return nil, errors.Wrapf(err, "public key certificate")
+			}
+			pubKey = certs[0].PublicKey
+			media = signutils.MediaTypePEM
+			value = string(signutils.SignatureBytesToPem(algo, sig, certs...))
+			iss = certs[0].Subject.String()
+		} else {
+			pubKey, err = signutils.GetPublicKey(pub)
+			if err != nil {
+				return nil, errors.ErrInvalidWrap(err, "public key")
+			}
+		}
+		if k, ok := pubKey.(interface{ Equal(crypto.PublicKey) bool }); !ok || !k.Equal(signer.Public()) {
+			return nil, fmt.Errorf("invalid public key for token key")
+		}
+	}
+
+	return &signing.Signature{
+		Value:     value,
+		MediaType: media,
+		Algorithm: algo,
+		Issuer:    iss,
+	}, nil
+}
+
+func PrivateKey(k interface{}) (*Key, error) {
+	switch t := k.(type) {
+	case *Key:
+		return t, nil
+	case []byte:
+		key := &Key{}
+		err := runtime.DefaultYAMLEncoding.Unmarshal(t, key)
+		if err != nil {
+			return nil, err
+		}
+		return key, err
+	default:
+		return nil, fmt.Errorf("unknown key specification %T", k)
+	}
+}
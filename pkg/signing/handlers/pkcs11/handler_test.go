@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pkcs11_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/signing"
+	"github.com/open-component-model/ocm/pkg/signing/handlers/pkcs11"
+	"github.com/open-component-model/ocm/pkg/signing/hasher/sha256"
+)
+
+const DIGEST = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+var _ = Describe("pkcs11 signer", func() {
+	var cctx credentials.Context
+
+	BeforeEach(func() {
+		cctx = credentials.New()
+	})
+
+	It("parses key specification", func() {
+		key := Must(pkcs11.PrivateKey([]byte(`
+library: /usr/lib/softhsm/libsofthsm2.so
+tokenLabel: test
+slot: 1
+id: "0102"
+`)))
+		slot := 1
+		Expect(key).To(Equal(&pkcs11.Key{
+			Library:    "/usr/lib/softhsm/libsofthsm2.so",
+			TokenLabel: "test",
+			Slot:       &slot,
+			KeyId:      "0102",
+		}))
+		Expect(pkcs11.GetConsumerId(key)).To(Equal(credentials.ConsumerIdentity{
+			credentials.ID_TYPE:   pkcs11.CONSUMER_TYPE,
+			pkcs11.ID_TOKEN_LABEL: "test",
+			pkcs11.ID_SLOT:        "1",
+		}))
+	})
+
+	It("rejects incomplete key specification", func() {
+		key := &pkcs11.Key{Library: "lib.so", TokenLabel: "test"}
+		Expect(key.Validate()).To(MatchError(`"key id or label" required`))
+		key.KeyId = "xyz"
+		Expect(key.Validate()).To(MatchError(ContainSubstring(`key id "xyz" is invalid`)))
+	})
+
+	It("validates token selection", func() {
+		slot := 1
+		key := &pkcs11.Key{Library: "lib.so", TokenLabel: "test", KeyLabel: "key"}
+		MustBeSuccessful(key.Validate())
+		key = &pkcs11.Key{Library: "lib.so", Slot: &slot, KeyLabel: "key"}
+		MustBeSuccessful(key.Validate())
+		key.TokenLabel = "test"
+		Expect(key.Validate()).To(MatchError("only one of token label or slot possible"))
+	})
+
+	It("provides PIN from credentials context", func() {
+		key := &pkcs11.Key{Library: "lib.so", TokenLabel: "test", KeyLabel: "key"}
+		cctx.SetCredentialsForConsumer(credentials.ConsumerIdentity{
+			credentials.ID_TYPE:   pkcs11.CONSUMER_TYPE,
+			pkcs11.ID_TOKEN_LABEL: "test",
+		}, credentials.DirectCredentials{pkcs11.ATTR_PIN: "1234"})
+
+		Expect(pkcs11.GetPIN(cctx, key)).To(Equal("1234"))
+
+		key.TokenLabel = "other"
+		ExpectError(pkcs11.GetPIN(cctx, key)).To(MatchError(ContainSubstring("not found")))
+	})
+
+	It("fails without PIN", func() {
+		sctx := &signing.DefaultSigningContext{
+			Hash:       signing.DefaultRegistry().GetHasher(sha256.Algorithm).Crypto(),
+			PrivateKey: &pkcs11.Key{Library: "lib.so", TokenLabel: "test", KeyLabel: "key"},
+		}
+		ExpectError(signing.DefaultHandlerRegistry().GetSigner(pkcs11.Name).Sign(cctx, DIGEST, sctx)).To(MatchError(ContainSubstring("no PIN for token")))
+	})
+})
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build cgo
+
+package pkcs11_test
+
+import (
+	"crypto/elliptic"
+	"os"
+
+	"github.com/ThalesIgnite/crypto11"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/signing"
+	"github.com/open-component-model/ocm/pkg/signing/handlers/ecdsa"
+	"github.com/open-component-model/ocm/pkg/signing/handlers/pkcs11"
+	"github.com/open-component-model/ocm/pkg/signing/handlers/rsa"
+	"github.com/open-component-model/ocm/pkg/signing/hasher/sha256"
+)
+
+// The token test requires an initialized SoftHSM (or other) token.
+// It is configured by the environment variables
+//   - PKCS11_TEST_LIBRARY: path of the PKCS#11 module
+//   - PKCS11_TEST_TOKEN: label of the token
+//   - PKCS11_TEST_PIN: user PIN of the token
+var _ = Describe("pkcs11 token", func() {
+	lib := os.Getenv("PKCS11_TEST_LIBRARY")
+	token := os.Getenv("PKCS11_TEST_TOKEN")
+	pin := os.Getenv("PKCS11_TEST_PIN")
+
+	var (
+		cctx credentials.Context
+		tctx *crypto11.Context
+	)
+
+	BeforeEach(func() {
+		if lib == "" || token == "" {
+			Skip("no PKCS#11 test token configured")
+		}
+		cctx = credentials.New()
+		cctx.SetCredentialsForConsumer(credentials.ConsumerIdentity{
+			credentials.ID_TYPE:   pkcs11.CONSUMER_TYPE,
+			pkcs11.ID_TOKEN_LABEL: token,
+		}, credentials.DirectCredentials{pkcs11.ATTR_PIN: pin})
+		tctx = Must(crypto11.Configure(&crypto11.Config{Path: lib, TokenLabel: token, Pin: pin}))
+	})
+
+	AfterEach(func() {
+		if tctx != nil {
+			tctx.Close()
+		}
+	})
+
+	DescribeTable("signs with token key", func(algo, media string, create func(id []byte) (crypto11.Signer, error)) {
+		id := []byte("ocm-" + algo)
+		key := Must(create(id))
+		defer key.Delete()
+
+		hasher := signing.DefaultRegistry().GetHasher(sha256.Algorithm)
+		hash := Must(signing.Hash(hasher.Create(), []byte("test")))
+		sctx := &signing.DefaultSigningContext{
+			Hash:       hasher.Crypto(),
+			PrivateKey: &pkcs11.Key{Library: lib, TokenLabel: token, KeyLabel: string(id)},
+			PublicKey:  key.Public(),
+		}
+		sig := Must(signing.DefaultHandlerRegistry().GetSigner(pkcs11.Name).Sign(cctx, hash, sctx))
+		Expect(sig.Algorithm).To(Equal(algo))
+		Expect(sig.MediaType).To(Equal(media))
+		MustBeSuccessful(signing.DefaultHandlerRegistry().GetVerifier(algo).Verify(hash, sig, sctx))
+	},
+		Entry("rsa", rsa.Algorithm, rsa.MediaType, func(id []byte) (crypto11.Signer, error) {
+			return tctx.GenerateRSAKeyPairWithLabel(id, id, 2048)
+		}),
+		Entry("ecdsa", ecdsa.Algorithm, ecdsa.MediaType, func(id []byte) (crypto11.Signer, error) {
+			return tctx.GenerateECDSAKeyPairWithLabel(id, id, elliptic.P256())
+		}),
+	)
+})
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build cgo
+
+package pkcs11
+
+import (
+	"crypto"
+	"encoding/hex"
+	"io"
+
+	"github.com/ThalesIgnite/crypto11"
+
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+func openSigner(key *Key, pin string) (crypto.Signer, io.Closer, error) {
+	ctx, err := crypto11.Configure(&crypto11.Config{
+		Path:       key.Library,
+		TokenLabel: key.TokenLabel,
+		SlotNumber: key.Slot,
+		Pin:        pin,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "cannot open PKCS#11 token")
+	}
+
+	var id, label []byte
+	if key.KeyId != "" {
+		id, _ = hex.DecodeString(key.KeyId)
+	}
+	if key.KeyLabel != "" {
+		label = []byte(key.KeyLabel)
+	}
+	signer, err := ctx.FindKeyPair(id, label)
+	if err == nil && signer == nil {
+		err = errors.ErrNotFound("key", keyName(key))
+	}
+	if err != nil {
+		ctx.Close()
+		return nil, nil, err
+	}
+	return signer, ctx, nil
+}
+
+func keyName(key *Key) string {
+	if key.KeyId != "" {
+		return key.KeyId
+	}
+	return key.KeyLabel
+}
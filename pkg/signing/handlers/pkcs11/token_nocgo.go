@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !cgo
+
+package pkcs11
+
+import (
+	"crypto"
+	"io"
+
+	"github.com/open-component-model/ocm/pkg/errors"
+)
+
+func openSigner(key *Key, pin string) (crypto.Signer, io.Closer, error) {
+	return nil, nil, errors.Newf("PKCS#11 signing requires a binary built with cgo")
+}
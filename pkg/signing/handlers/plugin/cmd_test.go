@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build unix
+
+package plugin_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/cmds/ocm/testhelper"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/plugindirattr"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/signingattr"
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/registration"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/signing"
+	"github.com/open-component-model/ocm/pkg/signing/handlers/plugin"
+	rsa_pss "github.com/open-component-model/ocm/pkg/signing/handlers/rsa-pss"
+)
+
+const (
+	ARCH      = "/tmp/ctf"
+	COMPONENT = "acme.org/test"
+	VERSION   = "v1"
+	SIGNATURE = "acme.org"
+	ALGORITHM = "test"
+)
+
+var _ = Describe("plugin signing handler", func() {
+	var env *TestEnv
+	var ctx ocm.Context
+
+	setCredentials := func(key string) {
+		env.CredentialsContext().SetCredentialsForConsumer(
+			plugin.GetConsumerId(ALGORITHM, nil),
+			credentials.DirectCredentials{"key": key},
+		)
+	}
+
+	BeforeEach(func() {
+		env = NewTestEnv(TestData())
+		ctx = env.OCMContext()
+		plugindirattr.Set(ctx, "testdata")
+		MustBeSuccessful(registration.RegisterExtensions(ctx))
+
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.Component(COMPONENT, func() {
+				env.Version(VERSION, func() {
+					env.Provider("acme.org")
+					env.Resource("testdata", "", "PlainText", metav1.LocalRelation, func() {
+						env.BlobStringData(mime.MIME_TEXT, "testdata")
+					})
+				})
+			})
+		})
+		setCredentials("secret")
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("registers signing handler", func() {
+		h := signingattr.Get(ctx).GetSigner(ALGORITHM)
+		Expect(h).NotTo(BeNil())
+		Expect(signing.ManagesKeys(h)).To(BeTrue())
+		Expect(signingattr.Get(ctx).GetVerifier(ALGORITHM)).To(BeIdenticalTo(h))
+		Expect(signing.DefaultHandlerRegistry().GetSigner(ALGORITHM)).To(BeNil())
+	})
+
+	It("does not replace built-in signing handlers", func() {
+		reg := signingattr.Get(ctx)
+		Expect(reg.GetSigner(rsa_pss.Algorithm)).To(BeIdenticalTo(signing.DefaultHandlerRegistry().GetSigner(rsa_pss.Algorithm)))
+		Expect(reg.GetVerifier(rsa_pss.Algorithm)).To(BeIdenticalTo(signing.DefaultHandlerRegistry().GetVerifier(rsa_pss.Algorithm)))
+		Expect(signing.ManagesKeys(reg.GetVerifier(rsa_pss.Algorithm))).To(BeFalse())
+	})
+
+	It("signs and verifies component version without local keys", func() {
+		buf := bytes.NewBuffer(nil)
+		MustBeSuccessful(env.CatchOutput(buf).Execute("sign", "components", "-s", SIGNATURE, "--algorithm", ALGORITHM, "--repo", ARCH, COMPONENT+":"+VERSION))
+		Expect(buf.String()).To(ContainSubstring("successfully signed " + COMPONENT + ":" + VERSION))
+
+		repo := Must(ctf.Open(ctx, accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(repo, "repo")
+		cv := Must(repo.LookupComponentVersion(COMPONENT, VERSION))
+		defer Close(cv, "cv")
+
+		i := cv.GetDescriptor().GetSignatureIndex(SIGNATURE)
+		Expect(i).To(BeNumerically(">=", 0))
+		sig := cv.GetDescriptor().Signatures[i]
+		Expect(sig.Signature.Algorithm).To(Equal(ALGORITHM))
+		Expect(sig.Signature.MediaType).To(Equal("application/vnd.test.signature"))
+		Expect(sig.Signature.Issuer).To(Equal("acme.org"))
+		Expect(sig.Signature.Value).To(Equal("secret:SHA-256:" + sig.Digest.Value))
+
+		buf.Reset()
+		MustBeSuccessful(env.CatchOutput(buf).Execute("verify", "components", "-s", SIGNATURE, "--repo", ARCH, COMPONENT+":"+VERSION))
+		Expect(buf.String()).To(ContainSubstring("successfully verified " + COMPONENT + ":" + VERSION))
+	})
+
+	It("verifies with dedicated algorithm", func() {
+		MustBeSuccessful(env.Execute("sign", "components", "-s", SIGNATURE, "--algorithm", ALGORITHM, "--repo", ARCH, COMPONENT+":"+VERSION))
+
+		MustBeSuccessful(env.Execute("verify", "components", "-s", SIGNATURE, "--algorithm", ALGORITHM, "--repo", ARCH, COMPONENT+":"+VERSION))
+		Expect(env.Execute("verify", "components", "-s", SIGNATURE, "--algorithm", "unknown", "--repo", ARCH, COMPONENT+":"+VERSION)).To(MatchError(`signature verification algorithm "unknown" is unknown`))
+		Expect(env.Execute("verify", "components", "-s", SIGNATURE, "--algorithm", rsa_pss.Algorithm, "--repo", ARCH, COMPONENT+":"+VERSION)).To(MatchError(ContainSubstring(`signature algorithm "test" does not match requested verification algorithm "RSASSA-PSS"`)))
+	})
+
+	It("fails verification with wrong credentials", func() {
+		MustBeSuccessful(env.Execute("sign", "components", "-s", SIGNATURE, "--algorithm", ALGORITHM, "--repo", ARCH, COMPONENT+":"+VERSION))
+
+		setCredentials("other")
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("verify", "components", "-s", SIGNATURE, "--repo", ARCH, COMPONENT+":"+VERSION)).To(HaveOccurred())
+		Expect(buf.String()).To(ContainSubstring("signature mismatch"))
+	})
+})
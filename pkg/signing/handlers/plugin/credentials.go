@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"crypto/x509/pkix"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/cpi"
+	"github.com/open-component-model/ocm/pkg/listformat"
+	"github.com/open-component-model/ocm/pkg/signing/signutils"
+)
+
+const (
+	CONSUMER_TYPE = "Signinghandler" + common.OCM_TYPE_GROUP_SUFFIX
+
+	ID_ALGORITHM = "algorithm"
+	ID_ISSUER    = "issuer"
+)
+
+func init() {
+	ids := listformat.FormatListElements("", listformat.StringElementDescriptionList{
+		ID_ALGORITHM, "name of the signing handler (signature algorithm)",
+		ID_ISSUER, "(optional) distinguished name of the issuer",
+	})
+	cpi.RegisterStandardIdentity(CONSUMER_TYPE, cpi.PartialMatch,
+		`plugin signing handler credential matcher
+
+This matcher matches credentials for signing handlers provided by plugins.
+All attributes of a configured identity must match the requested one.
+It uses the following identity attributes:
+`+ids,
+		`The evaluated properties are defined by the signing handler of the plugin.
+`)
+}
+
+// GetConsumerId provides the consumer identity used to look up the
+// credentials for a signing handler and an optional issuer.
+func GetConsumerId(algo string, issuer *pkix.Name) credentials.ConsumerIdentity {
+	id := credentials.ConsumerIdentity{
+		cpi.ID_TYPE:  CONSUMER_TYPE,
+		ID_ALGORITHM: algo,
+	}
+	if issuer != nil {
+		id[ID_ISSUER] = signutils.NormalizeDN(*issuer)
+	}
+	return id
+}
@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/plugin/descriptor"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/signing"
+	"github.com/open-component-model/ocm/pkg/signing/signutils"
+)
+
+// Handler is a signing.SignatureHandler delegating signing and
+// verification to a signing handler provided by a plugin.
+// The keys are managed by the plugin, locally configured keys
+// are optional and passed to the plugin, if present.
+type Handler struct {
+	plugin     plugin.Plugin
+	descriptor *descriptor.SigningHandlerDescriptor
+}
+
+var (
+	_ signing.SignatureHandler = (*Handler)(nil)
+	_ signing.KeyManager       = (*Handler)(nil)
+)
+
+func New(p plugin.Plugin, name string) (*Handler, error) {
+	hd := p.GetSigningHandlerDescriptor(name)
+	if hd == nil {
+		return nil, errors.ErrUnknown(plugin.KIND_SIGNINGHANDLER, name, plugin.KIND_PLUGIN, p.Name())
+	}
+	return &Handler{
+		plugin:     p,
+		descriptor: hd,
+	}, nil
+}
+
+func (h *Handler) Algorithm() string {
+	return h.descriptor.Name
+}
+
+func (h *Handler) Description() string {
+	return h.descriptor.Description
+}
+
+func (h *Handler) ManagesKeys() bool {
+	return true
+}
+
+func (h *Handler) Sign(cctx credentials.Context, digest string, sctx signing.SigningContext) (*signing.Signature, error) {
+	key, err := keyData(sctx.GetPrivateKey(), signutils.PemBlockForPrivateKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "private key")
+	}
+	creds, err := h.getCredentials(cctx, sctx.GetIssuer())
+	if err != nil {
+		return nil, err
+	}
+	req := &plugin.SigningRequest{
+		Digest:        digest,
+		HashAlgorithm: sctx.GetHash().String(),
+		Issuer:        issuerName(sctx.GetIssuer()),
+		PrivateKey:    key,
+	}
+	sig, err := h.plugin.Sign(h.Algorithm(), req, creds)
+	if err != nil {
+		return nil, err
+	}
+	if sig.Algorithm == "" {
+		sig.Algorithm = h.Algorithm()
+	}
+	return &signing.Signature{
+		Value:     sig.Value,
+		MediaType: sig.MediaType,
+		Algorithm: sig.Algorithm,
+		Issuer:    sig.Issuer,
+	}, nil
+}
+
+func (h *Handler) Verify(digest string, sig *signing.Signature, sctx signing.SigningContext) error {
+	key, err := keyData(sctx.GetPublicKey(), publicKeyPemBlock)
+	if err != nil {
+		return errors.Wrapf(err, "public key")
+	}
+	creds, err := h.getCredentials(h.plugin.Context().CredentialsContext(), sctx.GetIssuer())
+	if err != nil {
+		return err
+	}
+	req := &plugin.VerificationRequest{
+		Digest:        digest,
+		HashAlgorithm: sctx.GetHash().String(),
+		Issuer:        issuerName(sctx.GetIssuer()),
+		PublicKey:     key,
+		Signature: plugin.Signature{
+			Value:     sig.Value,
+			MediaType: sig.MediaType,
+			Algorithm: sig.Algorithm,
+			Issuer:    sig.Issuer,
+		},
+	}
+	return h.plugin.Verify(h.Algorithm(), req, creds)
+}
+
+func (h *Handler) getCredentials(cctx credentials.Context, issuer *pkix.Name) (json.RawMessage, error) {
+	if cctx == nil {
+		return nil, nil
+	}
+	creds, err := credentials.CredentialsForConsumer(cctx, GetConsumerId(h.Algorithm(), issuer))
+	if err != nil || creds == nil {
+		return nil, err
+	}
+	data, err := json.Marshal(creds.Properties())
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot marshal credentials")
+	}
+	return data, nil
+}
+
+func issuerName(issuer *pkix.Name) string {
+	if issuer == nil {
+		return ""
+	}
+	return signutils.NormalizeDN(*issuer)
+}
+
+// keyData provides the serialized form of a key passed to the plugin.
+// Keys configured for the OCM CLI are already given as byte sequence,
+// for example a PEM encoded key or a key reference understood by the
+// plugin. Parsed keys are PEM encoded.
+func keyData(key interface{}, pemBlock func(interface{}) *pem.Block) ([]byte, error) {
+	switch t := key.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return t, nil
+	case string:
+		return []byte(t), nil
+	default:
+		block := pemBlock(t)
+		if block == nil {
+			return nil, errors.ErrInvalid("key type", fmt.Sprintf("%T", t))
+		}
+		return pem.EncodeToMemory(block), nil
+	}
+}
+
+func publicKeyPemBlock(key interface{}) *pem.Block {
+	return signutils.PemBlockForPublicKey(key, true)
+}
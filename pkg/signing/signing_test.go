@@ -5,15 +5,23 @@
 package signing_test
 
 import (
+	"crypto/elliptic"
+	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/pem"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/open-component-model/ocm/pkg/contexts/credentials"
 	"github.com/open-component-model/ocm/pkg/signing"
+	"github.com/open-component-model/ocm/pkg/signing/handlers/ecdsa"
+	"github.com/open-component-model/ocm/pkg/signing/handlers/ed25519"
 	"github.com/open-component-model/ocm/pkg/signing/handlers/rsa"
 	"github.com/open-component-model/ocm/pkg/signing/hasher/sha256"
+	"github.com/open-component-model/ocm/pkg/signing/hasher/sha512"
+	"github.com/open-component-model/ocm/pkg/signing/signutils"
 )
 
 var registry = signing.DefaultRegistry()
@@ -56,4 +64,124 @@ var _ = Describe("signing", func() {
 		hash = "A" + hash[1:]
 		Expect(registry.GetVerifier(rsa.Algorithm).Verify(hash, sig, sctx)).To(HaveOccurred())
 	})
+
+	DescribeTable("uses elliptic curve signers", func(algo string, media string, create func() (signutils.GenericPrivateKey, signutils.GenericPublicKey, error), hashAlgo string) {
+		hasher := registry.GetHasher(hashAlgo)
+		hash, _ := signing.Hash(hasher.Create(), []byte("test"))
+
+		priv, pub, err := create()
+		Expect(err).To(Succeed())
+
+		sctx := &signing.DefaultSigningContext{
+			Hash:       hasher.Crypto(),
+			PrivateKey: priv,
+			PublicKey:  pub,
+		}
+		sig, err := registry.GetSigner(algo).Sign(defaultContext, hash, sctx)
+		Expect(err).To(Succeed())
+		Expect(sig.MediaType).To(Equal(media))
+		Expect(sig.Algorithm).To(Equal(algo))
+
+		Expect(registry.GetVerifier(algo).Verify(hash, sig, sctx)).To(Succeed())
+		hash = "A" + hash[1:]
+		Expect(registry.GetVerifier(algo).Verify(hash, sig, sctx)).To(HaveOccurred())
+	},
+		Entry("ecdsa P-256", ecdsa.Algorithm, ecdsa.MediaType, ecdsa.CreateKeyPair, sha256.Algorithm),
+		Entry("ecdsa P-384", ecdsa.Algorithm, ecdsa.MediaType, createECDSAKeyPair(elliptic.P384()), sha512.Algorithm),
+		Entry("ecdsa P-521", ecdsa.Algorithm, ecdsa.MediaType, createECDSAKeyPair(elliptic.P521()), sha512.Algorithm),
+		Entry("ed25519", ed25519.Algorithm, ed25519.MediaType, ed25519.CreateKeyPair, sha256.Algorithm),
+	)
+
+	It("rejects unsupported ecdsa curves", func() {
+		_, _, err := ecdsa.CreateKeyPairFor(elliptic.P224())
+		Expect(err).To(MatchError("elliptic curve \"P-224\" not supported"))
+	})
+
+	It("uses ecdsa keys in PKCS#8 format", func() {
+		hasher := registry.GetHasher(sha256.Algorithm)
+		hash, _ := signing.Hash(hasher.Create(), []byte("test"))
+
+		priv, pub, err := ecdsa.CreateKeyPair()
+		Expect(err).To(Succeed())
+		der, err := x509.MarshalPKCS8PrivateKey(priv)
+		Expect(err).To(Succeed())
+		data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+		key, err := ecdsa.ParseKey(data)
+		Expect(err).To(Succeed())
+		Expect(key).To(Equal(priv))
+		p, _, err := ecdsa.GetPublicKey(data)
+		Expect(err).To(Succeed())
+		Expect(p).To(Equal(pub))
+
+		sctx := &signing.DefaultSigningContext{
+			Hash:       hasher.Crypto(),
+			PrivateKey: data,
+			PublicKey:  pub,
+		}
+		sig, err := registry.GetSigner(ecdsa.Algorithm).Sign(defaultContext, hash, sctx)
+		Expect(err).To(Succeed())
+		Expect(registry.GetVerifier(ecdsa.Algorithm).Verify(hash, sig, sctx)).To(Succeed())
+	})
+
+	DescribeTable("uses elliptic curve signers with certificate chain", func(algo string, create func() (signutils.GenericPrivateKey, signutils.GenericPublicKey, error)) {
+		hasher := registry.GetHasher(sha256.Algorithm)
+		hash, _ := signing.Hash(hasher.Create(), []byte("test"))
+
+		capriv, _, err := create()
+		Expect(err).To(Succeed())
+		ca, _, err := signutils.CreateCertificate(&signutils.Specification{
+			Subject:      pkix.Name{CommonName: "ca-authority"},
+			Validity:     time.Hour,
+			CAPrivateKey: capriv,
+			IsCA:         true,
+			Usages:       signutils.Usages{x509.ExtKeyUsageCodeSigning, x509.KeyUsageDigitalSignature},
+		})
+		Expect(err).To(Succeed())
+
+		priv, pub, err := create()
+		Expect(err).To(Succeed())
+		_, certs, err := signutils.CreateCertificate(&signutils.Specification{
+			Subject:      *ISSUER,
+			Validity:     time.Hour,
+			RootCAs:      ca,
+			CAChain:      ca,
+			CAPrivateKey: capriv,
+			PublicKey:    pub,
+			Usages:       signutils.Usages{x509.ExtKeyUsageCodeSigning},
+		})
+		Expect(err).To(Succeed())
+
+		sctx := &signing.DefaultSigningContext{
+			Hash:       hasher.Crypto(),
+			PrivateKey: priv,
+			PublicKey:  certs,
+			RootCerts:  ca,
+			Issuer:     ISSUER,
+		}
+		sig, err := registry.GetSigner(algo).Sign(defaultContext, hash, sctx)
+		Expect(err).To(Succeed())
+		Expect(sig.MediaType).To(Equal(signutils.MediaTypePEM))
+		Expect(sig.Issuer).To(Equal("CN=mandelsoft"))
+
+		_, _, chain, err := signutils.GetSignatureFromPem([]byte(sig.Value))
+		Expect(err).To(Succeed())
+		Expect(chain).To(HaveLen(2))
+		sctx.PublicKey = chain[0]
+		Expect(registry.GetVerifier(algo).Verify(hash, sig, sctx)).To(Succeed())
+
+		sctx.PublicKey = certs
+		sctx.RootCerts = x509.NewCertPool()
+		_, err = registry.GetSigner(algo).Sign(defaultContext, hash, sctx)
+		Expect(err).To(HaveOccurred())
+	},
+		Entry("ecdsa", ecdsa.Algorithm, ecdsa.CreateKeyPair),
+		Entry("ed25519", ed25519.Algorithm, ed25519.CreateKeyPair),
+	)
 })
+
+func createECDSAKeyPair(c elliptic.Curve) func() (signutils.GenericPrivateKey, signutils.GenericPublicKey, error) {
+	return func() (signutils.GenericPrivateKey, signutils.GenericPublicKey, error) {
+		return ecdsa.CreateKeyPairFor(c)
+	}
+}
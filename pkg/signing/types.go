@@ -102,6 +102,24 @@ type SignatureHandler interface {
 	Verifier
 }
 
+// KeyManager is an optional interface for Signer and Verifier
+// implementations managing their keys on their own, for example
+// by using a remote key management service. For such handlers
+// no locally provided keys are required. If keys are configured
+// nevertheless, they are passed as usual.
+type KeyManager interface {
+	ManagesKeys() bool
+}
+
+// ManagesKeys checks whether the given signer or verifier manages
+// its keys on its own.
+func ManagesKeys(h interface{}) bool {
+	if m, ok := h.(KeyManager); ok {
+		return m.ManagesKeys()
+	}
+	return false
+}
+
 // Hasher creates a new hash.Hash interface.
 type Hasher interface {
 	Algorithm() string
@@ -0,0 +1,538 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/generics"
+	"github.com/open-component-model/ocm/pkg/toi"
+	"github.com/open-component-model/ocm/pkg/toi/install"
+	"github.com/open-component-model/ocm/pkg/utils"
+)
+
+const (
+	OptionKubeconfig       = "KUBECONFIG"
+	OptionContext          = "KUBE_CONTEXT"
+	OptionNamespace        = "NAMESPACE"
+	OptionServiceAccount   = "SERVICE_ACCOUNT"
+	OptionImagePullSecret  = "IMAGE_PULL_SECRET"
+	OptionPullPolicy       = "PULL_POLICY"
+	OptionCleanup          = "CLEANUP_RESOURCES"
+	OptionTimeout          = "TIMEOUT"
+	OptionCollectorImage   = "COLLECTOR_IMAGE"
+	PullPolicyAlways       = string(corev1.PullAlways)
+	PullPolicyNever        = string(corev1.PullNever)
+	PullPolicyIfNotPresent = string(corev1.PullIfNotPresent)
+
+	DefaultNamespace    = "default"
+	DefaultTimeout      = 30 * time.Minute
+	DefaultPollInterval = 2 * time.Second
+
+	// LabelJob is the label used to mark all resources
+	// created for an operation.
+	LabelJob = "toi.ocm.software/job"
+
+	ContainerExecutor  = "executor"
+	ContainerCollector = "collector"
+
+	trueAsString  = "true"
+	falseAsString = "false"
+)
+
+var Options = generics.NewSet[string](
+	OptionKubeconfig,
+	OptionContext,
+	OptionNamespace,
+	OptionServiceAccount,
+	OptionImagePullSecret,
+	OptionPullPolicy,
+	OptionCleanup,
+	OptionTimeout,
+	OptionCollectorImage,
+)
+
+// collectorScript stores the outputs found in the result volume into the
+// output config map. The arguments are pairs of the form <key>=<path>.
+const collectorScript = `
+set -e
+n=$#
+for a in "$@"; do
+  if [ -f "$OUTPUTS/${a#*=}" ]; then
+    set -- "$@" "--from-file=${a%%=*}=$OUTPUTS/${a#*=}"
+  fi
+done
+shift $n
+kubectl create configmap "$CONFIGMAP" "$@" --dry-run=client -o yaml | kubectl apply -f -
+`
+
+// Driver is capable of running TOI executor images as Kubernetes Job.
+// The input files are provided by a secret, the outputs are collected
+// from a result volume by a collector container, which stores them in a
+// config map. Therefore, the used service account must be able to read
+// and patch config maps in the target namespace. There is no default for
+// the collector image (providing sh and kubectl), it must be configured
+// with option COLLECTOR_IMAGE, preferably pinned by digest.
+type Driver struct {
+	config map[string]string
+	// If true, this will not actually run a job.
+	Simulate bool
+	// PollInterval is the interval used to check the job state.
+	PollInterval time.Duration
+
+	client         kubernetes.Interface
+	namespace      string
+	timeout        time.Duration
+	collectorImage string
+}
+
+var _ install.Driver = (*Driver)(nil)
+
+func New() install.Driver {
+	return &Driver{}
+}
+
+// SetConfig sets Kubernetes driver configuration.
+func (d *Driver) SetConfig(settings map[string]string) error {
+	if settings == nil {
+		settings = map[string]string{}
+	}
+	value, ok := settings[OptionCleanup]
+	if !ok {
+		settings[OptionCleanup] = trueAsString
+	} else if value != trueAsString && value != falseAsString {
+		return fmt.Errorf("config variable %s has unexpected value %q. Supported values are 'true', 'false', or unset", OptionCleanup, value)
+	}
+
+	value, ok = settings[OptionPullPolicy]
+	if ok {
+		if value != PullPolicyAlways && value != PullPolicyIfNotPresent && value != PullPolicyNever {
+			return fmt.Errorf("config variable %s has unexpected value %q. Supported values are '%s', '%s', '%s' , or unset", OptionPullPolicy, value, PullPolicyAlways, PullPolicyIfNotPresent, PullPolicyNever)
+		}
+	}
+
+	d.timeout = DefaultTimeout
+	if value, ok = settings[OptionTimeout]; ok {
+		t, err := time.ParseDuration(value)
+		if err != nil {
+			return errors.Wrapf(err, "config variable %s", OptionTimeout)
+		}
+		d.timeout = t
+	}
+
+	d.namespace = settings[OptionNamespace]
+	d.collectorImage = settings[OptionCollectorImage]
+	d.config = settings
+	return nil
+}
+
+// SetClient makes the driver use an already initialized client.
+func (d *Driver) SetClient(client kubernetes.Interface) {
+	d.client = client
+}
+
+func (d *Driver) initializeClient() (kubernetes.Interface, error) {
+	if d.config == nil {
+		if err := d.SetConfig(nil); err != nil {
+			return nil, err
+		}
+	}
+	if d.client != nil {
+		if d.namespace == "" {
+			d.namespace = DefaultNamespace
+		}
+		return d.client, nil
+	}
+
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if p := d.config[OptionKubeconfig]; p != "" {
+		rules.ExplicitPath = p
+	}
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: d.config[OptionContext]}
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides)
+	if d.namespace == "" {
+		ns, _, err := clientConfig.Namespace()
+		if err != nil || ns == "" {
+			ns = DefaultNamespace
+		}
+		d.namespace = ns
+	}
+	cfg, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot load kubeconfig")
+	}
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot create kubernetes client")
+	}
+	d.client = client
+	return client, nil
+}
+
+func (d *Driver) Exec(op *install.Operation) (result *install.OperationResult, err error) {
+	client, err := d.initializeClient()
+	if err != nil {
+		return nil, err
+	}
+	if d.Simulate {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.timeout)
+	defer cancel()
+
+	name := JobName(op.Action)
+	labels := map[string]string{LabelJob: name}
+
+	inputs, items, err := inputSecretData(op)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := outputKeys(op)
+	if err != nil {
+		return nil, err
+	}
+	if len(outputs) > 0 && d.collectorImage == "" {
+		return nil, errors.Newf("config variable %s required to collect outputs", OptionCollectorImage)
+	}
+
+	if d.config[OptionCleanup] == trueAsString {
+		defer func() {
+			err = errors.ErrListf("kubernetes job execution").Add(err, d.cleanup(client, name)).Result()
+		}()
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: d.namespace, Labels: labels},
+		Data:       inputs,
+	}
+	_, err = client.CoreV1().Secrets(d.namespace).Create(ctx, secret, metav1.CreateOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot create input secret %s", name)
+	}
+
+	if len(op.Outputs) > 0 {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: d.namespace, Labels: labels},
+		}
+		_, err = client.CoreV1().ConfigMaps(d.namespace).Create(ctx, cm, metav1.CreateOptions{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot create output config map %s", name)
+		}
+	}
+
+	job := d.job(op, name, labels, items, outputs)
+	_, err = client.BatchV1().Jobs(d.namespace).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot create job %s", name)
+	}
+	toi.Log.Info("job created", "namespace", d.namespace, "job", name)
+
+	var stdout io.Writer = os.Stdout
+	if op.Out != nil {
+		stdout = op.Out
+	}
+	job, err = d.wait(ctx, client, name, stdout)
+	if err != nil {
+		return nil, err
+	}
+	if failed, msg := jobFailed(job); failed {
+		return nil, errors.Newf("job %s failed: %s", name, msg)
+	}
+	return d.fetchOutputs(ctx, client, name, op)
+}
+
+// JobName provides a unique resource name for the execution of an action.
+func JobName(action string) string {
+	return "toi-" + toKey(strings.ToLower(action), "-") + "-" + utilrand.String(5)
+}
+
+var invalidKeyChars = regexp.MustCompile(`[^-._a-zA-Z0-9]`)
+
+func toKey(path, replacement string) string {
+	return invalidKeyChars.ReplaceAllString(path, replacement)
+}
+
+// OutputKey provides the config map key used to store an output path.
+func OutputKey(path string) string {
+	return toKey(path, "_")
+}
+
+func inputSecretData(op *install.Operation) (map[string][]byte, []corev1.KeyToPath, error) {
+	data := map[string][]byte{}
+	var items []corev1.KeyToPath
+	for _, path := range utils.StringMapKeys(op.Files) {
+		if strings.HasPrefix(path, "/") {
+			return nil, nil, fmt.Errorf("destination path %s should be a relative unix path", path)
+		}
+		content, err := op.Files[path].Get()
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "reading data for %q", path)
+		}
+		key := toKey(path, "_")
+		if _, ok := data[key]; ok {
+			return nil, nil, fmt.Errorf("ambiguous input path %s", path)
+		}
+		data[key] = content
+		items = append(items, corev1.KeyToPath{Key: key, Path: path})
+	}
+	return data, items, nil
+}
+
+// outputKeys provides the config map keys used for the output paths
+// of an operation.
+func outputKeys(op *install.Operation) (map[string]string, error) {
+	keys := map[string]string{}
+	paths := map[string]string{}
+	for _, path := range utils.StringMapKeys(op.Outputs) {
+		key := OutputKey(path)
+		if other, ok := paths[key]; ok {
+			return nil, fmt.Errorf("ambiguous output paths %s and %s", other, path)
+		}
+		paths[key] = path
+		keys[path] = key
+	}
+	return keys, nil
+}
+
+func (d *Driver) job(op *install.Operation, name string, labels map[string]string, items []corev1.KeyToPath, outputs map[string]string) *batchv1.Job {
+	var env []corev1.EnvVar
+	for _, k := range utils.StringMapKeys(op.Environment) {
+		env = append(env, corev1.EnvVar{Name: k, Value: op.Environment[k]})
+	}
+
+	image := op.Image.Ref
+	if op.Image.Digest != "" && !strings.Contains(image, "@") {
+		image += "@" + op.Image.Digest
+	}
+
+	executor := corev1.Container{
+		Name:            ContainerExecutor,
+		Image:           image,
+		ImagePullPolicy: corev1.PullPolicy(d.config[OptionPullPolicy]),
+		Args:            []string{op.Action, op.ComponentVersion},
+		Env:             env,
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: install.Inputs, MountPath: install.PathInputs, ReadOnly: true},
+			{Name: install.Outputs, MountPath: install.PathOutputs},
+		},
+	}
+
+	spec := corev1.PodSpec{
+		RestartPolicy:      corev1.RestartPolicyNever,
+		ServiceAccountName: d.config[OptionServiceAccount],
+		Volumes: []corev1.Volume{
+			{
+				Name: install.Inputs,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{SecretName: name, Items: items},
+				},
+			},
+			{
+				Name:         install.Outputs,
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			},
+		},
+	}
+	if s := d.config[OptionImagePullSecret]; s != "" {
+		spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: s}}
+	}
+
+	if len(outputs) == 0 {
+		spec.Containers = []corev1.Container{executor}
+	} else {
+		// the executor must be finished before the outputs can be collected.
+		args := []string{"sh", "-c", collectorScript, "collect"}
+		for _, p := range utils.StringMapKeys(outputs) {
+			args = append(args, outputs[p]+"="+p)
+		}
+		spec.InitContainers = []corev1.Container{executor}
+		spec.Containers = []corev1.Container{{
+			Name:    ContainerCollector,
+			Image:   d.collectorImage,
+			Command: args,
+			Env: []corev1.EnvVar{
+				{Name: "OUTPUTS", Value: install.PathOutputs},
+				{Name: "CONFIGMAP", Value: name},
+			},
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: install.Outputs, MountPath: install.PathOutputs, ReadOnly: true},
+			},
+		}}
+	}
+
+	backoff := int32(0)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: d.namespace, Labels: labels},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoff,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       spec,
+			},
+		},
+	}
+}
+
+// wait waits for the job to be finished and streams the log of the
+// executor container.
+func (d *Driver) wait(ctx context.Context, client kubernetes.Interface, name string, out io.Writer) (*batchv1.Job, error) {
+	interval := d.PollInterval
+	if interval == 0 {
+		interval = DefaultPollInterval
+	}
+
+	var logs sync.WaitGroup
+	defer logs.Wait()
+
+	streaming := false
+	stream := func(final bool) {
+		if streaming {
+			return
+		}
+		pod := d.findPod(ctx, client, name)
+		if pod == nil || !(final || containerStarted(pod)) {
+			return
+		}
+		streaming = true
+		logs.Add(1)
+		go func() {
+			defer logs.Done()
+			d.streamLogs(ctx, client, pod.Name, out)
+		}()
+	}
+
+	for {
+		job, err := client.BatchV1().Jobs(d.namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot get job %s", name)
+		}
+		if jobFinished(job) {
+			stream(true)
+			return job, nil
+		}
+		stream(false)
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrapf(ctx.Err(), "waiting for job %s", name)
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (d *Driver) findPod(ctx context.Context, client kubernetes.Interface, name string) *corev1.Pod {
+	list, err := client.CoreV1().Pods(d.namespace).List(ctx, metav1.ListOptions{LabelSelector: LabelJob + "=" + name})
+	if err != nil || len(list.Items) == 0 {
+		return nil
+	}
+	return &list.Items[0]
+}
+
+func containerStarted(pod *corev1.Pod) bool {
+	for _, s := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		if s.Name == ContainerExecutor {
+			return s.State.Running != nil || s.State.Terminated != nil
+		}
+	}
+	return false
+}
+
+func (d *Driver) streamLogs(ctx context.Context, client kubernetes.Interface, pod string, out io.Writer) {
+	r, err := client.CoreV1().Pods(d.namespace).GetLogs(pod, &corev1.PodLogOptions{Container: ContainerExecutor, Follow: true}).Stream(ctx)
+	if err != nil {
+		toi.Log.LogError(err, "cannot stream executor log", "pod", pod)
+		return
+	}
+	defer r.Close()
+	_, err = io.Copy(out, r)
+	if err != nil {
+		toi.Log.LogError(err, "cannot stream executor log", "pod", pod)
+	}
+}
+
+func jobFinished(job *batchv1.Job) bool {
+	if job.Status.Succeeded > 0 || job.Status.Failed > 0 {
+		return true
+	}
+	for _, c := range job.Status.Conditions {
+		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func jobFailed(job *batchv1.Job) (bool, string) {
+	for _, c := range job.Status.Conditions {
+		if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue {
+			return true, c.Message
+		}
+	}
+	if job.Status.Succeeded == 0 && job.Status.Failed > 0 {
+		return true, "executor pod failed"
+	}
+	return false, ""
+}
+
+// fetchOutputs reads the outputs declared by the operation from the
+// output config map.
+func (d *Driver) fetchOutputs(ctx context.Context, client kubernetes.Interface, name string, op *install.Operation) (*install.OperationResult, error) {
+	opResult := &install.OperationResult{
+		Outputs: map[string][]byte{},
+	}
+	if len(op.Outputs) == 0 {
+		return opResult, nil
+	}
+	cm, err := client.CoreV1().ConfigMaps(d.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get output config map %s", name)
+	}
+	for path, outputName := range op.Outputs {
+		key := OutputKey(path)
+		if data, ok := cm.BinaryData[key]; ok {
+			opResult.Outputs[outputName] = data
+		} else if data, ok := cm.Data[key]; ok {
+			opResult.Outputs[outputName] = []byte(data)
+		}
+	}
+	return opResult, nil
+}
+
+func (d *Driver) cleanup(client kubernetes.Interface, name string) error {
+	ctx := context.Background()
+	list := errors.ErrListf("cleanup")
+
+	propagation := metav1.DeletePropagationBackground
+	err := client.BatchV1().Jobs(d.namespace).Delete(ctx, name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+	list.Add(ignoreNotFound(err))
+	err = client.CoreV1().Secrets(d.namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	list.Add(ignoreNotFound(err))
+	err = client.CoreV1().ConfigMaps(d.namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	list.Add(ignoreNotFound(err))
+	return list.Result()
+}
+
+func ignoreNotFound(err error) error {
+	if err != nil && kerrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s_test
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/toi"
+	"github.com/open-component-model/ocm/pkg/toi/drivers/k8s"
+	"github.com/open-component-model/ocm/pkg/toi/install"
+)
+
+const (
+	NAMESPACE = "toi"
+	COLLECTOR = "registry.acme.org/kubectl@sha256:4567"
+)
+
+var _ = Describe("kubernetes driver", func() {
+	var client *fake.Clientset
+	var driver *k8s.Driver
+	var created *batchv1.Job
+	var op *install.Operation
+	var out *bytes.Buffer
+
+	// simulate the job execution by the cluster.
+	execute := func(succeeded bool, outputs map[string][]byte) {
+		client.PrependReactor("create", "jobs", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			job := action.(k8stesting.CreateAction).GetObject().(*batchv1.Job).DeepCopy()
+			created = job.DeepCopy()
+			tracker := client.Tracker()
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: job.Name + "-pod", Namespace: job.Namespace, Labels: job.Spec.Template.Labels},
+				Status: corev1.PodStatus{
+					InitContainerStatuses: []corev1.ContainerStatus{{
+						Name:  k8s.ContainerExecutor,
+						State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+					}},
+				},
+			}
+			Expect(tracker.Add(pod)).To(Succeed())
+			switch {
+			case succeeded && outputs != nil:
+				cm := &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: job.Name, Namespace: job.Namespace},
+					BinaryData: outputs,
+				}
+				Expect(tracker.Update(corev1.SchemeGroupVersion.WithResource("configmaps"), cm, job.Namespace)).To(Succeed())
+				fallthrough
+			case succeeded:
+				job.Status.Succeeded = 1
+			default:
+				job.Status.Failed = 1
+				job.Status.Conditions = []batchv1.JobCondition{{
+					Type:    batchv1.JobFailed,
+					Status:  corev1.ConditionTrue,
+					Message: "BackoffLimitExceeded",
+				}}
+			}
+			Expect(tracker.Create(batchv1.SchemeGroupVersion.WithResource("jobs"), job, job.Namespace)).To(Succeed())
+			return true, job, nil
+		})
+	}
+
+	BeforeEach(func() {
+		created = nil
+		client = fake.NewSimpleClientset()
+		driver = k8s.New().(*k8s.Driver)
+		driver.PollInterval = 10 * time.Millisecond
+		driver.SetClient(client)
+		out = bytes.NewBuffer(nil)
+
+		op = &install.Operation{
+			Action:           "install",
+			ComponentVersion: "acme.org/test:1.0.0",
+			Image:            toi.Image{Ref: "ghcr.io/acme/executor:1.0.0", Digest: "sha256:0123"},
+			Environment:      map[string]string{"VAR": "value"},
+			Files: map[string]blobaccess.BlobAccess{
+				install.InputConfig:     blobaccess.ForString(mime.MIME_YAML, "config"),
+				install.InputParameters: blobaccess.ForString(mime.MIME_YAML, "parameters"),
+			},
+			Outputs: map[string]string{"sub/result": "result"},
+			Out:     out,
+		}
+	})
+
+	It("validates config", func() {
+		ExpectError(driver.SetConfig(map[string]string{k8s.OptionPullPolicy: "sometimes"})).To(MatchError(ContainSubstring("unexpected value \"sometimes\"")))
+		ExpectError(driver.SetConfig(map[string]string{k8s.OptionTimeout: "ever"})).To(MatchError(ContainSubstring(k8s.OptionTimeout)))
+	})
+
+	It("runs job and collects outputs", func() {
+		MustBeSuccessful(driver.SetConfig(map[string]string{
+			k8s.OptionNamespace:      NAMESPACE,
+			k8s.OptionServiceAccount: "installer",
+			k8s.OptionPullPolicy:     k8s.PullPolicyAlways,
+			k8s.OptionCollectorImage: COLLECTOR,
+		}))
+		execute(true, map[string][]byte{k8s.OutputKey("sub/result"): []byte("done")})
+
+		result := Must(driver.Exec(op))
+		Expect(result.Outputs).To(Equal(map[string][]byte{"result": []byte("done")}))
+		Expect(out.String()).To(Equal("fake logs"))
+
+		Expect(created.Namespace).To(Equal(NAMESPACE))
+		spec := created.Spec.Template.Spec
+		Expect(spec.ServiceAccountName).To(Equal("installer"))
+		Expect(spec.InitContainers).To(HaveLen(1))
+		executor := spec.InitContainers[0]
+		Expect(executor.Image).To(Equal("ghcr.io/acme/executor:1.0.0@sha256:0123"))
+		Expect(executor.ImagePullPolicy).To(Equal(corev1.PullAlways))
+		Expect(executor.Args).To(Equal([]string{"install", "acme.org/test:1.0.0"}))
+		Expect(executor.Env).To(Equal([]corev1.EnvVar{{Name: "VAR", Value: "value"}}))
+		Expect(spec.Containers).To(HaveLen(1))
+		Expect(spec.Containers[0].Image).To(Equal(COLLECTOR))
+		Expect(spec.Containers[0].Command[4:]).To(Equal([]string{"sub_result=sub/result"}))
+		Expect(spec.Volumes[0].Secret.Items).To(Equal([]corev1.KeyToPath{
+			{Key: install.InputConfig, Path: install.InputConfig},
+			{Key: install.InputParameters, Path: install.InputParameters},
+		}))
+
+		// resources are cleaned up
+		Expect(Must(client.CoreV1().Secrets(NAMESPACE).List(context.Background(), metav1.ListOptions{})).Items).To(BeEmpty())
+		Expect(Must(client.CoreV1().ConfigMaps(NAMESPACE).List(context.Background(), metav1.ListOptions{})).Items).To(BeEmpty())
+		Expect(Must(client.BatchV1().Jobs(NAMESPACE).List(context.Background(), metav1.ListOptions{})).Items).To(BeEmpty())
+	})
+
+	It("runs executor as main container without outputs", func() {
+		op.Outputs = nil
+		execute(true, nil)
+
+		result := Must(driver.Exec(op))
+		Expect(result.Outputs).To(BeEmpty())
+		Expect(created.Namespace).To(Equal(k8s.DefaultNamespace))
+		Expect(created.Spec.Template.Spec.InitContainers).To(BeEmpty())
+		Expect(created.Spec.Template.Spec.Containers[0].Name).To(Equal(k8s.ContainerExecutor))
+	})
+
+	It("requires collector image for outputs", func() {
+		execute(true, nil)
+		ExpectError(driver.Exec(op)).To(MatchError(ContainSubstring(k8s.OptionCollectorImage)))
+		Expect(created).To(BeNil())
+	})
+
+	It("rejects ambiguous output paths", func() {
+		MustBeSuccessful(driver.SetConfig(map[string]string{k8s.OptionCollectorImage: COLLECTOR}))
+		op.Outputs["sub_result"] = "other"
+		execute(true, nil)
+		ExpectError(driver.Exec(op)).To(MatchError("ambiguous output paths sub/result and sub_result"))
+		Expect(created).To(BeNil())
+	})
+
+	It("reports failed job and keeps resources", func() {
+		MustBeSuccessful(driver.SetConfig(map[string]string{
+			k8s.OptionNamespace:      NAMESPACE,
+			k8s.OptionCleanup:        "false",
+			k8s.OptionCollectorImage: COLLECTOR,
+		}))
+		execute(false, nil)
+
+		ExpectError(driver.Exec(op)).To(MatchError(ContainSubstring("failed: BackoffLimitExceeded")))
+		Expect(out.String()).To(Equal("fake logs"))
+		Expect(Must(client.CoreV1().Secrets(NAMESPACE).List(context.Background(), metav1.ListOptions{})).Items).To(HaveLen(1))
+		Expect(Must(client.BatchV1().Jobs(NAMESPACE).List(context.Background(), metav1.ListOptions{})).Items).To(HaveLen(1))
+	})
+})
@@ -289,6 +289,13 @@ func ProcessConfig(name string, octx ocm.Context, cv ocm.ComponentVersionAccess,
 
 // ExecuteAction prepared the execution options and executes the action.
 func ExecuteAction(p common.Printer, d Driver, name string, spec *toi.PackageSpecification, creds *Credentials, params []byte, octxp ocm.ContextProvider, cv ocm.ComponentVersionAccess, resolver ocm.ComponentVersionResolver) (*OperationResult, error) {
+	result, _, err := executeAction(p, d, name, spec, creds, params, octxp, cv, resolver, nil)
+	return result, err
+}
+
+// executeAction executes the action and provides the used executor image.
+// If an installation state is given, it is passed to the executor.
+func executeAction(p common.Printer, d Driver, name string, spec *toi.PackageSpecification, creds *Credentials, params []byte, octxp ocm.ContextProvider, cv ocm.ComponentVersionAccess, resolver ocm.ComponentVersionResolver, state *Installation) (*OperationResult, *toi.Image, error) {
 	var err error
 
 	var finalize Finalizer
@@ -310,13 +317,13 @@ func ExecuteAction(p common.Printer, d Driver, name string, spec *toi.PackageSpe
 		}
 	}
 	if executor == nil {
-		return nil, errors.Newf("no executor found for action %s", name)
+		return nil, nil, errors.Newf("no executor found for action %s", name)
 	}
 
 	// validate executor config
 	espec, err := DetermineExecutor(executor, octx, cv, resolver)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if espec.Spec.Actions != nil {
@@ -328,7 +335,7 @@ func ExecuteAction(p common.Printer, d Driver, name string, spec *toi.PackageSpe
 			}
 		}
 		if !found {
-			return nil, errors.ErrNotSupported("action", name, "toi executor "+executor.ResourceRef.String())
+			return nil, nil, errors.ErrNotSupported("action", name, "toi executor "+executor.ResourceRef.String())
 		}
 	}
 
@@ -340,13 +347,13 @@ func ExecuteAction(p common.Printer, d Driver, name string, spec *toi.PackageSpe
 			}
 		}
 		if list.Len() > 0 {
-			return nil, list.Result()
+			return nil, nil, list.Result()
 		}
 	}
 	// prepare executor config
 	econfig, err := ProcessConfig("executor config", octx, espec.CV, resolver, espec.Spec.Template, executor.Config, espec.Spec.Libraries, espec.Spec.Scheme)
 	if err != nil {
-		return nil, errors.Wrapf(err, "error executor config")
+		return nil, nil, errors.Wrapf(err, "error executor config")
 	}
 
 	if econfig == nil {
@@ -357,13 +364,13 @@ func ExecuteAction(p common.Printer, d Driver, name string, spec *toi.PackageSpe
 	// handle credentials
 	credreqs, credmapping, err := CheckCredentialRequests(executor, spec, &espec.Spec)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// prepare ocm config with credential settings and logging config forwarding
 	if len(credreqs) > 0 {
 		if creds == nil {
-			return nil, errors.Newf("credential settings required")
+			return nil, nil, errors.Newf("credential settings required")
 		}
 	}
 
@@ -381,12 +388,12 @@ func ExecuteAction(p common.Printer, d Driver, name string, spec *toi.PackageSpe
 	}
 	ccfg, credvals, err := GetCredentials(octx.CredentialsContext(), creds, credreqs, credmapping)
 	if err != nil {
-		return nil, errors.Wrapf(err, "credential evaluation failed")
+		return nil, nil, errors.Wrapf(err, "credential evaluation failed")
 	}
 
 	if lc := logforward.Get(octx); lc != nil {
 		if err := ccfg.AddConfig(logcfg.NewWithConfig("default", lc)); err != nil {
-			return nil, errors.Wrapf(err, "cannot create logging config forwarding")
+			return nil, nil, errors.Wrapf(err, "cannot create logging config forwarding")
 		}
 	}
 	{
@@ -397,7 +404,7 @@ func ExecuteAction(p common.Printer, d Driver, name string, spec *toi.PackageSpe
 	// prepare user config
 	params, err = ProcessConfig("parameter data", octx, cv, resolver, spec.Template, params, spec.Libraries, spec.Scheme)
 	if err != nil {
-		return nil, errors.Wrapf(err, "error processing parameters")
+		return nil, nil, errors.Wrapf(err, "error processing parameters")
 	}
 	if params == nil {
 		p.Printf("no parameter config found\n")
@@ -419,7 +426,7 @@ func ExecuteAction(p common.Printer, d Driver, name string, spec *toi.PackageSpe
 		}
 	}
 	if err != nil {
-		return nil, errors.Wrapf(err, "error mapping parameters to executor")
+		return nil, nil, errors.Wrapf(err, "error mapping parameters to executor")
 	}
 
 	names := []string{}
@@ -451,13 +458,22 @@ func ExecuteAction(p common.Printer, d Driver, name string, spec *toi.PackageSpe
 	// prepare file content to be passed to executor
 	err = setupFiles(octx, &finalize, op, ccfg, params, econfig, cv, resolver)
 	if err != nil {
-		return nil, errors.Wrapf(err, "error setting up executor file set")
+		return nil, nil, errors.Wrapf(err, "error setting up executor file set")
+	}
+
+	if state != nil {
+		data, err := runtime.DefaultYAMLEncoding.Marshal(state)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "marshalling installation state failed")
+		}
+		op.Files[InputState] = blobaccess.ForData(mime.MIME_YAML, data)
 	}
 
 	op.Outputs = executor.Outputs
 
 	op.ComponentVersion = common.VersionedElementKey(cv).String()
-	return d.Exec(op)
+	result, err := d.Exec(op)
+	return result, espec.Image, err
 }
 
 func (op *Operation) Close() error {
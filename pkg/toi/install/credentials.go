@@ -66,6 +66,9 @@ func ParseCredentialRequest(data []byte) (*CredentialsRequest, error) {
 }
 
 func GetCredentials(ctx credentials.Context, spec *Credentials, req map[string]CredentialsRequestSpec, mapping map[string]string) (*globalconfig.Config, CredentialValues, error) {
+	if spec == nil {
+		spec = &Credentials{}
+	}
 	cfg := config.New()
 	mem := memorycfg.New("default")
 	memrepo := memory.NewRepositorySpec("default")
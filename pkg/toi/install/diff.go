@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package install
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/toi"
+	"github.com/open-component-model/ocm/pkg/utils"
+)
+
+// DiffPackageSpecifications describes the differences between two package
+// specifications. Every entry describes a changed (~), added (+) or removed (-)
+// field by its path.
+func DiffPackageSpecifications(oldSpec, newSpec *toi.PackageSpecification) ([]string, error) {
+	o, err := asGeneric(oldSpec)
+	if err != nil {
+		return nil, errors.Wrapf(err, "old package specification")
+	}
+	n, err := asGeneric(newSpec)
+	if err != nil {
+		return nil, errors.Wrapf(err, "new package specification")
+	}
+	return diffValues("", o, n, nil), nil
+}
+
+func asGeneric(spec *toi.PackageSpecification) (interface{}, error) {
+	if spec == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	err = json.Unmarshal(data, &v)
+	return v, err
+}
+
+func diffValues(path string, o, n interface{}, diffs []string) []string {
+	switch ov := o.(type) {
+	case map[string]interface{}:
+		if nv, ok := n.(map[string]interface{}); ok {
+			keys := map[string]interface{}{}
+			for k := range ov {
+				keys[k] = nil
+			}
+			for k := range nv {
+				keys[k] = nil
+			}
+			for _, k := range utils.StringMapKeys(keys) {
+				diffs = diffValues(subPath(path, k), ov[k], nv[k], diffs)
+			}
+			return diffs
+		}
+	case []interface{}:
+		if nv, ok := n.([]interface{}); ok {
+			for i := 0; i < len(ov) || i < len(nv); i++ {
+				var oe, ne interface{}
+				if i < len(ov) {
+					oe = ov[i]
+				}
+				if i < len(nv) {
+					ne = nv[i]
+				}
+				diffs = diffValues(fmt.Sprintf("%s[%d]", path, i), oe, ne, diffs)
+			}
+			return diffs
+		}
+	}
+	switch {
+	case reflect.DeepEqual(o, n):
+	case o == nil:
+		diffs = append(diffs, fmt.Sprintf("+ %s: %s", path, format(n)))
+	case n == nil:
+		diffs = append(diffs, fmt.Sprintf("- %s: %s", path, format(o)))
+	default:
+		diffs = append(diffs, fmt.Sprintf("~ %s: %s -> %s", path, format(o), format(n)))
+	}
+	return diffs
+}
+
+func subPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func format(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
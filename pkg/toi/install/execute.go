@@ -11,10 +11,20 @@ import (
 	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/utils"
 	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/runtime"
 	"github.com/open-component-model/ocm/pkg/toi"
 )
 
 func Execute(p common.Printer, d Driver, name string, rid metav1.Identity, credsrc blobaccess.DataSource, paramsrc blobaccess.DataSource, octx ocm.Context, cv ocm.ComponentVersionAccess, resolver ocm.ComponentVersionResolver) (*OperationResult, error) {
+	result, _, err := ExecuteInstallation(p, d, name, rid, credsrc, paramsrc, octx, cv, resolver, nil)
+	return result, err
+}
+
+// ExecuteInstallation executes an action for the package of a component version
+// and provides the resulting installation record. If the record of a previous
+// execution is given, it is passed to the executor as installation state
+// and its name is kept for the new record.
+func ExecuteInstallation(p common.Printer, d Driver, name string, rid metav1.Identity, credsrc blobaccess.DataSource, paramsrc blobaccess.DataSource, octx ocm.Context, cv ocm.ComponentVersionAccess, resolver ocm.ComponentVersionResolver, state *Installation) (*OperationResult, *Installation, error) {
 	var creds *Credentials
 	var params []byte
 	var err error
@@ -22,7 +32,7 @@ func Execute(p common.Printer, d Driver, name string, rid metav1.Identity, creds
 	if paramsrc != nil {
 		params, err = paramsrc.Get()
 		if err != nil {
-			return nil, errors.Wrapf(err, "parameters")
+			return nil, nil, errors.Wrapf(err, "parameters")
 		}
 	}
 
@@ -32,14 +42,52 @@ func Execute(p common.Printer, d Driver, name string, rid metav1.Identity, creds
 			creds, err = ParseCredentialSpecification(data, credsrc.Origin())
 		}
 		if err != nil {
-			return nil, errors.Wrapf(err, "credentials")
+			return nil, nil, errors.Wrapf(err, "credentials")
 		}
 	}
 
-	ires, _, err := utils.MatchResourceReference(cv, toi.TypeTOIPackage, metav1.NewResourceRef(rid), nil)
+	spec, err := GetPackageSpecification(cv, rid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, image, err := executeAction(p, d, name, spec, creds, params, octx, cv, resolver, state)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inst := &Installation{
+		Component:      cv.GetName(),
+		Version:        cv.GetVersion(),
+		Package:        rid,
+		Action:         name,
+		Executor:       image.String(),
+		ParametersHash: ParametersHash(params),
+		Parameters:     string(params),
+		Outputs:        result.Outputs,
+		Spec:           spec,
+		Timestamp:      metav1.NewTimestampP(),
+	}
+	if repo := cv.Repository(); repo != nil {
+		inst.Repository, err = runtime.ToUnstructuredTypedObject(repo.GetSpecification())
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "repository specification")
+		}
+	}
+	if state != nil {
+		inst.Name = state.Name
+	}
+	return result, inst, nil
+}
+
+// GetPackageSpecification provides the specification of the (first)
+// package resource of a component version matching the given identity.
+func GetPackageSpecification(cv ocm.ComponentVersionAccess, rid metav1.Identity) (*toi.PackageSpecification, error) {
+	ires, eff, err := utils.MatchResourceReference(cv, toi.TypeTOIPackage, metav1.NewResourceRef(rid), nil)
 	if err != nil {
 		return nil, errors.Wrapf(err, "package resource in %s", common.VersionedElementKey(cv).String())
 	}
+	defer eff.Close()
 
 	var spec toi.PackageSpecification
 
@@ -47,5 +95,5 @@ func Execute(p common.Printer, d Driver, name string, rid metav1.Identity, creds
 	if err != nil {
 		return nil, errors.ErrInvalidWrap(err, "package spec")
 	}
-	return ExecuteAction(p, d, name, &spec, creds, params, octx, cv, resolver)
+	return &spec, nil
 }
@@ -22,6 +22,7 @@ const (
 	InputConfig     = "config"
 	InputOCMConfig  = "ocmconfig"
 	InputOCMRepo    = "ocmrepo"
+	InputState      = "state"
 )
 
 type Driver interface {
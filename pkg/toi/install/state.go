@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package install
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/mandelsoft/filepath/pkg/filepath"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	metav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/runtime"
+	"github.com/open-component-model/ocm/pkg/toi"
+)
+
+const (
+	KIND_INSTALLATION = "installation"
+
+	ConfigDir        = ".ocm"
+	StateDirName     = "toi"
+	StateFileSuffix  = ".yaml"
+	InstallationsDir = "installations"
+)
+
+// Installation is the record of the last action executed for
+// a TOI package installation.
+type Installation struct {
+	// Name is the name of the installation used to look up the record.
+	Name string `json:"name"`
+	// Component is the name of the component containing the package.
+	Component string `json:"component"`
+	// Version is the version of the component containing the package.
+	Version string `json:"version"`
+	// Repository is the specification of the repository the component version
+	// has been taken from.
+	Repository *runtime.UnstructuredTypedObject `json:"repository,omitempty"`
+	// Package is the identity of the package resource, if specified.
+	Package metav1.Identity `json:"package,omitempty"`
+	// Action is the last executed action.
+	Action string `json:"action"`
+	// Executor is the image used to execute the action.
+	Executor string `json:"executor"`
+	// ParametersHash is the digest of the parameter input.
+	ParametersHash string `json:"parametersHash,omitempty"`
+	// Parameters is the parameter input used for the action.
+	Parameters string `json:"parameters,omitempty"`
+	// Outputs are the outputs provided by the executor.
+	Outputs map[string][]byte `json:"outputs,omitempty"`
+	// Spec is the package specification used for the action.
+	Spec *toi.PackageSpecification `json:"packageSpec,omitempty"`
+	// Timestamp is the time of the execution.
+	Timestamp *metav1.Timestamp `json:"timestamp,omitempty"`
+}
+
+func (i *Installation) ComponentVersion() common.NameVersion {
+	return common.NewNameVersion(i.Component, i.Version)
+}
+
+// ParametersHash provides the digest used to identify a parameter input.
+func ParametersHash(params []byte) string {
+	if params == nil {
+		return ""
+	}
+	return digest.FromBytes(params).String()
+}
+
+// Store persists installation records.
+type Store interface {
+	// Get provides the record for the given installation name. If there is
+	// no such installation, a not found error is returned.
+	Get(name string) (*Installation, error)
+	// List provides all stored records ordered by name.
+	List() ([]*Installation, error)
+	Put(inst *Installation) error
+	Delete(name string) error
+}
+
+// DefaultStateDir provides the default location of the installation records
+// (~/.ocm/toi/installations).
+func DefaultStateDir() (string, error) {
+	d, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(d, ConfigDir, StateDirName, InstallationsDir), nil
+}
+
+type fileStore struct {
+	fs   vfs.FileSystem
+	path string
+}
+
+var _ Store = (*fileStore)(nil)
+
+// NewFileStore provides a store keeping every installation record
+// as a dedicated yaml file in the given directory.
+func NewFileStore(fs vfs.FileSystem, path string) Store {
+	return &fileStore{fs: fs, path: path}
+}
+
+func (s *fileStore) file(name string) (string, error) {
+	if name == "" {
+		return "", errors.ErrInvalid(KIND_INSTALLATION, name)
+	}
+	for _, e := range strings.Split(name, "/") {
+		if e == "" || e == "." || e == ".." {
+			return "", errors.ErrInvalid(KIND_INSTALLATION, name)
+		}
+	}
+	return vfs.Join(s.fs, s.path, name+StateFileSuffix), nil
+}
+
+func (s *fileStore) read(path string) (*Installation, error) {
+	data, err := vfs.ReadFile(s.fs, path)
+	if err != nil {
+		return nil, err
+	}
+	var inst Installation
+	err = runtime.DefaultYAMLEncoding.Unmarshal(data, &inst)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid installation record %q", path)
+	}
+	return &inst, nil
+}
+
+func (s *fileStore) Get(name string) (*Installation, error) {
+	path, err := s.file(name)
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := vfs.FileExists(s.fs, path); !ok || err != nil {
+		return nil, errors.ErrNotFound(KIND_INSTALLATION, name)
+	}
+	return s.read(path)
+}
+
+func (s *fileStore) List() ([]*Installation, error) {
+	var result []*Installation
+	if ok, err := vfs.DirExists(s.fs, s.path); !ok || err != nil {
+		return nil, err
+	}
+	err := vfs.Walk(s.fs, s.path, func(path string, info vfs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, StateFileSuffix) {
+			return nil
+		}
+		inst, err := s.read(path)
+		if err != nil {
+			return err
+		}
+		result = append(result, inst)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+func (s *fileStore) Put(inst *Installation) error {
+	path, err := s.file(inst.Name)
+	if err != nil {
+		return err
+	}
+	data, err := runtime.DefaultYAMLEncoding.Marshal(inst)
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal installation record")
+	}
+	err = s.fs.MkdirAll(vfs.Dir(s.fs, path), 0o700)
+	if err != nil {
+		return errors.Wrapf(err, "cannot create state directory")
+	}
+	return vfs.WriteFile(s.fs, path, data, 0o600)
+}
+
+func (s *fileStore) Delete(name string) error {
+	path, err := s.file(name)
+	if err != nil {
+		return err
+	}
+	err = s.fs.Remove(path)
+	if err != nil && vfs.IsErrNotExist(err) {
+		return errors.ErrNotFound(KIND_INSTALLATION, name)
+	}
+	return err
+}
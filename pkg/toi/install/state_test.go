@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package install_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/open-component-model/ocm/pkg/env"
+	. "github.com/open-component-model/ocm/pkg/env/builder"
+	. "github.com/open-component-model/ocm/pkg/testutils"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+
+	"github.com/open-component-model/ocm/pkg/blobaccess"
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	v1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
+	"github.com/open-component-model/ocm/pkg/errors"
+	"github.com/open-component-model/ocm/pkg/mime"
+	"github.com/open-component-model/ocm/pkg/runtime"
+	"github.com/open-component-model/ocm/pkg/toi"
+	"github.com/open-component-model/ocm/pkg/toi/drivers/mock"
+	"github.com/open-component-model/ocm/pkg/toi/install"
+)
+
+var _ = Describe("installation state", func() {
+	Context("store", func() {
+		var store install.Store
+
+		BeforeEach(func() {
+			store = install.NewFileStore(memoryfs.New(), "/state")
+		})
+
+		It("handles records", func() {
+			Expect(store.List()).To(BeEmpty())
+			ExpectError(store.Get("acme.org/test")).To(Satisfy(errors.IsErrNotFound))
+
+			inst := &install.Installation{Name: "acme.org/test", Component: COMPONENT, Version: VERSION, Outputs: map[string][]byte{"out": []byte("value")}}
+			MustBeSuccessful(store.Put(inst))
+			MustBeSuccessful(store.Put(&install.Installation{Name: "other", Component: COMPONENT, Version: VERSION}))
+			Expect(store.Get("acme.org/test")).To(Equal(inst))
+
+			list := Must(store.List())
+			Expect(list).To(HaveLen(2))
+			Expect(list[0]).To(Equal(inst))
+
+			MustBeSuccessful(store.Delete("acme.org/test"))
+			ExpectError(store.Get("acme.org/test")).To(Satisfy(errors.IsErrNotFound))
+			Expect(store.Delete("acme.org/test")).To(Satisfy(errors.IsErrNotFound))
+			Expect(store.List()).To(HaveLen(1))
+		})
+
+		It("rejects invalid names", func() {
+			Expect(store.Put(&install.Installation{Name: "../escape"})).To(MatchError(`installation "../escape" is invalid`))
+		})
+	})
+
+	It("diffs package specifications", func() {
+		old := &toi.PackageSpecification{
+			Description: "old",
+			Executors: []toi.Executor{
+				{Actions: []string{"install"}, Image: &toi.Image{Ref: "a/b:v1"}},
+			},
+		}
+		upd := &toi.PackageSpecification{
+			Description: "old",
+			Executors: []toi.Executor{
+				{Actions: []string{"install", "upgrade"}, Image: &toi.Image{Ref: "a/b:v2"}},
+			},
+		}
+		Expect(install.DiffPackageSpecifications(old, old)).To(BeEmpty())
+		Expect(install.DiffPackageSpecifications(old, upd)).To(Equal([]string{
+			`+ executors[0].actions[1]: "upgrade"`,
+			`~ executors[0].image.ref: "a/b:v1" -> "a/b:v2"`,
+		}))
+	})
+
+	Context("execution", func() {
+		var env *Builder
+		var found *install.Operation
+
+		BeforeEach(func() {
+			env = NewBuilder(FileSystem(memoryfs.New(), ""))
+
+			env.OCMCommonTransport("ctf", accessio.FormatDirectory, func() {
+				env.ComponentVersion(COMPONENT, VERSION, func() {
+					env.Provider("acme.org")
+					env.Resource("package", VERSION, toi.TypeTOIPackage, v1.LocalRelation, func() {
+						env.BlobStringData(mime.MIME_YAML, `
+description: test
+executors:
+- image:
+    ref: a/b:v1
+  outputs:
+    result: result
+`)
+					})
+				})
+			})
+		})
+
+		AfterEach(func() {
+			env.Cleanup()
+		})
+
+		It("provides installation record and passes state", func() {
+			driver := mock.New(func(op *install.Operation) (*install.OperationResult, error) {
+				found = op
+				return &install.OperationResult{Outputs: map[string][]byte{"result": []byte("done")}}, nil
+			})
+			p, _ := common.NewBufferedPrinter()
+
+			repo := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, "/ctf", 0, env))
+			defer Close(repo)
+			cv := Must(repo.LookupComponentVersion(COMPONENT, VERSION))
+			defer Close(cv)
+
+			params := blobaccess.DataAccessForString("param: value\n")
+			_, inst := Must2(install.ExecuteInstallation(p, driver, "install", nil, nil, params, env.OCMContext(), cv, nil, nil))
+			Expect(found.Files).NotTo(HaveKey(install.InputState))
+			Expect(inst.ComponentVersion()).To(Equal(common.NewNameVersion(COMPONENT, VERSION)))
+			Expect(inst.Action).To(Equal("install"))
+			Expect(inst.Executor).To(Equal("a/b:v1"))
+			Expect(inst.Parameters).To(Equal("param: value\n"))
+			Expect(inst.ParametersHash).To(Equal(install.ParametersHash([]byte("param: value\n"))))
+			Expect(inst.Outputs).To(Equal(map[string][]byte{"result": []byte("done")}))
+			Expect(inst.Spec.Description).To(Equal("test"))
+			Expect(inst.Repository.GetType()).To(Equal(ctf.Type))
+
+			inst.Name = "test"
+			_, next := Must2(install.ExecuteInstallation(p, driver, "uninstall", nil, nil, params, env.OCMContext(), cv, nil, inst))
+			Expect(next.Name).To(Equal("test"))
+			var state install.Installation
+			MustBeSuccessful(runtime.DefaultYAMLEncoding.Unmarshal(Must(found.Files[install.InputState].Get()), &state))
+			Expect(state.Action).To(Equal("install"))
+			Expect(state.Outputs).To(Equal(map[string][]byte{"result": []byte("done")}))
+		})
+	})
+})
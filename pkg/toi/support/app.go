@@ -91,6 +91,7 @@ func (o *BootstrapperCLIOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVarP(&o.Root, "bootstraproot", "", install.PathTOI, "bootstrapper contract root folder")
 	fs.StringVarP(&o.Config, "config", "", "", "bootstrapper configuration input file")
 	fs.StringVarP(&o.Parameters, "parameters", "", "", "bootstrapper parameter input file")
+	fs.StringVarP(&o.StateFile, "state", "", "", "installation state input file")
 	fs.StringVarP(&o.RepoPath, "ctf", "", "", "bootstrapper transport archive")
 }
 
@@ -22,6 +22,7 @@ import (
 	ocmutils "github.com/open-component-model/ocm/pkg/contexts/ocm/utils"
 	"github.com/open-component-model/ocm/pkg/errors"
 	"github.com/open-component-model/ocm/pkg/out"
+	"github.com/open-component-model/ocm/pkg/runtime"
 	"github.com/open-component-model/ocm/pkg/toi/install"
 	utils2 "github.com/open-component-model/ocm/pkg/utils"
 )
@@ -40,6 +41,8 @@ type ExecutorOptions struct {
 	ConfigData           []byte
 	Parameters           string
 	ParameterData        []byte
+	StateFile            string
+	State                *install.Installation
 	RepoPath             string
 	Repository           ocm.Repository
 	CredentialRepo       credentials.Repository
@@ -124,6 +127,26 @@ func (o *ExecutorOptions) Complete() error {
 		}
 	}
 
+	if o.StateFile == "" {
+		p, _ := utils2.ResolvePath(o.Inputs + "/" + install.InputState)
+		if ok, err := vfs.FileExists(o.FileSystem(), p); ok && err == nil {
+			o.StateFile = p
+		}
+	}
+
+	if o.StateFile != "" && o.State == nil {
+		data, err := utils2.ReadFile(o.StateFile, o.FileSystem())
+		if err != nil {
+			return errors.Wrapf(err, "cannot read installation state %q", o.StateFile)
+		}
+		var state install.Installation
+		err = runtime.DefaultYAMLEncoding.Unmarshal(data, &state)
+		if err != nil {
+			return errors.Wrapf(err, "invalid installation state %q", o.StateFile)
+		}
+		o.State = &state
+	}
+
 	var repoCloser io.Closer
 	if o.Repository == nil {
 		repo, err := ctf.Open(o.Context, accessobj.ACC_READONLY, o.RepoPath, 0, accessio.PathFileSystem(o.FileSystem()))